@@ -1896,3 +1896,89 @@ func TestAgReqFiltersInsideField(t *testing.T) {
 	}
 
 }
+
+func TestAgReqUsageUnitsMSCC(t *testing.T) {
+	m := diam.NewRequest(diam.CreditControl, 4, nil)
+	m.NewAVP(avp.SessionID, avp.Mbit, 0, datatype.UTF8String("bb97be2b9f37c2be9614fff71c8b1d08b1acbff8"))
+	m.NewAVP(avp.CCRequestType, avp.Mbit, 0, datatype.Enumerated(2))
+	for _, mscc := range []struct{ rg, requested, used uint64 }{{1, 1000, 500}, {2, 2000, 700}} {
+		m.NewAVP(avp.MultipleServicesCreditControl, avp.Mbit, 0, &diam.GroupedAVP{
+			AVP: []*diam.AVP{
+				diam.NewAVP(avp.RatingGroup, avp.Mbit, 0, datatype.Unsigned32(mscc.rg)),
+				diam.NewAVP(avp.RequestedServiceUnit, avp.Mbit, 0, &diam.GroupedAVP{
+					AVP: []*diam.AVP{
+						diam.NewAVP(avp.CCTotalOctets, avp.Mbit, 0, datatype.Unsigned64(mscc.requested))}}),
+				diam.NewAVP(avp.UsedServiceUnit, avp.Mbit, 0, &diam.GroupedAVP{
+					AVP: []*diam.AVP{
+						diam.NewAVP(avp.CCTotalOctets, avp.Mbit, 0, datatype.Unsigned64(mscc.used))}}),
+			}})
+	}
+	cfg, _ := config.NewDefaultCGRConfig()
+	dm := engine.NewDataManager(engine.NewInternalDB(nil, nil, true, cfg.DataDbCfg().Items),
+		config.CgrConfig().CacheCfg(), nil)
+	filterS := engine.NewFilterS(cfg, nil, dm)
+	agReq := NewAgentRequest(newDADataProvider(nil, m), nil, nil,
+		config.NewNavigableMap(nil), nil, "cgrates.org", "", filterS, nil, nil)
+
+	// one unit per Rating-Group, mapped with the AVP selectors
+	var reqFlds, rplyFlds []*config.FCTemplate
+	for _, rg := range []string{"1", "2"} {
+		reqFlds = append(reqFlds,
+			&config.FCTemplate{Tag: "Usage" + rg,
+				Path: utils.MetaCgreq + utils.NestingSep + "Units." + rg + ".Usage", Type: utils.MetaVariable,
+				Value: config.NewRSRParsersMustCompile("~*req.Multiple-Services-Credit-Control.Requested-Service-Unit.CC-Total-Octets[~Rating-Group("+rg+")]",
+					true, utils.INFIELD_SEP)},
+			&config.FCTemplate{Tag: "LastUsed" + rg,
+				Path: utils.MetaCgreq + utils.NestingSep + "Units." + rg + ".LastUsed", Type: utils.MetaVariable,
+				Value: config.NewRSRParsersMustCompile("~*req.Multiple-Services-Credit-Control.Used-Service-Unit.CC-Total-Octets[~Rating-Group("+rg+")]",
+					true, utils.INFIELD_SEP)},
+		)
+		rplyFlds = append(rplyFlds,
+			&config.FCTemplate{Tag: "RatingGroup" + rg,
+				Path: utils.MetaRep + utils.NestingSep + "Multiple-Services-Credit-Control.Rating-Group", Type: utils.MetaGroup,
+				Filters:   []string{"*exists:~*cgrep.UnitsMaxUsage." + rg + ":"},
+				Value:     config.NewRSRParsersMustCompile(rg, true, utils.INFIELD_SEP),
+				NewBranch: true},
+			&config.FCTemplate{Tag: "Granted" + rg,
+				Path: utils.MetaRep + utils.NestingSep + "Multiple-Services-Credit-Control.Granted-Service-Unit.CC-Total-Octets", Type: utils.MetaGroup,
+				Filters: []string{"*exists:~*cgrep.UnitsMaxUsage." + rg + ":"},
+				Value:   config.NewRSRParsersMustCompile("~*cgrep.UnitsMaxUsage."+rg+"{*duration_nanoseconds}", true, utils.INFIELD_SEP)},
+		)
+	}
+	if err := agReq.SetFields(reqFlds); err != nil {
+		t.Fatal(err)
+	}
+	eEv := map[string]interface{}{
+		"Units.1.Usage":    "1000",
+		"Units.1.LastUsed": "500",
+		"Units.2.Usage":    "2000",
+		"Units.2.LastUsed": "700",
+	}
+	if cgrEv := agReq.CGRRequest.AsCGREvent(agReq.Tenant, utils.NestingSep); !reflect.DeepEqual(eEv, cgrEv.Event) {
+		t.Errorf("expecting: %s, received: %s", utils.ToJSON(eEv), utils.ToJSON(cgrEv.Event))
+	}
+
+	// granted units mapped back, one Multiple-Services-Credit-Control per Rating-Group
+	agReq.setCGRReply(myEv{
+		utils.CapUnitsMaxUsage: map[string]interface{}{
+			"1": time.Duration(1000),
+			"2": time.Duration(1500),
+		},
+	}, nil)
+	if err := agReq.SetFields(rplyFlds); err != nil {
+		t.Fatal(err)
+	}
+	a := m.Answer(diam.Success)
+	if err := updateDiamMsgFromNavMap(a, agReq.Reply, ""); err != nil {
+		t.Fatal(err)
+	}
+	for i, eGranted := range []string{"1000", "1500"} {
+		if rcv, err := newDADataProvider(nil, a).FieldAsString([]string{
+			"Multiple-Services-Credit-Control", "Granted-Service-Unit",
+			fmt.Sprintf("CC-Total-Octets[~Rating-Group(%d)]", i+1)}); err != nil {
+			t.Error(err)
+		} else if rcv != eGranted {
+			t.Errorf("expecting granted %s for Rating-Group %d, received: %s", eGranted, i+1, rcv)
+		}
+	}
+}
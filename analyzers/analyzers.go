@@ -20,17 +20,32 @@ package analyzers
 
 import (
 	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
 
+	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/cgrates/engine"
 	"github.com/cgrates/cgrates/utils"
+	"github.com/cgrates/ltcache"
 )
 
 // NewAnalyzerService initializes a AnalyzerService
-func NewAnalyzerService() (*AnalyzerService, error) {
-	return &AnalyzerService{}, nil
+func NewAnalyzerService(cfg *config.CGRConfig) (*AnalyzerService, error) {
+	return &AnalyzerService{
+		cfg: cfg,
+		db: ltcache.NewCache(cfg.AnalyzerSCfg().Limit,
+			cfg.AnalyzerSCfg().TTL, true, nil),
+	}, nil
 }
 
 // AnalyzerService is the service handling analyzer
 type AnalyzerService struct {
+	cfg    *config.CGRConfig
+	db     *ltcache.Cache // bounded storage for the captured API calls, indexed on groups
+	lastID uint64         // atomically incremented for each captured API call
 }
 
 // ListenAndServe will initialize the service
@@ -44,6 +59,112 @@ func (aS *AnalyzerService) ListenAndServe(exitChan chan bool) error {
 // Shutdown is called to shutdown the service
 func (aS *AnalyzerService) Shutdown() error {
 	utils.Logger.Info(fmt.Sprintf("<%s> service shutdown initialized", utils.AnalyzerS))
+	aS.db.Clear()
 	utils.Logger.Info(fmt.Sprintf("<%s> service shutdown complete", utils.AnalyzerS))
 	return nil
 }
+
+// logTrafic will store the API call into the database
+func (aS *AnalyzerService) logTrafic(method string, params, result interface{},
+	replyErr string, enc, from, to string, sTime, eTime time.Time) {
+	if strings.HasPrefix(method, utils.AnalyzerSv1) { // do not capture the queries on ourselves
+		return
+	}
+	info := &InfoRPC{
+		RequestID:          atomic.AddUint64(&aS.lastID, 1),
+		RequestMethod:      method,
+		RequestEncoding:    enc,
+		RequestSource:      from,
+		RequestDestination: to,
+		RequestParams:      toGenericData(params),
+		RequestStartTime:   sTime,
+		RequestDuration:    eTime.Sub(sTime),
+		ReplyError:         replyErr,
+	}
+	if replyErr == utils.EmptyString {
+		info.Reply = toGenericData(result)
+	}
+	aS.db.Set(strconv.FormatUint(info.RequestID, 10), info, info.indexes())
+}
+
+// V1StringQuery returns the API calls matching the header and content filters
+// sorted in the order they were captured
+func (aS *AnalyzerService) V1StringQuery(args *QueryArgs, reply *[]map[string]interface{}) (err error) {
+	var hdrFltrs []*headerFilter
+	if hdrFltrs, err = parseHeaderFilters(args.HeaderFilters); err != nil {
+		return utils.NewErrMandatoryIeMissing(err.Error())
+	}
+	cntFltrs := make([]*engine.FilterRule, 0, len(args.ContentFilters))
+	for _, inFltr := range args.ContentFilters {
+		var fltr *engine.Filter
+		if fltr, err = engine.NewFilterFromInline(aS.cfg.GeneralCfg().DefaultTenant, inFltr); err != nil {
+			return
+		}
+		cntFltrs = append(cntFltrs, fltr.Rules...)
+	}
+	var infos []*InfoRPC
+	for _, itmID := range aS.queryItemIDs(hdrFltrs) {
+		x, has := aS.db.Get(itmID)
+		if !has {
+			continue
+		}
+		info := x.(*InfoRPC)
+		if !info.passHeaderFilters(hdrFltrs) {
+			continue
+		}
+		if len(cntFltrs) != 0 {
+			dP := config.NewNavigableMap(info.AsMapInterface())
+			pass := true
+			for _, fltr := range cntFltrs {
+				// the API calls have different structures so not being
+				// able to navigate the path means no match
+				if pass, _ = fltr.Pass(dP); !pass {
+					break
+				}
+			}
+			if !pass {
+				continue
+			}
+		}
+		infos = append(infos, info)
+	}
+	if len(infos) == 0 {
+		return utils.ErrNotFound
+	}
+	sort.Slice(infos, func(i, j int) bool {
+		return infos[i].RequestID < infos[j].RequestID
+	})
+	if args.Limit != nil && *args.Limit < len(infos) {
+		infos = infos[len(infos)-*args.Limit:] // keep the most recent ones
+	}
+	rply := make([]map[string]interface{}, len(infos))
+	for i, info := range infos {
+		rply[i] = info.AsMapInterface()
+	}
+	*reply = rply
+	return
+}
+
+// queryItemIDs returns the IDs of the items to be checked, using the indexes when possible
+func (aS *AnalyzerService) queryItemIDs(hdrFltrs []*headerFilter) (itmIDs []string) {
+	var idxIDs utils.StringMap
+	for _, hdrFltr := range hdrFltrs {
+		if !hdrFltr.indexable() {
+			continue
+		}
+		fltrIDs := utils.StringMapFromSlice(aS.db.GetGroupItemIDs(hdrFltr.indexKey()))
+		if idxIDs == nil {
+			idxIDs = fltrIDs
+			continue
+		}
+		for itmID := range idxIDs {
+			if !fltrIDs.HasKey(itmID) {
+				delete(idxIDs, itmID)
+			}
+		}
+	}
+	if idxIDs == nil { // no index could be used, check all
+		return aS.db.GetItemIDs(utils.EmptyString)
+	}
+	return idxIDs.Slice()
+}
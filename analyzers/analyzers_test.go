@@ -0,0 +1,260 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package analyzers
+
+import (
+	"errors"
+	"net/rpc"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/cenkalti/rpc2"
+	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/cgrates/utils"
+)
+
+func newTestAnalyzerService(t *testing.T) *AnalyzerService {
+	cfg, err := config.NewDefaultCGRConfig()
+	if err != nil {
+		t.Fatal(err)
+	}
+	aS, err := NewAnalyzerService(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return aS
+}
+
+func TestAnalyzerSStringQuery(t *testing.T) {
+	aS := newTestAnalyzerService(t)
+	sTime := time.Date(2020, 4, 1, 10, 0, 0, 0, time.UTC)
+	aS.logTrafic(utils.CoreSv1Status, &utils.TenantWithArgDispatcher{}, map[string]string{"goroutines": "10"},
+		utils.EmptyString, utils.MetaJSON, "127.0.0.1:5565", "127.0.0.1:2012", sTime, sTime.Add(time.Millisecond))
+	aS.logTrafic(utils.AttributeSv1ProcessEvent, &utils.CGREvent{Tenant: "cgrates.org",
+		Event: map[string]interface{}{utils.Account: "1001"}}, nil,
+		utils.ErrNotFound.Error(), utils.MetaInternal, utils.MetaInternal, "*internal:*attributes", sTime, sTime.Add(time.Second))
+	aS.logTrafic(utils.AttributeSv1ProcessEvent, &utils.CGREvent{Tenant: "cgrates.org",
+		Event: map[string]interface{}{utils.Account: "1002"}}, nil,
+		utils.ErrNotFound.Error(), utils.MetaGOB, "127.0.0.1:5566", "127.0.0.1:2013", sTime, sTime.Add(time.Second))
+	aS.logTrafic(utils.AnalyzerSv1StringQuery, &QueryArgs{}, nil,
+		utils.EmptyString, utils.MetaJSON, "127.0.0.1:5565", "127.0.0.1:2012", sTime, sTime)
+
+	var rply []map[string]interface{}
+	if err := aS.V1StringQuery(&QueryArgs{HeaderFilters: "RequestMethod:AttributeSv1.ProcessEvent"}, &rply); err != nil {
+		t.Fatal(err)
+	} else if len(rply) != 2 {
+		t.Fatalf("Expected 2 API calls, received: %s", utils.ToJSON(rply))
+	} else if rply[0][utils.RequestID] != uint64(2) ||
+		rply[1][utils.RequestID] != uint64(3) {
+		t.Errorf("Unexpected order: %s", utils.ToJSON(rply))
+	}
+	if err := aS.V1StringQuery(&QueryArgs{HeaderFilters: "RequestMethod:AttributeSv1.ProcessEvent RequestEncoding:*gob"}, &rply); err != nil {
+		t.Fatal(err)
+	} else if len(rply) != 1 || rply[0][utils.RequestSource] != "127.0.0.1:5566" {
+		t.Errorf("Unexpected reply: %s", utils.ToJSON(rply))
+	}
+	if err := aS.V1StringQuery(&QueryArgs{HeaderFilters: "RequestMethod:AttributeSv1.*",
+		ContentFilters: []string{"*string:~RequestParams.Event.Account:1001"}}, &rply); err != nil {
+		t.Fatal(err)
+	} else if len(rply) != 1 || rply[0][utils.RequestEncoding] != utils.MetaInternal {
+		t.Errorf("Unexpected reply: %s", utils.ToJSON(rply))
+	}
+	eRply := map[string]interface{}{
+		utils.RequestID:          uint64(1),
+		utils.RequestMethod:      utils.CoreSv1Status,
+		utils.RequestEncoding:    utils.MetaJSON,
+		utils.RequestSource:      "127.0.0.1:5565",
+		utils.RequestDestination: "127.0.0.1:2012",
+		utils.RequestParams:      map[string]interface{}{},
+		utils.RequestStartTime:   sTime,
+		utils.RequestDuration:    time.Millisecond,
+		utils.Reply:              map[string]interface{}{"goroutines": "10"},
+		utils.ReplyError:         utils.EmptyString,
+	}
+	if err := aS.V1StringQuery(&QueryArgs{ContentFilters: []string{"*prefix:~Reply.goroutines:1"}}, &rply); err != nil {
+		t.Fatal(err)
+	} else if len(rply) != 1 || !reflect.DeepEqual(eRply, rply[0]) {
+		t.Errorf("Expected: %s, received: %s", utils.ToJSON(eRply), utils.ToJSON(rply))
+	}
+	if err := aS.V1StringQuery(&QueryArgs{HeaderFilters: "ReplyError:NOT_FOUND", Limit: utils.IntPointer(1)}, &rply); err != nil {
+		t.Fatal(err)
+	} else if len(rply) != 1 || rply[0][utils.RequestID] != uint64(3) {
+		t.Errorf("Unexpected reply: %s", utils.ToJSON(rply))
+	}
+	if err := aS.V1StringQuery(&QueryArgs{HeaderFilters: "RequestMethod:AnalyzerSv1.StringQuery"}, &rply); err != utils.ErrNotFound {
+		t.Errorf("Expected error: %v, received: %v", utils.ErrNotFound, err)
+	}
+	if err := aS.V1StringQuery(&QueryArgs{HeaderFilters: "RequestMethod"}, &rply); err == nil {
+		t.Error("Expected error for invalid header filter")
+	}
+}
+
+type mockConnector struct{}
+
+func (*mockConnector) Call(serviceMethod string, args, reply interface{}) error {
+	if serviceMethod != utils.CoreSv1Ping {
+		return errors.New("UNSUPPORTED_SERVICE_METHOD")
+	}
+	*reply.(*string) = utils.Pong
+	return nil
+}
+
+func TestAnalyzerSConnector(t *testing.T) {
+	aS := newTestAnalyzerService(t)
+	conn := aS.NewAnalyzerConnector(new(mockConnector), utils.MetaInternal, utils.MetaInternal, "*internal:*core")
+	var rply string
+	if err := conn.Call(utils.CoreSv1Ping, new(utils.CGREvent), &rply); err != nil {
+		t.Fatal(err)
+	}
+	if err := conn.Call(utils.CoreSv1Status, new(utils.CGREvent), &rply); err == nil {
+		t.Fatal("Expected error")
+	}
+	var infos []map[string]interface{}
+	if err := aS.V1StringQuery(&QueryArgs{HeaderFilters: "RequestDestination:*internal:*core"}, &infos); err != nil {
+		t.Fatal(err)
+	} else if len(infos) != 2 {
+		t.Fatalf("Expected 2 API calls, received: %s", utils.ToJSON(infos))
+	} else if infos[0][utils.Reply] != utils.Pong ||
+		infos[1][utils.ReplyError] != "UNSUPPORTED_SERVICE_METHOD" {
+		t.Errorf("Unexpected reply: %s", utils.ToJSON(infos))
+	}
+}
+
+type mockServerCodec struct {
+	resp *rpc.Response
+}
+
+func (*mockServerCodec) ReadRequestHeader(r *rpc.Request) error {
+	r.ServiceMethod = utils.CoreSv1Ping
+	r.Seq = 7
+	return nil
+}
+
+func (*mockServerCodec) ReadRequestBody(x interface{}) error {
+	*x.(*utils.CGREvent) = utils.CGREvent{Tenant: "cgrates.org"}
+	return nil
+}
+
+func (c *mockServerCodec) WriteResponse(r *rpc.Response, x interface{}) error {
+	c.resp = r
+	return nil
+}
+
+func (*mockServerCodec) Close() error { return nil }
+
+func TestAnalyzerSServerCodec(t *testing.T) {
+	aS := newTestAnalyzerService(t)
+	mc := new(mockServerCodec)
+	codec := aS.NewServerCodec(mc, utils.MetaJSON, "127.0.0.1:5565", "127.0.0.1:2012")
+	var req rpc.Request
+	if err := codec.ReadRequestHeader(&req); err != nil {
+		t.Fatal(err)
+	}
+	args := new(utils.CGREvent)
+	if err := codec.ReadRequestBody(args); err != nil {
+		t.Fatal(err)
+	}
+	rply := utils.Pong
+	if err := codec.WriteResponse(&rpc.Response{ServiceMethod: req.ServiceMethod, Seq: req.Seq}, &rply); err != nil {
+		t.Fatal(err)
+	} else if mc.resp == nil || mc.resp.Seq != 7 {
+		t.Errorf("Response not passed to the wrapped codec: %+v", mc.resp)
+	}
+	var infos []map[string]interface{}
+	if err := aS.V1StringQuery(&QueryArgs{HeaderFilters: "RequestMethod:CoreSv1.Ping RequestSource:127.0.0.1:5565",
+		ContentFilters: []string{"*string:~RequestParams.Tenant:cgrates.org"}}, &infos); err != nil {
+		t.Fatal(err)
+	} else if len(infos) != 1 || infos[0][utils.Reply] != utils.Pong {
+		t.Errorf("Unexpected reply: %s", utils.ToJSON(infos))
+	}
+}
+
+// mockBiRPCCodec receives one request followed by the reply to the request sent with Seq 3
+type mockBiRPCCodec struct {
+	read int
+}
+
+func (c *mockBiRPCCodec) ReadHeader(r *rpc2.Request, p *rpc2.Response) error {
+	c.read++
+	if c.read == 1 {
+		r.Method = utils.SessionSv1GetActiveSessions
+		r.Seq = 7
+		return nil
+	}
+	p.Seq = 3
+	return nil
+}
+
+func (*mockBiRPCCodec) ReadRequestBody(x interface{}) error {
+	*x.(*utils.CGREvent) = utils.CGREvent{Tenant: "cgrates.org"}
+	return nil
+}
+
+func (*mockBiRPCCodec) ReadResponseBody(x interface{}) error {
+	*x.(*string) = utils.OK
+	return nil
+}
+
+func (*mockBiRPCCodec) WriteRequest(*rpc2.Request, interface{}) error { return nil }
+
+func (*mockBiRPCCodec) WriteResponse(*rpc2.Response, interface{}) error { return nil }
+
+func (*mockBiRPCCodec) Close() error { return nil }
+
+func TestAnalyzerSBiRPCCodec(t *testing.T) {
+	aS := newTestAnalyzerService(t)
+	codec := aS.NewBiRPCCodec(new(mockBiRPCCodec), utils.MetaJSON, "127.0.0.1:5565", "127.0.0.1:2014")
+	var req rpc2.Request
+	var resp rpc2.Response
+	if err := codec.ReadHeader(&req, &resp); err != nil {
+		t.Fatal(err)
+	}
+	args := new(utils.CGREvent)
+	if err := codec.ReadRequestBody(args); err != nil {
+		t.Fatal(err)
+	}
+	rply := utils.Pong
+	if err := codec.WriteResponse(&rpc2.Response{Seq: req.Seq}, &rply); err != nil {
+		t.Fatal(err)
+	}
+	if err := codec.WriteRequest(&rpc2.Request{Seq: 3, Method: utils.SessionSv1DisconnectSession},
+		&utils.CGREvent{Tenant: "cgrates.net"}); err != nil {
+		t.Fatal(err)
+	}
+	req, resp = rpc2.Request{}, rpc2.Response{}
+	if err := codec.ReadHeader(&req, &resp); err != nil {
+		t.Fatal(err)
+	}
+	var reply string
+	if err := codec.ReadResponseBody(&reply); err != nil {
+		t.Fatal(err)
+	}
+	var infos []map[string]interface{}
+	if err := aS.V1StringQuery(&QueryArgs{HeaderFilters: "RequestSource:127.0.0.1:5565"}, &infos); err != nil {
+		t.Fatal(err)
+	} else if len(infos) != 1 || infos[0][utils.Reply] != utils.Pong {
+		t.Errorf("Unexpected received call: %s", utils.ToJSON(infos))
+	}
+	if err := aS.V1StringQuery(&QueryArgs{HeaderFilters: "RequestDestination:127.0.0.1:5565"}, &infos); err != nil {
+		t.Fatal(err)
+	} else if len(infos) != 1 || infos[0][utils.Reply] != utils.OK {
+		t.Errorf("Unexpected sent call: %s", utils.ToJSON(infos))
+	}
+}
@@ -0,0 +1,240 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package analyzers
+
+import (
+	"net/rpc"
+	"sync"
+	"time"
+
+	"github.com/cenkalti/rpc2"
+	"github.com/cgrates/cgrates/utils"
+	"github.com/cgrates/rpcclient"
+)
+
+// NewServerCodec wraps the server codec so the API calls received on it are captured
+func (aS *AnalyzerService) NewServerCodec(sc rpc.ServerCodec, enc, from, to string) rpc.ServerCodec {
+	return &AnalyzerServerCodec{
+		sc:   sc,
+		anz:  aS,
+		enc:  enc,
+		from: from,
+		to:   to,
+		reqs: make(map[uint64]*pendingRequest),
+	}
+}
+
+// pendingRequest keeps the request data until the reply is written
+type pendingRequest struct {
+	method string
+	params interface{}
+	sTime  time.Time
+}
+
+// AnalyzerServerCodec captures the API calls passing through a rpc.ServerCodec
+type AnalyzerServerCodec struct {
+	sc   rpc.ServerCodec
+	anz  *AnalyzerService
+	enc  string
+	from string
+	to   string
+
+	reqsLk sync.Mutex
+	reqIdx uint64 // sequence of the request which has the body read next
+	reqs   map[uint64]*pendingRequest
+}
+
+// ReadRequestHeader implements rpc.ServerCodec
+func (c *AnalyzerServerCodec) ReadRequestHeader(r *rpc.Request) (err error) {
+	if err = c.sc.ReadRequestHeader(r); err != nil {
+		return
+	}
+	c.reqsLk.Lock()
+	c.reqIdx = r.Seq
+	c.reqs[r.Seq] = &pendingRequest{
+		method: r.ServiceMethod,
+		sTime:  time.Now(),
+	}
+	c.reqsLk.Unlock()
+	return
+}
+
+// ReadRequestBody implements rpc.ServerCodec
+func (c *AnalyzerServerCodec) ReadRequestBody(x interface{}) (err error) {
+	err = c.sc.ReadRequestBody(x)
+	c.reqsLk.Lock()
+	if req, has := c.reqs[c.reqIdx]; has {
+		req.params = x
+	}
+	c.reqsLk.Unlock()
+	return
+}
+
+// WriteResponse implements rpc.ServerCodec
+func (c *AnalyzerServerCodec) WriteResponse(r *rpc.Response, x interface{}) error {
+	c.reqsLk.Lock()
+	req, has := c.reqs[r.Seq]
+	delete(c.reqs, r.Seq)
+	c.reqsLk.Unlock()
+	if has {
+		c.anz.logTrafic(req.method, req.params, x, r.Error,
+			c.enc, c.from, c.to, req.sTime, time.Now())
+	}
+	return c.sc.WriteResponse(r, x)
+}
+
+// Close implements rpc.ServerCodec
+func (c *AnalyzerServerCodec) Close() error { return c.sc.Close() }
+
+// NewAnalyzerConnector wraps the connection so the API calls sent over it are captured
+func (aS *AnalyzerService) NewAnalyzerConnector(conn rpcclient.ClientConnector,
+	enc, from, to string) rpcclient.ClientConnector {
+	return &AnalyzerConnector{
+		conn: conn,
+		anz:  aS,
+		enc:  enc,
+		from: from,
+		to:   to,
+	}
+}
+
+// AnalyzerConnector captures the API calls done on a rpcclient.ClientConnector
+type AnalyzerConnector struct {
+	conn rpcclient.ClientConnector
+	anz  *AnalyzerService
+	enc  string
+	from string
+	to   string
+}
+
+// Call implements rpcclient.ClientConnector
+func (c *AnalyzerConnector) Call(serviceMethod string, args, reply interface{}) (err error) {
+	sTime := time.Now()
+	err = c.conn.Call(serviceMethod, args, reply)
+	replyErr := utils.EmptyString
+	if err != nil {
+		replyErr = err.Error()
+	}
+	c.anz.logTrafic(serviceMethod, args, reply, replyErr,
+		c.enc, c.from, c.to, sTime, time.Now())
+	return
+}
+
+// NewBiRPCCodec wraps the BiRPC codec so the API calls received and sent on it are captured
+func (aS *AnalyzerService) NewBiRPCCodec(sc rpc2.Codec, enc, from, to string) rpc2.Codec {
+	return &AnalyzerBiRPCCodec{
+		sc:   sc,
+		anz:  aS,
+		enc:  enc,
+		from: from,
+		to:   to,
+		reqs: make(map[uint64]*pendingRequest),
+		reps: make(map[uint64]*pendingRequest),
+	}
+}
+
+// AnalyzerBiRPCCodec captures the API calls passing in both directions through a rpc2.Codec
+type AnalyzerBiRPCCodec struct {
+	sc   rpc2.Codec
+	anz  *AnalyzerService
+	enc  string
+	from string
+	to   string
+
+	reqsLk sync.Mutex
+	reqIdx uint64                     // sequence of the request which has the body read next
+	reqs   map[uint64]*pendingRequest // requests received from the remote side
+	repIdx uint64                     // sequence of the reply which has the body read next
+	repErr string                     // error of the reply which has the body read next
+	reps   map[uint64]*pendingRequest // requests sent to the remote side
+}
+
+// ReadHeader implements rpc2.Codec
+func (c *AnalyzerBiRPCCodec) ReadHeader(r *rpc2.Request, p *rpc2.Response) (err error) {
+	if err = c.sc.ReadHeader(r, p); err != nil {
+		return
+	}
+	c.reqsLk.Lock()
+	if r.Method != utils.EmptyString {
+		c.reqIdx = r.Seq
+		c.reqs[r.Seq] = &pendingRequest{
+			method: r.Method,
+			sTime:  time.Now(),
+		}
+	} else {
+		c.repIdx = p.Seq
+		c.repErr = p.Error
+	}
+	c.reqsLk.Unlock()
+	return
+}
+
+// ReadRequestBody implements rpc2.Codec
+func (c *AnalyzerBiRPCCodec) ReadRequestBody(x interface{}) (err error) {
+	err = c.sc.ReadRequestBody(x)
+	c.reqsLk.Lock()
+	if req, has := c.reqs[c.reqIdx]; has {
+		req.params = x
+	}
+	c.reqsLk.Unlock()
+	return
+}
+
+// ReadResponseBody implements rpc2.Codec
+func (c *AnalyzerBiRPCCodec) ReadResponseBody(x interface{}) (err error) {
+	err = c.sc.ReadResponseBody(x)
+	c.reqsLk.Lock()
+	rep, has := c.reps[c.repIdx]
+	delete(c.reps, c.repIdx)
+	repErr := c.repErr
+	c.reqsLk.Unlock()
+	if has {
+		c.anz.logTrafic(rep.method, rep.params, x, repErr,
+			c.enc, c.to, c.from, rep.sTime, time.Now())
+	}
+	return
+}
+
+// WriteRequest implements rpc2.Codec
+func (c *AnalyzerBiRPCCodec) WriteRequest(r *rpc2.Request, x interface{}) error {
+	c.reqsLk.Lock()
+	c.reps[r.Seq] = &pendingRequest{
+		method: r.Method,
+		params: x,
+		sTime:  time.Now(),
+	}
+	c.reqsLk.Unlock()
+	return c.sc.WriteRequest(r, x)
+}
+
+// WriteResponse implements rpc2.Codec
+func (c *AnalyzerBiRPCCodec) WriteResponse(r *rpc2.Response, x interface{}) error {
+	c.reqsLk.Lock()
+	req, has := c.reqs[r.Seq]
+	delete(c.reqs, r.Seq)
+	c.reqsLk.Unlock()
+	if has {
+		c.anz.logTrafic(req.method, req.params, x, r.Error,
+			c.enc, c.from, c.to, req.sTime, time.Now())
+	}
+	return c.sc.WriteResponse(r, x)
+}
+
+// Close implements rpc2.Codec
+func (c *AnalyzerBiRPCCodec) Close() error { return c.sc.Close() }
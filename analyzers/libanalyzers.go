@@ -0,0 +1,159 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package analyzers
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/cgrates/cgrates/utils"
+)
+
+// indexedFields are the InfoRPC fields indexed in the analyzer database
+var indexedFields = utils.NewStringSet([]string{utils.RequestMethod, utils.RequestEncoding,
+	utils.RequestSource, utils.RequestDestination})
+
+// InfoRPC is the information captured for one API call
+type InfoRPC struct {
+	RequestID          uint64
+	RequestMethod      string
+	RequestEncoding    string
+	RequestSource      string
+	RequestDestination string
+	RequestParams      interface{}
+	RequestStartTime   time.Time
+	RequestDuration    time.Duration
+	Reply              interface{}
+	ReplyError         string
+}
+
+// indexes returns the groups used to index the API call
+func (info *InfoRPC) indexes() (idx []string) {
+	for fldName, fldVal := range info.headers() {
+		if indexedFields.Has(fldName) {
+			idx = append(idx, utils.ConcatenatedKey(fldName, fldVal))
+		}
+	}
+	return
+}
+
+// headers returns the fields which can be queried by header filters
+func (info *InfoRPC) headers() map[string]string {
+	return map[string]string{
+		utils.RequestID:          utils.IfaceAsString(info.RequestID),
+		utils.RequestMethod:      info.RequestMethod,
+		utils.RequestEncoding:    info.RequestEncoding,
+		utils.RequestSource:      info.RequestSource,
+		utils.RequestDestination: info.RequestDestination,
+		utils.ReplyError:         info.ReplyError,
+	}
+}
+
+// passHeaderFilters checks if all header filters are matching
+func (info *InfoRPC) passHeaderFilters(hdrFltrs []*headerFilter) bool {
+	hdrs := info.headers()
+	for _, hdrFltr := range hdrFltrs {
+		if !hdrFltr.pass(hdrs[hdrFltr.field]) {
+			return false
+		}
+	}
+	return true
+}
+
+// AsMapInterface returns the API call as map so it can be replied or filtered
+func (info *InfoRPC) AsMapInterface() map[string]interface{} {
+	return map[string]interface{}{
+		utils.RequestID:          info.RequestID,
+		utils.RequestMethod:      info.RequestMethod,
+		utils.RequestEncoding:    info.RequestEncoding,
+		utils.RequestSource:      info.RequestSource,
+		utils.RequestDestination: info.RequestDestination,
+		utils.RequestParams:      info.RequestParams,
+		utils.RequestStartTime:   info.RequestStartTime,
+		utils.RequestDuration:    info.RequestDuration,
+		utils.Reply:              info.Reply,
+		utils.ReplyError:         info.ReplyError,
+	}
+}
+
+// QueryArgs are the arguments for AnalyzerSv1.StringQuery
+type QueryArgs struct {
+	HeaderFilters  string   // space separated Field:Value pairs, a trailing * matches by prefix, ie: "RequestMethod:CoreSv1.* RequestEncoding:*json"
+	ContentFilters []string // inline filters checked against the API call, ie: "*string:~RequestParams.Account:1001"
+	Limit          *int     // return only the last Limit API calls
+}
+
+// headerFilter is one Field:Value pair from QueryArgs.HeaderFilters
+type headerFilter struct {
+	field  string
+	value  string
+	prefix bool
+}
+
+// indexable returns true if the filter can be answered via the database indexes
+func (hf *headerFilter) indexable() bool {
+	return !hf.prefix && indexedFields.Has(hf.field)
+}
+
+// indexKey returns the group of the items matching this filter
+func (hf *headerFilter) indexKey() string {
+	return utils.ConcatenatedKey(hf.field, hf.value)
+}
+
+func (hf *headerFilter) pass(val string) bool {
+	if hf.prefix {
+		return strings.HasPrefix(val, hf.value)
+	}
+	return val == hf.value
+}
+
+// parseHeaderFilters parses the QueryArgs.HeaderFilters
+func parseHeaderFilters(qry string) (hdrFltrs []*headerFilter, err error) {
+	for _, fltrStr := range strings.Fields(qry) {
+		fltrSplt := strings.SplitN(fltrStr, utils.InInFieldSep, 2)
+		if len(fltrSplt) != 2 || fltrSplt[0] == utils.EmptyString {
+			return nil, fmt.Errorf("invalid header filter: <%s>", fltrStr)
+		}
+		hdrFltr := &headerFilter{field: fltrSplt[0], value: fltrSplt[1]}
+		if strings.HasSuffix(hdrFltr.value, utils.MASK_CHAR) {
+			hdrFltr.value = strings.TrimSuffix(hdrFltr.value, utils.MASK_CHAR)
+			hdrFltr.prefix = true
+		}
+		hdrFltrs = append(hdrFltrs, hdrFltr)
+	}
+	return
+}
+
+// toGenericData converts the API data into maps and slices so it can be navigated by filters
+// and stored without keeping references to the original objects
+func toGenericData(in interface{}) (out interface{}) {
+	if in == nil {
+		return
+	}
+	b, err := json.Marshal(in)
+	if err != nil {
+		return utils.IfaceAsString(in)
+	}
+	if err = json.Unmarshal(b, &out); err != nil {
+		return string(b)
+	}
+	return
+}
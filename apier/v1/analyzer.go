@@ -34,9 +34,9 @@ type AnalyzerSv1 struct {
 }
 
 // Call implements rpcclient.ClientConnector interface for internal RPC
-func (aSv1 *AnalyzerSv1) Call(serviceMethod string,
+func (alSv1 *AnalyzerSv1) Call(serviceMethod string,
 	args interface{}, reply interface{}) error {
-	return utils.APIerRPCCall(aSv1, serviceMethod, args, reply)
+	return utils.APIerRPCCall(alSv1, serviceMethod, args, reply)
 }
 
 // Ping return pong if the service is active
@@ -44,3 +44,8 @@ func (alSv1 *AnalyzerSv1) Ping(ign *utils.CGREvent, reply *string) error {
 	*reply = utils.Pong
 	return nil
 }
+
+// StringQuery returns the API calls captured by AnalyzerS which match the query
+func (alSv1 *AnalyzerSv1) StringQuery(args *analyzers.QueryArgs, reply *[]map[string]interface{}) error {
+	return alSv1.aS.V1StringQuery(args, reply)
+}
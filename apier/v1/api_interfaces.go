@@ -33,6 +33,7 @@ type ThresholdSv1Interface interface {
 	GetThresholdIDs(tenant *utils.TenantWithArgDispatcher, tIDs *[]string) error
 	GetThresholdsForEvent(args *engine.ArgsProcessEvent, reply *engine.Thresholds) error
 	GetThreshold(tntID *utils.TenantIDWithArgDispatcher, t *engine.Threshold) error
+	GetThresholdState(tntID *utils.TenantIDWithArgDispatcher, reply *engine.ThresholdState) error
 	ProcessEvent(args *engine.ArgsProcessEvent, tIDs *[]string) error
 	Ping(ign *utils.CGREventWithArgDispatcher, reply *string) error
 }
@@ -43,6 +44,7 @@ type StatSv1Interface interface {
 	GetStatQueuesForEvent(args *engine.StatsArgsProcessEvent, reply *[]string) (err error)
 	GetQueueStringMetrics(args *utils.TenantIDWithArgDispatcher, reply *map[string]string) (err error)
 	GetQueueFloatMetrics(args *utils.TenantIDWithArgDispatcher, reply *map[string]float64) (err error)
+	GetQueueHistory(args *utils.TenantIDWithArgDispatcher, reply *engine.StatQueueHistory) (err error)
 	Ping(ign *utils.CGREventWithArgDispatcher, reply *string) error
 }
 
@@ -83,6 +85,9 @@ type SessionSv1Interface interface {
 	TerminateSession(args *sessions.V1TerminateSessionArgs, rply *string) error
 	ProcessCDR(cgrEv *utils.CGREventWithArgDispatcher, rply *string) error
 	ProcessMessage(args *sessions.V1ProcessMessageArgs, rply *sessions.V1ProcessMessageReply) error
+	ReserveEvent(args *sessions.V1ReserveEventArgs, rply *time.Duration) error
+	CommitEvent(args *utils.CGREventWithArgDispatcher, rply *string) error
+	CancelEvent(args *utils.CGREventWithArgDispatcher, rply *string) error
 	ProcessEvent(args *sessions.V1ProcessEventArgs, rply *sessions.V1ProcessEventReply) error
 	GetCost(args *sessions.V1ProcessEventArgs, rply *sessions.V1GetCostReply) error
 	GetActiveSessions(args *utils.SessionFilter, rply *[]*sessions.ExternalSession) error
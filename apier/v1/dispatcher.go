@@ -243,6 +243,11 @@ func (dT *DispatcherThresholdSv1) GetThreshold(args *utils.TenantIDWithArgDispat
 	return dT.dS.ThresholdSv1GetThreshold(args, th)
 }
 
+func (dT *DispatcherThresholdSv1) GetThresholdState(args *utils.TenantIDWithArgDispatcher,
+	reply *engine.ThresholdState) error {
+	return dT.dS.ThresholdSv1GetThresholdState(args, reply)
+}
+
 func NewDispatcherStatSv1(dps *dispatchers.DispatcherService) *DispatcherStatSv1 {
 	return &DispatcherStatSv1{dS: dps}
 }
@@ -273,6 +278,12 @@ func (dSts *DispatcherStatSv1) GetQueueFloatMetrics(args *utils.TenantIDWithArgD
 	return dSts.dS.StatSv1GetQueueFloatMetrics(args, reply)
 }
 
+// GetQueueHistory implements StatSv1GetQueueHistory
+func (dSts *DispatcherStatSv1) GetQueueHistory(args *utils.TenantIDWithArgDispatcher,
+	reply *engine.StatQueueHistory) error {
+	return dSts.dS.StatSv1GetQueueHistory(args, reply)
+}
+
 func (dSts *DispatcherStatSv1) GetQueueIDs(args *utils.TenantWithArgDispatcher,
 	reply *[]string) error {
 	return dSts.dS.StatSv1GetQueueIDs(args, reply)
@@ -449,6 +460,24 @@ func (dS *DispatcherSessionSv1) ProcessMessage(args *sessions.V1ProcessMessageAr
 	return dS.dS.SessionSv1ProcessMessage(args, reply)
 }
 
+// ReserveEvent implements SessionSv1ReserveEvent
+func (dS *DispatcherSessionSv1) ReserveEvent(args *sessions.V1ReserveEventArgs,
+	reply *time.Duration) (err error) {
+	return dS.dS.SessionSv1ReserveEvent(args, reply)
+}
+
+// CommitEvent implements SessionSv1CommitEvent
+func (dS *DispatcherSessionSv1) CommitEvent(args *utils.CGREventWithArgDispatcher,
+	reply *string) (err error) {
+	return dS.dS.SessionSv1CommitEvent(args, reply)
+}
+
+// CancelEvent implements SessionSv1CancelEvent
+func (dS *DispatcherSessionSv1) CancelEvent(args *utils.CGREventWithArgDispatcher,
+	reply *string) (err error) {
+	return dS.dS.SessionSv1CancelEvent(args, reply)
+}
+
 // ProcessMessage implements SessionSv1ProcessMessage
 func (dS *DispatcherSessionSv1) ProcessEvent(args *sessions.V1ProcessEventArgs,
 	reply *sessions.V1ProcessEventReply) (err error) {
@@ -717,6 +746,12 @@ func (dSv1 DispatcherSv1) GetProfileForEvent(ev *dispatchers.DispatcherEvent,
 	return dSv1.dS.V1GetProfileForEvent(ev, dPrfl)
 }
 
+// GetHostsStatus returns the health state of the DispatcherHosts
+func (dSv1 DispatcherSv1) GetHostsStatus(args *utils.TenantArg,
+	reply *[]*dispatchers.HostStatus) error {
+	return dSv1.dS.V1GetHostsStatus(args, reply)
+}
+
 func (dSv1 DispatcherSv1) Apier(args *utils.MethodParameters, reply *interface{}) (err error) {
 	return dSv1.dS.V1Apier(new(APIerSv1), args, reply)
 }
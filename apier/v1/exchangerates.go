@@ -0,0 +1,79 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package v1
+
+import (
+	"fmt"
+
+	"github.com/cgrates/cgrates/engine"
+	"github.com/cgrates/cgrates/utils"
+)
+
+// SetExchangeRates stores the rates converting FromCurrency into ToCurrency
+func (apiv1 *APIerSv1) SetExchangeRates(exr *engine.ExchangeRates, reply *string) error {
+	if missing := utils.MissingStructFields(exr, []string{"FromCurrency", "ToCurrency"}); len(missing) != 0 {
+		return utils.NewErrMandatoryIeMissing(missing...)
+	}
+	if len(exr.Rates) == 0 {
+		return utils.NewErrMandatoryIeMissing("Rates")
+	}
+	if exr.FromCurrency == exr.ToCurrency {
+		return utils.NewErrServerError(fmt.Errorf("FromCurrency and ToCurrency are the same: %s", exr.FromCurrency))
+	}
+	for _, r := range exr.Rates {
+		if r == nil || r.Rate <= 0 {
+			return utils.NewErrServerError(fmt.Errorf("invalid exchange rate from %s to %s", exr.FromCurrency, exr.ToCurrency))
+		}
+	}
+	if err := apiv1.DataManager.SetExchangeRates(exr); err != nil {
+		return utils.APIErrorHandler(err)
+	}
+	*reply = utils.OK
+	return nil
+}
+
+type AttrGetExchangeRates struct {
+	FromCurrency string
+	ToCurrency   string
+}
+
+// GetExchangeRates returns the rates converting FromCurrency into ToCurrency
+func (apiv1 *APIerSv1) GetExchangeRates(attrs *AttrGetExchangeRates, reply *engine.ExchangeRates) error {
+	if missing := utils.MissingStructFields(attrs, []string{"FromCurrency", "ToCurrency"}); len(missing) != 0 {
+		return utils.NewErrMandatoryIeMissing(missing...)
+	}
+	exr, err := apiv1.DataManager.GetExchangeRates(attrs.FromCurrency, attrs.ToCurrency, false, utils.NonTransactional)
+	if err != nil {
+		return utils.APIErrorHandler(err)
+	}
+	*reply = *exr
+	return nil
+}
+
+// RemoveExchangeRates removes the rates converting FromCurrency into ToCurrency
+func (apiv1 *APIerSv1) RemoveExchangeRates(attrs *AttrGetExchangeRates, reply *string) error {
+	if missing := utils.MissingStructFields(attrs, []string{"FromCurrency", "ToCurrency"}); len(missing) != 0 {
+		return utils.NewErrMandatoryIeMissing(missing...)
+	}
+	if err := apiv1.DataManager.RemoveExchangeRates(attrs.FromCurrency, attrs.ToCurrency, utils.NonTransactional); err != nil {
+		return utils.APIErrorHandler(err)
+	}
+	*reply = utils.OK
+	return nil
+}
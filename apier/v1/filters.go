@@ -35,6 +35,9 @@ func (APIerSv1 *APIerSv1) SetFilter(arg *FilterWithCache, reply *string) error {
 	if missing := utils.MissingStructFields(arg.Filter, []string{"Tenant", "ID"}); len(missing) != 0 {
 		return utils.NewErrMandatoryIeMissing(missing...)
 	}
+	if err := arg.Filter.Compile(); err != nil {
+		return utils.APIErrorHandler(err)
+	}
 	if err := APIerSv1.DataManager.SetFilter(arg.Filter); err != nil {
 		return utils.APIErrorHandler(err)
 	}
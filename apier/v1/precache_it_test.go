@@ -274,6 +274,10 @@ func testPrecacheGetCacheStatsAfterRestart(t *testing.T) {
 			Items:  0,
 			Groups: 0,
 		},
+		utils.CacheSupplierCosts: {
+			Items:  0,
+			Groups: 0,
+		},
 		utils.CacheSupplierFilterIndexes: {
 			Items:  6,
 			Groups: 0,
@@ -298,6 +302,10 @@ func testPrecacheGetCacheStatsAfterRestart(t *testing.T) {
 			Items:  0,
 			Groups: 0,
 		},
+		utils.CacheExchangeRates: {
+			Items:  0,
+			Groups: 0,
+		},
 		utils.CacheDiameterMessages: {
 			Items:  0,
 			Groups: 0,
@@ -19,6 +19,8 @@ along with this program.  If not, see <http://www.gnu.org/licenses/>
 package v1
 
 import (
+	"time"
+
 	"github.com/cgrates/cgrates/dispatchers"
 	"github.com/cgrates/cgrates/sessions"
 	"github.com/cgrates/cgrates/utils"
@@ -77,6 +79,21 @@ func (ssv1 *SessionSv1) ProcessMessage(args *sessions.V1ProcessMessageArgs,
 	return ssv1.Ss.BiRPCv1ProcessMessage(nil, args, rply)
 }
 
+func (ssv1 *SessionSv1) ReserveEvent(args *sessions.V1ReserveEventArgs,
+	rply *time.Duration) error {
+	return ssv1.Ss.BiRPCv1ReserveEvent(nil, args, rply)
+}
+
+func (ssv1 *SessionSv1) CommitEvent(args *utils.CGREventWithArgDispatcher,
+	rply *string) error {
+	return ssv1.Ss.BiRPCv1CommitEvent(nil, args, rply)
+}
+
+func (ssv1 *SessionSv1) CancelEvent(args *utils.CGREventWithArgDispatcher,
+	rply *string) error {
+	return ssv1.Ss.BiRPCv1CancelEvent(nil, args, rply)
+}
+
 func (ssv1 *SessionSv1) ProcessEvent(args *sessions.V1ProcessEventArgs,
 	rply *sessions.V1ProcessEventReply) error {
 	return ssv1.Ss.BiRPCv1ProcessEvent(nil, args, rply)
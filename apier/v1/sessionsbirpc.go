@@ -19,6 +19,8 @@ along with this program.  If not, see <http://www.gnu.org/licenses/>
 package v1
 
 import (
+	"time"
+
 	"github.com/cenkalti/rpc2"
 	"github.com/cgrates/cgrates/sessions"
 	"github.com/cgrates/cgrates/utils"
@@ -41,6 +43,9 @@ func (ssv1 *SessionSv1) Handlers() map[string]interface{} {
 		utils.SessionSv1TerminateSession:          ssv1.BiRPCv1TerminateSession,
 		utils.SessionSv1ProcessCDR:                ssv1.BiRPCv1ProcessCDR,
 		utils.SessionSv1ProcessMessage:            ssv1.BiRPCv1ProcessMessage,
+		utils.SessionSv1ReserveEvent:              ssv1.BiRPCv1ReserveEvent,
+		utils.SessionSv1CommitEvent:               ssv1.BiRPCv1CommitEvent,
+		utils.SessionSv1CancelEvent:               ssv1.BiRPCv1CancelEvent,
 		utils.SessionSv1ProcessEvent:              ssv1.BiRPCv1ProcessEvent,
 		utils.SessionSv1GetCost:                   ssv1.BiRPCv1GetCost,
 
@@ -105,6 +110,21 @@ func (ssv1 *SessionSv1) BiRPCv1ProcessMessage(clnt *rpc2.Client, args *sessions.
 	return ssv1.Ss.BiRPCv1ProcessMessage(clnt, args, rply)
 }
 
+func (ssv1 *SessionSv1) BiRPCv1ReserveEvent(clnt *rpc2.Client, args *sessions.V1ReserveEventArgs,
+	rply *time.Duration) error {
+	return ssv1.Ss.BiRPCv1ReserveEvent(clnt, args, rply)
+}
+
+func (ssv1 *SessionSv1) BiRPCv1CommitEvent(clnt *rpc2.Client, args *utils.CGREventWithArgDispatcher,
+	rply *string) error {
+	return ssv1.Ss.BiRPCv1CommitEvent(clnt, args, rply)
+}
+
+func (ssv1 *SessionSv1) BiRPCv1CancelEvent(clnt *rpc2.Client, args *utils.CGREventWithArgDispatcher,
+	rply *string) error {
+	return ssv1.Ss.BiRPCv1CancelEvent(clnt, args, rply)
+}
+
 func (ssv1 *SessionSv1) BiRPCv1ProcessEvent(clnt *rpc2.Client, args *sessions.V1ProcessEventArgs,
 	rply *sessions.V1ProcessEventReply) error {
 	return ssv1.Ss.BiRPCv1ProcessEvent(clnt, args, rply)
@@ -198,6 +198,11 @@ func (stsv1 *StatSv1) GetQueueFloatMetrics(args *utils.TenantIDWithArgDispatcher
 	return stsv1.sS.V1GetQueueFloatMetrics(args.TenantID, reply)
 }
 
+// GetQueueHistory returns the window and per bucket metrics for a Queue
+func (stsv1 *StatSv1) GetQueueHistory(args *utils.TenantIDWithArgDispatcher, reply *engine.StatQueueHistory) (err error) {
+	return stsv1.sS.V1GetQueueHistory(args.TenantID, reply)
+}
+
 func (stSv1 *StatSv1) Ping(ign *utils.CGREventWithArgDispatcher, reply *string) error {
 	*reply = utils.Pong
 	return nil
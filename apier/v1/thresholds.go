@@ -55,6 +55,11 @@ func (tSv1 *ThresholdSv1) GetThreshold(tntID *utils.TenantIDWithArgDispatcher, t
 	return tSv1.tS.V1GetThreshold(tntID.TenantID, t)
 }
 
+// GetThresholdState returns the state of a stateful Threshold
+func (tSv1 *ThresholdSv1) GetThresholdState(tntID *utils.TenantIDWithArgDispatcher, reply *engine.ThresholdState) error {
+	return tSv1.tS.V1GetThresholdState(tntID.TenantID, reply)
+}
+
 // ProcessEvent will process an Event
 func (tSv1 *ThresholdSv1) ProcessEvent(args *engine.ArgsProcessEvent, tIDs *[]string) error {
 	return tSv1.tS.V1ProcessEvent(args, tIDs)
@@ -0,0 +1,90 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package v1
+
+import (
+	"github.com/cgrates/cgrates/utils"
+)
+
+// SetTPCalendar creates a new calendar within a tariff plan
+func (api *APIerSv1) SetTPCalendar(attrs utils.TPCalendar, reply *string) error {
+	if missing := utils.MissingStructFields(&attrs, []string{"TPid", "ID", "Dates"}); len(missing) != 0 { //Params missing
+		return utils.NewErrMandatoryIeMissing(missing...)
+	}
+	if err := api.StorDb.SetTPCalendars([]*utils.TPCalendar{&attrs}); err != nil {
+		return utils.APIErrorHandler(err)
+	}
+	*reply = utils.OK
+	return nil
+}
+
+type AttrGetTPCalendar struct {
+	TPid string // Tariff plan id
+	ID   string // Calendar id
+}
+
+// GetTPCalendar queries specific Calendar on Tariff plan
+func (api *APIerSv1) GetTPCalendar(attrs AttrGetTPCalendar, reply *utils.TPCalendar) error {
+	if missing := utils.MissingStructFields(&attrs, []string{"TPid", "ID"}); len(missing) != 0 { //Params missing
+		return utils.NewErrMandatoryIeMissing(missing...)
+	}
+	tpCals, err := api.StorDb.GetTPCalendars(attrs.TPid, attrs.ID)
+	if err != nil {
+		return utils.APIErrorHandler(err)
+	}
+	if len(tpCals) == 0 {
+		return utils.ErrNotFound
+	}
+	*reply = *tpCals[0]
+	return nil
+}
+
+type AttrGetTPCalendarIds struct {
+	TPid string // Tariff plan id
+	utils.PaginatorWithSearch
+}
+
+// GetTPCalendarIds queries calendar identities on specific tariff plan.
+func (api *APIerSv1) GetTPCalendarIds(attrs AttrGetTPCalendarIds, reply *[]string) error {
+	if missing := utils.MissingStructFields(&attrs, []string{"TPid"}); len(missing) != 0 { //Params missing
+		return utils.NewErrMandatoryIeMissing(missing...)
+	}
+	ids, err := api.StorDb.GetTpTableIds(attrs.TPid, utils.TBLTPCalendars,
+		utils.TPDistinctIds{"tag"}, nil, &attrs.PaginatorWithSearch)
+	if err != nil {
+		return utils.APIErrorHandler(err)
+	}
+	if ids == nil {
+		return utils.ErrNotFound
+	}
+	*reply = ids
+	return nil
+}
+
+// RemoveTPCalendar removes specific Calendar on Tariff plan
+func (api *APIerSv1) RemoveTPCalendar(attrs AttrGetTPCalendar, reply *string) error {
+	if missing := utils.MissingStructFields(&attrs, []string{"TPid", "ID"}); len(missing) != 0 { //Params missing
+		return utils.NewErrMandatoryIeMissing(missing...)
+	}
+	if err := api.StorDb.RemTpData(utils.TBLTPCalendars, attrs.TPid, map[string]string{"tag": attrs.ID}); err != nil {
+		return utils.APIErrorHandler(err)
+	}
+	*reply = utils.OK
+	return nil
+}
@@ -0,0 +1,95 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package v1
+
+import (
+	"github.com/cgrates/cgrates/utils"
+)
+
+// SetTPExchangeRates creates new exchange rates within a tariff plan
+func (api *APIerSv1) SetTPExchangeRates(attrs utils.TPExchangeRates, reply *string) error {
+	if missing := utils.MissingStructFields(&attrs,
+		[]string{"TPid", "FromCurrency", "ToCurrency", "Rates"}); len(missing) != 0 { //Params missing
+		return utils.NewErrMandatoryIeMissing(missing...)
+	}
+	if err := api.StorDb.SetTPExchangeRates([]*utils.TPExchangeRates{&attrs}); err != nil {
+		return utils.APIErrorHandler(err)
+	}
+	*reply = utils.OK
+	return nil
+}
+
+type AttrGetTPExchangeRates struct {
+	TPid         string // Tariff plan id
+	FromCurrency string
+	ToCurrency   string
+}
+
+// GetTPExchangeRates queries specific ExchangeRates on Tariff plan
+func (api *APIerSv1) GetTPExchangeRates(attrs AttrGetTPExchangeRates, reply *utils.TPExchangeRates) error {
+	if missing := utils.MissingStructFields(&attrs,
+		[]string{"TPid", "FromCurrency", "ToCurrency"}); len(missing) != 0 { //Params missing
+		return utils.NewErrMandatoryIeMissing(missing...)
+	}
+	tpExrs, err := api.StorDb.GetTPExchangeRates(attrs.TPid, attrs.FromCurrency, attrs.ToCurrency)
+	if err != nil {
+		return utils.APIErrorHandler(err)
+	}
+	if len(tpExrs) == 0 {
+		return utils.ErrNotFound
+	}
+	*reply = *tpExrs[0]
+	return nil
+}
+
+type AttrGetTPExchangeRatesIds struct {
+	TPid string // Tariff plan id
+	utils.PaginatorWithSearch
+}
+
+// GetTPExchangeRatesIds queries the FromCurrency:ToCurrency identities of the exchange rates on specific tariff plan.
+func (api *APIerSv1) GetTPExchangeRatesIds(attrs AttrGetTPExchangeRatesIds, reply *[]string) error {
+	if missing := utils.MissingStructFields(&attrs, []string{"TPid"}); len(missing) != 0 { //Params missing
+		return utils.NewErrMandatoryIeMissing(missing...)
+	}
+	ids, err := api.StorDb.GetTpTableIds(attrs.TPid, utils.TBLTPExchangeRates,
+		utils.TPDistinctIds{"from_currency", "to_currency"}, nil, &attrs.PaginatorWithSearch)
+	if err != nil {
+		return utils.APIErrorHandler(err)
+	}
+	if ids == nil {
+		return utils.ErrNotFound
+	}
+	*reply = ids
+	return nil
+}
+
+// RemoveTPExchangeRates removes specific ExchangeRates on Tariff plan
+func (api *APIerSv1) RemoveTPExchangeRates(attrs AttrGetTPExchangeRates, reply *string) error {
+	if missing := utils.MissingStructFields(&attrs,
+		[]string{"TPid", "FromCurrency", "ToCurrency"}); len(missing) != 0 { //Params missing
+		return utils.NewErrMandatoryIeMissing(missing...)
+	}
+	if err := api.StorDb.RemTpData(utils.TBLTPExchangeRates, attrs.TPid,
+		map[string]string{"from_currency": attrs.FromCurrency, "to_currency": attrs.ToCurrency}); err != nil {
+		return utils.APIErrorHandler(err)
+	}
+	*reply = utils.OK
+	return nil
+}
@@ -105,7 +105,9 @@ func testAPIerSv2itConnectDataDB(t *testing.T) {
 		apierCfg.DataDbCfg().DataDbHost, apierCfg.DataDbCfg().DataDbPort,
 		apierCfg.DataDbCfg().DataDbName, apierCfg.DataDbCfg().DataDbUser,
 		apierCfg.DataDbCfg().DataDbPass, apierCfg.GeneralCfg().DBDataEncoding,
-		apierCfg.DataDbCfg().DataDbSentinelName, apierCfg.DataDbCfg().Items)
+		apierCfg.DataDbCfg().DataDbSentinelName, apierCfg.DataDbCfg().RedisCluster,
+		apierCfg.DataDbCfg().RedisClusterSync, apierCfg.DataDbCfg().Items,
+		apierCfg.DataDbCfg().InternalPersistence)
 	if err != nil {
 		t.Fatal("Could not connect to Redis", err.Error())
 	}
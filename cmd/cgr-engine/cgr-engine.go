@@ -503,11 +503,11 @@ func main() {
 
 	ldrs := services.NewLoaderService(cfg, dmService, filterSChan, server, exitChan,
 		internalLoaderSChan, connManager)
-	anz := services.NewAnalyzerService(cfg, server, exitChan, internalAnalyzerSChan)
+	anz := services.NewAnalyzerService(cfg, server, exitChan, internalAnalyzerSChan, connManager)
 
 	srvManager.AddServices(attrS, chrS, tS, stS, reS, supS, schS, rals,
 		rals.GetResponder(), APIerSv1, APIerSv2, cdrS, smg,
-		services.NewEventReaderService(cfg, filterSChan, exitChan, connManager),
+		services.NewEventReaderService(cfg, filterSChan, exitChan, connManager, server),
 		services.NewDNSAgent(cfg, filterSChan, exitChan, connManager),
 		services.NewFreeswitchAgent(cfg, exitChan, connManager),
 		services.NewKamailioAgent(cfg, exitChan, connManager),
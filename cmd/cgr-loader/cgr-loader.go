@@ -292,7 +292,9 @@ func main() {
 			ldrCfg.DataDbCfg().DataDbHost, ldrCfg.DataDbCfg().DataDbPort,
 			ldrCfg.DataDbCfg().DataDbName, ldrCfg.DataDbCfg().DataDbUser,
 			ldrCfg.DataDbCfg().DataDbPass, ldrCfg.GeneralCfg().DBDataEncoding,
-			ldrCfg.DataDbCfg().DataDbSentinelName, ldrCfg.DataDbCfg().Items); err != nil {
+			ldrCfg.DataDbCfg().DataDbSentinelName, ldrCfg.DataDbCfg().RedisCluster,
+			ldrCfg.DataDbCfg().RedisClusterSync, ldrCfg.DataDbCfg().Items,
+			ldrCfg.DataDbCfg().InternalPersistence); err != nil {
 			log.Fatalf("Coud not open dataDB connection: %s", err.Error())
 		}
 		defer dataDB.Close()
@@ -305,7 +307,8 @@ func main() {
 			ldrCfg.StorDbCfg().Password, ldrCfg.GeneralCfg().DBDataEncoding, ldrCfg.StorDbCfg().SSLMode,
 			ldrCfg.StorDbCfg().MaxOpenConns, ldrCfg.StorDbCfg().MaxIdleConns,
 			ldrCfg.StorDbCfg().ConnMaxLifetime, ldrCfg.StorDbCfg().StringIndexedFields,
-			ldrCfg.StorDbCfg().PrefixIndexedFields, ldrCfg.StorDbCfg().Items); err != nil {
+			ldrCfg.StorDbCfg().PrefixIndexedFields, ldrCfg.StorDbCfg().Items,
+			ldrCfg.StorDbCfg().InternalPersistence); err != nil {
 			log.Fatalf("Coud not open storDB connection: %s", err.Error())
 		}
 		defer storDB.Close()
@@ -76,7 +76,9 @@ func durInternalRater(cd *engine.CallDescriptorWithArgDispatcher) (time.Duration
 		tstCfg.DataDbCfg().DataDbHost, tstCfg.DataDbCfg().DataDbPort,
 		tstCfg.DataDbCfg().DataDbName, tstCfg.DataDbCfg().DataDbUser,
 		tstCfg.DataDbCfg().DataDbPass, tstCfg.GeneralCfg().DBDataEncoding,
-		tstCfg.DataDbCfg().DataDbSentinelName, tstCfg.DataDbCfg().Items)
+		tstCfg.DataDbCfg().DataDbSentinelName, tstCfg.DataDbCfg().RedisCluster,
+		tstCfg.DataDbCfg().RedisClusterSync, tstCfg.DataDbCfg().Items,
+		tstCfg.DataDbCfg().InternalPersistence)
 	if err != nil {
 		return nilDuration, fmt.Errorf("Could not connect to data database: %s", err.Error())
 	}
@@ -84,7 +86,7 @@ func durInternalRater(cd *engine.CallDescriptorWithArgDispatcher) (time.Duration
 	defer dm.DataDB().Close()
 	engine.SetDataStorage(dm)
 	if err := dm.LoadDataDBCache(nil, nil, nil, nil, nil, nil, nil, nil,
-		nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil); err != nil {
+		nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil); err != nil {
 		return nilDuration, fmt.Errorf("Cache rating error: %s", err.Error())
 	}
 	log.Printf("Runnning %d cycles...", *runs)
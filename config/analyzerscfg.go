@@ -18,11 +18,17 @@ along with this program.  If not, see <http://www.gnu.org/licenses/>
 
 package config
 
-import "github.com/cgrates/cgrates/utils"
+import (
+	"time"
 
-// AttributeSCfg is the configuration of attribute service
+	"github.com/cgrates/cgrates/utils"
+)
+
+// AnalyzerSCfg is the configuration of analyzer service
 type AnalyzerSCfg struct {
 	Enabled bool
+	Limit   int           // maximum number of API calls kept, -1 for unlimited
+	TTL     time.Duration // how long an API call is kept, 0 to keep them until evicted by limit
 }
 
 func (alS *AnalyzerSCfg) loadFromJsonCfg(jsnCfg *AnalyzerSJsonCfg) (err error) {
@@ -32,11 +38,21 @@ func (alS *AnalyzerSCfg) loadFromJsonCfg(jsnCfg *AnalyzerSJsonCfg) (err error) {
 	if jsnCfg.Enabled != nil {
 		alS.Enabled = *jsnCfg.Enabled
 	}
+	if jsnCfg.Limit != nil {
+		alS.Limit = *jsnCfg.Limit
+	}
+	if jsnCfg.Ttl != nil {
+		if alS.TTL, err = utils.ParseDurationWithNanosecs(*jsnCfg.Ttl); err != nil {
+			return
+		}
+	}
 	return nil
 }
 
 func (alS *AnalyzerSCfg) AsMapInterface() map[string]interface{} {
 	return map[string]interface{}{
 		utils.EnabledCfg: alS.Enabled,
+		utils.LimitCfg:   alS.Limit,
+		utils.TTLCfg:     alS.TTL,
 	}
 }
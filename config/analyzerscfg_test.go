@@ -0,0 +1,59 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+package config
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestAnalyzerSCfgloadFromJsonCfg(t *testing.T) {
+	var alScfg, expected AnalyzerSCfg
+	if err := alScfg.loadFromJsonCfg(nil); err != nil {
+		t.Error(err)
+	} else if !reflect.DeepEqual(alScfg, expected) {
+		t.Errorf("Expected: %+v ,recived: %+v", expected, alScfg)
+	}
+	if err := alScfg.loadFromJsonCfg(new(AnalyzerSJsonCfg)); err != nil {
+		t.Error(err)
+	} else if !reflect.DeepEqual(alScfg, expected) {
+		t.Errorf("Expected: %+v ,recived: %+v", expected, alScfg)
+	}
+	cfgJSONStr := `{
+"analyzers":{
+	"enabled": true,
+	"limit": 100,
+	"ttl": "30m",
+	},
+}`
+	expected = AnalyzerSCfg{
+		Enabled: true,
+		Limit:   100,
+		TTL:     30 * time.Minute,
+	}
+	if jsnCfg, err := NewCgrJsonCfgFromBytes([]byte(cfgJSONStr)); err != nil {
+		t.Error(err)
+	} else if jsnAlSCfg, err := jsnCfg.AnalyzerCfgJson(); err != nil {
+		t.Error(err)
+	} else if err = alScfg.loadFromJsonCfg(jsnAlSCfg); err != nil {
+		t.Error(err)
+	} else if !reflect.DeepEqual(expected, alScfg) {
+		t.Errorf("Expected: %+v , recived: %+v", expected, alScfg)
+	}
+}
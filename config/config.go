@@ -140,6 +140,7 @@ func NewDefaultCGRConfig() (cfg *CGRConfig, err error) {
 	cfg.rpcConns = make(map[string]*RPCConn)
 	cfg.generalCfg = new(GeneralCfg)
 	cfg.generalCfg.NodeID = utils.UUIDSha1Prefix()
+	cfg.generalCfg.nodeIDGenerated = true
 	cfg.dataDbCfg = new(DataDbCfg)
 	cfg.dataDbCfg.Items = make(map[string]*ItemOpt)
 	cfg.storDbCfg = new(StorDbCfg)
@@ -301,7 +302,13 @@ var posibleLoaderTypes = utils.NewStringSet([]string{utils.MetaAttributes,
 
 var possibleReaderTypes = utils.NewStringSet([]string{utils.MetaFileCSV,
 	utils.MetaKafkajsonMap, utils.MetaFileXML, utils.MetaSQL, utils.MetaFileFWV,
-	utils.MetaPartialCSV, utils.MetaFlatstore, utils.MetaJSON, utils.META_NONE})
+	utils.MetaPartialCSV, utils.MetaFlatstore, utils.MetaJSON, utils.META_NONE,
+	utils.MetaAMQPjsonMap, utils.MetaAMQPV1jsonMap, utils.MetaSQSjsonMap,
+	utils.MetaS3jsonMap, utils.MetaS3CSV, utils.MetaHTTPPush})
+
+var possibleExporterTypes = utils.NewStringSet([]string{utils.MetaHTTPjsonMap,
+	utils.MetaHTTPPost, utils.MetaAMQPjsonMap, utils.MetaAMQPV1jsonMap,
+	utils.MetaSQSjsonMap, utils.MetaKafkajsonMap, utils.MetaS3jsonMap})
 
 func (cfg *CGRConfig) LazySanityCheck() {
 	for _, cdrePrfl := range cfg.cdrsCfg.OnlineCDRExports {
@@ -70,9 +70,17 @@ const CGRATES_CFG_JSON = `
 	"db_user": "cgrates", 					// username to use when connecting to data_db
 	"db_password": "", 						// password to use when connecting to data_db
 	"redis_sentinel":"",					// the name of sentinel when used
+	"redis_cluster": false,					// connect to a Redis Cluster, db_host being one of its nodes
+	"redis_cluster_sync": "5s",				// minimum interval between two refreshes of the cluster topology
 	"query_timeout":"10s",
 	"remote_conns":[],
 	"replication_conns":[],
+	"internal_persistence": {				// on-disk persistence in case of *internal
+		"path": "",							// directory holding the append-only log and the snapshot, empty keeps the data only in memory
+		"fsync": "*interval",				// when the log is synced to disk: <*always|*interval|*never>
+		"fsync_interval": "1s",				// sync interval in case of *interval fsync
+		"snapshot_interval": "5m",			// compact the log into a snapshot, 0 to compact only on start and shutdown
+	},
 	"items":{
 		"*accounts":{"remote":false, "replicate":false, "limit": -1, "ttl": "", "static_ttl": false}, 					
 		"*reverse_destinations": {"remote":false, "replicate":false, "limit": -1, "ttl": "", "static_ttl": false},
@@ -117,10 +125,18 @@ const CGRATES_CFG_JSON = `
 	"prefix_indexed_fields":[],				// prefix indexes on cdrs table to speed up queries, used in case of *internal
 	"query_timeout":"10s",
 	"sslmode":"disable",					// sslmode in case of *postgres
+	"internal_persistence": {				// on-disk persistence in case of *internal
+		"path": "",							// directory holding the append-only log and the snapshot, empty keeps the data only in memory
+		"fsync": "*interval",				// when the log is synced to disk: <*always|*interval|*never>
+		"fsync_interval": "1s",				// sync interval in case of *interval fsync
+		"snapshot_interval": "5m",			// compact the log into a snapshot, 0 to compact only on start and shutdown
+	},
 	"items":{
 		"session_costs": {"limit": -1, "ttl": "", "static_ttl": false}, 
 		"cdrs": {"limit": -1, "ttl": "", "static_ttl": false}, 		
 		"tp_timings":{"limit": -1, "ttl": "", "static_ttl": false}, 					
+		"tp_calendars":{"limit": -1, "ttl": "", "static_ttl": false},
+		"tp_exchange_rates":{"limit": -1, "ttl": "", "static_ttl": false},
 		"tp_destinations": {"limit": -1, "ttl": "", "static_ttl": false},
 		"tp_rates": {"limit": -1, "ttl": "", "static_ttl": false}, 
 		"tp_destination_rates": {"limit": -1, "ttl": "", "static_ttl": false}, 
@@ -195,6 +211,7 @@ const CGRATES_CFG_JSON = `
 		"*action_triggers": {"limit": -1, "ttl": "", "static_ttl": false, "precache": false, "replicate": false},		// action triggers caching
 		"*shared_groups": {"limit": -1, "ttl": "", "static_ttl": false, "precache": false, "replicate": false},			// shared groups caching
 		"*timings": {"limit": -1, "ttl": "", "static_ttl": false, "precache": false, "replicate": false},				// timings caching
+		"*exchange_rates": {"limit": -1, "ttl": "", "static_ttl": false, "precache": false, "replicate": false},		// exchange rates caching
 		"*resource_profiles": {"limit": -1, "ttl": "", "static_ttl": false, "precache": false, "replicate": false},		// control resource profiles caching
 		"*resources": {"limit": -1, "ttl": "", "static_ttl": false, "precache": false, "replicate": false},				// control resources caching
 		"*event_resources": {"limit": -1, "ttl": "", "static_ttl": false, "replicate": false},							// matching resources to events
@@ -226,6 +243,7 @@ const CGRATES_CFG_JSON = `
 		"*rpc_connections": {"limit": -1, "ttl": "", "static_ttl": false, "replicate": false},							// RPC connections caching
 		"*uch": {"limit": -1, "ttl": "3h", "static_ttl": false, "replicate": false},									// User cache
 		"*stir": {"limit": -1, "ttl": "3h", "static_ttl": false, "replicate": false},									// stirShaken cache keys
+		"*supplier_costs": {"limit": 0, "ttl": "1m", "static_ttl": false, "replicate": false},							// supplier costs caching for LCR
 	},
 	"replication_conns": [],
 },
@@ -235,6 +253,7 @@ const CGRATES_CFG_JSON = `
 	"stats_conns": [],						// connections to StatS for <*stats> filters, empty to disable stats functionality: <""|*internal|$rpc_conns_id>
 	"resources_conns": [],					// connections to ResourceS for <*resources> filters, empty to disable stats functionality: <""|*internal|$rpc_conns_id>
 	"apiers_conns": [],						// connections to RALs for <*accounts> filters, empty to disable stats functionality: <""|*internal|$rpc_conns_id>
+	"tenant_timezones": {},					// timezones used by <*cron> filters per tenant, defaults to general default_timezone: {$tenant: <UTC|Local|$IANA_TZ_DB>}
 },
 
 
@@ -338,6 +357,7 @@ const CGRATES_CFG_JSON = `
 				{"tag": "Usage", "path": "*cgreq.Usage", "type": "*variable", "value": "~*req.13", "mandatory": true},
 			],
 			"cache_dump_fields": [],
+			"exporters": [],									// mirror the events to posters: [{"id", "type": <*http_json_map|*http_post|*amqp_json_map|*kafka_json_map...>, "export_path", "attempts", "fields"}]
 		},
 	],
 },
@@ -377,6 +397,8 @@ const CGRATES_CFG_JSON = `
 		"privatekey_path": "",				// the path to the private key
 	},
 	"scheduler_conns": [],					// connections to SchedulerS in case of *dynaprepaid request
+	"store_interval": "0",					// persist active sessions into dataDB to restore them on restart: <""|0|-1|$dur>, 0 to disable, -1 on each change
+	"reservation_ttl": "1m",				// time after an event reservation which is not committed is automatically released, 0 to disable
 },
 
 
@@ -553,6 +575,7 @@ const CGRATES_CFG_JSON = `
 	//"string_indexed_fields": [],			// query indexes based on these fields for faster processing
 	"prefix_indexed_fields": [],			// query indexes based on these fields for faster processing
 	"nested_fields": false,					// determines which field is checked when matching indexed filters(true: all; false: only the one on the first level)
+	"shared_state": false,					// allocate and release the usages atomically in data_db so the limits hold across engines sharing it
 },
 
 
@@ -588,7 +611,8 @@ const CGRATES_CFG_JSON = `
 	"resources_conns": [],					// connections to ResourceS for *res sorting, empty to disable functionality: <""|*internal|$rpc_conns_id>
 	"stats_conns": [],						// connections to StatS for *stats sorting, empty to disable stats functionality: <""|*internal|$rpc_conns_id>
 	"rals_conns": [],						// connections to Rater for calculating cost, empty to disable stats functionality: <""|*internal|$rpc_conns_id>
-	"default_ratio":1						// default ratio used in case of *load strategy
+	"default_ratio":1,						// default ratio used in case of *load strategy
+	"cost_time_bucket": "1m",				// granularity of the SetupTime when caching supplier costs
 },
 
 
@@ -649,6 +673,7 @@ const CGRATES_CFG_JSON = `
 					{"tag": "Stored", "path": "Stored", "type": "*variable", "value": "~8"},
 					{"tag": "Weight", "path": "Weight", "type": "*variable", "value": "~9"},
 					{"tag": "ThresholdIDs", "path": "ThresholdIDs", "type": "*variable", "value": "~10"},
+					{"tag": "RateInterval", "path": "RateInterval", "type": "*variable", "value": "~11"},
 				],
 			},
 			{
@@ -668,6 +693,8 @@ const CGRATES_CFG_JSON = `
 					{"tag": "Stored", "path": "Stored", "type": "*variable", "value": "~10"},
 					{"tag": "Weight", "path": "Weight", "type": "*variable", "value": "~11"},
 					{"tag": "ThresholdIDs", "path": "ThresholdIDs", "type": "*variable", "value": "~12"},
+					{"tag": "BucketInterval", "path": "BucketInterval", "type": "*variable", "value": "~13"},
+					{"tag": "BucketCount", "path": "BucketCount", "type": "*variable", "value": "~14"},
 				],
 			},
 			{
@@ -685,6 +712,10 @@ const CGRATES_CFG_JSON = `
 					{"tag": "Weight", "path": "Weight", "type": "*variable", "value": "~8"},
 					{"tag": "ActionIDs", "path": "ActionIDs", "type": "*variable", "value": "~9"},
 					{"tag": "Async", "path": "Async", "type": "*variable", "value": "~10"},
+					{"tag": "WarningFilterIDs", "path": "WarningFilterIDs", "type": "*variable", "value": "~11"},
+					{"tag": "CriticalFilterIDs", "path": "CriticalFilterIDs", "type": "*variable", "value": "~12"},
+					{"tag": "EscalationActionIDs", "path": "EscalationActionIDs", "type": "*variable", "value": "~13"},
+					{"tag": "RecoveryActionIDs", "path": "RecoveryActionIDs", "type": "*variable", "value": "~14"},
 				],
 			},
 			{
@@ -752,6 +783,24 @@ const CGRATES_CFG_JSON = `
 					{"tag": "TLS", "path": "TLS", "type": "*variable", "value": "~4"},
 				],
 			},
+			{
+				"type": "*calendars",								// data source type
+				"file_name": "Calendars.csv",						// file name in the tp_in_dir
+				"fields": [
+					{"tag": "ID", "path": "ID", "type": "*variable", "value": "~0", "mandatory": true},
+					{"tag": "Date", "path": "Date", "type": "*variable", "value": "~1"},
+				],
+			},
+			{
+				"type": "*exchange_rates",							// data source type
+				"file_name": "ExchangeRates.csv",					// file name in the tp_in_dir
+				"fields": [
+					{"tag": "FromCurrency", "path": "FromCurrency", "type": "*variable", "value": "~0", "mandatory": true},
+					{"tag": "ToCurrency", "path": "ToCurrency", "type": "*variable", "value": "~1", "mandatory": true},
+					{"tag": "ActivationTime", "path": "ActivationTime", "type": "*variable", "value": "~2"},
+					{"tag": "Rate", "path": "Rate", "type": "*variable", "value": "~3"},
+				],
+			},
 		],
 	},
 ],
@@ -832,11 +881,17 @@ const CGRATES_CFG_JSON = `
 	"prefix_indexed_fields": [],			// query indexes based on these fields for faster processing
 	"nested_fields": false,					// determines which field is checked when matching indexed filters(true: all; false: only the one on the first level)
 	"attributes_conns": [],					// connections to AttributeS for API authorization, empty to disable auth functionality: <""|*internal|$rpc_conns_id>
+	"health_check_interval": "0",			// ping the dispatcher hosts regularly: <""|$dur>, 0 to disable health checks
+	"health_check_method": "CoreSv1.Ping",	// API used to ping the dispatcher hosts
+	"health_check_failures": 3,				// consecutive errors after which a host is marked down and skipped
+	"health_check_successes": 2,			// consecutive successful checks after which a host is marked back up
 },
 
 
 "analyzers":{								// AnalyzerS config
-	"enabled":false							// starts AnalyzerS service: <true|false>.
+	"enabled": false,						// starts AnalyzerS service: <true|false>.
+	"limit": 10000,							// maximum number of API calls kept in memory: <-1|0-n> (-1 for unlimited)
+	"ttl": "1h",							// how long an API call is kept in memory, <""|$dur> (empty to keep until evicted by limit)
 },
 
 
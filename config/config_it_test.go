@@ -265,6 +265,7 @@ func testCGRConfigReloadSupplierS(t *testing.T) {
 		ResponderSConns:     []string{},
 		IndexedSelects:      true,
 		DefaultRatio:        1,
+		CostTimeBucket:      time.Minute,
 	}
 	if !reflect.DeepEqual(expAttr, cfg.SupplierSCfg()) {
 		t.Errorf("Expected %s , received: %s ", utils.ToJSON(expAttr), utils.ToJSON(cfg.SupplierSCfg()))
@@ -459,6 +460,7 @@ func testCGRConfigReloadERs(t *testing.T) {
 				Flags:           flagsDefault,
 				Fields:          content,
 				CacheDumpFields: []*FCTemplate{},
+				Exporters:       []*EventExporterCfg{},
 				XmlRootPath:     utils.HierarchyPath{utils.EmptyString},
 			},
 			{
@@ -472,6 +474,7 @@ func testCGRConfigReloadERs(t *testing.T) {
 				Flags:           flags,
 				Fields:          content,
 				CacheDumpFields: []*FCTemplate{},
+				Exporters:       []*EventExporterCfg{},
 				XmlRootPath:     utils.HierarchyPath{utils.EmptyString},
 			},
 		},
@@ -837,6 +840,7 @@ func testCgrCfgV1ReloadConfigSection(t *testing.T) {
 				"PartialCacheExpiryAction": "",
 				"PartialRecordCache":       0,
 				"CacheDumpFields":          []interface{}{},
+				"Exporters":                []interface{}{},
 				"ConcurrentReqs":           1024,
 				"Fields":                   content,
 				"FieldSep":                 ",",
@@ -855,6 +859,7 @@ func testCgrCfgV1ReloadConfigSection(t *testing.T) {
 			},
 			map[string]interface{}{
 				"CacheDumpFields": []interface{}{},
+				"Exporters":       []interface{}{},
 				"ConcurrentReqs":  1024,
 				"FieldSep":        ",",
 				"Filters":         nil,
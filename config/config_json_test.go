@@ -103,6 +103,9 @@ func TestCacheJsonCfg(t *testing.T) {
 			utils.CacheTimings: &CacheParamJsonCfg{Limit: utils.IntPointer(-1),
 				Ttl: utils.StringPointer(""), Static_ttl: utils.BoolPointer(false),
 				Precache: utils.BoolPointer(false), Replicate: utils.BoolPointer(false)},
+			utils.CacheExchangeRates: &CacheParamJsonCfg{Limit: utils.IntPointer(-1),
+				Ttl: utils.StringPointer(""), Static_ttl: utils.BoolPointer(false),
+				Precache: utils.BoolPointer(false), Replicate: utils.BoolPointer(false)},
 			utils.CacheResourceProfiles: &CacheParamJsonCfg{Limit: utils.IntPointer(-1),
 				Ttl: utils.StringPointer(""), Static_ttl: utils.BoolPointer(false),
 				Precache: utils.BoolPointer(false), Replicate: utils.BoolPointer(false)},
@@ -196,6 +199,9 @@ func TestCacheJsonCfg(t *testing.T) {
 			utils.CacheSTIR: &CacheParamJsonCfg{Limit: utils.IntPointer(-1),
 				Ttl: utils.StringPointer("3h"), Static_ttl: utils.BoolPointer(false),
 				Replicate: utils.BoolPointer(false)},
+			utils.CacheSupplierCosts: &CacheParamJsonCfg{Limit: utils.IntPointer(0),
+				Ttl: utils.StringPointer("1m"), Static_ttl: utils.BoolPointer(false),
+				Replicate: utils.BoolPointer(false)},
 		},
 		Replication_conns: &[]string{},
 	}
@@ -225,16 +231,24 @@ func TestDfListenJsonCfg(t *testing.T) {
 
 func TestDfDataDbJsonCfg(t *testing.T) {
 	eCfg := &DbJsonCfg{
-		Db_type:           utils.StringPointer("*redis"),
-		Db_host:           utils.StringPointer("127.0.0.1"),
-		Db_port:           utils.IntPointer(6379),
-		Db_name:           utils.StringPointer("10"),
-		Db_user:           utils.StringPointer("cgrates"),
-		Db_password:       utils.StringPointer(""),
-		Redis_sentinel:    utils.StringPointer(""),
-		Query_timeout:     utils.StringPointer("10s"),
-		Replication_conns: &[]string{},
-		Remote_conns:      &[]string{},
+		Db_type:            utils.StringPointer("*redis"),
+		Db_host:            utils.StringPointer("127.0.0.1"),
+		Db_port:            utils.IntPointer(6379),
+		Db_name:            utils.StringPointer("10"),
+		Db_user:            utils.StringPointer("cgrates"),
+		Db_password:        utils.StringPointer(""),
+		Redis_sentinel:     utils.StringPointer(""),
+		Redis_cluster:      utils.BoolPointer(false),
+		Redis_cluster_sync: utils.StringPointer("5s"),
+		Query_timeout:      utils.StringPointer("10s"),
+		Replication_conns:  &[]string{},
+		Remote_conns:       &[]string{},
+		Internal_persistence: &InternalPersistenceJsonCfg{
+			Path:              utils.StringPointer(""),
+			Fsync:             utils.StringPointer(utils.MetaInterval),
+			Fsync_interval:    utils.StringPointer("1s"),
+			Snapshot_interval: utils.StringPointer("5m"),
+		},
 		Items: &map[string]*ItemOptJson{
 			utils.MetaAccounts: &ItemOptJson{
 				Replicate:  utils.BoolPointer(false),
@@ -410,11 +424,25 @@ func TestDfStorDBJsonCfg(t *testing.T) {
 		Prefix_indexed_fields: &[]string{},
 		Query_timeout:         utils.StringPointer("10s"),
 		Sslmode:               utils.StringPointer(utils.PostgressSSLModeDisable),
+		Internal_persistence: &InternalPersistenceJsonCfg{
+			Path:              utils.StringPointer(""),
+			Fsync:             utils.StringPointer(utils.MetaInterval),
+			Fsync_interval:    utils.StringPointer("1s"),
+			Snapshot_interval: utils.StringPointer("5m"),
+		},
 		Items: &map[string]*ItemOptJson{
 			utils.TBLTPTimings: &ItemOptJson{
 				Ttl:        utils.StringPointer(utils.EmptyString),
 				Limit:      utils.IntPointer(-1),
 				Static_ttl: utils.BoolPointer(false)},
+			utils.TBLTPCalendars: &ItemOptJson{
+				Ttl:        utils.StringPointer(utils.EmptyString),
+				Limit:      utils.IntPointer(-1),
+				Static_ttl: utils.BoolPointer(false)},
+			utils.TBLTPExchangeRates: &ItemOptJson{
+				Ttl:        utils.StringPointer(utils.EmptyString),
+				Limit:      utils.IntPointer(-1),
+				Static_ttl: utils.BoolPointer(false)},
 			utils.TBLTPDestinations: &ItemOptJson{
 				Ttl:        utils.StringPointer(utils.EmptyString),
 				Limit:      utils.IntPointer(-1),
@@ -695,6 +723,8 @@ func TestSmgJsonCfg(t *testing.T) {
 		Client_protocol:       utils.Float64Pointer(1.0),
 		Channel_sync_interval: utils.StringPointer("0"),
 		Terminate_attempts:    utils.IntPointer(5),
+		Store_interval:        utils.StringPointer("0"),
+		Reservation_ttl:       utils.StringPointer("1m"),
 		Alterable_fields:      &[]string{},
 		Stir: &STIRJsonCfg{
 			Allowed_attest:      &[]string{utils.META_ANY},
@@ -1033,9 +1063,10 @@ func TestDfChargerServJsonCfg(t *testing.T) {
 
 func TestDfFilterSJsonCfg(t *testing.T) {
 	eCfg := &FilterSJsonCfg{
-		Stats_conns:     &[]string{},
-		Resources_conns: &[]string{},
-		Apiers_conns:    &[]string{},
+		Stats_conns:      &[]string{},
+		Resources_conns:  &[]string{},
+		Apiers_conns:     &[]string{},
+		Tenant_timezones: &map[string]string{},
 	}
 	if cfg, err := dfCgrJsonCfg.FilterSJsonCfg(); err != nil {
 		t.Error(err)
@@ -1053,6 +1084,7 @@ func TestDfResourceLimiterSJsonCfg(t *testing.T) {
 		String_indexed_fields: nil,
 		Prefix_indexed_fields: &[]string{},
 		Nested_fields:         utils.BoolPointer(false),
+		Shared_state:          utils.BoolPointer(false),
 	}
 	if cfg, err := dfCgrJsonCfg.ResourceSJsonCfg(); err != nil {
 		t.Error(err)
@@ -1106,6 +1138,7 @@ func TestDfSupplierSJsonCfg(t *testing.T) {
 		Stats_conns:           &[]string{},
 		Rals_conns:            &[]string{},
 		Default_ratio:         utils.IntPointer(1),
+		Cost_time_bucket:      utils.StringPointer("1m"),
 		Nested_fields:         utils.BoolPointer(false),
 	}
 	if cfg, err := dfCgrJsonCfg.SupplierSJsonCfg(); err != nil {
@@ -1263,6 +1296,10 @@ func TestDfLoaderJsonCfg(t *testing.T) {
 							Path:  utils.StringPointer("ThresholdIDs"),
 							Type:  utils.StringPointer(utils.MetaVariable),
 							Value: utils.StringPointer("~10")},
+						{Tag: utils.StringPointer("RateInterval"),
+							Path:  utils.StringPointer("RateInterval"),
+							Type:  utils.StringPointer(utils.MetaVariable),
+							Value: utils.StringPointer("~11")},
 					},
 				},
 				{
@@ -1324,6 +1361,14 @@ func TestDfLoaderJsonCfg(t *testing.T) {
 							Path:  utils.StringPointer("ThresholdIDs"),
 							Type:  utils.StringPointer(utils.MetaVariable),
 							Value: utils.StringPointer("~12")},
+						{Tag: utils.StringPointer("BucketInterval"),
+							Path:  utils.StringPointer("BucketInterval"),
+							Type:  utils.StringPointer(utils.MetaVariable),
+							Value: utils.StringPointer("~13")},
+						{Tag: utils.StringPointer("BucketCount"),
+							Path:  utils.StringPointer("BucketCount"),
+							Type:  utils.StringPointer(utils.MetaVariable),
+							Value: utils.StringPointer("~14")},
 					},
 				},
 				{
@@ -1376,6 +1421,22 @@ func TestDfLoaderJsonCfg(t *testing.T) {
 							Path:  utils.StringPointer("Async"),
 							Type:  utils.StringPointer(utils.MetaVariable),
 							Value: utils.StringPointer("~10")},
+						{Tag: utils.StringPointer("WarningFilterIDs"),
+							Path:  utils.StringPointer("WarningFilterIDs"),
+							Type:  utils.StringPointer(utils.MetaVariable),
+							Value: utils.StringPointer("~11")},
+						{Tag: utils.StringPointer("CriticalFilterIDs"),
+							Path:  utils.StringPointer("CriticalFilterIDs"),
+							Type:  utils.StringPointer(utils.MetaVariable),
+							Value: utils.StringPointer("~12")},
+						{Tag: utils.StringPointer("EscalationActionIDs"),
+							Path:  utils.StringPointer("EscalationActionIDs"),
+							Type:  utils.StringPointer(utils.MetaVariable),
+							Value: utils.StringPointer("~13")},
+						{Tag: utils.StringPointer("RecoveryActionIDs"),
+							Path:  utils.StringPointer("RecoveryActionIDs"),
+							Type:  utils.StringPointer(utils.MetaVariable),
+							Value: utils.StringPointer("~14")},
 					},
 				},
 				{
@@ -1574,6 +1635,45 @@ func TestDfLoaderJsonCfg(t *testing.T) {
 							Value: utils.StringPointer("~4")},
 					},
 				},
+				{
+					Type:      utils.StringPointer(utils.MetaCalendars),
+					File_name: utils.StringPointer(utils.CalendarsCsv),
+					Fields: &[]*FcTemplateJsonCfg{
+						{Tag: utils.StringPointer(utils.ID),
+							Path:      utils.StringPointer(utils.ID),
+							Type:      utils.StringPointer(utils.MetaVariable),
+							Value:     utils.StringPointer("~0"),
+							Mandatory: utils.BoolPointer(true)},
+						{Tag: utils.StringPointer("Date"),
+							Path:  utils.StringPointer("Date"),
+							Type:  utils.StringPointer(utils.MetaVariable),
+							Value: utils.StringPointer("~1")},
+					},
+				},
+				{
+					Type:      utils.StringPointer(utils.MetaExchangeRates),
+					File_name: utils.StringPointer(utils.ExchangeRatesCsv),
+					Fields: &[]*FcTemplateJsonCfg{
+						{Tag: utils.StringPointer("FromCurrency"),
+							Path:      utils.StringPointer("FromCurrency"),
+							Type:      utils.StringPointer(utils.MetaVariable),
+							Value:     utils.StringPointer("~0"),
+							Mandatory: utils.BoolPointer(true)},
+						{Tag: utils.StringPointer("ToCurrency"),
+							Path:      utils.StringPointer("ToCurrency"),
+							Type:      utils.StringPointer(utils.MetaVariable),
+							Value:     utils.StringPointer("~1"),
+							Mandatory: utils.BoolPointer(true)},
+						{Tag: utils.StringPointer("ActivationTime"),
+							Path:  utils.StringPointer("ActivationTime"),
+							Type:  utils.StringPointer(utils.MetaVariable),
+							Value: utils.StringPointer("~2")},
+						{Tag: utils.StringPointer("Rate"),
+							Path:  utils.StringPointer("Rate"),
+							Type:  utils.StringPointer(utils.MetaVariable),
+							Value: utils.StringPointer("~3")},
+					},
+				},
 			},
 		},
 	}
@@ -1714,12 +1814,16 @@ func TestDfHttpJsonCfg(t *testing.T) {
 
 func TestDfDispatcherSJsonCfg(t *testing.T) {
 	eCfg := &DispatcherSJsonCfg{
-		Enabled:               utils.BoolPointer(false),
-		Indexed_selects:       utils.BoolPointer(true),
-		String_indexed_fields: nil,
-		Prefix_indexed_fields: &[]string{},
-		Attributes_conns:      &[]string{},
-		Nested_fields:         utils.BoolPointer(false),
+		Enabled:                utils.BoolPointer(false),
+		Indexed_selects:        utils.BoolPointer(true),
+		String_indexed_fields:  nil,
+		Prefix_indexed_fields:  &[]string{},
+		Attributes_conns:       &[]string{},
+		Nested_fields:          utils.BoolPointer(false),
+		Health_check_interval:  utils.StringPointer("0"),
+		Health_check_method:    utils.StringPointer(utils.CoreSv1Ping),
+		Health_check_failures:  utils.IntPointer(3),
+		Health_check_successes: utils.IntPointer(2),
 	}
 	if cfg, err := dfCgrJsonCfg.DispatcherSJsonCfg(); err != nil {
 		t.Error(err)
@@ -1792,6 +1896,8 @@ func TestDfTlsCfg(t *testing.T) {
 func TestDfAnalyzerCfg(t *testing.T) {
 	eCfg := &AnalyzerSJsonCfg{
 		Enabled: utils.BoolPointer(false),
+		Limit:   utils.IntPointer(10000),
+		Ttl:     utils.StringPointer("1h"),
 	}
 	if cfg, err := dfCgrJsonCfg.AnalyzerCfgJson(); err != nil {
 		t.Error(err)
@@ -1859,6 +1965,7 @@ func TestDfEventReaderCfg(t *testing.T) {
 				Flags:               &[]string{},
 				Fields:              &cdrFields,
 				Cache_dump_fields:   &[]*FcTemplateJsonCfg{},
+				Exporters:           &[]*EventExporterJsonCfg{},
 			},
 		},
 	}
@@ -337,6 +337,17 @@ func TestCgrCfgJSONDefaultsjsnDataDb(t *testing.T) {
 	if len(cgrCfg.DataDbCfg().RplConns) != 0 {
 		t.Errorf("Expecting:  0, recived: %+v", len(cgrCfg.DataDbCfg().RplConns))
 	}
+	eIntPers := &InternalPersistenceCfg{
+		Fsync:            utils.MetaInterval,
+		FsyncInterval:    time.Second,
+		SnapshotInterval: 5 * time.Minute,
+	}
+	if !reflect.DeepEqual(eIntPers, cgrCfg.DataDbCfg().InternalPersistence) {
+		t.Errorf("Expecting: %+v , recived: %+v", eIntPers, cgrCfg.DataDbCfg().InternalPersistence)
+	}
+	if cgrCfg.DataDbCfg().InternalPersistence.Enabled() {
+		t.Error("Expecting the internal persistence disabled by default")
+	}
 }
 
 func TestCgrCfgJSONDefaultsStorDB(t *testing.T) {
@@ -609,6 +620,7 @@ func TestCgrCfgJSONDefaultsSMGenericCfg(t *testing.T) {
 			DefaultAttest:      "A",
 		},
 		SchedulerConns: []string{},
+		ReservationTTL: time.Minute,
 	}
 	if !reflect.DeepEqual(eSessionSCfg, cgrCfg.sessionSCfg) {
 		t.Errorf("expecting: %s, received: %s",
@@ -639,6 +651,8 @@ func TestCgrCfgJSONDefaultsCacheCFG(t *testing.T) {
 				TTL: time.Duration(0), StaticTTL: false, Precache: false},
 			utils.CacheTimings: &CacheParamCfg{Limit: -1,
 				TTL: time.Duration(0), StaticTTL: false, Precache: false},
+			utils.CacheExchangeRates: &CacheParamCfg{Limit: -1,
+				TTL: time.Duration(0), StaticTTL: false, Precache: false},
 			utils.CacheResourceProfiles: &CacheParamCfg{Limit: -1,
 				TTL: time.Duration(0), StaticTTL: false, Precache: false},
 			utils.CacheResources: &CacheParamCfg{Limit: -1,
@@ -701,6 +715,8 @@ func TestCgrCfgJSONDefaultsCacheCFG(t *testing.T) {
 				TTL: time.Duration(3 * time.Hour), StaticTTL: false},
 			utils.CacheSTIR: &CacheParamCfg{Limit: -1,
 				TTL: time.Duration(3 * time.Hour), StaticTTL: false},
+			utils.CacheSupplierCosts: &CacheParamCfg{Limit: 0,
+				TTL: time.Duration(time.Minute), StaticTTL: false},
 		},
 		ReplicationConns: []string{},
 	}
@@ -768,9 +784,10 @@ func TestCgrCfgJSONDefaultssteriskAgentCfg(t *testing.T) {
 
 func TestCgrCfgJSONDefaultFiltersCfg(t *testing.T) {
 	eFiltersCfg := &FilterSCfg{
-		StatSConns:     []string{},
-		ResourceSConns: []string{},
-		ApierSConns:    []string{},
+		StatSConns:      []string{},
+		ResourceSConns:  []string{},
+		ApierSConns:     []string{},
+		TenantTimezones: map[string]string{},
 	}
 	if !reflect.DeepEqual(cgrCfg.filterSCfg, eFiltersCfg) {
 		t.Errorf("received: %+v, expecting: %+v", cgrCfg.filterSCfg, eFiltersCfg)
@@ -843,6 +860,7 @@ func TestCgrCfgJSONDefaultSupplierSCfg(t *testing.T) {
 		StatSConns:          []string{},
 		ResponderSConns:     []string{},
 		DefaultRatio:        1,
+		CostTimeBucket:      time.Minute,
 	}
 	if !reflect.DeepEqual(eSupplSCfg, cgrCfg.supplierSCfg) {
 		t.Errorf("received: %+v, expecting: %+v", eSupplSCfg, cgrCfg.supplierSCfg)
@@ -1204,6 +1222,11 @@ func TestCgrLoaderCfgITDefaults(t *testing.T) {
 							Type:   utils.MetaVariable,
 							Value:  NewRSRParsersMustCompile("~10", true, utils.INFIELD_SEP),
 							Layout: time.RFC3339},
+						{Tag: "RateInterval",
+							Path:   "RateInterval",
+							Type:   utils.MetaVariable,
+							Value:  NewRSRParsersMustCompile("~11", true, utils.INFIELD_SEP),
+							Layout: time.RFC3339},
 					},
 				},
 				{
@@ -1278,6 +1301,16 @@ func TestCgrLoaderCfgITDefaults(t *testing.T) {
 							Type:   utils.MetaVariable,
 							Value:  NewRSRParsersMustCompile("~12", true, utils.INFIELD_SEP),
 							Layout: time.RFC3339},
+						{Tag: "BucketInterval",
+							Path:   "BucketInterval",
+							Type:   utils.MetaVariable,
+							Value:  NewRSRParsersMustCompile("~13", true, utils.INFIELD_SEP),
+							Layout: time.RFC3339},
+						{Tag: "BucketCount",
+							Path:   "BucketCount",
+							Type:   utils.MetaVariable,
+							Value:  NewRSRParsersMustCompile("~14", true, utils.INFIELD_SEP),
+							Layout: time.RFC3339},
 					},
 				},
 				{
@@ -1341,6 +1374,26 @@ func TestCgrLoaderCfgITDefaults(t *testing.T) {
 							Type:   utils.MetaVariable,
 							Value:  NewRSRParsersMustCompile("~10", true, utils.INFIELD_SEP),
 							Layout: time.RFC3339},
+						{Tag: "WarningFilterIDs",
+							Path:   "WarningFilterIDs",
+							Type:   utils.MetaVariable,
+							Value:  NewRSRParsersMustCompile("~11", true, utils.INFIELD_SEP),
+							Layout: time.RFC3339},
+						{Tag: "CriticalFilterIDs",
+							Path:   "CriticalFilterIDs",
+							Type:   utils.MetaVariable,
+							Value:  NewRSRParsersMustCompile("~12", true, utils.INFIELD_SEP),
+							Layout: time.RFC3339},
+						{Tag: "EscalationActionIDs",
+							Path:   "EscalationActionIDs",
+							Type:   utils.MetaVariable,
+							Value:  NewRSRParsersMustCompile("~13", true, utils.INFIELD_SEP),
+							Layout: time.RFC3339},
+						{Tag: "RecoveryActionIDs",
+							Path:   "RecoveryActionIDs",
+							Type:   utils.MetaVariable,
+							Value:  NewRSRParsersMustCompile("~14", true, utils.INFIELD_SEP),
+							Layout: time.RFC3339},
 					},
 				},
 				{
@@ -1594,6 +1647,54 @@ func TestCgrLoaderCfgITDefaults(t *testing.T) {
 						},
 					},
 				},
+				{
+					Type:     utils.MetaCalendars,
+					Filename: utils.CalendarsCsv,
+					Fields: []*FCTemplate{
+						{Tag: "ID",
+							Path:      "ID",
+							Type:      utils.MetaVariable,
+							Value:     NewRSRParsersMustCompile("~0", true, utils.INFIELD_SEP),
+							Mandatory: true,
+							Layout:    time.RFC3339},
+						{Tag: "Date",
+							Path:   "Date",
+							Type:   utils.MetaVariable,
+							Value:  NewRSRParsersMustCompile("~1", true, utils.INFIELD_SEP),
+							Layout: time.RFC3339,
+						},
+					},
+				},
+				{
+					Type:     utils.MetaExchangeRates,
+					Filename: utils.ExchangeRatesCsv,
+					Fields: []*FCTemplate{
+						{Tag: "FromCurrency",
+							Path:      "FromCurrency",
+							Type:      utils.MetaVariable,
+							Value:     NewRSRParsersMustCompile("~0", true, utils.INFIELD_SEP),
+							Mandatory: true,
+							Layout:    time.RFC3339},
+						{Tag: "ToCurrency",
+							Path:      "ToCurrency",
+							Type:      utils.MetaVariable,
+							Value:     NewRSRParsersMustCompile("~1", true, utils.INFIELD_SEP),
+							Mandatory: true,
+							Layout:    time.RFC3339},
+						{Tag: "ActivationTime",
+							Path:   "ActivationTime",
+							Type:   utils.MetaVariable,
+							Value:  NewRSRParsersMustCompile("~2", true, utils.INFIELD_SEP),
+							Layout: time.RFC3339,
+						},
+						{Tag: "Rate",
+							Path:   "Rate",
+							Type:   utils.MetaVariable,
+							Value:  NewRSRParsersMustCompile("~3", true, utils.INFIELD_SEP),
+							Layout: time.RFC3339,
+						},
+					},
+				},
 			},
 		},
 	}
@@ -1605,11 +1706,14 @@ func TestCgrLoaderCfgITDefaults(t *testing.T) {
 
 func TestCgrCfgJSONDefaultDispatcherSCfg(t *testing.T) {
 	eDspSCfg := &DispatcherSCfg{
-		Enabled:             false,
-		IndexedSelects:      true,
-		StringIndexedFields: nil,
-		PrefixIndexedFields: &[]string{},
-		AttributeSConns:     []string{},
+		Enabled:              false,
+		IndexedSelects:       true,
+		StringIndexedFields:  nil,
+		PrefixIndexedFields:  &[]string{},
+		AttributeSConns:      []string{},
+		HealthCheckMethod:    utils.CoreSv1Ping,
+		HealthCheckFailures:  3,
+		HealthCheckSuccesses: 2,
 	}
 	if !reflect.DeepEqual(cgrCfg.dispatcherSCfg, eDspSCfg) {
 		t.Errorf("received: %+v, expecting: %+v", cgrCfg.dispatcherSCfg, eDspSCfg)
@@ -1716,6 +1820,8 @@ func TestCfgTlsCfg(t *testing.T) {
 func TestCgrCfgJSONDefaultAnalyzerSCfg(t *testing.T) {
 	aSCfg := &AnalyzerSCfg{
 		Enabled: false,
+		Limit:   10000,
+		TTL:     time.Hour,
 	}
 	if !reflect.DeepEqual(cgrCfg.analyzerSCfg, aSCfg) {
 		t.Errorf("received: %+v, expecting: %+v", cgrCfg.analyzerSCfg, aSCfg)
@@ -1826,6 +1932,7 @@ func TestCgrCdfEventReader(t *testing.T) {
 						Value: NewRSRParsersMustCompile("~*req.13", true, utils.INFIELD_SEP), Mandatory: true, Layout: time.RFC3339},
 				},
 				CacheDumpFields: []*FCTemplate{},
+				Exporters:       []*EventExporterCfg{},
 			},
 		},
 	}
@@ -1873,6 +1980,7 @@ func TestCgrCfgEventReaderDefault(t *testing.T) {
 				Value: NewRSRParsersMustCompile("~*req.13", true, utils.INFIELD_SEP), Mandatory: true, Layout: time.RFC3339},
 		},
 		CacheDumpFields: make([]*FCTemplate, 0),
+		Exporters:       make([]*EventExporterCfg, 0),
 	}
 	if !reflect.DeepEqual(cgrCfg.dfltEvRdr, eCfg) {
 		t.Errorf("received: %+v,\n expecting: %+v", utils.ToJSON(cgrCfg.dfltEvRdr), utils.ToJSON(eCfg))
@@ -204,6 +204,15 @@ func (cfg *CGRConfig) checkConfigSanity() error {
 				return fmt.Errorf("<%s> the following protected field can't be altered by session: <%s>", utils.SessionS, alfld)
 			}
 		}
+		if cfg.sessionSCfg.StoreInterval != 0 { // the sessions are restored only by the same node after restart
+			if cfg.generalCfg.nodeIDGenerated {
+				return fmt.Errorf("<%s> the store_interval field needs the node_id to be defined within general section", utils.SessionS)
+			}
+			if cfg.dataDbCfg.DataDbType == utils.INTERNAL &&
+				!cfg.dataDbCfg.InternalPersistence.Enabled() {
+				return fmt.Errorf("<%s> the store_interval field needs the internal_persistence when DataDB is *internal", utils.SessionS)
+			}
+		}
 	}
 
 	// FreeSWITCHAgent checks
@@ -403,6 +412,14 @@ func (cfg *CGRConfig) checkConfigSanity() error {
 			if !possibleReaderTypes.Has(rdr.Type) {
 				return fmt.Errorf("<%s> unsupported data type: %s for reader with ID: %s", utils.ERs, rdr.Type, rdr.ID)
 			}
+			for _, exp := range rdr.Exporters {
+				if !possibleExporterTypes.Has(exp.Type) {
+					return fmt.Errorf("<%s> unsupported exporter type: %s for exporter with ID: %s of reader with ID: %s", utils.ERs, exp.Type, exp.ID, rdr.ID)
+				}
+				if exp.ExportPath == utils.EmptyString {
+					return fmt.Errorf("<%s> empty ExportPath for exporter with ID: %s of reader with ID: %s", utils.ERs, exp.ID, rdr.ID)
+				}
+			}
 
 			switch rdr.Type {
 			case utils.MetaFileCSV, utils.MetaPartialCSV, utils.MetaFlatstore:
@@ -414,10 +431,22 @@ func (cfg *CGRConfig) checkConfigSanity() error {
 				if rdr.FieldSep == utils.EmptyString {
 					return fmt.Errorf("<%s> empty FieldSep for reader with ID: %s", utils.ERs, rdr.ID)
 				}
-			case utils.MetaKafkajsonMap:
+			case utils.MetaKafkajsonMap, utils.MetaAMQPjsonMap,
+				utils.MetaAMQPV1jsonMap, utils.MetaSQSjsonMap:
 				if rdr.RunDelay > 0 {
 					return fmt.Errorf("<%s> the RunDelay field can not be bigger than zero for reader with ID: %s", utils.ERs, rdr.ID)
 				}
+			case utils.MetaS3jsonMap, utils.MetaS3CSV:
+				if rdr.RunDelay < 0 {
+					return fmt.Errorf("<%s> the RunDelay field can not be negative for reader with ID: %s", utils.ERs, rdr.ID)
+				}
+				if rdr.Type == utils.MetaS3CSV && rdr.FieldSep == utils.EmptyString {
+					return fmt.Errorf("<%s> empty FieldSep for reader with ID: %s", utils.ERs, rdr.ID)
+				}
+			case utils.MetaHTTPPush:
+				if !strings.HasPrefix(rdr.SourcePath, utils.Slash) {
+					return fmt.Errorf("<%s> the SourcePath field must be an URL path for reader with ID: %s", utils.ERs, rdr.ID)
+				}
 			case utils.MetaFileXML, utils.MetaFileFWV, utils.MetaJSON:
 				for _, dir := range []string{rdr.ProcessedPath, rdr.SourcePath} {
 					if _, err := os.Stat(dir); err != nil && os.IsNotExist(err) {
@@ -435,7 +464,20 @@ func (cfg *CGRConfig) checkConfigSanity() error {
 			return fmt.Errorf("<%s> unsuported sslmode for storDB", utils.StorDB)
 		}
 	}
+	if cfg.storDbCfg.Type == utils.INTERNAL {
+		if err := checkInternalPersistence(cfg.storDbCfg.InternalPersistence); err != nil {
+			return fmt.Errorf("<%s> %s", utils.StorDB, err.Error())
+		}
+	}
 	// DataDB sanity checks
+	if cfg.dataDbCfg.DataDbType == utils.REDIS && cfg.dataDbCfg.RedisCluster {
+		if cfg.dataDbCfg.DataDbSentinelName != utils.EmptyString {
+			return fmt.Errorf("<%s> redis_cluster and redis_sentinel cannot be used together", utils.DataDB)
+		}
+		if cfg.dataDbCfg.DataDbName != "0" {
+			return fmt.Errorf("<%s> Redis Cluster supports only db_name 0, received: %s", utils.DataDB, cfg.dataDbCfg.DataDbName)
+		}
+	}
 	if cfg.dataDbCfg.DataDbType == utils.INTERNAL {
 		for key, config := range cfg.cacheCfg.Partitions {
 			if utils.CacheDataDBPartitions.Has(key) && config.Limit != 0 {
@@ -445,12 +487,18 @@ func (cfg *CGRConfig) checkConfigSanity() error {
 		if cfg.resourceSCfg.Enabled == true && cfg.resourceSCfg.StoreInterval != -1 {
 			return fmt.Errorf("<%s> the StoreInterval field needs to be -1 when DataBD is *internal, received : %d", utils.ResourceS, cfg.resourceSCfg.StoreInterval)
 		}
+		if cfg.resourceSCfg.Enabled && cfg.resourceSCfg.SharedState {
+			return fmt.Errorf("<%s> shared_state needs a DataDB shared between engines, received: %s", utils.ResourceS, utils.MetaInternal)
+		}
 		if cfg.statsCfg.Enabled == true && cfg.statsCfg.StoreInterval != -1 {
 			return fmt.Errorf("<%s> the StoreInterval field needs to be -1 when DataBD is *internal, received : %d", utils.StatS, cfg.statsCfg.StoreInterval)
 		}
 		if cfg.thresholdSCfg.Enabled == true && cfg.thresholdSCfg.StoreInterval != -1 {
 			return fmt.Errorf("<%s> the StoreInterval field needs to be -1 when DataBD is *internal, received : %d", utils.ThresholdS, cfg.thresholdSCfg.StoreInterval)
 		}
+		if err := checkInternalPersistence(cfg.dataDbCfg.InternalPersistence); err != nil {
+			return fmt.Errorf("<%s> %s", utils.DataDB, err.Error())
+		}
 	}
 	for item, val := range cfg.dataDbCfg.Items {
 		if val.Remote == true && len(cfg.dataDbCfg.RmtConns) == 0 {
@@ -487,6 +535,17 @@ func (cfg *CGRConfig) checkConfigSanity() error {
 				return fmt.Errorf("<%s> connection with id: <%s> not defined", utils.DispatcherS, connID)
 			}
 		}
+		if cfg.dispatcherSCfg.HealthCheckInterval > 0 {
+			if cfg.dispatcherSCfg.HealthCheckMethod == utils.EmptyString {
+				return fmt.Errorf("<%s> empty %s", utils.DispatcherS, utils.HealthCheckMethodCfg)
+			}
+			if cfg.dispatcherSCfg.HealthCheckFailures < 1 {
+				return fmt.Errorf("<%s> %s should be at least 1", utils.DispatcherS, utils.HealthCheckFailuresCfg)
+			}
+			if cfg.dispatcherSCfg.HealthCheckSuccesses < 1 {
+				return fmt.Errorf("<%s> %s should be at least 1", utils.DispatcherS, utils.HealthCheckSuccessesCfg)
+			}
+		}
 	}
 	// Cache check
 	for _, connID := range cfg.cacheCfg.ReplicationConns {
@@ -533,3 +592,24 @@ func (cfg *CGRConfig) checkConfigSanity() error {
 
 	return nil
 }
+
+// checkInternalPersistence validates the persistence options of the *internal database
+func checkInternalPersistence(ip *InternalPersistenceCfg) error {
+	if !ip.Enabled() {
+		return nil
+	}
+	switch ip.Fsync {
+	case utils.MetaAlways, utils.MetaNever:
+	case utils.MetaInterval:
+		if ip.FsyncInterval <= 0 {
+			return fmt.Errorf("fsync_interval needs to be positive for %s fsync, received: %s",
+				utils.MetaInterval, ip.FsyncInterval)
+		}
+	default:
+		return fmt.Errorf("unsupported fsync policy: <%s>", ip.Fsync)
+	}
+	if ip.SnapshotInterval < 0 {
+		return fmt.Errorf("snapshot_interval cannot be negative, received: %s", ip.SnapshotInterval)
+	}
+	return nil
+}
@@ -18,7 +18,9 @@ along with this program.  If not, see <http://www.gnu.org/licenses/>
 package config
 
 import (
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/cgrates/cgrates/utils"
 )
@@ -319,6 +321,24 @@ func TestConfigSanitySessionS(t *testing.T) {
 	if err := cfg.checkConfigSanity(); err == nil || err.Error() != expected {
 		t.Errorf("Expecting: %+q  received: %+q", expected, err)
 	}
+	cfg.sessionSCfg.AlterableFields = utils.NewStringSet(nil)
+
+	cfg.sessionSCfg.StoreInterval = time.Minute
+	expected = "<SessionS> the store_interval field needs the node_id to be defined within general section"
+	if err := cfg.checkConfigSanity(); err == nil || err.Error() != expected {
+		t.Errorf("Expecting: %+q  received: %+q", expected, err)
+	}
+	cfg.generalCfg.nodeIDGenerated = false
+	cfg.dataDbCfg.DataDbType = utils.INTERNAL
+	cfg.dataDbCfg.InternalPersistence = nil
+	expected = "<SessionS> the store_interval field needs the internal_persistence when DataDB is *internal"
+	if err := cfg.checkConfigSanity(); err == nil || err.Error() != expected {
+		t.Errorf("Expecting: %+q  received: %+q", expected, err)
+	}
+	cfg.dataDbCfg.InternalPersistence = &InternalPersistenceCfg{Path: "/tmp/internal_db"}
+	if err := cfg.checkConfigSanity(); err != nil && strings.HasPrefix(err.Error(), "<SessionS>") {
+		t.Error(err)
+	}
 }
 
 func TestConfigSanityFreeSWITCHAgent(t *testing.T) {
@@ -684,6 +704,32 @@ func TestConfigSanityEventReader(t *testing.T) {
 	if err := cfg.checkConfigSanity(); err == nil || err.Error() != expected {
 		t.Errorf("Expecting: %+q  received: %+q", expected, err)
 	}
+	cfg.ersCfg.Readers[0] = &EventReaderCfg{
+		ID:   "test4",
+		Type: utils.META_NONE,
+		Exporters: []*EventExporterCfg{
+			{ID: "exp1", Type: utils.MetaFileCSV, ExportPath: "/tmp"},
+		},
+	}
+	expected = "<ERs> unsupported exporter type: *file_csv for exporter with ID: exp1 of reader with ID: test4"
+	if err := cfg.checkConfigSanity(); err == nil || err.Error() != expected {
+		t.Errorf("Expecting: %+q  received: %+q", expected, err)
+	}
+	cfg.ersCfg.Readers[0].Exporters[0].Type = utils.MetaKafkajsonMap
+	cfg.ersCfg.Readers[0].Exporters[0].ExportPath = utils.EmptyString
+	expected = "<ERs> empty ExportPath for exporter with ID: exp1 of reader with ID: test4"
+	if err := cfg.checkConfigSanity(); err == nil || err.Error() != expected {
+		t.Errorf("Expecting: %+q  received: %+q", expected, err)
+	}
+	cfg.ersCfg.Readers[0] = &EventReaderCfg{
+		ID:         "test4",
+		Type:       utils.MetaHTTPPush,
+		SourcePath: "cdrs?reply_code=202",
+	}
+	expected = "<ERs> the SourcePath field must be an URL path for reader with ID: test4"
+	if err := cfg.checkConfigSanity(); err == nil || err.Error() != expected {
+		t.Errorf("Expecting: %+q  received: %+q", expected, err)
+	}
 	cfg.ersCfg.Readers[0] = &EventReaderCfg{
 		ID:            "test5",
 		Type:          utils.MetaFileXML,
@@ -725,6 +771,19 @@ func TestConfigSanityStorDB(t *testing.T) {
 
 func TestConfigSanityDataDB(t *testing.T) {
 	cfg, _ = NewDefaultCGRConfig()
+	cfg.dataDbCfg.RedisCluster = true
+	expected := "<data_db> Redis Cluster supports only db_name 0, received: 10"
+	if err := cfg.checkConfigSanity(); err == nil || err.Error() != expected {
+		t.Errorf("Expecting: %+q  received: %+q", expected, err)
+	}
+	cfg.dataDbCfg.DataDbSentinelName = "sentinel1"
+	expected = "<data_db> redis_cluster and redis_sentinel cannot be used together"
+	if err := cfg.checkConfigSanity(); err == nil || err.Error() != expected {
+		t.Errorf("Expecting: %+q  received: %+q", expected, err)
+	}
+	cfg.dataDbCfg.DataDbSentinelName = utils.EmptyString
+	cfg.dataDbCfg.RedisCluster = false
+
 	cfg.dataDbCfg.DataDbType = utils.INTERNAL
 
 	cfg.cacheCfg = &CacheCfg{
@@ -745,7 +804,7 @@ func TestConfigSanityDataDB(t *testing.T) {
 			},
 		},
 	}
-	expected := "<CacheS> *accounts needs to be 0 when DataBD is *internal, received : 1"
+	expected = "<CacheS> *accounts needs to be 0 when DataBD is *internal, received : 1"
 	if err := cfg.checkConfigSanity(); err == nil || err.Error() != expected {
 		t.Errorf("Expecting: %+q  received: %+q", expected, err)
 	}
@@ -755,6 +814,14 @@ func TestConfigSanityDataDB(t *testing.T) {
 	if err := cfg.checkConfigSanity(); err == nil || err.Error() != expected {
 		t.Errorf("Expecting: %+q  received: %+q", expected, err)
 	}
+	cfg.resourceSCfg.StoreInterval = -1
+	cfg.resourceSCfg.SharedState = true
+	expected = "<ResourceS> shared_state needs a DataDB shared between engines, received: *internal"
+	if err := cfg.checkConfigSanity(); err == nil || err.Error() != expected {
+		t.Errorf("Expecting: %+q  received: %+q", expected, err)
+	}
+	cfg.resourceSCfg.SharedState = false
+	cfg.resourceSCfg.StoreInterval = 0
 	cfg.resourceSCfg.Enabled = false
 
 	cfg.statsCfg.Enabled = true
@@ -771,6 +838,21 @@ func TestConfigSanityDataDB(t *testing.T) {
 	}
 	cfg.thresholdSCfg.Enabled = false
 
+	cfg.dataDbCfg.InternalPersistence = &InternalPersistenceCfg{
+		Path:  "/tmp/internal_db",
+		Fsync: "*sometimes",
+	}
+	expected = "<data_db> unsupported fsync policy: <*sometimes>"
+	if err := cfg.checkConfigSanity(); err == nil || err.Error() != expected {
+		t.Errorf("Expecting: %+q  received: %+q", expected, err)
+	}
+	cfg.dataDbCfg.InternalPersistence.Fsync = utils.MetaInterval
+	expected = "<data_db> fsync_interval needs to be positive for *interval fsync, received: 0s"
+	if err := cfg.checkConfigSanity(); err == nil || err.Error() != expected {
+		t.Errorf("Expecting: %+q  received: %+q", expected, err)
+	}
+	cfg.dataDbCfg.InternalPersistence = nil
+
 	cfg.dataDbCfg.Items = map[string]*ItemOpt{
 		"test1": &ItemOpt{
 			Remote: true,
@@ -834,6 +916,13 @@ func TestConfigSanityDispatcher(t *testing.T) {
 	if err := cfg.checkConfigSanity(); err == nil || err.Error() != expected {
 		t.Errorf("Expecting: %+q  received: %+q", expected, err)
 	}
+	cfg.dispatcherSCfg.AttributeSConns = []string{}
+	cfg.dispatcherSCfg.HealthCheckInterval = time.Second
+	cfg.dispatcherSCfg.HealthCheckMethod = utils.CoreSv1Ping
+	expected = "<DispatcherS> health_check_failures should be at least 1"
+	if err := cfg.checkConfigSanity(); err == nil || err.Error() != expected {
+		t.Errorf("Expecting: %+q  received: %+q", expected, err)
+	}
 }
 
 func TestConfigSanityCacheS(t *testing.T) {
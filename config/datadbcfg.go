@@ -29,17 +29,20 @@ import (
 
 // DataDbCfg Database config
 type DataDbCfg struct {
-	DataDbType         string
-	DataDbHost         string // The host to connect to. Values that start with / are for UNIX domain sockets.
-	DataDbPort         string // The port to bind to.
-	DataDbName         string // The name of the database to connect to.
-	DataDbUser         string // The user to sign in as.
-	DataDbPass         string // The user's password.
-	DataDbSentinelName string
-	QueryTimeout       time.Duration
-	RmtConns           []string // Remote DataDB  connIDs
-	RplConns           []string // Replication connIDs
-	Items              map[string]*ItemOpt
+	DataDbType          string
+	DataDbHost          string // The host to connect to. Values that start with / are for UNIX domain sockets.
+	DataDbPort          string // The port to bind to.
+	DataDbName          string // The name of the database to connect to.
+	DataDbUser          string // The user to sign in as.
+	DataDbPass          string // The user's password.
+	DataDbSentinelName  string
+	RedisCluster        bool          // connect to a Redis Cluster, DataDbHost holding one of its nodes
+	RedisClusterSync    time.Duration // minimum interval between two cluster topology refreshes
+	QueryTimeout        time.Duration
+	RmtConns            []string // Remote DataDB  connIDs
+	RplConns            []string // Replication connIDs
+	Items               map[string]*ItemOpt
+	InternalPersistence *InternalPersistenceCfg // on-disk persistence in case of *internal
 }
 
 //loadFromJsonCfg loads Database config from JsonCfg
@@ -72,6 +75,14 @@ func (dbcfg *DataDbCfg) loadFromJsonCfg(jsnDbCfg *DbJsonCfg) (err error) {
 	if jsnDbCfg.Redis_sentinel != nil {
 		dbcfg.DataDbSentinelName = *jsnDbCfg.Redis_sentinel
 	}
+	if jsnDbCfg.Redis_cluster != nil {
+		dbcfg.RedisCluster = *jsnDbCfg.Redis_cluster
+	}
+	if jsnDbCfg.Redis_cluster_sync != nil {
+		if dbcfg.RedisClusterSync, err = utils.ParseDurationWithNanosecs(*jsnDbCfg.Redis_cluster_sync); err != nil {
+			return err
+		}
+	}
 	if jsnDbCfg.Query_timeout != nil {
 		if dbcfg.QueryTimeout, err = utils.ParseDurationWithNanosecs(*jsnDbCfg.Query_timeout); err != nil {
 			return err
@@ -112,21 +123,32 @@ func (dbcfg *DataDbCfg) loadFromJsonCfg(jsnDbCfg *DbJsonCfg) (err error) {
 			dbcfg.Items[kJsn] = val
 		}
 	}
+	if jsnDbCfg.Internal_persistence != nil {
+		if dbcfg.InternalPersistence == nil {
+			dbcfg.InternalPersistence = new(InternalPersistenceCfg)
+		}
+		if err = dbcfg.InternalPersistence.loadFromJsonCfg(jsnDbCfg.Internal_persistence); err != nil {
+			return
+		}
+	}
 	return nil
 }
 
 // Clone returns the cloned object
 func (dbcfg *DataDbCfg) Clone() *DataDbCfg {
 	return &DataDbCfg{
-		DataDbType:         dbcfg.DataDbType,
-		DataDbHost:         dbcfg.DataDbHost,
-		DataDbPort:         dbcfg.DataDbPort,
-		DataDbName:         dbcfg.DataDbName,
-		DataDbUser:         dbcfg.DataDbUser,
-		DataDbPass:         dbcfg.DataDbPass,
-		DataDbSentinelName: dbcfg.DataDbSentinelName,
-		QueryTimeout:       dbcfg.QueryTimeout,
-		Items:              dbcfg.Items,
+		DataDbType:          dbcfg.DataDbType,
+		DataDbHost:          dbcfg.DataDbHost,
+		DataDbPort:          dbcfg.DataDbPort,
+		DataDbName:          dbcfg.DataDbName,
+		DataDbUser:          dbcfg.DataDbUser,
+		DataDbPass:          dbcfg.DataDbPass,
+		DataDbSentinelName:  dbcfg.DataDbSentinelName,
+		RedisCluster:        dbcfg.RedisCluster,
+		RedisClusterSync:    dbcfg.RedisClusterSync,
+		QueryTimeout:        dbcfg.QueryTimeout,
+		Items:               dbcfg.Items,
+		InternalPersistence: dbcfg.InternalPersistence.Clone(),
 	}
 }
 
@@ -139,9 +161,13 @@ func (dbcfg *DataDbCfg) AsMapInterface() map[string]interface{} {
 	if dbcfg.QueryTimeout != 0 {
 		queryTimeout = dbcfg.QueryTimeout.String()
 	}
+	var clusterSync string = "0"
+	if dbcfg.RedisClusterSync != 0 {
+		clusterSync = dbcfg.RedisClusterSync.String()
+	}
 	dbPort, _ := strconv.Atoi(dbcfg.DataDbPort)
 
-	return map[string]interface{}{
+	mp := map[string]interface{}{
 		utils.DataDbTypeCfg:         utils.Meta + dbcfg.DataDbType,
 		utils.DataDbHostCfg:         dbcfg.DataDbHost,
 		utils.DataDbPortCfg:         dbPort,
@@ -149,11 +175,17 @@ func (dbcfg *DataDbCfg) AsMapInterface() map[string]interface{} {
 		utils.DataDbUserCfg:         dbcfg.DataDbUser,
 		utils.DataDbPassCfg:         dbcfg.DataDbPass,
 		utils.DataDbSentinelNameCfg: dbcfg.DataDbSentinelName,
+		utils.RedisClusterCfg:       dbcfg.RedisCluster,
+		utils.RedisClusterSyncCfg:   clusterSync,
 		utils.QueryTimeoutCfg:       queryTimeout,
 		utils.RmtConnsCfg:           dbcfg.RmtConns,
 		utils.RplConnsCfg:           dbcfg.RplConns,
 		utils.ItemsCfg:              items,
 	}
+	if dbcfg.InternalPersistence != nil {
+		mp[utils.InternalPersistenceCfg] = dbcfg.InternalPersistence.AsMapInterface()
+	}
+	return mp
 }
 
 type ItemOpt struct {
@@ -211,3 +243,65 @@ func (itm *ItemOpt) loadFromJsonCfg(jsonItm *ItemOptJson) (err error) {
 	}
 	return
 }
+
+// InternalPersistenceCfg configures the on-disk persistence of the *internal database
+type InternalPersistenceCfg struct {
+	Path             string        // directory holding the append-only log and the snapshot, empty disables the persistence
+	Fsync            string        // when the log is synced to disk: <*always|*interval|*never>
+	FsyncInterval    time.Duration // used with *interval fsync
+	SnapshotInterval time.Duration // compact the log into a snapshot, 0 to compact only on start and shutdown
+}
+
+func (ip *InternalPersistenceCfg) loadFromJsonCfg(jsnCfg *InternalPersistenceJsonCfg) (err error) {
+	if jsnCfg == nil {
+		return
+	}
+	if jsnCfg.Path != nil {
+		ip.Path = *jsnCfg.Path
+	}
+	if jsnCfg.Fsync != nil {
+		ip.Fsync = *jsnCfg.Fsync
+	}
+	if jsnCfg.Fsync_interval != nil {
+		if ip.FsyncInterval, err = utils.ParseDurationWithNanosecs(*jsnCfg.Fsync_interval); err != nil {
+			return
+		}
+	}
+	if jsnCfg.Snapshot_interval != nil {
+		if ip.SnapshotInterval, err = utils.ParseDurationWithNanosecs(*jsnCfg.Snapshot_interval); err != nil {
+			return
+		}
+	}
+	return
+}
+
+// Clone returns a deep copy of InternalPersistenceCfg
+func (ip *InternalPersistenceCfg) Clone() *InternalPersistenceCfg {
+	if ip == nil {
+		return nil
+	}
+	cln := *ip
+	return &cln
+}
+
+// Enabled returns true if the *internal database should be persisted on disk
+func (ip *InternalPersistenceCfg) Enabled() bool {
+	return ip != nil && ip.Path != utils.EmptyString
+}
+
+func (ip *InternalPersistenceCfg) AsMapInterface() map[string]interface{} {
+	var fsyncInterval string = "0"
+	if ip.FsyncInterval != 0 {
+		fsyncInterval = ip.FsyncInterval.String()
+	}
+	var snapshotInterval string = "0"
+	if ip.SnapshotInterval != 0 {
+		snapshotInterval = ip.SnapshotInterval.String()
+	}
+	return map[string]interface{}{
+		utils.PathCfg:             ip.Path,
+		utils.FsyncCfg:            ip.Fsync,
+		utils.FsyncIntervalCfg:    fsyncInterval,
+		utils.SnapshotIntervalCfg: snapshotInterval,
+	}
+}
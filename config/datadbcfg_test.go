@@ -20,6 +20,7 @@ package config
 import (
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/cgrates/cgrates/utils"
 )
@@ -417,16 +418,18 @@ func TestDataDbCfgAsMapInterface(t *testing.T) {
 	},		
 }`
 	eMap := map[string]interface{}{
-		"db_type":           "*redis",
-		"db_host":           "127.0.0.1",
-		"db_port":           6379,
-		"db_name":           "10",
-		"db_user":           "cgrates",
-		"db_password":       "",
-		"redis_sentinel":    "",
-		"query_timeout":     "10s",
-		"remote_conns":      []string{},
-		"replication_conns": []string{},
+		"db_type":            "*redis",
+		"db_host":            "127.0.0.1",
+		"db_port":            6379,
+		"db_name":            "10",
+		"db_user":            "cgrates",
+		"db_password":        "",
+		"redis_sentinel":     "",
+		"redis_cluster":      false,
+		"redis_cluster_sync": "0",
+		"query_timeout":      "10s",
+		"remote_conns":       []string{},
+		"replication_conns":  []string{},
 		"items": map[string]interface{}{
 			"*accounts":             map[string]interface{}{"remote": true, "replicate": false, "limit": -1, "ttl": "", "static_ttl": false},
 			"*reverse_destinations": map[string]interface{}{"remote": false, "replicate": false, "limit": 7, "ttl": "", "static_ttl": true},
@@ -442,3 +445,46 @@ func TestDataDbCfgAsMapInterface(t *testing.T) {
 		t.Errorf("Expected: %+v ,\n recived: %+v", utils.ToJSON(eMap), utils.ToJSON(rcv))
 	}
 }
+
+func TestDataDbCfgloadFromJsonCfgInternalPersistence(t *testing.T) {
+	var dbcfg DataDbCfg
+	cfgJSONStr := `{
+	"data_db": {
+		"db_type": "*internal",
+		"internal_persistence": {
+			"path": "/var/lib/cgrates/internal_db",
+			"fsync": "*always",
+			"fsync_interval": "2s",
+			"snapshot_interval": "1h",
+		},
+	},
+}`
+	expected := &InternalPersistenceCfg{
+		Path:             "/var/lib/cgrates/internal_db",
+		Fsync:            utils.MetaAlways,
+		FsyncInterval:    2 * time.Second,
+		SnapshotInterval: time.Hour,
+	}
+	eMap := map[string]interface{}{
+		"path":              "/var/lib/cgrates/internal_db",
+		"fsync":             "*always",
+		"fsync_interval":    "2s",
+		"snapshot_interval": "1h0m0s",
+	}
+	if jsnCfg, err := NewCgrJsonCfgFromBytes([]byte(cfgJSONStr)); err != nil {
+		t.Error(err)
+	} else if jsnDataDbCfg, err := jsnCfg.DbJsonCfg(DATADB_JSN); err != nil {
+		t.Error(err)
+	} else if err = dbcfg.loadFromJsonCfg(jsnDataDbCfg); err != nil {
+		t.Error(err)
+	} else if !reflect.DeepEqual(expected, dbcfg.InternalPersistence) {
+		t.Errorf("Expected: %+v , recived: %+v", utils.ToJSON(expected), utils.ToJSON(dbcfg.InternalPersistence))
+	} else if !dbcfg.InternalPersistence.Enabled() {
+		t.Error("Expecting the internal persistence enabled")
+	} else if rcv := dbcfg.AsMapInterface()[utils.InternalPersistenceCfg]; !reflect.DeepEqual(eMap, rcv) {
+		t.Errorf("Expected: %+v ,\n recived: %+v", utils.ToJSON(eMap), utils.ToJSON(rcv))
+	} else if cln := dbcfg.Clone(); !reflect.DeepEqual(expected, cln.InternalPersistence) ||
+		cln.InternalPersistence == dbcfg.InternalPersistence {
+		t.Errorf("Expected a copy of: %+v , recived: %+v", utils.ToJSON(expected), utils.ToJSON(cln.InternalPersistence))
+	}
+}
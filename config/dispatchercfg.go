@@ -18,16 +18,24 @@ along with this program.  If not, see <http://www.gnu.org/licenses/>
 
 package config
 
-import "github.com/cgrates/cgrates/utils"
+import (
+	"time"
+
+	"github.com/cgrates/cgrates/utils"
+)
 
 // DispatcherSCfg is the configuration of dispatcher service
 type DispatcherSCfg struct {
-	Enabled             bool
-	IndexedSelects      bool
-	StringIndexedFields *[]string
-	PrefixIndexedFields *[]string
-	AttributeSConns     []string
-	NestedFields        bool
+	Enabled              bool
+	IndexedSelects       bool
+	StringIndexedFields  *[]string
+	PrefixIndexedFields  *[]string
+	AttributeSConns      []string
+	NestedFields         bool
+	HealthCheckInterval  time.Duration // ping the DispatcherHosts regularly, 0 to disable health checks
+	HealthCheckMethod    string        // API used to ping the DispatcherHosts
+	HealthCheckFailures  int           // consecutive errors marking a host as down
+	HealthCheckSuccesses int           // consecutive successful checks marking a host back up
 }
 
 func (dps *DispatcherSCfg) loadFromJsonCfg(jsnCfg *DispatcherSJsonCfg) (err error) {
@@ -68,17 +76,35 @@ func (dps *DispatcherSCfg) loadFromJsonCfg(jsnCfg *DispatcherSJsonCfg) (err erro
 	if jsnCfg.Nested_fields != nil {
 		dps.NestedFields = *jsnCfg.Nested_fields
 	}
+	if jsnCfg.Health_check_interval != nil {
+		if dps.HealthCheckInterval, err = utils.ParseDurationWithNanosecs(*jsnCfg.Health_check_interval); err != nil {
+			return
+		}
+	}
+	if jsnCfg.Health_check_method != nil {
+		dps.HealthCheckMethod = *jsnCfg.Health_check_method
+	}
+	if jsnCfg.Health_check_failures != nil {
+		dps.HealthCheckFailures = *jsnCfg.Health_check_failures
+	}
+	if jsnCfg.Health_check_successes != nil {
+		dps.HealthCheckSuccesses = *jsnCfg.Health_check_successes
+	}
 	return nil
 }
 
 func (dps *DispatcherSCfg) AsMapInterface() map[string]interface{} {
 	return map[string]interface{}{
-		utils.EnabledCfg:             dps.Enabled,
-		utils.IndexedSelectsCfg:      dps.IndexedSelects,
-		utils.StringIndexedFieldsCfg: dps.StringIndexedFields,
-		utils.PrefixIndexedFieldsCfg: dps.PrefixIndexedFields,
-		utils.AttributeSConnsCfg:     dps.AttributeSConns,
-		utils.NestedFieldsCfg:        dps.NestedFields,
+		utils.EnabledCfg:              dps.Enabled,
+		utils.IndexedSelectsCfg:       dps.IndexedSelects,
+		utils.StringIndexedFieldsCfg:  dps.StringIndexedFields,
+		utils.PrefixIndexedFieldsCfg:  dps.PrefixIndexedFields,
+		utils.AttributeSConnsCfg:      dps.AttributeSConns,
+		utils.NestedFieldsCfg:         dps.NestedFields,
+		utils.HealthCheckIntervalCfg:  dps.HealthCheckInterval,
+		utils.HealthCheckMethodCfg:    dps.HealthCheckMethod,
+		utils.HealthCheckFailuresCfg:  dps.HealthCheckFailures,
+		utils.HealthCheckSuccessesCfg: dps.HealthCheckSuccesses,
 	}
 
 }
@@ -131,6 +131,7 @@ type EventReaderCfg struct {
 	PartialCacheExpiryAction string
 	Fields                   []*FCTemplate
 	CacheDumpFields          []*FCTemplate
+	Exporters                []*EventExporterCfg
 }
 
 func (er *EventReaderCfg) loadFromJsonCfg(jsnCfg *EventReaderJsonCfg, sep string) (err error) {
@@ -206,6 +207,15 @@ func (er *EventReaderCfg) loadFromJsonCfg(jsnCfg *EventReaderJsonCfg, sep string
 			return err
 		}
 	}
+	if jsnCfg.Exporters != nil {
+		er.Exporters = make([]*EventExporterCfg, len(*jsnCfg.Exporters))
+		for i, jsnExp := range *jsnCfg.Exporters {
+			er.Exporters[i] = new(EventExporterCfg)
+			if err = er.Exporters[i].loadFromJsonCfg(jsnExp, sep); err != nil {
+				return
+			}
+		}
+	}
 	return
 }
 
@@ -244,6 +254,10 @@ func (er *EventReaderCfg) Clone() (cln *EventReaderCfg) {
 	for idx, fld := range er.CacheDumpFields {
 		cln.CacheDumpFields[idx] = fld.Clone()
 	}
+	cln.Exporters = make([]*EventExporterCfg, len(er.Exporters))
+	for idx, exp := range er.Exporters {
+		cln.Exporters[idx] = exp.Clone()
+	}
 	return
 }
 
@@ -268,6 +282,10 @@ func (er *EventReaderCfg) AsMapInterface(separator string) map[string]interface{
 	for i, item := range er.CacheDumpFields {
 		cacheDumpFields[i] = item.AsMapInterface(separator)
 	}
+	exporters := make([]map[string]interface{}, len(er.Exporters))
+	for i, item := range er.Exporters {
+		exporters[i] = item.AsMapInterface(separator)
+	}
 
 	return map[string]interface{}{
 		utils.IDCfg:                       er.ID,
@@ -288,5 +306,70 @@ func (er *EventReaderCfg) AsMapInterface(separator string) map[string]interface{
 		utils.PartialCacheExpiryActionCfg: er.PartialCacheExpiryAction,
 		utils.FieldsCfg:                   fields,
 		utils.CacheDumpFieldsCfg:          cacheDumpFields,
+		utils.ExportersCfg:                exporters,
+	}
+}
+
+// EventExporterCfg is the configuration of one target the reader mirrors its events to
+type EventExporterCfg struct {
+	ID         string
+	Type       string
+	ExportPath string
+	Attempts   int           // 0 to use the general poster_attempts
+	Fields     []*FCTemplate // build the exported event out of the raw record, empty to export the mapped event
+}
+
+func (exp *EventExporterCfg) loadFromJsonCfg(jsnCfg *EventExporterJsonCfg, sep string) (err error) {
+	if jsnCfg == nil {
+		return
+	}
+	if jsnCfg.Id != nil {
+		exp.ID = *jsnCfg.Id
+	}
+	if jsnCfg.Type != nil {
+		exp.Type = *jsnCfg.Type
+	}
+	if jsnCfg.Export_path != nil {
+		exp.ExportPath = *jsnCfg.Export_path
+	}
+	if jsnCfg.Attempts != nil {
+		exp.Attempts = *jsnCfg.Attempts
+	}
+	if jsnCfg.Fields != nil {
+		if exp.Fields, err = FCTemplatesFromFCTemplatesJsonCfg(*jsnCfg.Fields, sep); err != nil {
+			return
+		}
+	}
+	return
+}
+
+// Clone itself into a new EventExporterCfg
+func (exp *EventExporterCfg) Clone() (cln *EventExporterCfg) {
+	cln = &EventExporterCfg{
+		ID:         exp.ID,
+		Type:       exp.Type,
+		ExportPath: exp.ExportPath,
+		Attempts:   exp.Attempts,
+	}
+	if exp.Fields != nil {
+		cln.Fields = make([]*FCTemplate, len(exp.Fields))
+		for idx, fld := range exp.Fields {
+			cln.Fields[idx] = fld.Clone()
+		}
+	}
+	return
+}
+
+func (exp *EventExporterCfg) AsMapInterface(separator string) map[string]interface{} {
+	fields := make([]map[string]interface{}, len(exp.Fields))
+	for i, item := range exp.Fields {
+		fields[i] = item.AsMapInterface(separator)
+	}
+	return map[string]interface{}{
+		utils.IDCfg:         exp.ID,
+		utils.TypeCfg:       exp.Type,
+		utils.ExportPathCfg: exp.ExportPath,
+		utils.AttemptsCfg:   exp.Attempts,
+		utils.FieldsCfg:     fields,
 	}
 }
@@ -49,6 +49,7 @@ func TestEventRedearClone(t *testing.T) {
 			},
 		},
 		CacheDumpFields: make([]*FCTemplate, 0),
+		Exporters:       make([]*EventExporterCfg, 0),
 	}
 	cloned := orig.Clone()
 	if !reflect.DeepEqual(cloned, orig) {
@@ -77,6 +78,7 @@ func TestEventRedearClone(t *testing.T) {
 			},
 		},
 		CacheDumpFields: make([]*FCTemplate, 0),
+		Exporters:       make([]*EventExporterCfg, 0),
 	}
 	orig.Filters = []string{"SingleFilter"}
 	orig.Fields = []*FCTemplate{
@@ -136,6 +138,7 @@ func TestEventReaderLoadFromJSON(t *testing.T) {
 						Value: NewRSRParsersMustCompile("~*req.13", true, utils.INFIELD_SEP), Mandatory: true, Layout: time.RFC3339},
 				},
 				CacheDumpFields: make([]*FCTemplate, 0),
+				Exporters:       make([]*EventExporterCfg, 0),
 			},
 			{
 				ID:             "file_reader1",
@@ -175,6 +178,7 @@ func TestEventReaderLoadFromJSON(t *testing.T) {
 						Value: NewRSRParsersMustCompile("~*req.13", true, utils.INFIELD_SEP), Mandatory: true, Layout: time.RFC3339},
 				},
 				CacheDumpFields: make([]*FCTemplate, 0),
+				Exporters:       make([]*EventExporterCfg, 0),
 			},
 		},
 	}
@@ -268,6 +272,7 @@ func TestEventReaderSameID(t *testing.T) {
 						Value: NewRSRParsersMustCompile("~*req.13", true, utils.INFIELD_SEP), Mandatory: true, Layout: time.RFC3339},
 				},
 				CacheDumpFields: make([]*FCTemplate, 0),
+				Exporters:       make([]*EventExporterCfg, 0),
 			},
 			{
 				ID:             "file_reader1",
@@ -288,6 +293,7 @@ func TestEventReaderSameID(t *testing.T) {
 						Value: NewRSRParsersMustCompile("CustomValue2", true, utils.INFIELD_SEP), Mandatory: true, Layout: time.RFC3339},
 				},
 				CacheDumpFields: make([]*FCTemplate, 0),
+				Exporters:       make([]*EventExporterCfg, 0),
 			},
 		},
 	}
@@ -331,3 +337,65 @@ func TestEventReaderSameID(t *testing.T) {
 	}
 
 }
+
+func TestEventReaderExportersLoadFromJSON(t *testing.T) {
+	cfgJSONStr := `{
+"ers": {
+	"enabled": true,
+	"readers": [
+		{
+			"id": "file_reader1",
+			"type": "*file_csv",
+			"source_path": "/tmp/ers/in",
+			"processed_path": "/tmp/ers/out",
+			"exporters": [
+				{"id": "lake", "type": "*kafka_json_map", "export_path": "localhost:9092?topic=cdrs", "attempts": 3,
+					"fields": [
+						{"tag": "OriginID", "path": "*cgreq.OriginID", "type": "*variable", "value": "~*req.3"},
+					],
+				},
+				{"id": "mirror", "type": "*http_post", "export_path": "http://localhost:8080/cdrs"},
+			],
+		},
+	],
+}
+}`
+	eExps := []*EventExporterCfg{
+		{
+			ID:         "lake",
+			Type:       utils.MetaKafkajsonMap,
+			ExportPath: "localhost:9092?topic=cdrs",
+			Attempts:   3,
+			Fields: []*FCTemplate{
+				{Tag: utils.OriginID, Path: utils.MetaCgreq + utils.NestingSep + utils.OriginID, Type: utils.MetaVariable,
+					Value: NewRSRParsersMustCompile("~*req.3", true, utils.INFIELD_SEP), Layout: time.RFC3339},
+			},
+		},
+		{
+			ID:         "mirror",
+			Type:       utils.MetaHTTPPost,
+			ExportPath: "http://localhost:8080/cdrs",
+		},
+	}
+	cfg, err := NewCGRConfigFromJsonStringWithDefaults(cfgJSONStr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rdr := cfg.ersCfg.Readers[1]
+	if !reflect.DeepEqual(eExps, rdr.Exporters) {
+		t.Errorf("Expected: %s ,\n recived: %s", utils.ToJSON(eExps), utils.ToJSON(rdr.Exporters))
+	}
+	if cln := rdr.Clone(); !reflect.DeepEqual(eExps, cln.Exporters) {
+		t.Errorf("Expected: %s ,\n recived: %s", utils.ToJSON(eExps), utils.ToJSON(cln.Exporters))
+	}
+	eMp := map[string]interface{}{
+		utils.IDCfg:         "mirror",
+		utils.TypeCfg:       utils.MetaHTTPPost,
+		utils.ExportPathCfg: "http://localhost:8080/cdrs",
+		utils.AttemptsCfg:   0,
+		utils.FieldsCfg:     []map[string]interface{}{},
+	}
+	if rcv := rdr.AsMapInterface(utils.EmptyString)[utils.ExportersCfg].([]map[string]interface{}); !reflect.DeepEqual(eMp, rcv[1]) {
+		t.Errorf("Expected: %+v ,\n recived: %+v", eMp, rcv[1])
+	}
+}
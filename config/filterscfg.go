@@ -21,9 +21,10 @@ package config
 import "github.com/cgrates/cgrates/utils"
 
 type FilterSCfg struct {
-	StatSConns     []string
-	ResourceSConns []string
-	ApierSConns    []string
+	StatSConns      []string
+	ResourceSConns  []string
+	ApierSConns     []string
+	TenantTimezones map[string]string // timezones used by *cron filters, per tenant
 }
 
 func (fSCfg *FilterSCfg) loadFromJsonCfg(jsnCfg *FilterSJsonCfg) (err error) {
@@ -63,13 +64,20 @@ func (fSCfg *FilterSCfg) loadFromJsonCfg(jsnCfg *FilterSJsonCfg) (err error) {
 			}
 		}
 	}
+	if jsnCfg.Tenant_timezones != nil {
+		fSCfg.TenantTimezones = make(map[string]string, len(*jsnCfg.Tenant_timezones))
+		for tnt, tz := range *jsnCfg.Tenant_timezones {
+			fSCfg.TenantTimezones[tnt] = tz
+		}
+	}
 	return
 }
 
 func (fSCfg *FilterSCfg) AsMapInterface() map[string]interface{} {
 	return map[string]interface{}{
-		utils.StatSConnsCfg:     fSCfg.StatSConns,
-		utils.ResourceSConnsCfg: fSCfg.ResourceSConns,
-		utils.ApierSConnsCfg:    fSCfg.ApierSConns,
+		utils.StatSConnsCfg:      fSCfg.StatSConns,
+		utils.ResourceSConnsCfg:  fSCfg.ResourceSConns,
+		utils.ApierSConnsCfg:     fSCfg.ApierSConns,
+		utils.TenantTimezonesCfg: fSCfg.TenantTimezones,
 	}
 }
@@ -39,10 +39,12 @@ func TestFilterSCfgloadFromJsonCfg(t *testing.T) {
 	cfgJSONStr := `{
 "filters": {								// Filters configuration (*new)
 	"stats_conns": ["*localhost"],		// address where to reach the stat service, empty to disable stats functionality: <""|*internal|x.y.z.y:1234>
+	"tenant_timezones": {"cgrates.org": "Europe/Berlin"},
 	},
 }`
 	expected = FilterSCfg{
-		StatSConns: []string{utils.MetaLocalHost},
+		StatSConns:      []string{utils.MetaLocalHost},
+		TenantTimezones: map[string]string{"cgrates.org": "Europe/Berlin"},
 	}
 	if jsnCfg, err := NewCgrJsonCfgFromBytes([]byte(cfgJSONStr)); err != nil {
 		t.Error(err)
@@ -51,6 +51,7 @@ type GeneralCfg struct {
 	DigestEqual       string        //
 	RSRSep            string        // separator used to split RSRParser (by degault is used ";")
 	MaxParralelConns  int           // the maximum number of connection used by the *parallel strategy
+	nodeIDGenerated   bool          // NodeID not configured, a random one is generated on each start
 }
 
 //loadFromJsonCfg loads General config from JsonCfg
@@ -60,6 +61,7 @@ func (gencfg *GeneralCfg) loadFromJsonCfg(jsnGeneralCfg *GeneralJsonCfg) (err er
 	}
 	if jsnGeneralCfg.Node_id != nil && *jsnGeneralCfg.Node_id != "" {
 		gencfg.NodeID = *jsnGeneralCfg.Node_id
+		gencfg.nodeIDGenerated = false
 	}
 	if jsnGeneralCfg.Logger != nil {
 		gencfg.Logger = *jsnGeneralCfg.Logger
@@ -92,11 +92,22 @@ type DbJsonCfg struct {
 	String_indexed_fields *[]string
 	Prefix_indexed_fields *[]string
 	Redis_sentinel        *string
+	Redis_cluster         *bool
+	Redis_cluster_sync    *string
 	Query_timeout         *string
 	Sslmode               *string // Used only in case of storDb
 	Remote_conns          *[]string
 	Replication_conns     *[]string
 	Items                 *map[string]*ItemOptJson
+	Internal_persistence  *InternalPersistenceJsonCfg
+}
+
+// Persistence of the *internal database
+type InternalPersistenceJsonCfg struct {
+	Path              *string
+	Fsync             *string
+	Fsync_interval    *string
+	Snapshot_interval *string
 }
 
 type ItemOptJson struct {
@@ -112,9 +123,10 @@ type ItemOptJson struct {
 
 // Filters config
 type FilterSJsonCfg struct {
-	Stats_conns     *[]string
-	Resources_conns *[]string
-	Apiers_conns    *[]string
+	Stats_conns      *[]string
+	Resources_conns  *[]string
+	Apiers_conns     *[]string
+	Tenant_timezones *map[string]string
 }
 
 // Rater config section
@@ -193,6 +205,16 @@ type EventReaderJsonCfg struct {
 	Partial_cache_expiry_action *string
 	Fields                      *[]*FcTemplateJsonCfg
 	Cache_dump_fields           *[]*FcTemplateJsonCfg
+	Exporters                   *[]*EventExporterJsonCfg
+}
+
+// EventExporterJsonCfg is the configuration of one ERs exporter
+type EventExporterJsonCfg struct {
+	Id          *string
+	Type        *string
+	Export_path *string
+	Attempts    *int
+	Fields      *[]*FcTemplateJsonCfg
 }
 
 // SM-Generic config section
@@ -224,6 +246,8 @@ type SessionSJsonCfg struct {
 	Min_dur_low_balance   *string
 	Scheduler_conns       *[]string
 	Stir                  *STIRJsonCfg
+	Store_interval        *string
+	Reservation_ttl       *string
 }
 
 // FreeSWITCHAgent config section
@@ -404,6 +428,7 @@ type ResourceSJsonCfg struct {
 	String_indexed_fields *[]string
 	Prefix_indexed_fields *[]string
 	Nested_fields         *bool // applies when indexed fields is not defined
+	Shared_state          *bool
 }
 
 // Stat service config section
@@ -440,6 +465,7 @@ type SupplierSJsonCfg struct {
 	Stats_conns           *[]string
 	Rals_conns            *[]string
 	Default_ratio         *int
+	Cost_time_bucket      *string
 }
 
 type LoaderJsonDataType struct {
@@ -501,12 +527,16 @@ type SureTaxJsonCfg struct {
 }
 
 type DispatcherSJsonCfg struct {
-	Enabled               *bool
-	Indexed_selects       *bool
-	String_indexed_fields *[]string
-	Prefix_indexed_fields *[]string
-	Nested_fields         *bool // applies when indexed fields is not defined
-	Attributes_conns      *[]string
+	Enabled                *bool
+	Indexed_selects        *bool
+	String_indexed_fields  *[]string
+	Prefix_indexed_fields  *[]string
+	Nested_fields          *bool // applies when indexed fields is not defined
+	Attributes_conns       *[]string
+	Health_check_interval  *string
+	Health_check_method    *string
+	Health_check_failures  *int
+	Health_check_successes *int
 }
 
 type LoaderCfgJson struct {
@@ -563,6 +593,8 @@ type FcTemplateJsonCfg struct {
 // Analyzer service json config section
 type AnalyzerSJsonCfg struct {
 	Enabled *bool
+	Limit   *int
+	Ttl     *string
 }
 
 type ApierJsonCfg struct {
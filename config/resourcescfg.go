@@ -32,6 +32,7 @@ type ResourceSConfig struct {
 	StringIndexedFields *[]string
 	PrefixIndexedFields *[]string
 	NestedFields        bool
+	SharedState         bool // allocate and release the usages atomically in dataDB, shared between engines
 }
 
 func (rlcfg *ResourceSConfig) loadFromJsonCfg(jsnCfg *ResourceSJsonCfg) (err error) {
@@ -77,6 +78,9 @@ func (rlcfg *ResourceSConfig) loadFromJsonCfg(jsnCfg *ResourceSJsonCfg) (err err
 	if jsnCfg.Nested_fields != nil {
 		rlcfg.NestedFields = *jsnCfg.Nested_fields
 	}
+	if jsnCfg.Shared_state != nil {
+		rlcfg.SharedState = *jsnCfg.Shared_state
+	}
 	return nil
 }
 
@@ -90,6 +94,7 @@ func (rlcfg *ResourceSConfig) AsMapInterface() map[string]interface{} {
 		utils.StringIndexedFieldsCfg: rlcfg.StringIndexedFields,
 		utils.PrefixIndexedFieldsCfg: rlcfg.PrefixIndexedFields,
 		utils.NestedFieldsCfg:        rlcfg.NestedFields,
+		utils.SharedStateCfg:         rlcfg.SharedState,
 	}
 
 }
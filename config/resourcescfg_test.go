@@ -42,6 +42,7 @@ func TestResourceSConfigloadFromJsonCfg(t *testing.T) {
 	"thresholds_conns": [],					// address where to reach the thresholds service, empty to disable thresholds functionality: <""|*internal|x.y.z.y:1234>
 	//"string_indexed_fields": [],			// query indexes based on these fields for faster processing
 	"prefix_indexed_fields": ["index1", "index2"],			// query indexes based on these fields for faster processing
+	"shared_state": true,
 },	
 }`
 	expected = ResourceSConfig{
@@ -49,6 +50,7 @@ func TestResourceSConfigloadFromJsonCfg(t *testing.T) {
 		StoreInterval:       time.Duration(time.Second),
 		ThresholdSConns:     []string{},
 		PrefixIndexedFields: &[]string{"index1", "index2"},
+		SharedState:         true,
 	}
 	if jsnCfg, err := NewCgrJsonCfgFromBytes([]byte(cfgJSONStr)); err != nil {
 		t.Error(err)
@@ -101,6 +101,8 @@ type SessionSCfg struct {
 	MinDurLowBalance    time.Duration
 	SchedulerConns      []string
 	STIRCfg             *STIRcfg
+	StoreInterval       time.Duration // persist the active sessions into dataDB, 0 to disable
+	ReservationTTL      time.Duration // release the event reservations not committed in time, 0 to disable
 }
 
 func (scfg *SessionSCfg) loadFromJsonCfg(jsnCfg *SessionSJsonCfg) (err error) {
@@ -269,6 +271,16 @@ func (scfg *SessionSCfg) loadFromJsonCfg(jsnCfg *SessionSJsonCfg) (err error) {
 	if jsnCfg.Terminate_attempts != nil {
 		scfg.TerminateAttempts = *jsnCfg.Terminate_attempts
 	}
+	if jsnCfg.Store_interval != nil {
+		if scfg.StoreInterval, err = utils.ParseDurationWithNanosecs(*jsnCfg.Store_interval); err != nil {
+			return err
+		}
+	}
+	if jsnCfg.Reservation_ttl != nil {
+		if scfg.ReservationTTL, err = utils.ParseDurationWithNanosecs(*jsnCfg.Reservation_ttl); err != nil {
+			return err
+		}
+	}
 	if jsnCfg.Alterable_fields != nil {
 		scfg.AlterableFields = utils.NewStringSet(*jsnCfg.Alterable_fields)
 	}
@@ -320,6 +332,8 @@ func (scfg *SessionSCfg) AsMapInterface() map[string]interface{} {
 		utils.AlterableFieldsCfg:     scfg.AlterableFields.AsSlice(),
 		utils.MinDurLowBalanceCfg:    scfg.MinDurLowBalance,
 		utils.STIRCfg:                scfg.STIRCfg.AsMapInterface(),
+		utils.StoreIntervalCfg:       scfg.StoreInterval,
+		utils.ReservationTTLCfg:      scfg.ReservationTTL,
 	}
 }
 
@@ -42,6 +42,7 @@ type StorDbCfg struct {
 	QueryTimeout        time.Duration
 	SSLMode             string // for PostgresDB used to change default sslmode
 	Items               map[string]*ItemOpt
+	InternalPersistence *InternalPersistenceCfg // on-disk persistence in case of *internal
 }
 
 // loadFromJsonCfg loads StoreDb config from JsonCfg
@@ -104,6 +105,14 @@ func (dbcfg *StorDbCfg) loadFromJsonCfg(jsnDbCfg *DbJsonCfg) (err error) {
 			dbcfg.Items[kJsn] = val
 		}
 	}
+	if jsnDbCfg.Internal_persistence != nil {
+		if dbcfg.InternalPersistence == nil {
+			dbcfg.InternalPersistence = new(InternalPersistenceCfg)
+		}
+		if err = dbcfg.InternalPersistence.loadFromJsonCfg(jsnDbCfg.Internal_persistence); err != nil {
+			return
+		}
+	}
 	return nil
 }
 
@@ -124,6 +133,7 @@ func (dbcfg *StorDbCfg) Clone() *StorDbCfg {
 		QueryTimeout:        dbcfg.QueryTimeout,
 		SSLMode:             dbcfg.SSLMode,
 		Items:               dbcfg.Items,
+		InternalPersistence: dbcfg.InternalPersistence.Clone(),
 	}
 }
 
@@ -138,7 +148,7 @@ func (dbcfg *StorDbCfg) AsMapInterface() map[string]interface{} {
 	}
 	dbPort, _ := strconv.Atoi(dbcfg.Port)
 
-	return map[string]interface{}{
+	mp := map[string]interface{}{
 		utils.TypeCfg:                utils.Meta + dbcfg.Type,
 		utils.HostCfg:                dbcfg.Host,
 		utils.PortCfg:                dbPort,
@@ -154,4 +164,8 @@ func (dbcfg *StorDbCfg) AsMapInterface() map[string]interface{} {
 		utils.SSLModeCfg:             dbcfg.SSLMode,
 		utils.ItemsCfg:               items,
 	}
+	if dbcfg.InternalPersistence != nil {
+		mp[utils.InternalPersistenceCfg] = dbcfg.InternalPersistence.AsMapInterface()
+	}
+	return mp
 }
@@ -18,7 +18,11 @@ along with this program.  If not, see <http://www.gnu.org/licenses/>
 
 package config
 
-import "github.com/cgrates/cgrates/utils"
+import (
+	"time"
+
+	"github.com/cgrates/cgrates/utils"
+)
 
 // SupplierSCfg is the configuration of supplier service
 type SupplierSCfg struct {
@@ -32,6 +36,7 @@ type SupplierSCfg struct {
 	ResponderSConns     []string
 	DefaultRatio        int
 	NestedFields        bool
+	CostTimeBucket      time.Duration
 }
 
 func (spl *SupplierSCfg) loadFromJsonCfg(jsnCfg *SupplierSJsonCfg) (err error) {
@@ -108,6 +113,11 @@ func (spl *SupplierSCfg) loadFromJsonCfg(jsnCfg *SupplierSJsonCfg) (err error) {
 	if jsnCfg.Nested_fields != nil {
 		spl.NestedFields = *jsnCfg.Nested_fields
 	}
+	if jsnCfg.Cost_time_bucket != nil {
+		if spl.CostTimeBucket, err = utils.ParseDurationWithNanosecs(*jsnCfg.Cost_time_bucket); err != nil {
+			return
+		}
+	}
 	return nil
 }
 
@@ -124,6 +134,7 @@ func (spl *SupplierSCfg) AsMapInterface() map[string]interface{} {
 		utils.RALsConnsCfg:           spl.ResponderSConns,
 		utils.DefaultRatioCfg:        spl.DefaultRatio,
 		utils.NestedFieldsCfg:        spl.NestedFields,
+		utils.CostTimeBucketCfg:      spl.CostTimeBucket,
 	}
 
 }
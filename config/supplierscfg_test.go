@@ -20,6 +20,7 @@ package config
 import (
 	"reflect"
 	"testing"
+	"time"
 )
 
 func TestSupplierSCfgloadFromJsonCfg(t *testing.T) {
@@ -43,6 +44,7 @@ func TestSupplierSCfgloadFromJsonCfg(t *testing.T) {
 	"resources_conns": [],					// address where to reach the Resource service, empty to disable functionality: <""|*internal|x.y.z.y:1234>
 	"stats_conns": [],						// address where to reach the Stat service, empty to disable stats functionality: <""|*internal|x.y.z.y:1234>
 	"default_ratio":1,
+	"cost_time_bucket": "30s",
 },
 }`
 	expected = SupplierSCfg{
@@ -51,6 +53,7 @@ func TestSupplierSCfgloadFromJsonCfg(t *testing.T) {
 		ResourceSConns:      []string{},
 		StatSConns:          []string{},
 		DefaultRatio:        1,
+		CostTimeBucket:      30 * time.Second,
 	}
 	if jsnCfg, err := NewCgrJsonCfgFromBytes([]byte(cfgJSONStr)); err != nil {
 		t.Error(err)
@@ -0,0 +1,66 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package console
+
+import (
+	"github.com/cgrates/cgrates/analyzers"
+	"github.com/cgrates/cgrates/utils"
+)
+
+func init() {
+	c := &CmdAnalyzerStringQuery{
+		name:      "analyzer_string_query",
+		rpcMethod: utils.AnalyzerSv1StringQuery,
+		rpcParams: &analyzers.QueryArgs{},
+	}
+	commands[c.Name()] = c
+	c.CommandExecuter = &CommandExecuter{c}
+}
+
+// CmdAnalyzerStringQuery queries the API calls captured by AnalyzerS
+type CmdAnalyzerStringQuery struct {
+	name      string
+	rpcMethod string
+	rpcParams *analyzers.QueryArgs
+	*CommandExecuter
+}
+
+func (self *CmdAnalyzerStringQuery) Name() string {
+	return self.name
+}
+
+func (self *CmdAnalyzerStringQuery) RpcMethod() string {
+	return self.rpcMethod
+}
+
+func (self *CmdAnalyzerStringQuery) RpcParams(reset bool) interface{} {
+	if reset || self.rpcParams == nil {
+		self.rpcParams = &analyzers.QueryArgs{}
+	}
+	return self.rpcParams
+}
+
+func (self *CmdAnalyzerStringQuery) PostprocessRpcParams() error {
+	return nil
+}
+
+func (self *CmdAnalyzerStringQuery) RpcResult() interface{} {
+	var atr []map[string]interface{}
+	return &atr
+}
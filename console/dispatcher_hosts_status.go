@@ -0,0 +1,65 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package console
+
+import (
+	"github.com/cgrates/cgrates/dispatchers"
+	"github.com/cgrates/cgrates/utils"
+)
+
+func init() {
+	c := &CmdDispatcherHostsStatus{
+		name:      "dispatcher_hosts_status",
+		rpcMethod: utils.DispatcherSv1GetHostsStatus,
+	}
+	commands[c.Name()] = c
+	c.CommandExecuter = &CommandExecuter{c}
+}
+
+// Commander implementation
+type CmdDispatcherHostsStatus struct {
+	name      string
+	rpcMethod string
+	rpcParams *utils.TenantArg
+	*CommandExecuter
+}
+
+func (self *CmdDispatcherHostsStatus) Name() string {
+	return self.name
+}
+
+func (self *CmdDispatcherHostsStatus) RpcMethod() string {
+	return self.rpcMethod
+}
+
+func (self *CmdDispatcherHostsStatus) RpcParams(reset bool) interface{} {
+	if reset || self.rpcParams == nil {
+		self.rpcParams = new(utils.TenantArg)
+	}
+	return self.rpcParams
+}
+
+func (self *CmdDispatcherHostsStatus) PostprocessRpcParams() error {
+	return nil
+}
+
+func (self *CmdDispatcherHostsStatus) RpcResult() interface{} {
+	var s []*dispatchers.HostStatus
+	return &s
+}
@@ -0,0 +1,70 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package console
+
+import (
+	v1 "github.com/cgrates/cgrates/apier/v1"
+	"github.com/cgrates/cgrates/engine"
+	"github.com/cgrates/cgrates/utils"
+)
+
+func init() {
+	c := &CmdGetExchangeRates{
+		name:      "exchange_rates",
+		rpcMethod: utils.APIerSv1GetExchangeRates,
+		rpcParams: &v1.AttrGetExchangeRates{},
+	}
+	commands[c.Name()] = c
+	c.CommandExecuter = &CommandExecuter{c}
+}
+
+type CmdGetExchangeRates struct {
+	name      string
+	rpcMethod string
+	rpcParams *v1.AttrGetExchangeRates
+	*CommandExecuter
+}
+
+func (self *CmdGetExchangeRates) Name() string {
+	return self.name
+}
+
+func (self *CmdGetExchangeRates) RpcMethod() string {
+	return self.rpcMethod
+}
+
+func (self *CmdGetExchangeRates) RpcParams(reset bool) interface{} {
+	if reset || self.rpcParams == nil {
+		self.rpcParams = &v1.AttrGetExchangeRates{}
+	}
+	return self.rpcParams
+}
+
+func (self *CmdGetExchangeRates) PostprocessRpcParams() error {
+	return nil
+}
+
+func (self *CmdGetExchangeRates) RpcResult() interface{} {
+	var atr engine.ExchangeRates
+	return &atr
+}
+
+func (self *CmdGetExchangeRates) GetFormatedResult(result interface{}) string {
+	return GetFormatedResult(result, nil)
+}
@@ -0,0 +1,72 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package console
+
+import (
+	"github.com/cgrates/cgrates/engine"
+	"github.com/cgrates/cgrates/utils"
+)
+
+func init() {
+	c := &CmdGetThresholdState{
+		name:      "threshold_state",
+		rpcMethod: utils.ThresholdSv1GetThresholdState,
+		rpcParams: &utils.TenantIDWithArgDispatcher{},
+	}
+	commands[c.Name()] = c
+	c.CommandExecuter = &CommandExecuter{c}
+}
+
+type CmdGetThresholdState struct {
+	name      string
+	rpcMethod string
+	rpcParams *utils.TenantIDWithArgDispatcher
+	*CommandExecuter
+}
+
+func (self *CmdGetThresholdState) Name() string {
+	return self.name
+}
+
+func (self *CmdGetThresholdState) RpcMethod() string {
+	return self.rpcMethod
+}
+
+func (self *CmdGetThresholdState) RpcParams(reset bool) interface{} {
+	if reset || self.rpcParams == nil {
+		self.rpcParams = &utils.TenantIDWithArgDispatcher{
+			TenantID:      new(utils.TenantID),
+			ArgDispatcher: new(utils.ArgDispatcher),
+		}
+	}
+	return self.rpcParams
+}
+
+func (self *CmdGetThresholdState) PostprocessRpcParams() error {
+	return nil
+}
+
+func (self *CmdGetThresholdState) RpcResult() interface{} {
+	var atr engine.ThresholdState
+	return &atr
+}
+
+func (self *CmdGetThresholdState) GetFormatedResult(result interface{}) string {
+	return GetFormatedResult(result, nil)
+}
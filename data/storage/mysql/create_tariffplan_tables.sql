@@ -11,6 +11,7 @@ CREATE TABLE `tp_timings` (
   `month_days` varchar(255) NOT NULL,
   `week_days` varchar(255) NOT NULL,
   `time` varchar(32) NOT NULL,
+  `calendars` varchar(255) NOT NULL DEFAULT '',
   `created_at` TIMESTAMP,
   PRIMARY KEY (`id`),
   KEY `tpid` (`tpid`),
@@ -18,6 +19,42 @@ CREATE TABLE `tp_timings` (
   UNIQUE KEY `tpid_tag` (`tpid`,`tag`)
 );
 
+--
+-- Table structure for table `tp_calendars`
+--
+
+DROP TABLE IF EXISTS `tp_calendars`;
+CREATE TABLE `tp_calendars` (
+  `id` int(11) NOT NULL AUTO_INCREMENT,
+  `tpid` varchar(64) NOT NULL,
+  `tag` varchar(64) NOT NULL,
+  `date` varchar(16) NOT NULL,
+  `created_at` TIMESTAMP,
+  PRIMARY KEY (`id`),
+  KEY `tpid` (`tpid`),
+  KEY `tpid_calid` (`tpid`,`tag`),
+  UNIQUE KEY `tpid_cal_date` (`tpid`,`tag`,`date`)
+);
+
+--
+-- Table structure for table `tp_exchange_rates`
+--
+
+DROP TABLE IF EXISTS `tp_exchange_rates`;
+CREATE TABLE `tp_exchange_rates` (
+  `id` int(11) NOT NULL AUTO_INCREMENT,
+  `tpid` varchar(64) NOT NULL,
+  `from_currency` varchar(3) NOT NULL,
+  `to_currency` varchar(3) NOT NULL,
+  `activation_time` varchar(26) NOT NULL,
+  `rate` DECIMAL(20,8) NOT NULL,
+  `created_at` TIMESTAMP,
+  PRIMARY KEY (`id`),
+  KEY `tpid` (`tpid`),
+  KEY `tpid_exr` (`tpid`,`from_currency`,`to_currency`),
+  UNIQUE KEY `tpid_exr_atime` (`tpid`,`from_currency`,`to_currency`,`activation_time`)
+);
+
 --
 -- Table structure for table `tp_destinations`
 --
@@ -90,6 +127,7 @@ CREATE TABLE `tp_rating_plans` (
   `destrates_tag` varchar(64) NOT NULL,
   `timing_tag` varchar(64) NOT NULL,
   `weight` DECIMAL(8,2) NOT NULL,
+  `currency` varchar(3) NOT NULL DEFAULT '',
   `created_at` TIMESTAMP,
   PRIMARY KEY (`id`),
   KEY `tpid` (`tpid`),
@@ -261,6 +299,7 @@ CREATE TABLE tp_resources (
   `stored` BOOLEAN NOT NULL,
   `weight` decimal(8,2) NOT NULL,
   `threshold_ids` varchar(64) NOT NULL,
+  `rate_interval` varchar(32) NOT NULL DEFAULT '',
   `created_at` TIMESTAMP,
   PRIMARY KEY (`pk`),
   KEY `tpid` (`tpid`),
@@ -288,6 +327,8 @@ CREATE TABLE tp_stats (
   `blocker` BOOLEAN NOT NULL,
   `weight` decimal(8,2) NOT NULL,
   `threshold_ids` varchar(64) NOT NULL,
+  `bucket_interval` varchar(32) NOT NULL DEFAULT '',
+  `bucket_count` int(11) NOT NULL DEFAULT 0,
   `created_at` TIMESTAMP,
   PRIMARY KEY (`pk`),
   KEY `tpid` (`tpid`),
@@ -313,6 +354,10 @@ CREATE TABLE tp_thresholds (
   `weight` decimal(8,2) NOT NULL,
   `action_ids` varchar(64) NOT NULL,
   `async` BOOLEAN NOT NULL,
+  `warning_filter_ids` varchar(64) NOT NULL DEFAULT '',
+  `critical_filter_ids` varchar(64) NOT NULL DEFAULT '',
+  `escalation_action_ids` varchar(64) NOT NULL DEFAULT '',
+  `recovery_action_ids` varchar(64) NOT NULL DEFAULT '',
   `created_at` TIMESTAMP,
   PRIMARY KEY (`pk`),
   KEY `tpid` (`tpid`),
@@ -11,12 +11,47 @@ CREATE TABLE tp_timings (
   month_days VARCHAR(255) NOT NULL,
   week_days VARCHAR(255) NOT NULL,
   time VARCHAR(32) NOT NULL,
+  calendars VARCHAR(255) NOT NULL DEFAULT '',
   created_at TIMESTAMP WITH TIME ZONE,
   UNIQUE  (tpid, tag)
 );
 CREATE INDEX tptimings_tpid_idx ON tp_timings (tpid);
 CREATE INDEX tptimings_idx ON tp_timings (tpid,tag);
 
+--
+-- Table structure for table `tp_calendars`
+--
+
+DROP TABLE IF EXISTS tp_calendars;
+CREATE TABLE tp_calendars (
+  id SERIAL PRIMARY KEY,
+  tpid VARCHAR(64) NOT NULL,
+  tag VARCHAR(64) NOT NULL,
+  date VARCHAR(16) NOT NULL,
+  created_at TIMESTAMP WITH TIME ZONE,
+  UNIQUE (tpid, tag, date)
+);
+CREATE INDEX tpcals_tpid_idx ON tp_calendars (tpid);
+CREATE INDEX tpcals_idx ON tp_calendars (tpid,tag);
+
+--
+-- Table structure for table `tp_exchange_rates`
+--
+
+DROP TABLE IF EXISTS tp_exchange_rates;
+CREATE TABLE tp_exchange_rates (
+  id SERIAL PRIMARY KEY,
+  tpid VARCHAR(64) NOT NULL,
+  from_currency VARCHAR(3) NOT NULL,
+  to_currency VARCHAR(3) NOT NULL,
+  activation_time VARCHAR(26) NOT NULL,
+  rate NUMERIC(20,8) NOT NULL,
+  created_at TIMESTAMP WITH TIME ZONE,
+  UNIQUE (tpid, from_currency, to_currency, activation_time)
+);
+CREATE INDEX tpexrs_tpid_idx ON tp_exchange_rates (tpid);
+CREATE INDEX tpexrs_idx ON tp_exchange_rates (tpid,from_currency,to_currency);
+
 --
 -- Table structure for table `tp_destinations`
 --
@@ -86,6 +121,7 @@ CREATE TABLE tp_rating_plans (
   destrates_tag VARCHAR(64) NOT NULL,
   timing_tag VARCHAR(64) NOT NULL,
   weight NUMERIC(8,2) NOT NULL,
+  currency VARCHAR(3) NOT NULL DEFAULT '',
   created_at TIMESTAMP WITH TIME ZONE,
   UNIQUE (tpid, tag, destrates_tag, timing_tag)
 );
@@ -257,6 +293,7 @@ CREATE TABLE tp_resources (
   "stored" BOOLEAN NOT NULL,
   "weight" NUMERIC(8,2) NOT NULL,
   "threshold_ids" varchar(64) NOT NULL,
+  "rate_interval" varchar(32) NOT NULL DEFAULT '',
   "created_at" TIMESTAMP WITH TIME ZONE
 );
 CREATE INDEX tp_resources_idx ON tp_resources (tpid);
@@ -284,6 +321,8 @@ CREATE TABLE tp_stats (
   "blocker" BOOLEAN NOT NULL,
   "weight" decimal(8,2) NOT NULL,
   "threshold_ids" varchar(64) NOT NULL,
+  "bucket_interval" varchar(32) NOT NULL DEFAULT '',
+  "bucket_count" INTEGER NOT NULL DEFAULT 0,
   "created_at" TIMESTAMP WITH TIME ZONE
 );
 CREATE INDEX tp_stats_idx ON tp_stats (tpid);
@@ -308,6 +347,10 @@ CREATE TABLE tp_thresholds (
   "weight" decimal(8,2) NOT NULL,
   "action_ids" varchar(64) NOT NULL,
   "async" BOOLEAN NOT NULL,
+  "warning_filter_ids" varchar(64) NOT NULL DEFAULT '',
+  "critical_filter_ids" varchar(64) NOT NULL DEFAULT '',
+  "escalation_action_ids" varchar(64) NOT NULL DEFAULT '',
+  "recovery_action_ids" varchar(64) NOT NULL DEFAULT '',
   "created_at" TIMESTAMP WITH TIME ZONE
 );
 CREATE INDEX tp_thresholds_idx ON tp_thresholds (tpid);
@@ -23,6 +23,7 @@ import (
 	"fmt"
 	"reflect"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/cgrates/cgrates/config"
@@ -37,7 +38,8 @@ func NewDispatcherService(dm *engine.DataManager,
 	connMgr *engine.ConnManager) (*DispatcherService, error) {
 
 	return &DispatcherService{dm: dm, cfg: cfg,
-		fltrS: fltrS, connMgr: connMgr}, nil
+		fltrS: fltrS, connMgr: connMgr,
+		health: newHostsHealth(cfg)}, nil
 }
 
 // DispatcherService  is the service handling dispatching towards internal components
@@ -47,6 +49,12 @@ type DispatcherService struct {
 	cfg     *config.CGRConfig
 	fltrS   *engine.FilterS
 	connMgr *engine.ConnManager
+
+	health           *hostsHealth
+	loopMux          sync.Mutex     // protects the health checks loop state
+	loopWg           sync.WaitGroup // done when the health checks loop exits
+	stopHealthChecks chan struct{}  // nil if the loop is not running
+	shutdown         bool           // do not restart the loop after Shutdown
 }
 
 // ListenAndServe will initialize the service
@@ -60,10 +68,43 @@ func (dS *DispatcherService) ListenAndServe(exitChan chan bool) error {
 // Shutdown is called to shutdown the service
 func (dS *DispatcherService) Shutdown() error {
 	utils.Logger.Info(fmt.Sprintf("<%s> service shutdown initialized", utils.DispatcherS))
+	dS.loopMux.Lock()
+	dS.shutdown = true
+	dS.loopMux.Unlock()
+	dS.stopLoop()
 	utils.Logger.Info(fmt.Sprintf("<%s> service shutdown complete", utils.DispatcherS))
 	return nil
 }
 
+// StartLoop starts the gorutine with the health checks loop
+func (dS *DispatcherService) StartLoop() {
+	dS.loopMux.Lock()
+	defer dS.loopMux.Unlock()
+	if dS.shutdown || dS.stopHealthChecks != nil { // stopped or already running
+		return
+	}
+	dS.stopHealthChecks = make(chan struct{})
+	dS.loopWg.Add(1)
+	go dS.runHealthChecks(dS.stopHealthChecks)
+}
+
+// stopLoop stops the health checks loop and waits for it to exit
+func (dS *DispatcherService) stopLoop() {
+	dS.loopMux.Lock()
+	if dS.stopHealthChecks != nil {
+		close(dS.stopHealthChecks)
+		dS.stopHealthChecks = nil
+	}
+	dS.loopMux.Unlock()
+	dS.loopWg.Wait()
+}
+
+// Reload restarts the health checks loop with the new config
+func (dS *DispatcherService) Reload() {
+	dS.stopLoop()
+	dS.StartLoop()
+}
+
 func (dS *DispatcherService) authorizeEvent(ev *utils.CGREvent,
 	reply *engine.AttrSProcessEventReply) (err error) {
 	if err = dS.connMgr.Call(dS.cfg.DispatcherSCfg().AttributeSConns, nil,
@@ -181,12 +222,12 @@ func (dS *DispatcherService) Dispatch(ev *utils.CGREvent, subsys string, routeID
 	if x, ok := engine.Cache.Get(utils.CacheDispatchers,
 		tntID); ok && x != nil {
 		d = x.(Dispatcher)
-	} else if d, err = newDispatcher(dS.dm, dPrfl); err != nil {
+	} else if d, err = newDispatcher(dS.dm, dPrfl, dS.health); err != nil {
 		return utils.NewErrDispatcherS(err)
 	}
 	engine.Cache.Set(utils.CacheDispatchers, tntID, d, nil,
 		true, utils.EmptyString)
-	return d.Dispatch(routeID, subsys, serviceMethod, args, reply)
+	return d.Dispatch(ev, routeID, subsys, serviceMethod, args, reply)
 }
 
 func (dS *DispatcherService) V1GetProfileForEvent(ev *DispatcherEvent,
@@ -0,0 +1,250 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package dispatchers
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/cgrates/utils"
+)
+
+// HostStatus is the health state of one DispatcherHost
+type HostStatus struct {
+	Tenant               string
+	ID                   string
+	Up                   bool
+	ConsecutiveErrors    int
+	ConsecutiveSuccesses int
+	LastCheck            time.Time
+	LastError            string
+}
+
+func newHostsHealth(cfg *config.CGRConfig) *hostsHealth {
+	return &hostsHealth{
+		cfg:   cfg,
+		hosts: make(map[string]*HostStatus),
+	}
+}
+
+// hostsHealth keeps the state of DispatcherHosts based on health checks and dispatched calls,
+// a host is marked down after consecutive errors and back up after consecutive successful checks
+type hostsHealth struct {
+	sync.RWMutex
+	cfg   *config.CGRConfig
+	hosts map[string]*HostStatus // map[tenant:hostID]*HostStatus
+}
+
+// enabled returns true if the health of hosts is tracked
+func (hh *hostsHealth) enabled() bool {
+	return hh != nil && hh.cfg.DispatcherSCfg().HealthCheckInterval > 0
+}
+
+// isUp returns false only for the hosts marked down
+func (hh *hostsHealth) isUp(tnt, hostID string) (up bool) {
+	if !hh.enabled() {
+		return true
+	}
+	hh.RLock()
+	hs, has := hh.hosts[utils.ConcatenatedKey(tnt, hostID)]
+	up = !has || hs.Up
+	hh.RUnlock()
+	return
+}
+
+// upHostIDs filters out the hosts marked down keeping the order
+// if all hosts are down the complete list is returned as last resort
+func (hh *hostsHealth) upHostIDs(tnt string, hostIDs []string) (upIDs []string) {
+	if !hh.enabled() {
+		return hostIDs
+	}
+	upIDs = make([]string, 0, len(hostIDs))
+	for _, hostID := range hostIDs {
+		if hh.isUp(tnt, hostID) {
+			upIDs = append(upIDs, hostID)
+		}
+	}
+	if len(upIDs) == 0 {
+		return hostIDs
+	}
+	return
+}
+
+// setCallResult records the result of a dispatched call
+// only the network errors count as host errors
+func (hh *hostsHealth) setCallResult(tnt, hostID string, err error) {
+	if !utils.IsNetworkError(err) {
+		err = nil
+	}
+	hh.setResult(tnt, hostID, err, false)
+}
+
+// setResult updates the status of the host
+// a host can be marked back up only by health checks
+func (hh *hostsHealth) setResult(tnt, hostID string, err error, check bool) {
+	if !hh.enabled() {
+		return
+	}
+	dspCfg := hh.cfg.DispatcherSCfg()
+	tntID := utils.ConcatenatedKey(tnt, hostID)
+	hh.Lock()
+	defer hh.Unlock()
+	hs, has := hh.hosts[tntID]
+	if !has {
+		hs = &HostStatus{Tenant: tnt, ID: hostID, Up: true}
+		hh.hosts[tntID] = hs
+	}
+	if check {
+		hs.LastCheck = time.Now()
+	}
+	if err != nil {
+		hs.ConsecutiveSuccesses = 0
+		hs.ConsecutiveErrors++
+		hs.LastError = err.Error()
+		if hs.Up && hs.ConsecutiveErrors >= dspCfg.HealthCheckFailures {
+			hs.Up = false
+			utils.Logger.Warning(fmt.Sprintf("<%s> host: <%s> marked down after %d consecutive errors, last error: %s",
+				utils.DispatcherS, tntID, hs.ConsecutiveErrors, hs.LastError))
+		}
+		return
+	}
+	hs.ConsecutiveErrors = 0
+	if !check {
+		return
+	}
+	hs.ConsecutiveSuccesses++
+	if !hs.Up && hs.ConsecutiveSuccesses >= dspCfg.HealthCheckSuccesses {
+		hs.Up = true
+		hs.LastError = utils.EmptyString
+		utils.Logger.Info(fmt.Sprintf("<%s> host: <%s> marked up after %d consecutive successful checks",
+			utils.DispatcherS, tntID, hs.ConsecutiveSuccesses))
+	}
+}
+
+// hostStatus returns a copy of the host status
+func (hh *hostsHealth) hostStatus(tnt, hostID string) (hs *HostStatus) {
+	hh.RLock()
+	if status, has := hh.hosts[utils.ConcatenatedKey(tnt, hostID)]; has {
+		hs = new(HostStatus)
+		*hs = *status
+	}
+	hh.RUnlock()
+	if hs == nil {
+		hs = &HostStatus{Tenant: tnt, ID: hostID, Up: true}
+	}
+	return
+}
+
+// removeMissing drops the status of the hosts not present in tntIDs
+func (hh *hostsHealth) removeMissing(tntIDs utils.StringMap) {
+	hh.Lock()
+	for tntID := range hh.hosts {
+		if !tntIDs.HasKey(tntID) {
+			delete(hh.hosts, tntID)
+		}
+	}
+	hh.Unlock()
+}
+
+// checkHosts pings all DispatcherHosts and updates their status
+func (dS *DispatcherService) checkHosts() {
+	keys, err := dS.dm.DataDB().GetKeysForPrefix(utils.DispatcherHostPrefix)
+	if err != nil {
+		utils.Logger.Warning(fmt.Sprintf("<%s> cannot query DispatcherHosts for health checks, error: %s",
+			utils.DispatcherS, err.Error()))
+		return
+	}
+	tntIDs := make(utils.StringMap)
+	var wg sync.WaitGroup
+	for _, key := range keys {
+		tntID := key[len(utils.DispatcherHostPrefix):]
+		tntIDs[tntID] = true
+		wg.Add(1)
+		go func(tID *utils.TenantID) {
+			dS.checkHost(tID.Tenant, tID.ID)
+			wg.Done()
+		}(utils.NewTenantID(tntID))
+	}
+	wg.Wait()
+	dS.health.removeMissing(tntIDs)
+}
+
+// checkHost pings one DispatcherHost using the configured method
+func (dS *DispatcherService) checkHost(tnt, hostID string) {
+	dH, err := dS.dm.GetDispatcherHost(tnt, hostID, true, true, utils.NonTransactional)
+	if err != nil {
+		if err != utils.ErrNotFound {
+			utils.Logger.Warning(fmt.Sprintf("<%s> cannot get DispatcherHost: <%s> for health check, error: %s",
+				utils.DispatcherS, utils.ConcatenatedKey(tnt, hostID), err.Error()))
+		}
+		return
+	}
+	var reply string
+	err = dH.Call(dS.cfg.DispatcherSCfg().HealthCheckMethod,
+		&utils.CGREventWithArgDispatcher{
+			CGREvent: &utils.CGREvent{
+				Tenant: tnt,
+				ID:     utils.UUIDSha1Prefix(),
+			},
+		}, &reply)
+	dS.health.setResult(tnt, hostID, err, true)
+}
+
+// runHealthChecks will regularly ping the DispatcherHosts
+func (dS *DispatcherService) runHealthChecks(stop <-chan struct{}) {
+	defer dS.loopWg.Done()
+	checkInterval := dS.cfg.DispatcherSCfg().HealthCheckInterval
+	if checkInterval <= 0 {
+		return
+	}
+	for {
+		dS.checkHosts()
+		select {
+		case <-stop:
+			return
+		case <-time.After(checkInterval):
+		}
+	}
+}
+
+// V1GetHostsStatus returns the health state of the DispatcherHosts for one tenant
+func (dS *DispatcherService) V1GetHostsStatus(args *utils.TenantArg, reply *[]*HostStatus) (err error) {
+	tnt := args.Tenant
+	if tnt == utils.EmptyString {
+		tnt = dS.cfg.GeneralCfg().DefaultTenant
+	}
+	prfx := utils.DispatcherHostPrefix + tnt + utils.CONCATENATED_KEY_SEP
+	var keys []string
+	if keys, err = dS.dm.DataDB().GetKeysForPrefix(prfx); err != nil {
+		return
+	}
+	if len(keys) == 0 {
+		return utils.ErrNotFound
+	}
+	sts := make([]*HostStatus, len(keys))
+	for i, key := range keys {
+		sts[i] = dS.health.hostStatus(tnt, key[len(prfx):])
+	}
+	sort.Slice(sts, func(i, j int) bool { return sts[i].ID < sts[j].ID })
+	*reply = sts
+	return
+}
@@ -0,0 +1,130 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package dispatchers
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/cgrates/engine"
+	"github.com/cgrates/cgrates/utils"
+)
+
+func TestHostsHealthSetResult(t *testing.T) {
+	cfg, _ := config.NewDefaultCGRConfig()
+	hh := newHostsHealth(cfg)
+	hh.setCallResult("cgrates.org", "HOST1", utils.ErrDisconnected)
+	if len(hh.hosts) != 0 { // health checks disabled
+		t.Errorf("Expected no status, received: %s", utils.ToJSON(hh.hosts))
+	}
+	cfg.DispatcherSCfg().HealthCheckInterval = time.Second
+	hh.setCallResult("cgrates.org", "HOST1", utils.ErrNotFound) // not a network error
+	hh.setCallResult("cgrates.org", "HOST1", utils.ErrDisconnected)
+	hh.setCallResult("cgrates.org", "HOST1", utils.ErrDisconnected)
+	if !hh.isUp("cgrates.org", "HOST1") {
+		t.Error("Expected host up")
+	}
+	hh.setResult("cgrates.org", "HOST1", utils.ErrDisconnected, true)
+	if hh.isUp("cgrates.org", "HOST1") {
+		t.Error("Expected host down")
+	}
+	if exp, rcv := []string{"HOST2"}, hh.upHostIDs("cgrates.org", []string{"HOST1", "HOST2"}); !reflect.DeepEqual(exp, rcv) {
+		t.Errorf("Expected: %+v, received: %+v", exp, rcv)
+	}
+	if exp, rcv := []string{"HOST1"}, hh.upHostIDs("cgrates.org", []string{"HOST1"}); !reflect.DeepEqual(exp, rcv) {
+		t.Errorf("Expected: %+v, received: %+v", exp, rcv)
+	}
+	hh.setCallResult("cgrates.org", "HOST1", nil) // calls do not bring the host back
+	hh.setResult("cgrates.org", "HOST1", nil, true)
+	if hh.isUp("cgrates.org", "HOST1") {
+		t.Error("Expected host down")
+	}
+	hh.setResult("cgrates.org", "HOST1", nil, true)
+	hs := hh.hostStatus("cgrates.org", "HOST1")
+	if !hs.Up || hs.ConsecutiveSuccesses != 2 || hs.ConsecutiveErrors != 0 ||
+		hs.LastError != utils.EmptyString || hs.LastCheck.IsZero() {
+		t.Errorf("Unexpected status: %s", utils.ToJSON(hs))
+	}
+	hh.removeMissing(utils.StringMap{})
+	if len(hh.hosts) != 0 {
+		t.Errorf("Expected no status, received: %s", utils.ToJSON(hh.hosts))
+	}
+}
+
+func TestDispatcherServiceV1GetHostsStatus(t *testing.T) {
+	cfg, _ := config.NewDefaultCGRConfig()
+	cfg.DispatcherSCfg().HealthCheckInterval = time.Second
+	cfg.DispatcherSCfg().HealthCheckFailures = 1
+	data := engine.NewInternalDB(nil, nil, true, cfg.DataDbCfg().Items)
+	dm := engine.NewDataManager(data, cfg.CacheCfg(), nil)
+	dS, _ := NewDispatcherService(dm, cfg, nil, nil)
+	var reply []*HostStatus
+	if err := dS.V1GetHostsStatus(&utils.TenantArg{Tenant: "cgrates.org"}, &reply); err != utils.ErrNotFound {
+		t.Errorf("Expected %v, received: %v", utils.ErrNotFound, err)
+	}
+	for _, hostID := range []string{"HOST2", "HOST1"} {
+		if err := dm.SetDispatcherHost(&engine.DispatcherHost{
+			Tenant: "cgrates.org",
+			ID:     hostID,
+		}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	dS.health.setCallResult("cgrates.org", "HOST2", utils.ErrDisconnected)
+	exp := []*HostStatus{
+		{Tenant: "cgrates.org", ID: "HOST1", Up: true},
+		{Tenant: "cgrates.org", ID: "HOST2", Up: false,
+			ConsecutiveErrors: 1, LastError: utils.ErrDisconnected.Error()},
+	}
+	if err := dS.V1GetHostsStatus(&utils.TenantArg{Tenant: "cgrates.org"}, &reply); err != nil {
+		t.Error(err)
+	} else if !reflect.DeepEqual(exp, reply) {
+		t.Errorf("Expected: %s, received: %s", utils.ToJSON(exp), utils.ToJSON(reply))
+	}
+}
+
+func TestDispatcherServiceHealthChecksLoop(t *testing.T) {
+	cfg, _ := config.NewDefaultCGRConfig()
+	data := engine.NewInternalDB(nil, nil, true, cfg.DataDbCfg().Items)
+	dm := engine.NewDataManager(data, cfg.CacheCfg(), nil)
+	dS, _ := NewDispatcherService(dm, cfg, nil, nil)
+	done := make(chan struct{})
+	go func() {
+		dS.StartLoop() // health checks disabled, the loop exits directly
+		dS.stopLoop()
+		cfg.DispatcherSCfg().HealthCheckInterval = time.Millisecond
+		dS.StartLoop()
+		dS.Reload()
+		dS.Shutdown()
+		dS.Reload() // no effect after shutdown
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("health checks loop did not stop")
+	}
+	dS.loopMux.Lock()
+	if dS.stopHealthChecks != nil {
+		t.Error("health checks loop restarted after shutdown")
+	}
+	dS.loopMux.Unlock()
+}
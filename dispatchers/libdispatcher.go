@@ -19,6 +19,8 @@ along with this program.  If not, see <http://www.gnu.org/licenses/>
 package dispatchers
 
 import (
+	"crypto/sha1"
+	"encoding/binary"
 	"encoding/gob"
 	"fmt"
 	"sort"
@@ -46,7 +48,7 @@ type Dispatcher interface {
 	// HostIDs returns the ordered list of host IDs
 	HostIDs() (hostIDs []string)
 	// Dispatch is used to send the method over the connections given
-	Dispatch(routeID *string, subsystem,
+	Dispatch(ev *utils.CGREvent, routeID *string, subsystem,
 		serviceMethod string, args interface{}, reply interface{}) (err error)
 }
 
@@ -57,7 +59,8 @@ type strategyDispatcher interface {
 }
 
 // newDispatcher constructs instances of Dispatcher
-func newDispatcher(dm *engine.DataManager, pfl *engine.DispatcherProfile) (d Dispatcher, err error) {
+func newDispatcher(dm *engine.DataManager, pfl *engine.DispatcherProfile,
+	hh *hostsHealth) (d Dispatcher, err error) {
 	pfl.Hosts.Sort() // make sure the connections are sorted
 	switch pfl.Strategy {
 	case utils.MetaWeight:
@@ -65,32 +68,32 @@ func newDispatcher(dm *engine.DataManager, pfl *engine.DispatcherProfile) (d Dis
 			dm:       dm,
 			tnt:      pfl.Tenant,
 			hosts:    pfl.Hosts.Clone(),
-			strategy: new(singleResultstrategyDispatcher),
+			strategy: &singleResultstrategyDispatcher{hh: hh},
 		}
 	case utils.MetaRandom:
 		d = &RandomDispatcher{
 			dm:       dm,
 			tnt:      pfl.Tenant,
 			hosts:    pfl.Hosts.Clone(),
-			strategy: new(singleResultstrategyDispatcher),
+			strategy: &singleResultstrategyDispatcher{hh: hh},
 		}
 	case utils.MetaRoundRobin:
 		d = &RoundRobinDispatcher{
 			dm:       dm,
 			tnt:      pfl.Tenant,
 			hosts:    pfl.Hosts.Clone(),
-			strategy: new(singleResultstrategyDispatcher),
+			strategy: &singleResultstrategyDispatcher{hh: hh},
 		}
 	case utils.MetaBroadcast:
 		d = &BroadcastDispatcher{
 			dm:       dm,
 			tnt:      pfl.Tenant,
 			hosts:    pfl.Hosts.Clone(),
-			strategy: new(brodcastStrategyDispatcher),
+			strategy: &brodcastStrategyDispatcher{hh: hh},
 		}
 	case utils.MetaLoad:
 		hosts := pfl.Hosts.Clone()
-		ls, err := newLoadStrategyDispatcher(hosts, pfl.TenantID())
+		ls, err := newLoadStrategyDispatcher(hosts, pfl.TenantID(), hh)
 		if err != nil {
 			return nil, err
 		}
@@ -100,6 +103,18 @@ func newDispatcher(dm *engine.DataManager, pfl *engine.DispatcherProfile) (d Dis
 			hosts:    hosts,
 			strategy: ls,
 		}
+	case utils.MetaHash:
+		ring, err := newHashRing(pfl.Hosts)
+		if err != nil {
+			return nil, err
+		}
+		d = &HashDispatcher{
+			dm:        dm,
+			tnt:       pfl.Tenant,
+			hashField: hashFieldFromParams(pfl.StrategyParams),
+			ring:      ring,
+			strategy:  &singleResultstrategyDispatcher{hh: hh},
+		}
 	default:
 		err = fmt.Errorf("unsupported dispatch strategy: <%s>", pfl.Strategy)
 	}
@@ -130,7 +145,7 @@ func (wd *WeightDispatcher) HostIDs() (hostIDs []string) {
 	return
 }
 
-func (wd *WeightDispatcher) Dispatch(routeID *string, subsystem,
+func (wd *WeightDispatcher) Dispatch(ev *utils.CGREvent, routeID *string, subsystem,
 	serviceMethod string, args interface{}, reply interface{}) (err error) {
 	return wd.strategy.dispatch(wd.dm, routeID, subsystem, wd.tnt, wd.HostIDs(),
 		serviceMethod, args, reply)
@@ -161,7 +176,7 @@ func (d *RandomDispatcher) HostIDs() (hostIDs []string) {
 	return hosts.HostIDs()
 }
 
-func (d *RandomDispatcher) Dispatch(routeID *string, subsystem,
+func (d *RandomDispatcher) Dispatch(ev *utils.CGREvent, routeID *string, subsystem,
 	serviceMethod string, args interface{}, reply interface{}) (err error) {
 	return d.strategy.dispatch(d.dm, routeID, subsystem, d.tnt, d.HostIDs(),
 		serviceMethod, args, reply)
@@ -196,7 +211,7 @@ func (d *RoundRobinDispatcher) HostIDs() (hostIDs []string) {
 	return hosts.HostIDs()
 }
 
-func (d *RoundRobinDispatcher) Dispatch(routeID *string, subsystem,
+func (d *RoundRobinDispatcher) Dispatch(ev *utils.CGREvent, routeID *string, subsystem,
 	serviceMethod string, args interface{}, reply interface{}) (err error) {
 	return d.strategy.dispatch(d.dm, routeID, subsystem, d.tnt, d.HostIDs(),
 		serviceMethod, args, reply)
@@ -226,15 +241,172 @@ func (d *BroadcastDispatcher) HostIDs() (hostIDs []string) {
 	return
 }
 
-func (d *BroadcastDispatcher) Dispatch(routeID *string, subsystem,
+func (d *BroadcastDispatcher) Dispatch(ev *utils.CGREvent, routeID *string, subsystem,
 	serviceMethod string, args interface{}, reply interface{}) (lastErr error) { // no cache needed for this strategy because we need to call all connections
 	return d.strategy.dispatch(d.dm, routeID, subsystem, d.tnt, d.HostIDs(),
 		serviceMethod, args, reply)
 }
 
-type singleResultstrategyDispatcher struct{}
+// HashDispatcher maps the value of one event field onto a consistent-hash
+// ring of hosts so the events sharing that value land on the same host
+type HashDispatcher struct {
+	sync.RWMutex
+	dm        *engine.DataManager
+	tnt       string
+	hashField string
+	ring      *hashRing
+	strategy  strategyDispatcher
+}
+
+func (d *HashDispatcher) SetProfile(pfl *engine.DispatcherProfile) {
+	pfl.Hosts.Sort() // ring hosts in weight order for the events without hash field
+	ring, err := newHashRing(pfl.Hosts)
+	if err != nil {
+		utils.Logger.Warning(fmt.Sprintf("<%s> cannot update hash ring for profile: %s, error: %s",
+			utils.DispatcherS, pfl.TenantID(), err.Error()))
+		return
+	}
+	d.Lock()
+	d.hashField = hashFieldFromParams(pfl.StrategyParams)
+	d.ring = ring
+	d.Unlock()
+	return
+}
+
+// HostIDs returns the hosts of the ring in weight order
+func (d *HashDispatcher) HostIDs() (hostIDs []string) {
+	d.RLock()
+	hostIDs = make([]string, len(d.ring.hosts))
+	copy(hostIDs, d.ring.hosts)
+	d.RUnlock()
+	return
+}
+
+// hostIDsForEvent returns the hosts ordered by walking the ring
+// starting with the point of the hashed event field
+// or in weight order if the event is missing the field (ie: StatSv1.GetQueueIDs)
+func (d *HashDispatcher) hostIDsForEvent(ev *utils.CGREvent) (hostIDs []string, err error) {
+	d.RLock()
+	defer d.RUnlock()
+	var key string
+	if ev != nil {
+		key, err = ev.FieldAsString(d.hashField)
+	}
+	if ev == nil || err == utils.ErrNotFound {
+		hostIDs = make([]string, len(d.ring.hosts))
+		copy(hostIDs, d.ring.hosts)
+		return hostIDs, nil
+	}
+	if err != nil {
+		return
+	}
+	return d.ring.hostIDs(key), nil
+}
+
+func (d *HashDispatcher) Dispatch(ev *utils.CGREvent, routeID *string, subsystem,
+	serviceMethod string, args interface{}, reply interface{}) (err error) {
+	var hostIDs []string
+	if hostIDs, err = d.hostIDsForEvent(ev); err != nil {
+		return utils.NewErrDispatcherS(err)
+	}
+	return d.strategy.dispatch(d.dm, routeID, subsystem, d.tnt, hostIDs,
+		serviceMethod, args, reply)
+}
+
+// hashFieldFromParams returns the event field used as hash key,
+// either as *hash_field or as first positional strategy parameter
+func hashFieldFromParams(params map[string]interface{}) string {
+	if fld, has := params[utils.MetaHashField]; has {
+		return utils.IfaceAsString(fld)
+	}
+	if fld, has := params["0"]; has { // StrategyParameters loaded from TariffPlan
+		return utils.IfaceAsString(fld)
+	}
+	return utils.Account
+}
+
+// hashRingReplicas is the number of ring points per host, multiplied by host *ratio
+const hashRingReplicas = 100
+
+// hashRing is a consistent-hash ring with virtual nodes for each host
+type hashRing struct {
+	hosts   []string // host IDs in profile order
+	points  []uint64 // sorted ring points
+	pHostID []string // host ID for each point
+}
 
-func (_ *singleResultstrategyDispatcher) dispatch(dm *engine.DataManager, routeID *string, subsystem, tnt string,
+func newHashRing(hosts engine.DispatcherHostProfiles) (hr *hashRing, err error) {
+	hr = &hashRing{hosts: make([]string, 0, len(hosts))}
+	type ringPoint struct {
+		point  uint64
+		hostID string
+	}
+	var rPoints []ringPoint
+	seen := make(utils.StringMap)
+	for _, host := range hosts {
+		if seen.HasKey(host.ID) {
+			continue
+		}
+		seen[host.ID] = true
+		hr.hosts = append(hr.hosts, host.ID)
+		ratio := int64(1)
+		if strRatio, has := host.Params[utils.MetaRatio]; has {
+			if ratio, err = strconv.ParseInt(utils.IfaceAsString(strRatio), 10, 64); err != nil {
+				return nil, err
+			}
+		}
+		for i := int64(0); i < ratio*hashRingReplicas; i++ {
+			rPoints = append(rPoints, ringPoint{
+				point:  hashRingKey(host.ID + utils.CONCATENATED_KEY_SEP + strconv.FormatInt(i, 10)),
+				hostID: host.ID,
+			})
+		}
+	}
+	sort.Slice(rPoints, func(i, j int) bool {
+		if rPoints[i].point == rPoints[j].point {
+			return rPoints[i].hostID < rPoints[j].hostID
+		}
+		return rPoints[i].point < rPoints[j].point
+	})
+	hr.points = make([]uint64, len(rPoints))
+	hr.pHostID = make([]string, len(rPoints))
+	for i, rp := range rPoints {
+		hr.points[i] = rp.point
+		hr.pHostID[i] = rp.hostID
+	}
+	return
+}
+
+// hostIDs returns the distinct hosts found walking the ring clockwise from key
+// the first one owns the key while the rest are used as fallback
+func (hr *hashRing) hostIDs(key string) (hostIDs []string) {
+	if len(hr.points) == 0 {
+		return
+	}
+	hostIDs = make([]string, 0, len(hr.hosts))
+	seen := make(utils.StringMap)
+	h := hashRingKey(key)
+	idx := sort.Search(len(hr.points), func(i int) bool { return hr.points[i] >= h })
+	for i := 0; i < len(hr.points) && len(hostIDs) < len(hr.hosts); i++ {
+		hostID := hr.pHostID[(idx+i)%len(hr.points)]
+		if !seen.HasKey(hostID) {
+			seen[hostID] = true
+			hostIDs = append(hostIDs, hostID)
+		}
+	}
+	return
+}
+
+func hashRingKey(key string) uint64 {
+	h := sha1.Sum([]byte(key))
+	return binary.BigEndian.Uint64(h[:8])
+}
+
+type singleResultstrategyDispatcher struct {
+	hh *hostsHealth
+}
+
+func (sd *singleResultstrategyDispatcher) dispatch(dm *engine.DataManager, routeID *string, subsystem, tnt string,
 	hostIDs []string, serviceMethod string, args interface{}, reply interface{}) (err error) {
 	var dH *engine.DispatcherHost
 	if routeID != nil && *routeID != "" {
@@ -244,17 +416,23 @@ func (_ *singleResultstrategyDispatcher) dispatch(dm *engine.DataManager, routeI
 		if x, ok := engine.Cache.Get(utils.CacheDispatcherRoutes,
 			*routeID); ok && x != nil {
 			dH = x.(*engine.DispatcherHost)
-			if err = dH.Call(serviceMethod, args, reply); !utils.IsNetworkError(err) {
-				return
+			if sd.hh.isUp(tnt, dH.ID) {
+				err = dH.Call(serviceMethod, args, reply)
+				sd.hh.setCallResult(tnt, dH.ID, err)
+				if !utils.IsNetworkError(err) {
+					return
+				}
 			}
 		}
 	}
-	for _, hostID := range hostIDs {
+	for _, hostID := range sd.hh.upHostIDs(tnt, hostIDs) {
 		if dH, err = dm.GetDispatcherHost(tnt, hostID, true, true, utils.NonTransactional); err != nil {
 			err = utils.NewErrDispatcherS(err)
 			return
 		}
-		if err = dH.Call(serviceMethod, args, reply); utils.IsNetworkError(err) {
+		err = dH.Call(serviceMethod, args, reply)
+		sd.hh.setCallResult(tnt, hostID, err)
+		if utils.IsNetworkError(err) {
 			continue
 		}
 		if routeID != nil && *routeID != "" { // cache the discovered route
@@ -268,18 +446,28 @@ func (_ *singleResultstrategyDispatcher) dispatch(dm *engine.DataManager, routeI
 	return
 }
 
-type brodcastStrategyDispatcher struct{}
+type brodcastStrategyDispatcher struct {
+	hh *hostsHealth
+}
 
-func (_ *brodcastStrategyDispatcher) dispatch(dm *engine.DataManager, routeID *string, subsystem, tnt string, hostIDs []string,
+func (bd *brodcastStrategyDispatcher) dispatch(dm *engine.DataManager, routeID *string, subsystem, tnt string, hostIDs []string,
 	serviceMethod string, args interface{}, reply interface{}) (err error) {
 	var hasErrors bool
 	for _, hostID := range hostIDs {
+		if !bd.hh.isUp(tnt, hostID) {
+			utils.Logger.Err(fmt.Sprintf("<%s> skipping hostID %q marked down at %s strategy",
+				utils.DispatcherS, hostID, utils.MetaBroadcast))
+			hasErrors = true
+			continue
+		}
 		var dH *engine.DispatcherHost
 		if dH, err = dm.GetDispatcherHost(tnt, hostID, true, true, utils.NonTransactional); err != nil {
 			err = utils.NewErrDispatcherS(err)
 			return
 		}
-		if err = dH.Call(serviceMethod, args, reply); utils.IsNetworkError(err) {
+		err = dH.Call(serviceMethod, args, reply)
+		bd.hh.setCallResult(tnt, hostID, err)
+		if utils.IsNetworkError(err) {
 			utils.Logger.Err(fmt.Sprintf("<%s> network error: <%s> at %s strategy for hostID %q",
 				utils.DispatcherS, err.Error(), utils.MetaBroadcast, hostID))
 			hasErrors = true
@@ -295,10 +483,12 @@ func (_ *brodcastStrategyDispatcher) dispatch(dm *engine.DataManager, routeID *s
 	return
 }
 
-func newLoadStrategyDispatcher(hosts engine.DispatcherHostProfiles, tntID string) (ls *loadStrategyDispatcher, err error) {
+func newLoadStrategyDispatcher(hosts engine.DispatcherHostProfiles, tntID string,
+	hh *hostsHealth) (ls *loadStrategyDispatcher, err error) {
 	ls = &loadStrategyDispatcher{
 		tntID: tntID,
 		hosts: hosts,
+		hh:    hh,
 	}
 
 	return
@@ -307,6 +497,7 @@ func newLoadStrategyDispatcher(hosts engine.DispatcherHostProfiles, tntID string
 type loadStrategyDispatcher struct {
 	tntID string
 	hosts engine.DispatcherHostProfiles
+	hh    *hostsHealth
 }
 
 func newLoadMetrics(hosts engine.DispatcherHostProfiles) (*LoadMetrics, error) {
@@ -356,15 +547,18 @@ func (ld *loadStrategyDispatcher) dispatch(dm *engine.DataManager, routeID *stri
 		if x, ok := engine.Cache.Get(utils.CacheDispatcherRoutes,
 			*routeID); ok && x != nil {
 			dH = x.(*engine.DispatcherHost)
-			lM.incrementLoad(dH.ID, ld.tntID)
-			err = dH.Call(serviceMethod, args, reply)
-			lM.decrementLoad(dH.ID, ld.tntID) // call ended
-			if !utils.IsNetworkError(err) {
-				return
+			if ld.hh.isUp(tnt, dH.ID) {
+				lM.incrementLoad(dH.ID, ld.tntID)
+				err = dH.Call(serviceMethod, args, reply)
+				lM.decrementLoad(dH.ID, ld.tntID) // call ended
+				ld.hh.setCallResult(tnt, dH.ID, err)
+				if !utils.IsNetworkError(err) {
+					return
+				}
 			}
 		}
 	}
-	for _, hostID := range lM.getHosts(hostIDs) {
+	for _, hostID := range lM.getHosts(ld.hh.upHostIDs(tnt, hostIDs)) {
 		if dH, err = dm.GetDispatcherHost(tnt, hostID, true, true, utils.NonTransactional); err != nil {
 			err = utils.NewErrDispatcherS(err)
 			return
@@ -372,6 +566,7 @@ func (ld *loadStrategyDispatcher) dispatch(dm *engine.DataManager, routeID *stri
 		lM.incrementLoad(hostID, ld.tntID)
 		err = dH.Call(serviceMethod, args, reply)
 		lM.decrementLoad(hostID, ld.tntID) // call ended
+		ld.hh.setCallResult(tnt, hostID, err)
 		if utils.IsNetworkError(err) {
 			continue
 		}
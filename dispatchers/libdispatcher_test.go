@@ -0,0 +1,179 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package dispatchers
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/cgrates/cgrates/engine"
+	"github.com/cgrates/cgrates/utils"
+)
+
+func TestHashRingHostIDs(t *testing.T) {
+	hosts := engine.DispatcherHostProfiles{
+		{ID: "HOST1"}, {ID: "HOST2"}, {ID: "HOST3"},
+	}
+	hr, err := newHashRing(hosts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(hr.points) != 3*hashRingReplicas {
+		t.Errorf("Expected %d points, received: %d", 3*hashRingReplicas, len(hr.points))
+	}
+	hostIDs := hr.hostIDs("1001")
+	if len(hostIDs) != 3 {
+		t.Fatalf("Expected all hosts, received: %+v", hostIDs)
+	}
+	for i := 0; i < 10; i++ { // sticky
+		if rcv := hr.hostIDs("1001"); !reflect.DeepEqual(hostIDs, rcv) {
+			t.Errorf("Expected: %+v, received: %+v", hostIDs, rcv)
+		}
+	}
+	counts := make(map[string]int)
+	for i := 0; i < 3000; i++ {
+		counts[hr.hostIDs(fmt.Sprintf("ACC%d", i))[0]]++
+	}
+	for _, host := range hosts {
+		if counts[host.ID] < 500 {
+			t.Errorf("Unbalanced ring: %+v", counts)
+		}
+	}
+}
+
+func TestHashRingRemap(t *testing.T) {
+	hr3, err := newHashRing(engine.DispatcherHostProfiles{
+		{ID: "HOST1"}, {ID: "HOST2"}, {ID: "HOST3"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	hr4, err := newHashRing(engine.DispatcherHostProfiles{
+		{ID: "HOST1"}, {ID: "HOST2"}, {ID: "HOST3"}, {ID: "HOST4"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	hr2, err := newHashRing(engine.DispatcherHostProfiles{
+		{ID: "HOST1"}, {ID: "HOST3"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var moved int
+	for i := 0; i < 1000; i++ {
+		key := fmt.Sprintf("ACC%d", i)
+		owner := hr3.hostIDs(key)[0]
+		if rcv := hr4.hostIDs(key)[0]; rcv != owner {
+			moved++
+			if rcv != "HOST4" { // keys only move to the added host
+				t.Errorf("Key %s moved from %s to %s", key, owner, rcv)
+			}
+		}
+		if owner != "HOST2" { // keys of remaining hosts stay in place
+			if rcv := hr2.hostIDs(key)[0]; rcv != owner {
+				t.Errorf("Key %s moved from %s to %s", key, owner, rcv)
+			}
+		}
+	}
+	if moved == 0 || moved > 400 {
+		t.Errorf("Unexpected number of remapped keys: %d", moved)
+	}
+}
+
+func TestHashRingRatio(t *testing.T) {
+	hr, err := newHashRing(engine.DispatcherHostProfiles{
+		{ID: "HOST1", Params: map[string]interface{}{utils.MetaRatio: 3}},
+		{ID: "HOST2"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(hr.points) != 4*hashRingReplicas {
+		t.Errorf("Expected %d points, received: %d", 4*hashRingReplicas, len(hr.points))
+	}
+	if _, err := newHashRing(engine.DispatcherHostProfiles{
+		{ID: "HOST1", Params: map[string]interface{}{utils.MetaRatio: "A"}},
+	}); err == nil {
+		t.Error("Expected error for invalid ratio")
+	}
+}
+
+func TestHashDispatcherHostIDsForEvent(t *testing.T) {
+	pfl := &engine.DispatcherProfile{
+		Tenant:   "cgrates.org",
+		ID:       "DSP_HASH",
+		Strategy: utils.MetaHash,
+		StrategyParams: map[string]interface{}{
+			utils.MetaHashField: utils.OriginID,
+		},
+		Hosts: engine.DispatcherHostProfiles{
+			{ID: "HOST1", Weight: 10}, {ID: "HOST2", Weight: 20},
+		},
+	}
+	d, err := newDispatcher(nil, pfl, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	hd, canCast := d.(*HashDispatcher)
+	if !canCast {
+		t.Fatalf("Expected *HashDispatcher, received: %T", d)
+	}
+	if exp := []string{"HOST2", "HOST1"}; !reflect.DeepEqual(exp, hd.HostIDs()) {
+		t.Errorf("Expected: %+v, received: %+v", exp, hd.HostIDs())
+	}
+	ev := &utils.CGREvent{
+		Tenant: "cgrates.org",
+		Event: map[string]interface{}{
+			utils.OriginID: "ORIGIN1",
+		},
+	}
+	if hostIDs, err := hd.hostIDsForEvent(ev); err != nil {
+		t.Error(err)
+	} else if exp := hd.ring.hostIDs("ORIGIN1"); !reflect.DeepEqual(exp, hostIDs) {
+		t.Errorf("Expected: %+v, received: %+v", exp, hostIDs)
+	}
+	ev.Event = map[string]interface{}{utils.Account: "1001"}
+	if hostIDs, err := hd.hostIDsForEvent(ev); err != nil {
+		t.Error(err)
+	} else if exp := []string{"HOST2", "HOST1"}; !reflect.DeepEqual(exp, hostIDs) {
+		t.Errorf("Expected: %+v, received: %+v", exp, hostIDs)
+	}
+	if hostIDs, err := hd.hostIDsForEvent(nil); err != nil {
+		t.Error(err)
+	} else if exp := []string{"HOST2", "HOST1"}; !reflect.DeepEqual(exp, hostIDs) {
+		t.Errorf("Expected: %+v, received: %+v", exp, hostIDs)
+	}
+}
+
+func TestHashFieldFromParams(t *testing.T) {
+	if rcv := hashFieldFromParams(nil); rcv != utils.Account {
+		t.Errorf("Expected: %s, received: %s", utils.Account, rcv)
+	}
+	if rcv := hashFieldFromParams(map[string]interface{}{"0": utils.Subject}); rcv != utils.Subject {
+		t.Errorf("Expected: %s, received: %s", utils.Subject, rcv)
+	}
+	if rcv := hashFieldFromParams(map[string]interface{}{
+		"0":                 utils.Subject,
+		utils.MetaHashField: utils.OriginID,
+	}); rcv != utils.OriginID {
+		t.Errorf("Expected: %s, received: %s", utils.OriginID, rcv)
+	}
+}
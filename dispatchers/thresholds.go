@@ -135,3 +135,27 @@ func (dS *DispatcherService) ThresholdSv1GetThreshold(args *utils.TenantIDWithAr
 		ID:     args.ID,
 	}, utils.MetaThresholds, routeID, utils.ThresholdSv1GetThreshold, args, th)
 }
+
+func (dS *DispatcherService) ThresholdSv1GetThresholdState(args *utils.TenantIDWithArgDispatcher, reply *engine.ThresholdState) (err error) {
+	tnt := dS.cfg.GeneralCfg().DefaultTenant
+	if args.TenantID != nil && args.TenantID.Tenant != utils.EmptyString {
+		tnt = args.TenantID.Tenant
+	}
+	if args.ArgDispatcher == nil {
+		return utils.NewErrMandatoryIeMissing(utils.ArgDispatcherField)
+	}
+	if len(dS.cfg.DispatcherSCfg().AttributeSConns) != 0 {
+		if err = dS.authorize(utils.ThresholdSv1GetThresholdState, tnt,
+			args.APIKey, utils.TimePointer(time.Now())); err != nil {
+			return
+		}
+	}
+	var routeID *string
+	if args.ArgDispatcher != nil {
+		routeID = args.ArgDispatcher.RouteID
+	}
+	return dS.Dispatch(&utils.CGREvent{
+		Tenant: tnt,
+		ID:     args.ID,
+	}, utils.MetaThresholds, routeID, utils.ThresholdSv1GetThresholdState, args, reply)
+}
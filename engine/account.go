@@ -496,7 +496,9 @@ func (acc *Account) debitCreditBalance(cd *CallDescriptor, count bool, dryRun bo
 
 		if initialLength == 0 {
 			// this is the first add, debit the connect fee
-			ok, debitedConnectFeeBalance = acc.DebitConnectionFee(cc, usefulMoneyBalances, count, true)
+			if ok, debitedConnectFeeBalance, err = acc.DebitConnectionFee(cc, usefulMoneyBalances, count, true); err != nil {
+				return
+			}
 		}
 		//log.Printf("Left CC: %+v ", leftCC)
 		// get the default money balanance
@@ -505,21 +507,26 @@ func (acc *Account) debitCreditBalance(cd *CallDescriptor, count bool, dryRun bo
 			utils.Logger.Warning(fmt.Sprintf("<Rater> Going negative on account %s with AllowNegative: false", cd.GetAccountKey()))
 		}
 		leftCC.Timespans.Decompress()
+		exchs := make(exchangers)
 		for tsIndex, ts := range leftCC.Timespans {
 			if ts.Increments == nil {
 				ts.createIncrementsSlice()
 			}
-
+			var exRate float64
+			if exRate, err = acc.defaultBalanceExchangeRate(exchs, acc.GetDefaultMoneyBalance(), ts); err != nil {
+				return
+			}
 			if tsIndex == 0 && ts.RateInterval.Rating.ConnectFee > 0 && cc.deductConnectFee && ok {
-
+				cfRate, _ := exchs.rate(debitedConnectFeeBalance.Currency, ts.ratingCurrency(), ts.TimeStart)
 				inc := &Increment{
 					Duration: 0,
 					Cost:     ts.RateInterval.Rating.ConnectFee,
 					BalanceInfo: &DebitInfo{
 						Monetary: &MonetaryInfo{
-							UUID:  debitedConnectFeeBalance.Uuid,
-							ID:    debitedConnectFeeBalance.ID,
-							Value: debitedConnectFeeBalance.Value,
+							UUID:         debitedConnectFeeBalance.Uuid,
+							ID:           debitedConnectFeeBalance.ID,
+							Value:        debitedConnectFeeBalance.Value,
+							ExchangeRate: cfRate,
 						},
 						AccountID: acc.ID,
 					},
@@ -536,7 +543,7 @@ func (acc *Account) debitCreditBalance(cd *CallDescriptor, count bool, dryRun bo
 					continue
 				}
 
-				cost := increment.Cost
+				cost := exchangeCost(increment.Cost, exRate)
 				defaultBalance := acc.GetDefaultMoneyBalance()
 				defaultBalance.SubstractValue(cost)
 				//send default balance to thresholdS to be processed
@@ -563,9 +570,10 @@ func (acc *Account) debitCreditBalance(cd *CallDescriptor, count bool, dryRun bo
 				}
 
 				increment.BalanceInfo.Monetary = &MonetaryInfo{
-					UUID:  defaultBalance.Uuid,
-					ID:    defaultBalance.ID,
-					Value: defaultBalance.Value,
+					UUID:         defaultBalance.Uuid,
+					ID:           defaultBalance.ID,
+					Value:        defaultBalance.Value,
+					ExchangeRate: exRate,
 				}
 				increment.BalanceInfo.AccountID = acc.ID
 				increment.paid = true
@@ -612,6 +620,16 @@ func (acc *Account) GetDefaultMoneyBalance() *Balance {
 	return defaultBalance
 }
 
+// defaultBalanceExchangeRate returns the rate converting the costs of ts into the currency of the default balance
+// errors if the exchange rates are missing so the costs are not debited unconverted
+func (acc *Account) defaultBalanceExchangeRate(exchs exchangers, b *Balance, ts *TimeSpan) (exRate float64, err error) {
+	if exRate, err = exchs.rate(b.Currency, ts.ratingCurrency(), ts.TimeStart); err != nil {
+		utils.Logger.Warning(fmt.Sprintf("<RALs> cannot debit the costs from balance <%s> of account <%s>: %s",
+			b.ID, acc.ID, err.Error()))
+	}
+	return
+}
+
 // ExecuteActionTriggers scans the action triggers and execute the actions for which trigger is met
 func (acc *Account) ExecuteActionTriggers(a *Action) {
 	if acc.executingTriggers {
@@ -864,42 +882,53 @@ func (acc *Account) Clone() *Account {
 }
 
 // DebitConnectionFee debits the connection fee
-func (acc *Account) DebitConnectionFee(cc *CallCost, usefulMoneyBalances Balances, count bool, block bool) (bool, Balance) {
+func (acc *Account) DebitConnectionFee(cc *CallCost, usefulMoneyBalances Balances, count bool, block bool) (bool, Balance, error) {
 	var debitedBalance Balance
 
 	if cc.deductConnectFee {
 		connectFee := cc.GetConnectFee()
 		//log.Print("CONNECT FEE: %f", connectFee)
 		connectFeePaid := false
+		exchs := make(exchangers)
 		for _, b := range usefulMoneyBalances {
-			if b.GetValue() >= connectFee {
-				b.SubstractValue(connectFee)
+			bConnectFee, canPay := connectFee, true
+			if connectFee != 0 { // converted into the balance currency
+				exRate, err := exchs.rate(b.Currency, cc.Timespans[0].ratingCurrency(), cc.Timespans[0].TimeStart)
+				bConnectFee, canPay = exchangeCost(connectFee, exRate), err == nil
+			}
+			if canPay && b.GetValue() >= bConnectFee {
+				b.SubstractValue(bConnectFee)
 				// the conect fee is not refundable!
 				if count {
-					acc.countUnits(connectFee, utils.MONETARY, cc, b)
+					acc.countUnits(bConnectFee, utils.MONETARY, cc, b)
 				}
 				connectFeePaid = true
 				debitedBalance = *b
 				break
 			}
 			if b.Blocker && block { // stop here
-				return false, debitedBalance
+				return false, debitedBalance, nil
 			}
 		}
 		// debit connect fee
 		if connectFee > 0 && !connectFeePaid {
-			cc.negativeConnectFee = true
 			// there are no money for the connect fee; go negative
 			b := acc.GetDefaultMoneyBalance()
-			b.SubstractValue(connectFee)
+			exRate, err := acc.defaultBalanceExchangeRate(exchs, b, cc.Timespans[0])
+			if err != nil {
+				return false, debitedBalance, err
+			}
+			cc.negativeConnectFee = true
+			bConnectFee := exchangeCost(connectFee, exRate)
+			b.SubstractValue(bConnectFee)
 			debitedBalance = *b
 			// the conect fee is not refundable!
 			if count {
-				acc.countUnits(connectFee, utils.MONETARY, cc, b)
+				acc.countUnits(bConnectFee, utils.MONETARY, cc, b)
 			}
 		}
 	}
-	return true, debitedBalance
+	return true, debitedBalance, nil
 }
 
 func (acc *Account) matchActionFilter(condition string) (bool, error) {
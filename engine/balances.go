@@ -45,6 +45,7 @@ type Balance struct {
 	Disabled       bool
 	Factor         ValueFactor
 	Blocker        bool
+	Currency       string // currency of the *monetary balance, empty for the implicit one
 	precision      int
 	account        *Account // used to store ub reference for shared balances
 	dirty          bool
@@ -66,7 +67,8 @@ func (b *Balance) Equal(o *Balance) bool {
 		b.Categories.Equal(o.Categories) &&
 		b.SharedGroups.Equal(o.SharedGroups) &&
 		b.Disabled == o.Disabled &&
-		b.Blocker == o.Blocker
+		b.Blocker == o.Blocker &&
+		b.Currency == o.Currency
 }
 
 func (b *Balance) MatchFilter(o *BalanceFilter, skipIds, skipExpiry bool) bool {
@@ -91,7 +93,8 @@ func (b *Balance) MatchFilter(o *BalanceFilter, skipIds, skipExpiry bool) bool {
 		(o.Categories == nil || b.Categories.Includes(*o.Categories)) &&
 		(o.TimingIDs == nil || b.TimingIDs.Includes(*o.TimingIDs)) &&
 		(o.SharedGroups == nil || b.SharedGroups.Includes(*o.SharedGroups)) &&
-		(o.RatingSubject == nil || b.RatingSubject == *o.RatingSubject)
+		(o.RatingSubject == nil || b.RatingSubject == *o.RatingSubject) &&
+		(o.Currency == nil || b.Currency == *o.Currency)
 }
 
 func (b *Balance) HardMatchFilter(o *BalanceFilter, skipIds bool) bool {
@@ -112,7 +115,8 @@ func (b *Balance) HardMatchFilter(o *BalanceFilter, skipIds bool) bool {
 		(o.Categories == nil || b.Categories.Equal(*o.Categories)) &&
 		(o.TimingIDs == nil || b.TimingIDs.Equal(*o.TimingIDs)) &&
 		(o.SharedGroups == nil || b.SharedGroups.Equal(*o.SharedGroups)) &&
-		(o.RatingSubject == nil || b.RatingSubject == *o.RatingSubject)
+		(o.RatingSubject == nil || b.RatingSubject == *o.RatingSubject) &&
+		(o.Currency == nil || b.Currency == *o.Currency)
 }
 
 // the default balance has standard Id
@@ -177,6 +181,7 @@ func (b *Balance) Clone() *Balance {
 		Timings:        b.Timings, // should not be a problem with aliasing
 		Blocker:        b.Blocker,
 		Disabled:       b.Disabled,
+		Currency:       b.Currency,
 		dirty:          b.dirty,
 	}
 	if b.DestinationIDs != nil {
@@ -201,6 +206,7 @@ func (b *Balance) AsNavigableMap(_ []*config.FCTemplate) (*config.NavigableMap,
 		"Disabled":       b.Disabled,
 		"Factor":         b.Factor,
 		"Blocker":        b.Blocker,
+		"Currency":       b.Currency,
 	}), nil
 }
 
@@ -408,14 +414,16 @@ func (b *Balance) debitUnits(cd *CallDescriptor, ub *Account, moneyBalances Bala
 		}
 		if debitConnectFee {
 			// this is the first add, debit the connect fee
-			if ok, debitedConnectFeeBalance = ub.DebitConnectionFee(cc, moneyBalances, count, true); !ok {
+			if ok, debitedConnectFeeBalance, err = ub.DebitConnectionFee(cc, moneyBalances, count, true); err != nil {
+				return nil, err
+			} else if !ok {
 				// found blocker balance
 				return nil, nil
 			}
 		}
 		cc.Timespans.Decompress()
 		//log.Printf("CC: %+v", cc)
-
+		exchs := make(exchangers) // converting the costs into the currencies of the money balances
 		for tsIndex, ts := range cc.Timespans {
 			if ts.Increments == nil {
 				ts.createIncrementsSlice()
@@ -426,15 +434,16 @@ func (b *Balance) debitUnits(cd *CallDescriptor, ub *Account, moneyBalances Bala
 			}
 
 			if tsIndex == 0 && ts.RateInterval.Rating.ConnectFee > 0 && debitConnectFee && cc.deductConnectFee && ok {
-
+				cfRate, _ := exchs.rate(debitedConnectFeeBalance.Currency, ts.ratingCurrency(), ts.TimeStart)
 				inc := &Increment{
 					Duration: 0,
 					Cost:     ts.RateInterval.Rating.ConnectFee,
 					BalanceInfo: &DebitInfo{
 						Monetary: &MonetaryInfo{
-							UUID:  debitedConnectFeeBalance.Uuid,
-							ID:    debitedConnectFeeBalance.ID,
-							Value: debitedConnectFeeBalance.Value,
+							UUID:         debitedConnectFeeBalance.Uuid,
+							ID:           debitedConnectFeeBalance.ID,
+							Value:        debitedConnectFeeBalance.Value,
+							ExchangeRate: cfRate,
 						},
 						AccountID: ub.ID,
 					},
@@ -489,15 +498,23 @@ func (b *Balance) debitUnits(cd *CallDescriptor, ub *Account, moneyBalances Bala
 					continue
 				}
 				var moneyBal *Balance
+				var exRate float64
 				for _, mb := range moneyBalances {
-					if mb.GetValue() >= cost {
-						moneyBal = mb
+					mbRate, err := exchs.rate(mb.Currency, ts.ratingCurrency(), ts.TimeStart)
+					if err != nil { // cannot pay in the balance currency
+						continue
+					}
+					if mb.GetValue() >= exchangeCost(cost, mbRate) {
+						moneyBal, exRate = mb, mbRate
 						break
 					}
 				}
 				if cost != 0 && moneyBal == nil && (!dryRun || ub.AllowNegative) { // Fix for issue #685
 					utils.Logger.Warning(fmt.Sprintf("<RALs> Going negative on account %s with AllowNegative: false", cd.GetAccountKey()))
 					moneyBal = ub.GetDefaultMoneyBalance()
+					if exRate, err = ub.defaultBalanceExchangeRate(exchs, moneyBal, ts); err != nil {
+						return nil, err
+					}
 				}
 				if b.GetValue() >= amount && (moneyBal != nil || cost == 0) {
 					b.SubstractValue(amount)
@@ -512,11 +529,12 @@ func (b *Balance) debitUnits(cd *CallDescriptor, ub *Account, moneyBalances Bala
 					}
 					inc.BalanceInfo.AccountID = ub.ID
 					if cost != 0 {
-						moneyBal.SubstractValue(cost)
+						moneyBal.SubstractValue(exchangeCost(cost, exRate))
 						inc.BalanceInfo.Monetary = &MonetaryInfo{
-							UUID:  moneyBal.Uuid,
-							ID:    moneyBal.ID,
-							Value: moneyBal.Value,
+							UUID:         moneyBal.Uuid,
+							ID:           moneyBal.ID,
+							Value:        moneyBal.Value,
+							ExchangeRate: exRate,
 						}
 						cd.MaxCostSoFar += cost
 					}
@@ -524,7 +542,7 @@ func (b *Balance) debitUnits(cd *CallDescriptor, ub *Account, moneyBalances Bala
 					if count {
 						ub.countUnits(amount, cc.ToR, cc, b)
 						if cost != 0 {
-							ub.countUnits(cost, utils.MONETARY, cc, moneyBal)
+							ub.countUnits(exchangeCost(cost, exRate), utils.MONETARY, cc, moneyBal)
 						}
 					}
 				} else {
@@ -558,6 +576,15 @@ func (b *Balance) debitMoney(cd *CallDescriptor, ub *Account, moneyBalances Bala
 	if err != nil {
 		return nil, err
 	}
+	// the costs are converted into the balance currency
+	exch := newExchanger(b.Currency)
+	for _, ts := range cc.Timespans {
+		if _, err = exch.rate(ts.ratingCurrency(), ts.TimeStart); err != nil {
+			utils.Logger.Warning(fmt.Sprintf("<RALs> skipping balance <%s> of account <%s>: %s",
+				b.ID, ub.ID, err.Error()))
+			return nil, nil
+		}
+	}
 
 	var debitedConnectFeeBalance Balance
 	var ok bool
@@ -565,7 +592,9 @@ func (b *Balance) debitMoney(cd *CallDescriptor, ub *Account, moneyBalances Bala
 	if debitConnectFee {
 
 		// this is the first add, debit the connect fee
-		if ok, debitedConnectFeeBalance = ub.DebitConnectionFee(cc, moneyBalances, count, true); !ok {
+		if ok, debitedConnectFeeBalance, err = ub.DebitConnectionFee(cc, moneyBalances, count, true); err != nil {
+			return nil, err
+		} else if !ok {
 			// balance is blocker
 			return nil, nil
 		}
@@ -586,16 +615,18 @@ func (b *Balance) debitMoney(cd *CallDescriptor, ub *Account, moneyBalances Bala
 			return nil, errors.New("timespan with no rate interval assigned")
 		}
 
+		exRate, _ := exch.rate(ts.ratingCurrency(), ts.TimeStart)
 		if tsIndex == 0 && ts.RateInterval.Rating.ConnectFee > 0 && debitConnectFee && cc.deductConnectFee && ok {
-
+			cfRate, _ := newExchanger(debitedConnectFeeBalance.Currency).rate(ts.ratingCurrency(), ts.TimeStart)
 			inc := &Increment{
 				Duration: 0,
 				Cost:     ts.RateInterval.Rating.ConnectFee,
 				BalanceInfo: &DebitInfo{
 					Monetary: &MonetaryInfo{
-						UUID:  debitedConnectFeeBalance.Uuid,
-						ID:    debitedConnectFeeBalance.ID,
-						Value: debitedConnectFeeBalance.Value,
+						UUID:         debitedConnectFeeBalance.Uuid,
+						ID:           debitedConnectFeeBalance.ID,
+						Value:        debitedConnectFeeBalance.Value,
+						ExchangeRate: cfRate,
 					},
 					AccountID: ub.ID,
 				},
@@ -654,13 +685,14 @@ func (b *Balance) debitMoney(cd *CallDescriptor, ub *Account, moneyBalances Bala
 				continue
 			}
 
-			if b.GetValue() >= amount {
-				b.SubstractValue(amount)
+			if bAmount := exchangeCost(amount, exRate); b.GetValue() >= bAmount {
+				b.SubstractValue(bAmount)
 				cd.MaxCostSoFar += amount
 				inc.BalanceInfo.Monetary = &MonetaryInfo{
-					UUID:  b.Uuid,
-					ID:    b.ID,
-					Value: b.Value,
+					UUID:         b.Uuid,
+					ID:           b.ID,
+					Value:        b.Value,
+					ExchangeRate: exRate,
 				}
 				inc.BalanceInfo.AccountID = ub.ID
 				if b.RatingSubject != "" {
@@ -668,7 +700,7 @@ func (b *Balance) debitMoney(cd *CallDescriptor, ub *Account, moneyBalances Bala
 				}
 				inc.paid = true
 				if count {
-					ub.countUnits(amount, utils.MONETARY, cc, b)
+					ub.countUnits(bAmount, utils.MONETARY, cc, b)
 				}
 			} else {
 				inc.paid = false
@@ -142,6 +142,16 @@ func TestBalanceMatchFilterDiffId(t *testing.T) {
 	}
 }
 
+func TestBalanceMatchFilterCurrency(t *testing.T) {
+	mb1 := &Balance{ID: "T1", Weight: 1, Currency: "EUR", DestinationIDs: utils.StringMap{}}
+	if !mb1.MatchFilter(&BalanceFilter{Currency: utils.StringPointer("EUR")}, false, false) {
+		t.Errorf("Match filter failure: %+v", mb1)
+	}
+	if mb1.MatchFilter(&BalanceFilter{Currency: utils.StringPointer("USD")}, false, false) {
+		t.Errorf("Match filter failure: %+v", mb1)
+	}
+}
+
 func TestBalanceClone(t *testing.T) {
 	mb1 := &Balance{Value: 1, Weight: 2, RatingSubject: "test", DestinationIDs: utils.NewStringMap("5")}
 	mb2 := mb1.Clone()
@@ -259,6 +259,12 @@ func (chS *CacheS) V1RemoveItem(args *utils.ArgsGetCacheItemWithArgDispatcher,
 func (chS *CacheS) V1Clear(args *utils.AttrCacheIDsWithArgDispatcher,
 	reply *string) (err error) {
 	chS.tCache.Clear(args.CacheIDs)
+	for _, cacheID := range args.CacheIDs {
+		switch cacheID {
+		case utils.CacheDestinations, utils.CacheReverseDestinations, utils.CacheRatingPlans:
+			chS.tCache.Clear([]string{utils.CacheSupplierCosts}) // costs rated out of the old data
+		}
+	}
 	*reply = utils.OK
 	return
 }
@@ -408,6 +414,10 @@ func (chS *CacheS) V1ReloadCache(attrs utils.AttrReloadCacheWithArgDispatcher, r
 	if err = chS.reloadCache(utils.DispatcherHostPrefix, attrs.DispatcherHostIDs); err != nil {
 		return
 	}
+	// ExchangeRates
+	if err = chS.reloadCache(utils.ExchangeRatesPrefix, attrs.ExchangeRatesIDs); err != nil {
+		return
+	}
 
 	//get loadIDs from database for all types
 	loadIDs, err := chS.dm.GetItemLoadIDs(utils.EmptyString, false)
@@ -461,6 +471,7 @@ func (chS *CacheS) V1LoadCache(args utils.AttrReloadCacheWithArgDispatcher, repl
 		toStringSlice(args.ChargerProfileIDs),
 		toStringSlice(args.DispatcherProfileIDs),
 		toStringSlice(args.DispatcherHostIDs),
+		toStringSlice(args.ExchangeRatesIDs),
 	); err != nil {
 		return utils.NewErrServerError(err)
 	}
@@ -520,6 +531,7 @@ func (chS *CacheS) V1FlushCache(args utils.AttrReloadCacheWithArgDispatcher, rep
 	chS.flushCache(utils.CacheDispatcherProfiles, args.DispatcherProfileIDs)
 	chS.flushCache(utils.CacheDispatcherHosts, args.DispatcherHostIDs)
 	chS.flushCache(utils.CacheDispatcherRoutes, args.DispatcherRoutesIDs)
+	chS.flushCache(utils.CacheExchangeRates, args.ExchangeRatesIDs)
 	//get loadIDs for all types
 	loadIDs, err := chS.dm.GetItemLoadIDs(utils.EmptyString, false)
 	if err != nil {
@@ -598,6 +610,9 @@ func populateCacheLoadIDs(loadIDs map[string]int64, attrs utils.AttrReloadCache)
 	if attrs.DispatcherProfileIDs == nil || len(*attrs.DispatcherProfileIDs) != 0 {
 		cacheLoadIDs[utils.CacheDispatcherProfiles] = loadIDs[utils.CacheDispatcherProfiles]
 	}
+	if attrs.ExchangeRatesIDs == nil || len(*attrs.ExchangeRatesIDs) != 0 {
+		cacheLoadIDs[utils.CacheExchangeRates] = loadIDs[utils.CacheExchangeRates]
+	}
 	return
 }
 
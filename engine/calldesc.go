@@ -694,7 +694,7 @@ func (origCD *CallDescriptor) getMaxSessionDuration(origAcc *Account) (time.Dura
 		for _, incr := range ts.Increments {
 			totalCost += incr.Cost
 			if incr.BalanceInfo.Monetary != nil && incr.BalanceInfo.Monetary.UUID == defaultBalance.Uuid {
-				initialDefaultBalanceValue -= incr.Cost
+				initialDefaultBalanceValue -= exchangeCost(incr.Cost, incr.BalanceInfo.Monetary.ExchangeRate)
 				if initialDefaultBalanceValue < 0 {
 					// this increment was payed with debt
 					// TODO: improve this check
@@ -912,8 +912,9 @@ func (cd *CallDescriptor) refundIncrements() (acnt *Account, err error) {
 			if balance = account.BalanceMap[utils.MONETARY].GetBalance(increment.BalanceInfo.Monetary.UUID); balance == nil {
 				return
 			}
-			balance.AddValue(increment.Cost)
-			account.countUnits(-increment.Cost, utils.MONETARY, cc, balance)
+			cost := exchangeCost(increment.Cost, increment.BalanceInfo.Monetary.ExchangeRate)
+			balance.AddValue(cost)
+			account.countUnits(-cost, utils.MONETARY, cc, balance)
 		}
 	}
 	acnt = accountsCache[utils.ConcatenatedKey(cd.Tenant, cd.Account)]
@@ -965,8 +966,9 @@ func (cd *CallDescriptor) refundRounding() (err error) {
 			if balance = account.BalanceMap[utils.MONETARY].GetBalance(increment.BalanceInfo.Monetary.UUID); balance == nil {
 				return
 			}
-			balance.AddValue(-increment.Cost)
-			account.countUnits(increment.Cost, utils.MONETARY, cc, balance)
+			cost := exchangeCost(increment.Cost, increment.BalanceInfo.Monetary.ExchangeRate)
+			balance.AddValue(-cost)
+			account.countUnits(cost, utils.MONETARY, cc, balance)
 		}
 	}
 	return
@@ -20,6 +20,7 @@ package engine
 
 import (
 	"fmt"
+	"sync"
 
 	"github.com/cgrates/cgrates/config"
 	"github.com/cgrates/cgrates/utils"
@@ -37,6 +38,34 @@ func NewConnManager(cfg *config.CGRConfig, rpcInternal map[string]chan rpcclient
 type ConnManager struct {
 	cfg         *config.CGRConfig
 	rpcInternal map[string]chan rpcclient.ClientConnector
+	anzMux      sync.RWMutex
+	anz         utils.RPCAnalyzer
+}
+
+// SetAnalyzer will make the ConnManager pass the outgoing requests through the analyzer
+// use nil to stop capturing
+func (cM *ConnManager) SetAnalyzer(anz utils.RPCAnalyzer) {
+	cM.anzMux.Lock()
+	cM.anz = anz
+	cM.anzMux.Unlock()
+}
+
+// connEncoding returns the encoding used to reach the connection
+func (cM *ConnManager) connEncoding(connID string) string {
+	if _, has := cM.rpcInternal[connID]; has {
+		return utils.MetaInternal
+	}
+	connCfg, has := cM.cfg.RPCConns()[connID]
+	if !has || len(connCfg.Conns) == 0 {
+		return utils.EmptyString
+	}
+	if connCfg.Conns[0].Address == utils.MetaInternal {
+		return utils.MetaInternal
+	}
+	if connCfg.Conns[0].Transport == utils.EmptyString {
+		return utils.MetaGOB
+	}
+	return connCfg.Conns[0].Transport
 }
 
 // getConn is used to retrieve a connection from cache
@@ -131,11 +160,18 @@ func (cM *ConnManager) Call(connIDs []string, biRPCClient rpcclient.ClientConnec
 	if len(connIDs) == 0 {
 		return utils.NewErrMandatoryIeMissing("connIDs")
 	}
+	cM.anzMux.RLock()
+	anz := cM.anz
+	cM.anzMux.RUnlock()
 	var conn rpcclient.ClientConnector
 	for _, connID := range connIDs {
 		if conn, err = cM.getConn(connID, biRPCClient); err != nil {
 			continue
 		}
+		if anz != nil {
+			conn = anz.NewAnalyzerConnector(conn, cM.connEncoding(connID),
+				utils.MetaInternal, connID)
+		}
 		if err = conn.Call(method, arg, reply); utils.IsNetworkError(err) {
 			continue
 		} else {
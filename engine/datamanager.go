@@ -67,6 +67,7 @@ var (
 		utils.SHARED_GROUP_PREFIX:        true,
 		utils.ResourceProfilesPrefix:     true,
 		utils.TimingsPrefix:              true,
+		utils.ExchangeRatesPrefix:        true,
 		utils.ResourcesPrefix:            true,
 		utils.StatQueuePrefix:            true,
 		utils.StatQueueProfilePrefix:     true,
@@ -118,7 +119,7 @@ func (dm *DataManager) DataDB() DataDB {
 
 func (dm *DataManager) LoadDataDBCache(dstIDs, rvDstIDs, rplIDs, rpfIDs, actIDs, aplIDs,
 	aaPlIDs, atrgIDs, sgIDs, rpIDs, resIDs, stqIDs, stqpIDs, thIDs, thpIDs, fltrIDs,
-	splPrflIDs, alsPrfIDs, cppIDs, dppIDs, dphIDs []string) (err error) {
+	splPrflIDs, alsPrfIDs, cppIDs, dppIDs, dphIDs, exrIDs []string) (err error) {
 	if dm == nil {
 		err = utils.ErrNoDatabaseConn
 		return
@@ -159,6 +160,7 @@ func (dm *DataManager) LoadDataDBCache(dstIDs, rvDstIDs, rplIDs, rpfIDs, actIDs,
 			utils.ChargerProfilePrefix:       cppIDs,
 			utils.DispatcherProfilePrefix:    dppIDs,
 			utils.DispatcherHostPrefix:       dphIDs,
+			utils.ExchangeRatesPrefix:        exrIDs,
 		} {
 			if err = dm.CacheDataFromDB(key, ids, false); err != nil {
 				return
@@ -207,6 +209,10 @@ func (dm *DataManager) CacheDataFromDB(prfx string, ids []string, mustBeCached b
 			utils.UnsupportedCachePrefix,
 			fmt.Sprintf("prefix <%s> is not a supported cache prefix", prfx))
 	}
+	switch prfx {
+	case utils.DESTINATION_PREFIX, utils.REVERSE_DESTINATION_PREFIX, utils.RATING_PLAN_PREFIX:
+		Cache.Clear([]string{utils.CacheSupplierCosts}) // costs rated out of the old data
+	}
 	if ids == nil {
 		keyIDs, err := dm.DataDB().GetKeysForPrefix(prfx)
 		if err != nil {
@@ -270,6 +276,12 @@ func (dm *DataManager) CacheDataFromDB(prfx string, ids []string, mustBeCached b
 			_, err = dm.GetStatQueue(tntID.Tenant, tntID.ID, false, true, utils.NonTransactional)
 		case utils.TimingsPrefix:
 			_, err = dm.GetTiming(dataID, true, utils.NonTransactional)
+		case utils.ExchangeRatesPrefix:
+			currencies := strings.Split(dataID, utils.CONCATENATED_KEY_SEP)
+			if len(currencies) != 2 {
+				return utils.ErrInvalidKey
+			}
+			_, err = dm.GetExchangeRates(currencies[0], currencies[1], true, utils.NonTransactional)
 		case utils.ThresholdProfilePrefix:
 			tntID := utils.NewTenantID(dataID)
 			_, err = dm.GetThresholdProfile(tntID.Tenant, tntID.ID, false, true, utils.NonTransactional)
@@ -2018,6 +2030,29 @@ func (dm *DataManager) RemoveRatingPlan(key string, transactionID string) (err e
 	return
 }
 
+// GetCalendarsRatingPlans returns the RatingPlans referencing the calendars, with their days updated
+func (dm *DataManager) GetCalendarsRatingPlans(cals map[string]utils.StringMap) (rps []*RatingPlan, err error) {
+	if dm == nil {
+		err = utils.ErrNoDatabaseConn
+		return
+	}
+	var keys []string
+	if keys, err = dm.DataDB().GetKeysForPrefix(utils.RATING_PLAN_PREFIX); err != nil {
+		return
+	}
+	for _, key := range keys {
+		var rp *RatingPlan
+		if rp, err = dm.GetRatingPlan(key[len(utils.RATING_PLAN_PREFIX):], true,
+			utils.NonTransactional); err != nil {
+			return
+		}
+		if rp = rp.WithCalendars(cals); rp != nil {
+			rps = append(rps, rp)
+		}
+	}
+	return
+}
+
 // GetRatingProfile returns the RatingProfile for the key
 func (dm *DataManager) GetRatingProfile(key string, skipCache bool,
 	transactionID string) (rpf *RatingProfile, err error) {
@@ -3006,10 +3041,127 @@ func (dm *DataManager) SetLoadIDs(loadIDs map[string]int64) (err error) {
 	return
 }
 
+// GetStoredSessions returns the sessions stored by the SessionS with nodeID
+func (dm *DataManager) GetStoredSessions(nodeID string) (sSs []*StoredSession, err error) {
+	if dm == nil {
+		err = utils.ErrNoDatabaseConn
+		return
+	}
+	return dm.DataDB().GetStoredSessionsDrv(nodeID)
+}
+
+// SetStoredSession stores one active session
+func (dm *DataManager) SetStoredSession(sS *StoredSession) (err error) {
+	if dm == nil {
+		err = utils.ErrNoDatabaseConn
+		return
+	}
+	return dm.DataDB().SetStoredSessionDrv(sS)
+}
+
+// RemoveStoredSession removes one stored session
+func (dm *DataManager) RemoveStoredSession(nodeID, cgrID string) (err error) {
+	if dm == nil {
+		err = utils.ErrNoDatabaseConn
+		return
+	}
+	return dm.DataDB().RemoveStoredSessionDrv(nodeID, cgrID)
+}
+
+// GetResourceUsages returns the active usages of a resource out of the shared state
+func (dm *DataManager) GetResourceUsages(tenant, id string) (usages map[string]*ResourceUsage, err error) {
+	if dm == nil {
+		err = utils.ErrNoDatabaseConn
+		return
+	}
+	usages, _, err = dm.DataDB().UpdateResourceUsagesDrv(tenant, id, "", nil, nil)
+	return
+}
+
+// AllocateResourceUsage records atomically the usage for a resource if it fits within limit
+// a nil limit records the usage without checking it
+func (dm *DataManager) AllocateResourceUsage(tenant, id string, ru *ResourceUsage,
+	limit *float64) (usages map[string]*ResourceUsage, allocated bool, err error) {
+	if dm == nil {
+		err = utils.ErrNoDatabaseConn
+		return
+	}
+	return dm.DataDB().UpdateResourceUsagesDrv(tenant, id, ru.ID, ru, limit)
+}
+
+// ReleaseResourceUsage removes atomically one usage of a resource
+func (dm *DataManager) ReleaseResourceUsage(tenant, id, ruID string) (usages map[string]*ResourceUsage, err error) {
+	if dm == nil {
+		err = utils.ErrNoDatabaseConn
+		return
+	}
+	usages, _, err = dm.DataDB().UpdateResourceUsagesDrv(tenant, id, ruID, nil, nil)
+	return
+}
+
+// GetExchangeRates returns the rates converting fromCurrency into toCurrency
+func (dm *DataManager) GetExchangeRates(fromCurrency, toCurrency string, skipCache bool,
+	transactionID string) (exr *ExchangeRates, err error) {
+	id := utils.ConcatenatedKey(fromCurrency, toCurrency)
+	if !skipCache {
+		if x, ok := Cache.Get(utils.CacheExchangeRates, id); ok {
+			if x == nil {
+				return nil, utils.ErrNotFound
+			}
+			return x.(*ExchangeRates), nil
+		}
+	}
+	if dm == nil {
+		err = utils.ErrNoDatabaseConn
+		return
+	}
+	if exr, err = dm.DataDB().GetExchangeRatesDrv(fromCurrency, toCurrency); err != nil {
+		if err == utils.ErrNotFound {
+			Cache.Set(utils.CacheExchangeRates, id, nil, nil,
+				cacheCommit(transactionID), transactionID)
+		}
+		return nil, err
+	}
+	Cache.Set(utils.CacheExchangeRates, id, exr, nil,
+		cacheCommit(transactionID), transactionID)
+	return
+}
+
+// SetExchangeRates stores the exchange rates sorted on their ActivationTime
+func (dm *DataManager) SetExchangeRates(exr *ExchangeRates) (err error) {
+	if dm == nil {
+		err = utils.ErrNoDatabaseConn
+		return
+	}
+	exr.Sort()
+	if err = dm.DataDB().SetExchangeRatesDrv(exr); err != nil {
+		return
+	}
+	return dm.CacheDataFromDB(utils.ExchangeRatesPrefix, []string{exr.ID()}, true)
+}
+
+// RemoveExchangeRates removes the rates converting fromCurrency into toCurrency
+func (dm *DataManager) RemoveExchangeRates(fromCurrency, toCurrency, transactionID string) (err error) {
+	if dm == nil {
+		err = utils.ErrNoDatabaseConn
+		return
+	}
+	if err = dm.DataDB().RemoveExchangeRatesDrv(fromCurrency, toCurrency); err != nil {
+		return
+	}
+	Cache.Remove(utils.CacheExchangeRates, utils.ConcatenatedKey(fromCurrency, toCurrency),
+		cacheCommit(transactionID), transactionID)
+	return
+}
+
 // Reconnect reconnects to the DB when the config was changed
 func (dm *DataManager) Reconnect(marshaller string, newcfg *config.DataDbCfg) (err error) {
+	if _, isInternal := dm.dataDB.(*InternalDB); isInternal {
+		dm.dataDB.Close() // release the persistence files before they are opened again
+	}
 	d, err := NewDataDBConn(newcfg.DataDbType, newcfg.DataDbHost, newcfg.DataDbPort, newcfg.DataDbName,
-		newcfg.DataDbUser, newcfg.DataDbPass, marshaller, newcfg.DataDbSentinelName, newcfg.Items)
+		newcfg.DataDbUser, newcfg.DataDbPass, marshaller, newcfg.DataDbSentinelName,
+		newcfg.RedisCluster, newcfg.RedisClusterSync, newcfg.Items, newcfg.InternalPersistence)
 	if err != nil {
 		return
 	}
@@ -53,7 +53,7 @@ func TestDMitinitDB(t *testing.T) {
 		dataDB, err = NewRedisStorage(
 			fmt.Sprintf("%s:%s", cfg.DataDbCfg().DataDbHost, cfg.DataDbCfg().DataDbPort),
 			4, cfg.DataDbCfg().DataDbPass, cfg.GeneralCfg().DBDataEncoding,
-			utils.REDIS_MAX_CONNS, "")
+			utils.REDIS_MAX_CONNS, "", false, 0)
 		if err != nil {
 			t.Fatal("Could not connect to Redis", err.Error())
 		}
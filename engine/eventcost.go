@@ -74,10 +74,11 @@ func NewEventCostFromCallCost(cc *CallCost, cgrID, runID string) (ec *EventCost)
 				if incr.BalanceInfo.Monetary != nil {
 					if uuid := ec.Accounting.GetIDWithSet(
 						&BalanceCharge{
-							AccountID:   incr.BalanceInfo.AccountID,
-							BalanceUUID: incr.BalanceInfo.Monetary.UUID,
-							Units:       incr.Cost,
-							RatingID:    ec.ratingIDForRateInterval(incr.BalanceInfo.Monetary.RateInterval, rf),
+							AccountID:    incr.BalanceInfo.AccountID,
+							BalanceUUID:  incr.BalanceInfo.Monetary.UUID,
+							Units:        incr.Cost,
+							RatingID:     ec.ratingIDForRateInterval(incr.BalanceInfo.Monetary.RateInterval, rf),
+							ExchangeRate: incr.BalanceInfo.Monetary.ExchangeRate,
 						}); uuid != "" {
 						ecUUID = uuid
 					}
@@ -92,10 +93,11 @@ func NewEventCostFromCallCost(cc *CallCost, cgrID, runID string) (ec *EventCost)
 			} else if incr.BalanceInfo.Monetary != nil { // Only monetary
 				cIt.AccountingID = ec.Accounting.GetIDWithSet(
 					&BalanceCharge{
-						AccountID:   incr.BalanceInfo.AccountID,
-						BalanceUUID: incr.BalanceInfo.Monetary.UUID,
-						Units:       incr.Cost,
-						RatingID:    ec.ratingIDForRateInterval(incr.BalanceInfo.Monetary.RateInterval, rf)})
+						AccountID:    incr.BalanceInfo.AccountID,
+						BalanceUUID:  incr.BalanceInfo.Monetary.UUID,
+						Units:        incr.Cost,
+						RatingID:     ec.ratingIDForRateInterval(incr.BalanceInfo.Monetary.RateInterval, rf),
+						ExchangeRate: incr.BalanceInfo.Monetary.ExchangeRate})
 			}
 			cIl.Increments[j] = cIt
 		}
@@ -159,7 +161,8 @@ func (ec *EventCost) ratingIDForRateInterval(ri *RateInterval, rf RatingMatchedF
 			MaxCostStrategy:  ri.Rating.MaxCostStrategy,
 			TimingID:         tmID,
 			RatesID:          rtUUID,
-			RatingFiltersID:  rfUUID})
+			RatingFiltersID:  rfUUID,
+			Currency:         ri.Rating.Currency})
 }
 
 func (ec *EventCost) rateIntervalForRatingID(ratingID string) (ri *RateInterval) {
@@ -171,7 +174,8 @@ func (ec *EventCost) rateIntervalForRatingID(ratingID string) (ri *RateInterval)
 	ri.Rating = &RIRate{ConnectFee: cIlRU.ConnectFee,
 		RoundingMethod:   cIlRU.RoundingMethod,
 		RoundingDecimals: cIlRU.RoundingDecimals,
-		MaxCost:          cIlRU.MaxCost, MaxCostStrategy: cIlRU.MaxCostStrategy}
+		MaxCost:          cIlRU.MaxCost, MaxCostStrategy: cIlRU.MaxCostStrategy,
+		Currency: cIlRU.Currency}
 	if cIlRU.RatesID != "" {
 		ri.Rating.Rates = ec.Rates[cIlRU.RatesID]
 	}
@@ -313,7 +317,8 @@ func (ec *EventCost) AsRefundIncrements(tor string) (cd *CallDescriptor) {
 					}
 					blncSmry := ec.AccountSummary.BalanceSummaries.BalanceSummaryWithUUD(ec.Accounting[cIcrm.AccountingID].BalanceUUID)
 					if blncSmry.Type == utils.MONETARY {
-						cd.Increments[iIdx].BalanceInfo.Monetary = &MonetaryInfo{UUID: blncSmry.UUID}
+						cd.Increments[iIdx].BalanceInfo.Monetary = &MonetaryInfo{UUID: blncSmry.UUID,
+							ExchangeRate: ec.Accounting[cIcrm.AccountingID].ExchangeRate}
 					} else if utils.NonMonetaryBalances.Has(blncSmry.Type) {
 						cd.Increments[iIdx].BalanceInfo.Unit = &UnitInfo{UUID: blncSmry.UUID}
 					}
@@ -326,7 +331,8 @@ func (ec *EventCost) AsRefundIncrements(tor string) (cd *CallDescriptor) {
 					extraSmry := ec.AccountSummary.BalanceSummaries.BalanceSummaryWithUUD(
 						ec.Accounting[ec.Accounting[cIcrm.AccountingID].ExtraChargeID].BalanceUUID)
 					if extraSmry.Type == utils.MONETARY {
-						cd.Increments[iIdx].BalanceInfo.Monetary = &MonetaryInfo{UUID: extraSmry.UUID}
+						cd.Increments[iIdx].BalanceInfo.Monetary = &MonetaryInfo{UUID: extraSmry.UUID,
+							ExchangeRate: ec.Accounting[ec.Accounting[cIcrm.AccountingID].ExtraChargeID].ExchangeRate}
 					} else if utils.NonMonetaryBalances.Has(blncSmry.Type) {
 						cd.Increments[iIdx].BalanceInfo.Unit = &UnitInfo{UUID: extraSmry.UUID}
 					}
@@ -398,7 +404,7 @@ func (ec *EventCost) AsCallCost(tor string) *CallCost {
 					}
 				}
 				if cBC.ExtraChargeID != utils.META_NONE {
-					incr.BalanceInfo.Monetary = &MonetaryInfo{UUID: cBC.BalanceUUID}
+					incr.BalanceInfo.Monetary = &MonetaryInfo{UUID: cBC.BalanceUUID, ExchangeRate: cBC.ExchangeRate}
 					incr.BalanceInfo.Monetary.RateInterval = ec.rateIntervalForRatingID(cBC.RatingID)
 				}
 			}
@@ -0,0 +1,162 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package engine
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/cgrates/cgrates/utils"
+)
+
+// ExchangeRate converts one unit of the source currency starting with ActivationTime
+type ExchangeRate struct {
+	ActivationTime time.Time
+	Rate           float64
+}
+
+// ExchangeRates are the time-effective rates converting FromCurrency into ToCurrency
+type ExchangeRates struct {
+	FromCurrency string
+	ToCurrency   string
+	Rates        []*ExchangeRate
+}
+
+// ID returns the key used to store the exchange rates
+func (exr *ExchangeRates) ID() string {
+	return utils.ConcatenatedKey(exr.FromCurrency, exr.ToCurrency)
+}
+
+// Sort orders the rates based on their ActivationTime
+func (exr *ExchangeRates) Sort() {
+	sort.Slice(exr.Rates, func(i, j int) bool {
+		return exr.Rates[i].ActivationTime.Before(exr.Rates[j].ActivationTime)
+	})
+}
+
+// RateAt returns the rate active at the given time, considering the rates sorted
+func (exr *ExchangeRates) RateAt(atTime time.Time) (rate float64, err error) {
+	var has bool
+	for _, r := range exr.Rates {
+		if r.ActivationTime.After(atTime) {
+			break
+		}
+		rate, has = r.Rate, true
+	}
+	if !has {
+		err = utils.ErrNotFound
+	}
+	return
+}
+
+// Reverse returns the rates converting ToCurrency into FromCurrency
+func (exr *ExchangeRates) Reverse() (rExr *ExchangeRates) {
+	rExr = &ExchangeRates{
+		FromCurrency: exr.ToCurrency,
+		ToCurrency:   exr.FromCurrency,
+		Rates:        make([]*ExchangeRate, len(exr.Rates)),
+	}
+	for i, r := range exr.Rates {
+		rExr.Rates[i] = &ExchangeRate{ActivationTime: r.ActivationTime}
+		if r.Rate != 0 {
+			rExr.Rates[i].Rate = 1 / r.Rate
+		}
+	}
+	return
+}
+
+// Clone returns a copy of the exchange rates
+func (exr *ExchangeRates) Clone() (cln *ExchangeRates) {
+	if exr == nil {
+		return
+	}
+	cln = &ExchangeRates{
+		FromCurrency: exr.FromCurrency,
+		ToCurrency:   exr.ToCurrency,
+	}
+	if exr.Rates != nil {
+		cln.Rates = make([]*ExchangeRate, len(exr.Rates))
+		for i, r := range exr.Rates {
+			cln.Rates[i] = &ExchangeRate{ActivationTime: r.ActivationTime, Rate: r.Rate}
+		}
+	}
+	return
+}
+
+// exchanger converts the costs into one currency, querying the exchange rates only once per debit
+type exchanger struct {
+	toCurrency string
+	rates      map[string]*ExchangeRates // indexed on the source currency
+}
+
+func newExchanger(toCurrency string) *exchanger {
+	return &exchanger{
+		toCurrency: toCurrency,
+		rates:      make(map[string]*ExchangeRates),
+	}
+}
+
+// rate returns the rate converting currency at the given time, 0 if no conversion is needed
+// the reversed rates are used when the direct ones are not defined
+func (ex *exchanger) rate(currency string, atTime time.Time) (rate float64, err error) {
+	if currency == "" || ex.toCurrency == "" || currency == ex.toCurrency {
+		return
+	}
+	exr, has := ex.rates[currency]
+	if !has {
+		if exr, err = dm.GetExchangeRates(currency, ex.toCurrency, false, utils.NonTransactional); err == utils.ErrNotFound {
+			if exr, err = dm.GetExchangeRates(ex.toCurrency, currency, false, utils.NonTransactional); err == nil {
+				exr = exr.Reverse()
+			}
+		}
+		if err != nil && err != utils.ErrNotFound {
+			return
+		}
+		ex.rates[currency] = exr // nil when missing so we do not query again
+	}
+	if exr == nil {
+		return 0, fmt.Errorf("no exchange rates from %s to %s", currency, ex.toCurrency)
+	}
+	if rate, err = exr.RateAt(atTime); err != nil {
+		err = fmt.Errorf("no exchange rate from %s to %s active at %s", currency, ex.toCurrency, atTime)
+	}
+	return
+}
+
+// exchangers holds one exchanger per destination currency
+type exchangers map[string]*exchanger
+
+// rate returns the rate converting currency into toCurrency at the given time, 0 if no conversion is needed
+func (exs exchangers) rate(toCurrency, currency string, atTime time.Time) (float64, error) {
+	ex, has := exs[toCurrency]
+	if !has {
+		ex = newExchanger(toCurrency)
+		exs[toCurrency] = ex
+	}
+	return ex.rate(currency, atTime)
+}
+
+// exchangeCost converts the cost with the rate, 0 meaning no conversion
+func exchangeCost(cost, rate float64) float64 {
+	if rate == 0 {
+		return cost
+	}
+	return utils.Round(cost*rate, globalRoundingDecimals, utils.ROUNDING_MIDDLE)
+}
@@ -0,0 +1,269 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package engine
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/cgrates/cgrates/utils"
+)
+
+func TestExchangeRatesRateAt(t *testing.T) {
+	exr := &ExchangeRates{
+		FromCurrency: "EUR",
+		ToCurrency:   "USD",
+		Rates: []*ExchangeRate{
+			{ActivationTime: time.Date(2020, 2, 1, 0, 0, 0, 0, time.UTC), Rate: 1.2},
+			{ActivationTime: time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC), Rate: 1.1},
+		},
+	}
+	exr.Sort()
+	if _, err := exr.RateAt(time.Date(2019, 12, 31, 0, 0, 0, 0, time.UTC)); err != utils.ErrNotFound {
+		t.Errorf("Expecting: %+v, received: %+v", utils.ErrNotFound, err)
+	}
+	if rate, err := exr.RateAt(time.Date(2020, 1, 15, 0, 0, 0, 0, time.UTC)); err != nil {
+		t.Error(err)
+	} else if rate != 1.1 {
+		t.Errorf("Expecting: 1.1, received: %v", rate)
+	}
+	if rate, err := exr.RateAt(time.Date(2020, 2, 1, 0, 0, 0, 0, time.UTC)); err != nil {
+		t.Error(err)
+	} else if rate != 1.2 {
+		t.Errorf("Expecting: 1.2, received: %v", rate)
+	}
+}
+
+func TestExchangeRatesReverse(t *testing.T) {
+	aTime := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	exr := &ExchangeRates{
+		FromCurrency: "EUR",
+		ToCurrency:   "USD",
+		Rates:        []*ExchangeRate{{ActivationTime: aTime, Rate: 1.25}},
+	}
+	eExr := &ExchangeRates{
+		FromCurrency: "USD",
+		ToCurrency:   "EUR",
+		Rates:        []*ExchangeRate{{ActivationTime: aTime, Rate: 0.8}},
+	}
+	if rcv := exr.Reverse(); !reflect.DeepEqual(eExr, rcv) {
+		t.Errorf("Expecting: %s, received: %s", utils.ToJSON(eExr), utils.ToJSON(rcv))
+	}
+	if exr.Rates[0].Rate != 1.25 {
+		t.Errorf("Reverse modified the original rates: %s", utils.ToJSON(exr))
+	}
+}
+
+func TestExchangerRate(t *testing.T) {
+	aTime := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	if err := dm.SetExchangeRates(&ExchangeRates{
+		FromCurrency: "GBP",
+		ToCurrency:   "CHF",
+		Rates:        []*ExchangeRate{{ActivationTime: aTime, Rate: 1.25}},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	defer dm.RemoveExchangeRates("GBP", "CHF", utils.NonTransactional)
+	exch := newExchanger("CHF")
+	if rate, err := exch.rate("CHF", aTime); err != nil {
+		t.Error(err)
+	} else if rate != 0 {
+		t.Errorf("Expecting no conversion, received: %v", rate)
+	}
+	if rate, err := exch.rate("GBP", aTime); err != nil {
+		t.Error(err)
+	} else if rate != 1.25 {
+		t.Errorf("Expecting: 1.25, received: %v", rate)
+	}
+	if _, err := exch.rate("GBP", aTime.Add(-time.Hour)); err == nil {
+		t.Error("Expecting error for inactive rate")
+	}
+	if _, err := exch.rate("JPY", aTime); err == nil {
+		t.Error("Expecting error for missing rates")
+	}
+	exchGBP := newExchanger("GBP") // uses the reversed rates
+	if rate, err := exchGBP.rate("CHF", aTime); err != nil {
+		t.Error(err)
+	} else if rate != 0.8 {
+		t.Errorf("Expecting: 0.8, received: %v", rate)
+	}
+}
+
+func TestExchangeCost(t *testing.T) {
+	if rcv := exchangeCost(10, 0); rcv != 10 {
+		t.Errorf("Expecting: 10, received: %v", rcv)
+	}
+	if rcv := exchangeCost(10, 1.25); rcv != 12.5 {
+		t.Errorf("Expecting: 12.5, received: %v", rcv)
+	}
+}
+
+func TestDataManagerExchangeRatesCache(t *testing.T) {
+	exr := &ExchangeRates{
+		FromCurrency: "EUR",
+		ToCurrency:   "RON",
+		Rates: []*ExchangeRate{{
+			ActivationTime: time.Date(2013, 1, 1, 0, 0, 0, 0, time.UTC),
+			Rate:           4.8}},
+	}
+	if _, err := dm.GetExchangeRates("EUR", "RON", false,
+		utils.NonTransactional); err != utils.ErrNotFound {
+		t.Errorf("Expecting: %v, received: %v", utils.ErrNotFound, err)
+	}
+	if err := dm.SetExchangeRates(exr); err != nil {
+		t.Fatal(err)
+	}
+	if rcv, err := dm.GetExchangeRates("EUR", "RON", false, utils.NonTransactional); err != nil {
+		t.Error(err)
+	} else if !reflect.DeepEqual(exr, rcv) {
+		t.Errorf("Expecting: %s, received: %s", utils.ToJSON(exr), utils.ToJSON(rcv))
+	}
+	if _, has := Cache.Get(utils.CacheExchangeRates, exr.ID()); !has {
+		t.Error("Expecting the exchange rates cached")
+	}
+	exr = &ExchangeRates{
+		FromCurrency: "EUR",
+		ToCurrency:   "RON",
+		Rates: []*ExchangeRate{{
+			ActivationTime: time.Date(2013, 1, 1, 0, 0, 0, 0, time.UTC),
+			Rate:           4.9}},
+	}
+	if err := dm.SetExchangeRates(exr); err != nil {
+		t.Fatal(err)
+	}
+	if rcv, err := dm.GetExchangeRates("EUR", "RON", false, utils.NonTransactional); err != nil {
+		t.Error(err)
+	} else if !reflect.DeepEqual(exr, rcv) {
+		t.Errorf("Expecting: %s, received: %s", utils.ToJSON(exr), utils.ToJSON(rcv))
+	}
+	if err := dm.RemoveExchangeRates("EUR", "RON", utils.NonTransactional); err != nil {
+		t.Error(err)
+	}
+	if _, err := dm.GetExchangeRates("EUR", "RON", false,
+		utils.NonTransactional); err != utils.ErrNotFound {
+		t.Errorf("Expecting: %v, received: %v", utils.ErrNotFound, err)
+	}
+}
+
+func TestDebitCreditExchangedMoney(t *testing.T) {
+	if err := dm.SetExchangeRates(&ExchangeRates{
+		FromCurrency: "EUR",
+		ToCurrency:   "USD",
+		Rates: []*ExchangeRate{{
+			ActivationTime: time.Date(2013, 1, 1, 0, 0, 0, 0, time.UTC),
+			Rate:           1.2}},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	defer dm.RemoveExchangeRates("EUR", "USD", utils.NonTransactional)
+	cc := &CallCost{
+		Destination: "0723045326",
+		Timespans: []*TimeSpan{
+			&TimeSpan{
+				TimeStart:     time.Date(2013, 9, 24, 10, 48, 0, 0, time.UTC),
+				TimeEnd:       time.Date(2013, 9, 24, 10, 49, 0, 0, time.UTC),
+				DurationIndex: 0,
+				RateInterval: &RateInterval{
+					Rating: &RIRate{
+						Currency: "EUR",
+						Rates: RateGroups{
+							&Rate{GroupIntervalStart: 0,
+								Value:         1,
+								RateIncrement: 10 * time.Second,
+								RateUnit:      time.Second}}}},
+			},
+		},
+		ToR: utils.VOICE,
+	}
+	cd := &CallDescriptor{
+		TimeStart:     cc.Timespans[0].TimeStart,
+		TimeEnd:       cc.Timespans[0].TimeEnd,
+		Destination:   cc.Destination,
+		ToR:           cc.ToR,
+		DurationIndex: cc.GetDuration(),
+		testCallcost:  cc,
+	}
+	acc := &Account{ID: "cgrates.org:exchanged",
+		BalanceMap: map[string]Balances{
+			utils.MONETARY: Balances{
+				&Balance{Uuid: "chf", Value: 100, Currency: "CHF", Weight: 20},
+				&Balance{Uuid: "usd", Value: 100, Currency: "USD", Weight: 10}},
+		}}
+	cc, err := acc.debitCreditBalance(cd, false, false, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rcv := acc.BalanceMap[utils.MONETARY][0].GetValue(); rcv != 100 {
+		t.Errorf("Expecting the CHF balance skipped, received value: %v", rcv)
+	}
+	if rcv := acc.BalanceMap[utils.MONETARY][1].GetValue(); rcv != 28 {
+		t.Errorf("Expecting: 28, received: %v", rcv)
+	}
+	inc := cc.Timespans[0].Increments[0]
+	if inc.BalanceInfo.Monetary.UUID != "usd" ||
+		inc.BalanceInfo.Monetary.ExchangeRate != 1.2 ||
+		inc.Cost != 10 {
+		t.Errorf("Unexpected increment: %s", utils.ToJSON(inc))
+	}
+	if cost := cc.Timespans[0].CalculateCost(); cost != 60 {
+		t.Errorf("Expecting the cost in rating currency: 60, received: %v", cost)
+	}
+}
+
+func TestDebitCreditMissingExchangeRate(t *testing.T) {
+	cc := &CallCost{
+		Destination: "0723045326",
+		Timespans: []*TimeSpan{
+			&TimeSpan{
+				TimeStart:     time.Date(2013, 9, 24, 10, 48, 0, 0, time.UTC),
+				TimeEnd:       time.Date(2013, 9, 24, 10, 49, 0, 0, time.UTC),
+				DurationIndex: 0,
+				RateInterval: &RateInterval{
+					Rating: &RIRate{
+						Currency: "EUR",
+						Rates: RateGroups{
+							&Rate{GroupIntervalStart: 0,
+								Value:         1,
+								RateIncrement: 10 * time.Second,
+								RateUnit:      time.Second}}}},
+			},
+		},
+		ToR: utils.VOICE,
+	}
+	cd := &CallDescriptor{
+		TimeStart:     cc.Timespans[0].TimeStart,
+		TimeEnd:       cc.Timespans[0].TimeEnd,
+		Destination:   cc.Destination,
+		ToR:           cc.ToR,
+		DurationIndex: cc.GetDuration(),
+		testCallcost:  cc,
+	}
+	acc := &Account{ID: "cgrates.org:unexchanged",
+		BalanceMap: map[string]Balances{
+			utils.MONETARY: Balances{
+				&Balance{Uuid: "chf", ID: utils.MetaDefault, Value: 10, Currency: "CHF"}},
+		}}
+	if _, err := acc.debitCreditBalance(cd, false, false, true); err == nil {
+		t.Error("Expecting error for missing exchange rates")
+	}
+	if rcv := acc.BalanceMap[utils.MONETARY][0].GetValue(); rcv != 10 {
+		t.Errorf("Expecting the unconverted costs not debited, received value: %v", rcv)
+	}
+}
@@ -175,3 +175,42 @@ func TestFilterMatchingItemIDsForEvent2(t *testing.T) {
 		t.Errorf("Expecting: %+v, received: %+v", prefixFilterID, aPrflIDs)
 	}
 }
+
+func TestFilterMatchingItemIDsForEventIPNet(t *testing.T) {
+	data := NewInternalDB(nil, nil, true, config.CgrConfig().DataDbCfg().Items)
+	dmMatch = NewDataManager(data, config.CgrConfig().CacheCfg(), nil)
+	context := utils.MetaRating
+	x, err := NewFilterRule(utils.MetaIPNet, "~*req.SourceIP", []string{"10.10.0.0/20"})
+	if err != nil {
+		t.Errorf("Error: %+v", err)
+	}
+	attribIPNetF := &Filter{
+		Tenant: config.CgrConfig().GeneralCfg().DefaultTenant,
+		ID:     "ipNetFilter",
+		Rules:  []*FilterRule{x}}
+	dmMatch.SetFilter(attribIPNetF)
+	prefix := utils.ConcatenatedKey(config.CgrConfig().GeneralCfg().DefaultTenant, context)
+	atrRFI := NewFilterIndexer(dmMatch, utils.AttributeProfilePrefix, prefix)
+	atrRFI.IndexTPFilter(FilterToTPFilter(attribIPNetF), "ipNetFilterID")
+	if err = atrRFI.StoreIndexes(true, utils.NonTransactional); err != nil {
+		t.Errorf("Error: %+v", err)
+	}
+	matchEV = map[string]interface{}{
+		"SourceIP": "10.10.3.7",
+	}
+	aPrflIDs, err := MatchingItemIDsForEvent(matchEV, nil, nil,
+		dmMatch, utils.CacheAttributeFilterIndexes, prefix, true, false)
+	if err != nil {
+		t.Errorf("Error: %+v", err)
+	}
+	if _, has := aPrflIDs["ipNetFilterID"]; !has {
+		t.Errorf("Expecting: %+v, received: %+v", "ipNetFilterID", aPrflIDs)
+	}
+	matchEV = map[string]interface{}{
+		"SourceIP": "10.11.3.7",
+	}
+	if _, err = MatchingItemIDsForEvent(matchEV, nil, nil,
+		dmMatch, utils.CacheAttributeFilterIndexes, prefix, true, false); err != utils.ErrNotFound {
+		t.Errorf("Expecting: %+v, received: %+v", utils.ErrNotFound, err)
+	}
+}
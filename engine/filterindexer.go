@@ -20,6 +20,8 @@ package engine
 
 import (
 	"fmt"
+	"net"
+	"strings"
 
 	"github.com/cgrates/cgrates/config"
 	"github.com/cgrates/cgrates/guardian"
@@ -46,27 +48,16 @@ type FilterIndexer struct {
 // IndexTPFilter parses reqFltrs, adding itemID in the indexes and marks the changed keys in chngdIndxKeys
 func (rfi *FilterIndexer) IndexTPFilter(tpFltr *utils.TPFilterProfile, itemID string) {
 	for _, fltr := range tpFltr.Filters {
-		switch fltr.Type {
-		case utils.MetaString:
-			for _, fldVal := range fltr.Values {
-				concatKey := utils.ConcatenatedKey(fltr.Type, fltr.Element, fldVal)
-				if _, hasIt := rfi.indexes[concatKey]; !hasIt {
-					rfi.indexes[concatKey] = make(utils.StringMap)
-				}
-				rfi.indexes[concatKey][itemID] = true
-				rfi.chngdIndxKeys[concatKey] = true
-			}
-		case utils.MetaPrefix:
-			for _, fldVal := range fltr.Values {
-				concatKey := utils.ConcatenatedKey(fltr.Type, fltr.Element, fldVal)
-				if _, hasIt := rfi.indexes[concatKey]; !hasIt {
-					rfi.indexes[concatKey] = make(utils.StringMap)
-				}
-				rfi.indexes[concatKey][itemID] = true
-				rfi.chngdIndxKeys[concatKey] = true
-			}
-		case utils.META_NONE:
-			concatKey := utils.ConcatenatedKey(utils.META_NONE, utils.ANY, utils.ANY)
+		idxKeys, err := filterRuleIndexKeys(rfi.dm, tpFltr.Tenant,
+			fltr.Type, fltr.Element, fltr.Values, 0)
+		if err != nil {
+			utils.Logger.Warning(
+				fmt.Sprintf("<%s> cannot index filter: %s for item: %s, error: %s",
+					utils.FilterS, tpFltr.ID, itemID, err.Error()))
+			continue
+		}
+		for _, idxKey := range idxKeys {
+			concatKey := utils.ConcatenatedKey(idxKey.fltrType, idxKey.fldName, idxKey.fldVal)
 			if _, hasIt := rfi.indexes[concatKey]; !hasIt {
 				rfi.indexes[concatKey] = make(utils.StringMap)
 			}
@@ -247,15 +238,14 @@ func (rfi *FilterIndexer) RemoveItemFromIndex(tenant, itemID string, oldFilters
 			return err
 		}
 		for _, flt := range fltr.Rules {
-			var fldType, fldName string
-			var fldVals []string
-			if utils.SliceHasMember([]string{utils.META_NONE, utils.MetaPrefix, utils.MetaString}, flt.Type) {
-				fldType, fldName = flt.Type, flt.Element
-				fldVals = flt.Values
+			var idxKeys []*filterIndexKey
+			if idxKeys, err = filterRuleIndexKeys(rfi.dm, tenant,
+				flt.Type, flt.Element, flt.Values, 0); err != nil {
+				return err
 			}
-			for _, fldVal := range fldVals {
-				if err = rfi.loadFldNameFldValIndex(fldType,
-					fldName, fldVal); err != nil && err != utils.ErrNotFound {
+			for _, idxKey := range idxKeys {
+				if err = rfi.loadFldNameFldValIndex(idxKey.fltrType,
+					idxKey.fldName, idxKey.fldVal); err != nil && err != utils.ErrNotFound {
 					return err
 				}
 			}
@@ -271,6 +261,85 @@ func (rfi *FilterIndexer) RemoveItemFromIndex(tenant, itemID string, oldFilters
 	return rfi.StoreIndexes(false, utils.NonTransactional)
 }
 
+// filterIndexKey is one of the index entries an item is stored under
+type filterIndexKey struct {
+	fltrType string
+	fldName  string
+	fldVal   string
+}
+
+// filterRuleIndexKeys returns the index entries under which an item having the filter rule is stored
+// *ipnet rules on IPv4 networks are indexed as *prefix on the complete octets of the network
+// *or rules are indexed under the entries of all the filters they reference, provided each of them can be indexed
+func filterRuleIndexKeys(dm *DataManager, tenant, fltrType, fldName string,
+	vals []string, depth int) (idxKeys []*filterIndexKey, err error) {
+	switch fltrType {
+	case utils.META_NONE:
+		idxKeys = []*filterIndexKey{{fltrType: utils.META_NONE, fldName: utils.ANY, fldVal: utils.ANY}}
+	case utils.MetaPrefix, utils.MetaString:
+		for _, val := range vals {
+			idxKeys = append(idxKeys, &filterIndexKey{fltrType: fltrType, fldName: fldName, fldVal: val})
+		}
+	case utils.MetaIPNet:
+		for _, val := range vals {
+			if prfx, canIndex := ipNetIndexPrefix(val); canIndex {
+				idxKeys = append(idxKeys, &filterIndexKey{fltrType: utils.MetaPrefix, fldName: fldName, fldVal: prfx})
+			}
+		}
+	case utils.MetaOr:
+		if depth >= maxFilterDepth {
+			return nil, fmt.Errorf("filter nesting deeper than %d levels", maxFilterDepth)
+		}
+		for _, fltrID := range vals {
+			var fltr *Filter
+			if fltr, err = dm.GetFilter(tenant, fltrID,
+				true, false, utils.NonTransactional); err != nil {
+				if err == utils.ErrNotFound {
+					err = fmt.Errorf("broken reference to filter: %+v within %s rule",
+						fltrID, utils.MetaOr)
+				}
+				return nil, err
+			}
+			var fltrKeys []*filterIndexKey
+			for _, rule := range fltr.Rules {
+				var ruleKeys []*filterIndexKey
+				if ruleKeys, err = filterRuleIndexKeys(dm, tenant, rule.Type,
+					rule.Element, rule.Values, depth+1); err != nil {
+					return nil, err
+				}
+				fltrKeys = append(fltrKeys, ruleKeys...)
+			}
+			if len(fltrKeys) == 0 { // one alternative not indexed makes the whole rule unusable within indexes
+				return nil, nil
+			}
+			idxKeys = append(idxKeys, fltrKeys...)
+		}
+	}
+	return
+}
+
+// ipNetIndexPrefix returns the string prefix matched by all the IPv4 addresses within the network
+func ipNetIndexPrefix(val string) (prfx string, canIndex bool) {
+	ipNet, err := parseIPNet(val)
+	if err != nil {
+		return
+	}
+	ip4 := ipNet.IP.To4()
+	if ip4 == nil {
+		return
+	}
+	ones, _ := ipNet.Mask.Size()
+	octets := ones / 8
+	if octets == 0 {
+		return
+	}
+	prfx = strings.Join(strings.Split(ip4.String(), ".")[:octets], ".")
+	if octets < net.IPv4len {
+		prfx += "."
+	}
+	return prfx, true
+}
+
 //createAndIndex create indexes for an item
 func createAndIndex(itemPrefix, tenant, context, itemID string, filterIDs []string, dm *DataManager) (err error) {
 	indexerKey := tenant
@@ -308,15 +377,14 @@ func createAndIndex(itemPrefix, tenant, context, itemID string, filterIDs []stri
 			return
 		}
 		for _, flt := range fltr.Rules {
-			var fldType, fldName string
-			var fldVals []string
-			if utils.SliceHasMember([]string{utils.META_NONE, utils.MetaPrefix, utils.MetaString}, flt.Type) {
-				fldType, fldName = flt.Type, flt.Element
-				fldVals = flt.Values
+			var idxKeys []*filterIndexKey
+			if idxKeys, err = filterRuleIndexKeys(dm, tenant,
+				flt.Type, flt.Element, flt.Values, 0); err != nil {
+				return
 			}
-			for _, fldVal := range fldVals {
-				if err = indexer.loadFldNameFldValIndex(fldType,
-					fldName, fldVal); err != nil && err != utils.ErrNotFound {
+			for _, idxKey := range idxKeys {
+				if err = indexer.loadFldNameFldValIndex(idxKey.fltrType,
+					idxKey.fldName, idxKey.fldVal); err != nil && err != utils.ErrNotFound {
 					return err
 				}
 			}
@@ -73,7 +73,7 @@ func TestFilterIndexerIT(t *testing.T) {
 		redisDB, err := NewRedisStorage(
 			fmt.Sprintf("%s:%s", cfg.DataDbCfg().DataDbHost, cfg.DataDbCfg().DataDbPort),
 			4, cfg.DataDbCfg().DataDbPass, cfg.GeneralCfg().DBDataEncoding,
-			utils.REDIS_MAX_CONNS, "")
+			utils.REDIS_MAX_CONNS, "", false, 0)
 		if err != nil {
 			t.Fatal("Could not connect to Redis", err.Error())
 		}
@@ -19,11 +19,13 @@ import (
 	"fmt"
 	"net"
 	"reflect"
+	"regexp"
 	"strings"
 	"time"
 
 	"github.com/cgrates/cgrates/config"
 	"github.com/cgrates/cgrates/utils"
+	"github.com/gorhill/cronexpr"
 )
 
 // NewFilterS initializtes the filter service
@@ -45,11 +47,20 @@ type FilterS struct {
 	connMgr *ConnManager
 }
 
+// maxFilterDepth limits the nesting of the *or rules, protecting against circular references
+const maxFilterDepth = 10
+
 // Pass will check all filters wihin filterIDs and require them passing for dataProvider
 // there should be at least one filter passing, ie: if filters are not active event will fail to pass
 // receives the event as DataProvider so we can accept undecoded data (ie: HttpRequest)
 func (fS *FilterS) Pass(tenant string, filterIDs []string,
 	ev config.DataProvider) (pass bool, err error) {
+	return fS.pass(tenant, filterIDs, ev, 0)
+}
+
+// pass implements Pass, depth being the level of *or nesting
+func (fS *FilterS) pass(tenant string, filterIDs []string,
+	ev config.DataProvider, depth int) (pass bool, err error) {
 	if len(filterIDs) == 0 {
 		return true, nil
 	}
@@ -68,7 +79,7 @@ func (fS *FilterS) Pass(tenant string, filterIDs []string,
 		}
 		dDP := newDynamicDP(fS.cfg, fS.connMgr, tenant, ev)
 		for _, fltr := range f.Rules {
-			if pass, err = fltr.Pass(dDP); err != nil || !pass {
+			if pass, err = fS.passRule(tenant, fltr, ev, dDP, depth); err != nil || !pass {
 				return pass, err
 			}
 		}
@@ -77,6 +88,25 @@ func (fS *FilterS) Pass(tenant string, filterIDs []string,
 	return
 }
 
+// passRule checks one rule, the *or ones passing if any of the filters they reference does
+func (fS *FilterS) passRule(tenant string, rule *FilterRule, ev, dDP config.DataProvider,
+	depth int) (pass bool, err error) {
+	if rule.Type != utils.MetaOr && rule.Type != utils.MetaNotOr {
+		return rule.Pass(dDP)
+	}
+	if depth >= maxFilterDepth {
+		return false, fmt.Errorf("filter nesting deeper than %d levels", maxFilterDepth)
+	}
+	for _, fltrID := range rule.Values {
+		if pass, err = fS.pass(tenant, []string{fltrID}, ev, depth+1); err != nil {
+			return false, err
+		} else if pass {
+			break
+		}
+	}
+	return pass != (rule.Type == utils.MetaNotOr), nil
+}
+
 //checkPrefix verify if the value has as prefix one of the prefixes
 func checkPrefix(value string, prefixes []string) (hasPrefix bool) {
 	for _, prefix := range prefixes {
@@ -138,7 +168,7 @@ func (fS *FilterS) LazyPass(tenant string, filterIDs []string,
 				continue
 			}
 			dDP := newDynamicDP(fS.cfg, fS.connMgr, tenant, ev)
-			if pass, err = rule.Pass(dDP); err != nil || !pass {
+			if pass, err = fS.passRule(tenant, rule, ev, dDP, 0); err != nil || !pass {
 				return
 			}
 		}
@@ -203,15 +233,16 @@ var supportedFiltersType *utils.StringSet = utils.NewStringSet([]string{utils.Me
 	utils.MetaTimings, utils.MetaRSR, utils.MetaDestinations,
 	utils.MetaEmpty, utils.MetaExists, utils.MetaLessThan, utils.MetaLessOrEqual,
 	utils.MetaGreaterThan, utils.MetaGreaterOrEqual, utils.MetaEqual,
-	utils.MetaNotEqual})
+	utils.MetaNotEqual, utils.MetaRegex, utils.MetaIPNet, utils.MetaOr, utils.MetaCron})
 var needsFieldName *utils.StringSet = utils.NewStringSet([]string{utils.MetaString, utils.MetaPrefix,
 	utils.MetaSuffix, utils.MetaTimings, utils.MetaDestinations, utils.MetaLessThan,
 	utils.MetaEmpty, utils.MetaExists, utils.MetaLessOrEqual, utils.MetaGreaterThan,
-	utils.MetaGreaterOrEqual, utils.MetaEqual, utils.MetaNotEqual})
+	utils.MetaGreaterOrEqual, utils.MetaEqual, utils.MetaNotEqual, utils.MetaRegex, utils.MetaIPNet})
 var needsValues *utils.StringSet = utils.NewStringSet([]string{utils.MetaString, utils.MetaPrefix,
 	utils.MetaSuffix, utils.MetaTimings, utils.MetaRSR, utils.MetaDestinations,
 	utils.MetaLessThan, utils.MetaLessOrEqual, utils.MetaGreaterThan, utils.MetaGreaterOrEqual,
-	utils.MetaEqual, utils.MetaNotEqual})
+	utils.MetaEqual, utils.MetaNotEqual, utils.MetaRegex, utils.MetaIPNet, utils.MetaOr,
+	utils.MetaCron})
 
 // NewFilterRule returns a new filter
 func NewFilterRule(rfType, fieldName string, vals []string) (*FilterRule, error) {
@@ -249,6 +280,9 @@ type FilterRule struct {
 	Element   string            // Name of the field providing us the Values to check (used in case of some )
 	Values    []string          // Filter definition
 	rsrFields config.RSRParsers // Cache here the RSRFilter Values
+	regexes   []*regexp.Regexp  // Cache here the compiled *regex Values
+	ipNets    []*net.IPNet      // Cache here the parsed *ipnet Values
+	crons     []*cronExpr       // Cache here the parsed *cron Values
 	negative  *bool
 }
 
@@ -265,10 +299,72 @@ func (fltr *FilterRule) CompileValues() (err error) {
 				return
 			}
 		}
+	case utils.MetaRegex, utils.MetaNotRegex:
+		fltr.regexes = make([]*regexp.Regexp, len(fltr.Values))
+		for i, val := range fltr.Values {
+			if fltr.regexes[i], err = regexp.Compile(val); err != nil {
+				return
+			}
+		}
+	case utils.MetaIPNet, utils.MetaNotIPNet:
+		fltr.ipNets = make([]*net.IPNet, len(fltr.Values))
+		for i, val := range fltr.Values {
+			if fltr.ipNets[i], err = parseIPNet(val); err != nil {
+				return
+			}
+		}
+	case utils.MetaCron, utils.MetaNotCron:
+		fltr.crons = make([]*cronExpr, len(fltr.Values))
+		for i, val := range fltr.Values {
+			if fltr.crons[i], err = newCronExpr(val); err != nil {
+				return
+			}
+		}
 	}
 	return
 }
 
+// cronExpr is a parsed *cron filter value
+type cronExpr struct {
+	expr *cronexpr.Expression
+	prec time.Duration // time.Second for expressions with seconds field, time.Minute otherwise
+}
+
+// newCronExpr parses a cron expression: <minute hour day_of_month month day_of_week [year]> or
+// <second minute hour day_of_month month day_of_week year>
+func newCronExpr(val string) (cExp *cronExpr, err error) {
+	cExp = &cronExpr{prec: time.Minute}
+	if cExp.expr, err = cronexpr.Parse(val); err != nil {
+		return nil, fmt.Errorf("invalid cron expression <%s>: %s", val, err.Error())
+	}
+	if len(strings.Fields(val)) == 7 {
+		cExp.prec = time.Second
+	}
+	return
+}
+
+// matches returns true if the cron expression selects the time t
+func (cExp *cronExpr) matches(t time.Time) bool {
+	t = t.Truncate(cExp.prec)
+	return cExp.expr.Next(t.Add(-cExp.prec)).Equal(t)
+}
+
+// parseIPNet parses a CIDR, a single IP address being considered a host network
+func parseIPNet(val string) (ipNet *net.IPNet, err error) {
+	if !strings.Contains(val, "/") {
+		ip := net.ParseIP(val)
+		if ip == nil {
+			return nil, fmt.Errorf("invalid IP address <%s>", val)
+		}
+		if ip4 := ip.To4(); ip4 != nil {
+			return &net.IPNet{IP: ip4, Mask: net.CIDRMask(32, 32)}, nil
+		}
+		return &net.IPNet{IP: ip, Mask: net.CIDRMask(128, 128)}, nil
+	}
+	_, ipNet, err = net.ParseCIDR(val)
+	return
+}
+
 // Pass is the method which should be used from outside.
 func (fltr *FilterRule) Pass(dDP config.DataProvider) (result bool, err error) {
 	if fltr.negative == nil {
@@ -296,6 +392,12 @@ func (fltr *FilterRule) Pass(dDP config.DataProvider) (result bool, err error) {
 		result, err = fltr.passGreaterThan(dDP)
 	case utils.MetaEqual, utils.MetaNotEqual:
 		result, err = fltr.passEqualTo(dDP)
+	case utils.MetaRegex, utils.MetaNotRegex:
+		result, err = fltr.passRegex(dDP)
+	case utils.MetaIPNet, utils.MetaNotIPNet:
+		result, err = fltr.passIPNet(dDP)
+	case utils.MetaCron, utils.MetaNotCron:
+		result, err = fltr.passCron(dDP)
 	default:
 		err = utils.ErrPrefixNotErrNotImplemented(fltr.Type)
 	}
@@ -457,6 +559,88 @@ func (fltr *FilterRule) passRSR(dDP config.DataProvider) (bool, error) {
 	return true, nil
 }
 
+func (fltr *FilterRule) passRegex(dDP config.DataProvider) (bool, error) {
+	strVal, err := config.DPDynamicString(fltr.Element, dDP)
+	if err != nil {
+		if err == utils.ErrNotFound {
+			return false, nil
+		}
+		return false, err
+	}
+	for _, re := range fltr.regexes {
+		if re.MatchString(strVal) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (fltr *FilterRule) passIPNet(dDP config.DataProvider) (bool, error) {
+	strVal, err := config.DPDynamicString(fltr.Element, dDP)
+	if err != nil {
+		if err == utils.ErrNotFound {
+			return false, nil
+		}
+		return false, err
+	}
+	ip := net.ParseIP(strVal)
+	if ip == nil { // might come together with the port
+		if host, _, errSplit := net.SplitHostPort(strVal); errSplit == nil {
+			ip = net.ParseIP(host)
+		}
+	}
+	if ip == nil {
+		return false, nil
+	}
+	for _, ipNet := range fltr.ipNets {
+		if ipNet.Contains(ip) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (fltr *FilterRule) passCron(dDP config.DataProvider) (bool, error) {
+	tz := cronTimezone(dDP)
+	t := time.Now()
+	if fltr.Element != utils.EmptyString {
+		tIface, err := config.DPDynamicInterface(fltr.Element, dDP)
+		if err != nil {
+			if err == utils.ErrNotFound {
+				return false, nil
+			}
+			return false, err
+		}
+		if t, err = utils.IfaceAsTime(tIface, tz); err != nil {
+			return false, err
+		}
+	}
+	loc, err := time.LoadLocation(tz)
+	if err != nil {
+		return false, err
+	}
+	t = t.In(loc)
+	for _, cExp := range fltr.crons {
+		if cExp.matches(t) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// cronTimezone returns the timezone the *cron rules are evaluated in,
+// the one configured for the tenant of the event if present
+func cronTimezone(dDP config.DataProvider) string {
+	cfg := config.CgrConfig()
+	if dynDP, canCast := dDP.(*dynamicDP); canCast && dynDP.cfg != nil {
+		cfg = dynDP.cfg
+		if tz, has := cfg.FilterSCfg().TenantTimezones[dynDP.tenant]; has {
+			return tz
+		}
+	}
+	return cfg.GeneralCfg().DefaultTimezone
+}
+
 func (fltr *FilterRule) passGreaterThan(dDP config.DataProvider) (bool, error) {
 	fldIf, err := config.DPDynamicInterface(fltr.Element, dDP)
 	if err != nil {
@@ -1212,3 +1212,335 @@ func TestPassPartial(t *testing.T) {
 		t.Errorf("Expecting: %+v, received: %+v", 0, len(ruleList))
 	}
 }
+
+func TestFilterPassRegex(t *testing.T) {
+	ev := config.NewNavigableMap(map[string]interface{}{
+		utils.Account:     "1001",
+		utils.Destination: "+4986517174963",
+	})
+	rf, err := NewFilterRule(utils.MetaRegex, "~Destination", []string{"^\\+49", "^\\+40"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if passes, err := rf.Pass(ev); err != nil {
+		t.Error(err)
+	} else if !passes {
+		t.Error("Not passes filter")
+	}
+	rf, err = NewFilterRule(utils.MetaRegex, "~Account", []string{"^10[1-9]\\d$"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if passes, err := rf.Pass(ev); err != nil {
+		t.Error(err)
+	} else if passes {
+		t.Error("Passes filter")
+	}
+	rf, err = NewFilterRule(utils.MetaRegex, "~NonExisting", []string{".*"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if passes, err := rf.Pass(ev); err != nil {
+		t.Error(err)
+	} else if passes {
+		t.Error("Passes filter")
+	}
+	//not
+	rf, err = NewFilterRule(utils.MetaNotRegex, "~Account", []string{"^10[1-9]\\d$"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if passes, err := rf.Pass(ev); err != nil {
+		t.Error(err)
+	} else if !passes {
+		t.Error("Not passes filter")
+	}
+	if _, err = NewFilterRule(utils.MetaRegex, "~Account", []string{"^(10"}); err == nil {
+		t.Error("Expecting error for invalid regex")
+	}
+}
+
+func TestFilterPassIPNet(t *testing.T) {
+	ev := config.NewNavigableMap(map[string]interface{}{
+		"SourceIP":   "10.10.1.5",
+		"SourceAddr": "192.168.56.203:5060",
+		"SourceIPv6": "2001:db8::1",
+		"Account":    "1001",
+	})
+	for _, tc := range []struct {
+		fltrType string
+		element  string
+		vals     []string
+		passes   bool
+	}{
+		{utils.MetaIPNet, "~SourceIP", []string{"10.10.0.0/16"}, true},
+		{utils.MetaIPNet, "~SourceIP", []string{"10.11.0.0/16", "10.10.1.5"}, true},
+		{utils.MetaIPNet, "~SourceIP", []string{"10.11.0.0/16", "10.10.1.6"}, false},
+		{utils.MetaIPNet, "~SourceAddr", []string{"192.168.56.0/24"}, true},
+		{utils.MetaIPNet, "~SourceIPv6", []string{"2001:db8::/32"}, true},
+		{utils.MetaIPNet, "~SourceIPv6", []string{"10.0.0.0/8"}, false},
+		{utils.MetaIPNet, "~Account", []string{"0.0.0.0/0"}, false},
+		{utils.MetaIPNet, "~NonExisting", []string{"0.0.0.0/0"}, false},
+		{utils.MetaNotIPNet, "~SourceIP", []string{"10.11.0.0/16"}, true},
+		{utils.MetaNotIPNet, "~SourceIP", []string{"10.0.0.0/8"}, false},
+	} {
+		rf, err := NewFilterRule(tc.fltrType, tc.element, tc.vals)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if passes, err := rf.Pass(ev); err != nil {
+			t.Error(err)
+		} else if passes != tc.passes {
+			t.Errorf("%s:%s:%v expecting: %v, received: %v",
+				tc.fltrType, tc.element, tc.vals, tc.passes, passes)
+		}
+	}
+	if _, err := NewFilterRule(utils.MetaIPNet, "~SourceIP", []string{"10.10.0.0/33"}); err == nil {
+		t.Error("Expecting error for invalid network")
+	}
+	if _, err := NewFilterRule(utils.MetaIPNet, "~SourceIP", []string{"10.10.a.1"}); err == nil {
+		t.Error("Expecting error for invalid address")
+	}
+}
+
+func TestInlineFilterPassIPNet(t *testing.T) {
+	cfg, _ := config.NewDefaultCGRConfig()
+	data := NewInternalDB(nil, nil, true, cfg.DataDbCfg().Items)
+	dmFilterPass := NewDataManager(data, config.CgrConfig().CacheCfg(), nil)
+	filterS := FilterS{
+		cfg: cfg,
+		dm:  dmFilterPass,
+	}
+	ev := config.NewNavigableMap(nil)
+	ev.Set([]string{utils.MetaReq}, map[string]interface{}{
+		"SourceIP": "2001:db8::10",
+	}, false, false)
+	if pass, err := filterS.Pass("cgrates.org",
+		[]string{"*ipnet:~*req.SourceIP:2001:db8::/64"}, ev); err != nil {
+		t.Error(err)
+	} else if !pass {
+		t.Errorf("Expecting: %+v, received: %+v", true, pass)
+	}
+	if pass, err := filterS.Pass("cgrates.org",
+		[]string{"*notipnet:~*req.SourceIP:2001:db8::/64"}, ev); err != nil {
+		t.Error(err)
+	} else if pass {
+		t.Errorf("Expecting: %+v, received: %+v", false, pass)
+	}
+}
+
+func TestFilterRuleIndexKeys(t *testing.T) {
+	eKeys := []*filterIndexKey{
+		{fltrType: utils.MetaString, fldName: "~*req.Account", fldVal: "1001"},
+		{fltrType: utils.MetaString, fldName: "~*req.Account", fldVal: "1002"},
+	}
+	if idxKeys, err := filterRuleIndexKeys(nil, "cgrates.org", utils.MetaString,
+		"~*req.Account", []string{"1001", "1002"}, 0); err != nil {
+		t.Error(err)
+	} else if !reflect.DeepEqual(eKeys, idxKeys) {
+		t.Errorf("Expecting: %s, received: %s", utils.ToJSON(eKeys), utils.ToJSON(idxKeys))
+	}
+	eKeys = []*filterIndexKey{
+		{fltrType: utils.MetaPrefix, fldName: "~*req.SourceIP", fldVal: "10."},
+		{fltrType: utils.MetaPrefix, fldName: "~*req.SourceIP", fldVal: "10.10."},
+		{fltrType: utils.MetaPrefix, fldName: "~*req.SourceIP", fldVal: "192.168.56."},
+		{fltrType: utils.MetaPrefix, fldName: "~*req.SourceIP", fldVal: "192.168.56.203"},
+	}
+	if idxKeys, err := filterRuleIndexKeys(nil, "cgrates.org", utils.MetaIPNet, "~*req.SourceIP",
+		[]string{"10.0.0.0/8", "10.10.0.0/20", "192.168.56.0/24", "192.168.56.203",
+			"0.0.0.0/0", "2001:db8::/32", "invalid"}, 0); err != nil {
+		t.Error(err)
+	} else if !reflect.DeepEqual(eKeys, idxKeys) {
+		t.Errorf("Expecting: %s, received: %s", utils.ToJSON(eKeys), utils.ToJSON(idxKeys))
+	}
+	if idxKeys, err := filterRuleIndexKeys(nil, "cgrates.org", utils.MetaRegex,
+		"~*req.Account", []string{"^1001$"}, 0); err != nil {
+		t.Error(err)
+	} else if len(idxKeys) != 0 {
+		t.Errorf("Expecting no keys, received: %s", utils.ToJSON(idxKeys))
+	}
+	// inline alternatives
+	eKeys = []*filterIndexKey{
+		{fltrType: utils.MetaPrefix, fldName: "~*req.Destination", fldVal: "49"},
+		{fltrType: utils.MetaString, fldName: "~*req.Account", fldVal: "1001"},
+	}
+	if idxKeys, err := filterRuleIndexKeys(nil, "cgrates.org", utils.MetaOr, utils.EmptyString,
+		[]string{"*prefix:~*req.Destination:49", "*string:~*req.Account:1001"}, 0); err != nil {
+		t.Error(err)
+	} else if !reflect.DeepEqual(eKeys, idxKeys) {
+		t.Errorf("Expecting: %s, received: %s", utils.ToJSON(eKeys), utils.ToJSON(idxKeys))
+	}
+	if idxKeys, err := filterRuleIndexKeys(nil, "cgrates.org", utils.MetaOr, utils.EmptyString,
+		[]string{"*prefix:~*req.Destination:49", "*gt:~*req.Usage:10s"}, 0); err != nil {
+		t.Error(err)
+	} else if len(idxKeys) != 0 {
+		t.Errorf("Expecting no keys, received: %s", utils.ToJSON(idxKeys))
+	}
+}
+
+func TestFilterPassOr(t *testing.T) {
+	cfg, _ := config.NewDefaultCGRConfig()
+	data := NewInternalDB(nil, nil, true, cfg.DataDbCfg().Items)
+	dmFilterPass := NewDataManager(data, config.CgrConfig().CacheCfg(), nil)
+	filterS := FilterS{
+		cfg: cfg,
+		dm:  dmFilterPass,
+	}
+	for _, fltr := range []*Filter{
+		{
+			Tenant: "cgrates.org",
+			ID:     "FLTR_DST_49",
+			Rules: []*FilterRule{{Type: utils.MetaPrefix,
+				Element: "~*req.Destination", Values: []string{"49"}}},
+		},
+		{
+			Tenant: "cgrates.org",
+			ID:     "FLTR_DST_43_1002",
+			Rules: []*FilterRule{
+				{Type: utils.MetaPrefix, Element: "~*req.Destination", Values: []string{"43"}},
+				{Type: utils.MetaString, Element: "~*req.Account", Values: []string{"1002"}},
+			},
+		},
+		{
+			Tenant: "cgrates.org",
+			ID:     "FLTR_OR",
+			Rules: []*FilterRule{{Type: utils.MetaOr,
+				Values: []string{"FLTR_DST_49", "FLTR_DST_43_1002"}}},
+		},
+		{
+			Tenant: "cgrates.org",
+			ID:     "FLTR_NESTED",
+			Rules: []*FilterRule{{Type: utils.MetaOr,
+				Values: []string{"FLTR_OR", "*string:~*req.Account:1003"}}},
+		},
+		{
+			Tenant: "cgrates.org",
+			ID:     "FLTR_CIRCULAR",
+			Rules: []*FilterRule{{Type: utils.MetaOr,
+				Values: []string{"*string:~*req.Account:1010", "FLTR_CIRCULAR"}}},
+		},
+	} {
+		if err := fltr.Compile(); err != nil {
+			t.Fatal(err)
+		}
+		if err := dmFilterPass.SetFilter(fltr); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for _, tc := range []struct {
+		fltrIDs []string
+		ev      map[string]interface{}
+		pass    bool
+	}{
+		{[]string{"FLTR_OR"}, map[string]interface{}{"Account": "1001", "Destination": "4986517174963"}, true},
+		{[]string{"FLTR_OR"}, map[string]interface{}{"Account": "1002", "Destination": "4356789"}, true},
+		{[]string{"FLTR_OR"}, map[string]interface{}{"Account": "1001", "Destination": "4356789"}, false},
+		{[]string{"FLTR_OR", "*string:~*req.Account:1001"}, map[string]interface{}{"Account": "1001", "Destination": "4986517174963"}, true},
+		{[]string{"FLTR_OR", "*string:~*req.Account:1002"}, map[string]interface{}{"Account": "1001", "Destination": "4986517174963"}, false},
+		{[]string{"*or::*prefix:~*req.Destination:49;*prefix:~*req.Destination:43"}, map[string]interface{}{"Destination": "4356789"}, true},
+		{[]string{"*notor::FLTR_DST_49;FLTR_DST_43_1002"}, map[string]interface{}{"Account": "1001", "Destination": "4356789"}, true},
+		{[]string{"FLTR_NESTED"}, map[string]interface{}{"Account": "1003", "Destination": "4356789"}, true},
+		{[]string{"FLTR_NESTED"}, map[string]interface{}{"Account": "1002", "Destination": "4356789"}, true},
+		{[]string{"FLTR_NESTED"}, map[string]interface{}{"Account": "1004", "Destination": "4356789"}, false},
+	} {
+		ev := config.NewNavigableMap(nil)
+		ev.Set([]string{utils.MetaReq}, tc.ev, false, false)
+		if pass, err := filterS.Pass("cgrates.org", tc.fltrIDs, ev); err != nil {
+			t.Error(err)
+		} else if pass != tc.pass {
+			t.Errorf("%v for %v expecting: %v, received: %v", tc.fltrIDs, tc.ev, tc.pass, pass)
+		}
+	}
+	ev := config.NewNavigableMap(nil)
+	ev.Set([]string{utils.MetaReq}, map[string]interface{}{"Account": "1010"}, false, false)
+	if pass, err := filterS.Pass("cgrates.org", []string{"FLTR_CIRCULAR"}, ev); err != nil {
+		t.Error(err)
+	} else if !pass {
+		t.Errorf("Expecting: %v, received: %v", true, pass)
+	}
+	ev.Set([]string{utils.MetaReq}, map[string]interface{}{"Account": "1011"}, false, false)
+	if _, err := filterS.Pass("cgrates.org", []string{"FLTR_CIRCULAR"}, ev); err == nil {
+		t.Error("Expecting error for circular reference")
+	}
+	if _, err := NewFilterRule(utils.MetaOr, utils.EmptyString, nil); err == nil {
+		t.Error("Expecting error for missing values")
+	}
+}
+
+func TestFilterPassCron(t *testing.T) {
+	cfg, _ := config.NewDefaultCGRConfig()
+	cfg.GeneralCfg().DefaultTimezone = "UTC"
+	nM := config.NewNavigableMap(nil)
+	nM.Set([]string{utils.MetaReq}, map[string]interface{}{
+		utils.AnswerTime: time.Date(2020, 4, 6, 10, 30, 25, 0, time.UTC), // Monday
+		"WeekendTime":    "2020-04-05T10:30:25Z",
+		"InvalidTime":    "notATime",
+	}, false, false)
+	ev := newDynamicDP(cfg, nil, "cgrates.org", nM)
+	for _, tc := range []struct {
+		fltrType string
+		element  string
+		vals     []string
+		passes   bool
+	}{
+		{utils.MetaCron, "~*req.AnswerTime", []string{"* 8-17 * * 1-5"}, true},
+		{utils.MetaCron, "~*req.WeekendTime", []string{"* 8-17 * * 1-5"}, false},
+		{utils.MetaCron, "~*req.WeekendTime", []string{"* 8-17 * * 1-5", "* * * * 0,6"}, true},
+		{utils.MetaCron, "~*req.AnswerTime", []string{"0 8-18 * * 1-5"}, false},
+		{utils.MetaCron, "~*req.AnswerTime", []string{"30 10 6 4 *"}, true},
+		{utils.MetaCron, "~*req.AnswerTime", []string{"25 30 10 * * * 2020"}, true},
+		{utils.MetaCron, "~*req.AnswerTime", []string{"26 30 10 * * * 2020"}, false},
+		{utils.MetaCron, "~*req.NonExisting", []string{"* * * * *"}, false},
+		{utils.MetaCron, utils.EmptyString, []string{"* * * * *"}, true},
+		{utils.MetaNotCron, "~*req.AnswerTime", []string{"* 8-17 * * 1-5"}, false},
+		{utils.MetaNotCron, "~*req.WeekendTime", []string{"* 8-17 * * 1-5"}, true},
+	} {
+		rf, err := NewFilterRule(tc.fltrType, tc.element, tc.vals)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if passes, err := rf.Pass(ev); err != nil {
+			t.Error(err)
+		} else if passes != tc.passes {
+			t.Errorf("%s:%s:%v expecting: %v, received: %v",
+				tc.fltrType, tc.element, tc.vals, tc.passes, passes)
+		}
+	}
+	rf, err := NewFilterRule(utils.MetaCron, "~*req.InvalidTime", []string{"* * * * *"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := rf.Pass(ev); err == nil {
+		t.Error("Expecting error for invalid time")
+	}
+	if _, err := NewFilterRule(utils.MetaCron, "~*req.AnswerTime", []string{"* 25 * * *"}); err == nil {
+		t.Error("Expecting error for invalid cron expression")
+	}
+}
+
+func TestFilterPassCronTenantTimezone(t *testing.T) {
+	cfg, _ := config.NewDefaultCGRConfig()
+	cfg.FilterSCfg().TenantTimezones = map[string]string{"cgrates.org": "Europe/Berlin"}
+	cfg.GeneralCfg().DefaultTimezone = "UTC"
+	data := NewInternalDB(nil, nil, true, cfg.DataDbCfg().Items)
+	dmFilterPass := NewDataManager(data, config.CgrConfig().CacheCfg(), nil)
+	filterS := FilterS{
+		cfg: cfg,
+		dm:  dmFilterPass,
+	}
+	ev := config.NewNavigableMap(nil)
+	ev.Set([]string{utils.MetaReq}, map[string]interface{}{
+		utils.AnswerTime: time.Date(2020, 4, 6, 7, 30, 0, 0, time.UTC), // 09:30 in Berlin
+	}, false, false)
+	fltrIDs := []string{"*cron:~*req.AnswerTime:* 8-17 * * 1-5"}
+	if pass, err := filterS.Pass("cgrates.org", fltrIDs, ev); err != nil {
+		t.Error(err)
+	} else if !pass {
+		t.Errorf("Expecting: %+v, received: %+v", true, pass)
+	}
+	if pass, err := filterS.Pass("itsyscom.com", fltrIDs, ev); err != nil {
+		t.Error(err)
+	} else if pass {
+		t.Errorf("Expecting: %+v, received: %+v", false, pass)
+	}
+}
@@ -0,0 +1,76 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package engine
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/cgrates/cgrates/utils"
+)
+
+// NewAWSOptions parses the AWS connection options out of the dial URL
+// ie: "http://s3.us-east-2.amazonaws.com/?aws_region=us-east-2&aws_key=testkey&aws_secret=testsecret"
+func NewAWSOptions(dialURL string) (opts *AWSOptions) {
+	qry := utils.GetUrlRawArguments(dialURL)
+	opts = &AWSOptions{
+		Endpoint: strings.TrimSuffix(strings.Split(dialURL, "?")[0], "/"), // used to remove / to point to correct endpoint
+		Region:   qry[utils.AWSRegion],
+		ID:       qry[utils.AWSKey],
+		Key:      qry[utils.AWSSecret],
+		Token:    qry[utils.AWSToken],
+	}
+	if val, has := qry[utils.AWSForcePathStyle]; has {
+		opts.ForcePathStyle, _ = strconv.ParseBool(val)
+	}
+	return
+}
+
+// AWSOptions are the connection options shared by the SQS and S3 posters and readers
+type AWSOptions struct {
+	Endpoint       string
+	Region         string
+	ID             string
+	Key            string
+	Token          string
+	ForcePathStyle bool // needed by most S3 compatible servers
+}
+
+// NewSession creates a new AWS session out of options
+func (opts *AWSOptions) NewSession() (*session.Session, error) {
+	cfg := aws.Config{Endpoint: aws.String(opts.Endpoint)}
+	if len(opts.Region) != 0 {
+		cfg.Region = aws.String(opts.Region)
+	}
+	if len(opts.ID) != 0 &&
+		len(opts.Key) != 0 {
+		cfg.Credentials = credentials.NewStaticCredentials(opts.ID, opts.Key, opts.Token)
+	}
+	if opts.ForcePathStyle {
+		cfg.S3ForcePathStyle = aws.Bool(true)
+	}
+	return session.NewSessionWithOptions(
+		session.Options{
+			Config: cfg,
+		},
+	)
+}
@@ -77,6 +77,39 @@ func addFailedPost(expPath, format, module string, ev interface{}) {
 	failedPostCache.Set(key, failedPost, nil)
 }
 
+// PostEvent sends the body towards the exportPath using the poster for the exportFormat
+// the body should be url.Values for *http_post and []byte for the rest
+// failed posts are stored for replay if enabled
+func PostEvent(cfg *config.GeneralCfg, exportPath, exportFormat, module string, attempts int, body interface{}) (err error) {
+	switch exportFormat {
+	default:
+		return fmt.Errorf("unsupported exportFormat: <%s>", exportFormat)
+	case utils.MetaHTTPjsonMap, utils.MetaHTTPPost:
+		var pstr *HTTPPoster
+		if pstr, err = NewHTTPPoster(cfg.HttpSkipTlsVerify,
+			cfg.ReplyTimeout, exportPath,
+			utils.PosterTransportContentTypes[exportFormat], attempts); err != nil {
+			return
+		}
+		err = pstr.Post(body, utils.EmptyString)
+	case utils.MetaAMQPjsonMap:
+		err = PostersCache.PostAMQP(exportPath, attempts, body.([]byte))
+	case utils.MetaAMQPV1jsonMap:
+		err = PostersCache.PostAMQPv1(exportPath, attempts, body.([]byte))
+	case utils.MetaSQSjsonMap:
+		err = PostersCache.PostSQS(exportPath, attempts, body.([]byte))
+	case utils.MetaKafkajsonMap:
+		err = PostersCache.PostKafka(exportPath, attempts, body.([]byte), utils.UUIDSha1Prefix())
+	case utils.MetaS3jsonMap:
+		err = PostersCache.PostS3(exportPath, attempts, body.([]byte), utils.UUIDSha1Prefix())
+	}
+	if err != nil && cfg.FailedPostsDir != utils.META_NONE {
+		addFailedPost(exportPath, exportFormat, module, body)
+		err = nil
+	}
+	return
+}
+
 // NewExportEventsFromFile returns ExportEvents from the file
 // used only on replay failed post
 func NewExportEventsFromFile(filePath string) (expEv *ExportEvents, err error) {
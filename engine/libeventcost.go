@@ -190,6 +190,7 @@ type BalanceCharge struct {
 	RatingID      string  // special price applied on this balance
 	Units         float64 // number of units charged
 	ExtraChargeID string  // used in cases when paying *voice with *monetary
+	ExchangeRate  float64 // rate converting the Units into the balance currency, 0 if not converted
 }
 
 // FieldAsInterface func to help EventCost FieldAsInterface
@@ -210,6 +211,8 @@ func (bc *BalanceCharge) FieldAsInterface(fldPath []string) (val interface{}, er
 		return bc.Units, nil
 	case utils.ExtraChargeID:
 		return bc.ExtraChargeID, nil
+	case utils.ExchangeRate:
+		return bc.ExchangeRate, nil
 	}
 }
 
@@ -227,7 +230,8 @@ func (bc *BalanceCharge) Equals(oBC *BalanceCharge) bool {
 		bc.BalanceUUID == oBC.BalanceUUID &&
 		bc.RatingID == oBC.RatingID &&
 		bc.Units == oBC.Units &&
-		bcExtraChargeID == oBCExtraChargerID
+		bcExtraChargeID == oBCExtraChargerID &&
+		bc.ExchangeRate == oBC.ExchangeRate
 }
 
 // Clone creates a copy of BalanceCharge
@@ -330,6 +334,7 @@ type RatingUnit struct {
 	TimingID         string // This RatingUnit is bounded to specific timing profile
 	RatesID          string
 	RatingFiltersID  string
+	Currency         string // currency of the costs, empty for the implicit one
 }
 
 // Equals returns if RatingUnit is equal to the other
@@ -341,7 +346,8 @@ func (ru *RatingUnit) Equals(oRU *RatingUnit) bool {
 		ru.MaxCostStrategy == oRU.MaxCostStrategy &&
 		ru.TimingID == oRU.TimingID &&
 		ru.RatesID == oRU.RatesID &&
-		ru.RatingFiltersID == oRU.RatingFiltersID
+		ru.RatingFiltersID == oRU.RatingFiltersID &&
+		ru.Currency == oRU.Currency
 }
 
 // Clone creates a copy of RatingUnit
@@ -375,6 +381,8 @@ func (ru *RatingUnit) FieldAsInterface(fldPath []string) (val interface{}, err e
 		return ru.RatesID, nil
 	case utils.RatingFiltersID:
 		return ru.RatingFiltersID, nil
+	case utils.Currency:
+		return ru.Currency, nil
 	}
 }
 
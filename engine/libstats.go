@@ -41,7 +41,9 @@ type StatQueueProfile struct {
 	Stored             bool
 	Blocker            bool // blocker flag to stop processing on filters matched
 	Weight             float64
-	ThresholdIDs       []string // list of thresholds to be checked after changes
+	ThresholdIDs       []string      // list of thresholds to be checked after changes
+	BucketInterval     time.Duration // aggregate the events into buckets of this size, 0 to disable
+	BucketCount        int           // number of buckets forming the window
 }
 
 // StatQueueProfileWithArgDispatcher is used in replicatorV1 for dispatcher
@@ -54,6 +56,15 @@ func (sqp *StatQueueProfile) TenantID() string {
 	return utils.ConcatenatedKey(sqp.Tenant, sqp.ID)
 }
 
+// bucketWindow returns the duration covered by all the buckets of the profile
+func (sqp *StatQueueProfile) bucketWindow() time.Duration {
+	bktCnt := sqp.BucketCount
+	if bktCnt < 1 {
+		bktCnt = 1
+	}
+	return time.Duration(bktCnt) * sqp.BucketInterval
+}
+
 type MetricWithFilters struct {
 	FilterIDs []string
 	MetricID  string
@@ -62,12 +73,14 @@ type MetricWithFilters struct {
 // NewStoredStatQueue initiates a StoredStatQueue out of StatQueue
 func NewStoredStatQueue(sq *StatQueue, ms Marshaler) (sSQ *StoredStatQueue, err error) {
 	sSQ = &StoredStatQueue{
-		Tenant:     sq.Tenant,
-		ID:         sq.ID,
-		Compressed: sq.Compress(int64(config.CgrConfig().StatSCfg().StoreUncompressedLimit)),
-		SQItems:    make([]SQItem, len(sq.SQItems)),
-		SQMetrics:  make(map[string][]byte, len(sq.SQMetrics)),
-		MinItems:   sq.MinItems,
+		Tenant:      sq.Tenant,
+		ID:          sq.ID,
+		Compressed:  sq.Compress(int64(config.CgrConfig().StatSCfg().StoreUncompressedLimit)),
+		SQItems:     make([]SQItem, len(sq.SQItems)),
+		SQMetrics:   make(map[string][]byte, len(sq.SQMetrics)),
+		MinItems:    sq.MinItems,
+		SQBuckets:   sq.SQBuckets,
+		BucketStart: sq.BucketStart,
 	}
 	for i, sqItm := range sq.SQItems {
 		sSQ.SQItems[i] = sqItm
@@ -79,17 +92,59 @@ func NewStoredStatQueue(sq *StatQueue, ms Marshaler) (sSQ *StoredStatQueue, err
 			sSQ.SQMetrics[metricID] = marshaled
 		}
 	}
+	if sq.BucketMetrics != nil {
+		if sSQ.BucketMetrics, err = marshalStatMetrics(sq.BucketMetrics, ms); err != nil {
+			return nil, err
+		}
+	}
+	if sq.SQBucketMetrics != nil {
+		sSQ.SQBucketMetrics = make([]map[string][]byte, len(sq.SQBucketMetrics))
+		for i, bktMetrics := range sq.SQBucketMetrics {
+			if sSQ.SQBucketMetrics[i], err = marshalStatMetrics(bktMetrics, ms); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return
+}
+
+// marshalStatMetrics marshals each of the metrics
+func marshalStatMetrics(metrics map[string]StatMetric, ms Marshaler) (mrshled map[string][]byte, err error) {
+	mrshled = make(map[string][]byte, len(metrics))
+	for metricID, metric := range metrics {
+		if mrshled[metricID], err = metric.Marshal(ms); err != nil {
+			return nil, err
+		}
+	}
+	return
+}
+
+// unmarshalStatMetrics loads each of the metrics out of their marshaled form
+func unmarshalStatMetrics(mrshled map[string][]byte, minItems int, ms Marshaler) (metrics map[string]StatMetric, err error) {
+	metrics = make(map[string]StatMetric, len(mrshled))
+	for metricID, marshaled := range mrshled {
+		if metrics[metricID], err = NewStatMetric(metricID, minItems, []string{}); err != nil {
+			return nil, err
+		}
+		if err = metrics[metricID].LoadMarshaled(ms, marshaled); err != nil {
+			return nil, err
+		}
+	}
 	return
 }
 
 // StoredStatQueue differs from StatQueue due to serialization of SQMetrics
 type StoredStatQueue struct {
-	Tenant     string
-	ID         string
-	SQItems    []SQItem
-	SQMetrics  map[string][]byte
-	MinItems   int
-	Compressed bool
+	Tenant          string
+	ID              string
+	SQItems         []SQItem
+	SQMetrics       map[string][]byte
+	MinItems        int
+	Compressed      bool
+	SQBuckets       []*StatBucket
+	SQBucketMetrics []map[string][]byte
+	BucketStart     *time.Time
+	BucketMetrics   map[string][]byte
 }
 
 type StoredStatQueueWithArgDispatcher struct {
@@ -108,11 +163,13 @@ func (ssq *StoredStatQueue) AsStatQueue(ms Marshaler) (sq *StatQueue, err error)
 		return
 	}
 	sq = &StatQueue{
-		Tenant:    ssq.Tenant,
-		ID:        ssq.ID,
-		SQItems:   make([]SQItem, len(ssq.SQItems)),
-		SQMetrics: make(map[string]StatMetric, len(ssq.SQMetrics)),
-		MinItems:  ssq.MinItems,
+		Tenant:      ssq.Tenant,
+		ID:          ssq.ID,
+		SQItems:     make([]SQItem, len(ssq.SQItems)),
+		SQMetrics:   make(map[string]StatMetric, len(ssq.SQMetrics)),
+		MinItems:    ssq.MinItems,
+		SQBuckets:   ssq.SQBuckets,
+		BucketStart: ssq.BucketStart,
 	}
 	for i, sqItm := range ssq.SQItems {
 		sq.SQItems[i] = sqItm
@@ -126,6 +183,19 @@ func (ssq *StoredStatQueue) AsStatQueue(ms Marshaler) (sq *StatQueue, err error)
 			sq.SQMetrics[metricID] = metric
 		}
 	}
+	if ssq.BucketMetrics != nil {
+		if sq.BucketMetrics, err = unmarshalStatMetrics(ssq.BucketMetrics, ssq.MinItems, ms); err != nil {
+			return nil, err
+		}
+	}
+	if ssq.SQBucketMetrics != nil {
+		sq.SQBucketMetrics = make([]map[string]StatMetric, len(ssq.SQBucketMetrics))
+		for i, mrshled := range ssq.SQBucketMetrics {
+			if sq.SQBucketMetrics[i], err = unmarshalStatMetrics(mrshled, ssq.MinItems, ms); err != nil {
+				return nil, err
+			}
+		}
+	}
 	if ssq.Compressed {
 		sq.Expand()
 	}
@@ -137,17 +207,28 @@ type SQItem struct {
 	ExpiryTime *time.Time // Used to auto-expire events
 }
 
+// StatBucket holds the metric values computed over one time bucket of a StatQueue
+type StatBucket struct {
+	StartTime time.Time
+	Metrics   map[string]float64
+}
+
 // StatQueue represents an individual stats instance
 type StatQueue struct {
-	lk        sync.RWMutex // protect the elements from within
-	Tenant    string
-	ID        string
-	SQItems   []SQItem
-	SQMetrics map[string]StatMetric
-	MinItems  int
-	sqPrfl    *StatQueueProfile
-	dirty     *bool          // needs save
-	ttl       *time.Duration // timeToLeave, picked on each init
+	lk              sync.RWMutex // protect the elements from within
+	Tenant          string
+	ID              string
+	SQItems         []SQItem
+	SQMetrics       map[string]StatMetric
+	MinItems        int
+	SQBuckets       []*StatBucket           // closed buckets, ordered by StartTime
+	SQBucketMetrics []map[string]StatMetric // aggregated metrics of the closed buckets, one for each of SQBuckets
+	BucketStart     *time.Time              // start of the bucket currently populated
+	BucketMetrics   map[string]StatMetric   // metrics of the bucket currently populated
+	sqPrfl          *StatQueueProfile
+	dirty           *bool                 // needs save
+	ttl             *time.Duration        // timeToLeave, picked on each init
+	closedMetrics   map[string]StatMetric // SQBucketMetrics merged, rebuilt when the buckets rotate
 }
 
 // RLock only to implement sync.RWMutex methods
@@ -169,6 +250,9 @@ func (sq *StatQueue) TenantID() string {
 
 // ProcessEvent processes a utils.CGREvent, returns true if processed
 func (sq *StatQueue) ProcessEvent(ev *utils.CGREvent, filterS *FilterS) (err error) {
+	if err = sq.rotateBuckets(time.Now()); err != nil {
+		return
+	}
 	if err = sq.remExpired(); err != nil {
 		return
 	}
@@ -230,8 +314,123 @@ func (sq *StatQueue) remOnQueueLength() (err error) {
 	return
 }
 
+// rotateBuckets closes the current bucket once now passed its end
+// and drops the closed buckets which fell outside of the window
+func (sq *StatQueue) rotateBuckets(now time.Time) (err error) {
+	if sq.sqPrfl == nil || sq.sqPrfl.BucketInterval <= 0 {
+		return
+	}
+	bktStart := now.Truncate(sq.sqPrfl.BucketInterval)
+	if sq.BucketStart != nil && !sq.BucketStart.Before(bktStart) { // still within the current bucket
+		return
+	}
+	if sq.BucketStart != nil { // keep only the aggregated values of the closed bucket
+		var aggrMetrics map[string]StatMetric
+		if aggrMetrics, err = sq.newMetrics(); err != nil {
+			return
+		}
+		mergeStatMetrics(aggrMetrics, sq.BucketMetrics)
+		sq.SQBuckets = append(sq.SQBuckets, sq.currentBucket())
+		sq.SQBucketMetrics = append(sq.SQBucketMetrics, aggrMetrics)
+	}
+	winStart := bktStart.Add(sq.sqPrfl.BucketInterval - sq.sqPrfl.bucketWindow())
+	var expIdx int
+	for expIdx < len(sq.SQBuckets) &&
+		sq.SQBuckets[expIdx].StartTime.Before(winStart) {
+		expIdx++
+	}
+	sq.SQBuckets = sq.SQBuckets[expIdx:]
+	if expIdx < len(sq.SQBucketMetrics) {
+		sq.SQBucketMetrics = sq.SQBucketMetrics[expIdx:]
+	} else {
+		sq.SQBucketMetrics = nil
+	}
+	if sq.BucketMetrics, err = sq.newMetrics(); err != nil {
+		return
+	}
+	sq.BucketStart = &bktStart
+	sq.closedMetrics = nil
+	return sq.updateWindowMetrics()
+}
+
+// newMetrics returns the empty metrics defined within the profile
+func (sq *StatQueue) newMetrics() (metrics map[string]StatMetric, err error) {
+	metrics = make(map[string]StatMetric, len(sq.sqPrfl.Metrics))
+	for _, metric := range sq.sqPrfl.Metrics {
+		if metrics[metric.MetricID], err = NewStatMetric(metric.MetricID,
+			sq.sqPrfl.MinItems, metric.FilterIDs); err != nil {
+			return
+		}
+	}
+	return
+}
+
+// mergeStatMetrics merges the aggregated values of the oMetrics into the metrics with the same ID
+func mergeStatMetrics(metrics, oMetrics map[string]StatMetric) {
+	for metricID, metric := range metrics {
+		if oMetric, has := oMetrics[metricID]; has {
+			metric.Merge(oMetric)
+		}
+	}
+}
+
+// updateWindowMetrics computes the SQMetrics out of the closed buckets and the current one
+func (sq *StatQueue) updateWindowMetrics() (err error) {
+	if sq.closedMetrics == nil {
+		if sq.closedMetrics, err = sq.newMetrics(); err != nil {
+			return
+		}
+		for _, bktMetrics := range sq.SQBucketMetrics {
+			mergeStatMetrics(sq.closedMetrics, bktMetrics)
+		}
+	}
+	var winMetrics map[string]StatMetric
+	if winMetrics, err = sq.newMetrics(); err != nil {
+		return
+	}
+	mergeStatMetrics(winMetrics, sq.closedMetrics)
+	mergeStatMetrics(winMetrics, sq.BucketMetrics)
+	sq.SQMetrics = winMetrics
+	return
+}
+
+// Buckets returns the buckets within the window of sqPrfl ending at now, current one included
+func (sq *StatQueue) Buckets(sqPrfl *StatQueueProfile, now time.Time) (bkts []*StatBucket) {
+	bkts = make([]*StatBucket, 0)
+	if sqPrfl.BucketInterval <= 0 {
+		return
+	}
+	winStart := now.Truncate(sqPrfl.BucketInterval).Add(
+		sqPrfl.BucketInterval - sqPrfl.bucketWindow())
+	for _, bkt := range sq.SQBuckets {
+		if !bkt.StartTime.Before(winStart) {
+			bkts = append(bkts, bkt)
+		}
+	}
+	if sq.BucketStart != nil && !sq.BucketStart.Before(winStart) {
+		bkts = append(bkts, sq.currentBucket())
+	}
+	return
+}
+
+// currentBucket snapshots the values of the bucket currently populated
+func (sq *StatQueue) currentBucket() (bkt *StatBucket) {
+	bkt = &StatBucket{
+		StartTime: *sq.BucketStart,
+		Metrics:   make(map[string]float64, len(sq.BucketMetrics)),
+	}
+	for metricID, metric := range sq.BucketMetrics {
+		bkt.Metrics[metricID] = metric.GetFloat64Value()
+	}
+	return
+}
+
 // addStatEvent computes metrics for an event
 func (sq *StatQueue) addStatEvent(ev *utils.CGREvent, filterS *FilterS) (err error) {
+	if sq.sqPrfl != nil && sq.sqPrfl.BucketInterval > 0 &&
+		sq.BucketMetrics != nil {
+		return sq.addBucketEvent(ev, filterS)
+	}
 	var expTime *time.Time
 	if sq.ttl != nil {
 		expTime = utils.TimePointer(time.Now().Add(*sq.ttl))
@@ -260,6 +459,28 @@ func (sq *StatQueue) addStatEvent(ev *utils.CGREvent, filterS *FilterS) (err err
 	return
 }
 
+// addBucketEvent computes the metrics of the current bucket for an event
+// the events are not queued, they leave the window together with their bucket
+func (sq *StatQueue) addBucketEvent(ev *utils.CGREvent, filterS *FilterS) (err error) {
+	var pass bool
+	evNm := config.NewNavigableMap(nil)
+	evNm.Set([]string{utils.MetaReq}, ev.Event, false, false)
+	for metricID, metric := range sq.BucketMetrics {
+		if pass, err = filterS.Pass(ev.Tenant, metric.GetFilterIDs(),
+			evNm); err != nil {
+			return
+		} else if !pass {
+			continue
+		}
+		if err = metric.AddEvent(ev); err != nil {
+			utils.Logger.Warning(fmt.Sprintf("<StatQueue> metricID: %s, add eventID: %s to bucket, error: %s",
+				metricID, ev.ID, err.Error()))
+			return
+		}
+	}
+	return sq.updateWindowMetrics()
+}
+
 func (sq *StatQueue) Compress(maxQL int64) bool {
 	if int64(len(sq.SQItems)) < maxQL || maxQL == 0 {
 		return false
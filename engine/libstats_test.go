@@ -692,3 +692,129 @@ func TestStatRemoveExpiredQueue(t *testing.T) {
 		t.Errorf("Expecting: 2, received: %+v", len(sq.SQItems))
 	}
 }
+
+func TestStatRotateBuckets(t *testing.T) {
+	sqPrfl := &StatQueueProfile{
+		BucketInterval: time.Minute,
+		BucketCount:    3,
+		Metrics:        []*MetricWithFilters{{MetricID: utils.MetaASR}},
+	}
+	asr, _ := NewASR(0, utils.EmptyString, nil)
+	sq = &StatQueue{
+		SQMetrics: map[string]StatMetric{utils.MetaASR: asr},
+		sqPrfl:    sqPrfl,
+	}
+	t0 := time.Date(2020, 1, 1, 10, 0, 30, 0, time.UTC)
+	if err := sq.rotateBuckets(t0); err != nil {
+		t.Fatal(err)
+	} else if eStart := time.Date(2020, 1, 1, 10, 0, 0, 0, time.UTC); sq.BucketStart == nil ||
+		!sq.BucketStart.Equal(eStart) {
+		t.Errorf("Expecting: %v, received: %v", eStart, sq.BucketStart)
+	}
+	sq.addStatEvent(&utils.CGREvent{Tenant: "cgrates.org", ID: "ev1",
+		Event: map[string]interface{}{utils.AnswerTime: t0}}, nil)
+	if err := sq.rotateBuckets(t0.Add(time.Minute)); err != nil {
+		t.Fatal(err)
+	}
+	sq.addStatEvent(&utils.CGREvent{Tenant: "cgrates.org", ID: "ev2"}, nil)
+	// the events are kept only by the current bucket
+	if len(sq.SQItems) != 0 {
+		t.Errorf("Expecting no queued events, received: %s", utils.ToJSON(sq.SQItems))
+	}
+	if len(sq.SQBucketMetrics) != 1 {
+		t.Fatalf("Expecting one closed bucket, received: %s", utils.ToJSON(sq.SQBucketMetrics))
+	} else if closedASR := sq.SQBucketMetrics[0][utils.MetaASR].(*StatASR); len(closedASR.Events) != 0 ||
+		closedASR.Answered != 1 || closedASR.Count != 1 {
+		t.Errorf("Expecting only the aggregated values, received: %s", utils.ToJSON(closedASR))
+	}
+	if winASR := sq.SQMetrics[utils.MetaASR].(*StatASR); len(winASR.Events) != 0 ||
+		winASR.Answered != 1 || winASR.Count != 2 {
+		t.Errorf("Expecting only the aggregated values, received: %s", utils.ToJSON(winASR))
+	}
+	if bktASR := sq.BucketMetrics[utils.MetaASR].(*StatASR); len(bktASR.Events) != 1 {
+		t.Errorf("Expecting the events of the current bucket, received: %s", utils.ToJSON(bktASR))
+	}
+	eBkts := []*StatBucket{
+		{StartTime: time.Date(2020, 1, 1, 10, 0, 0, 0, time.UTC),
+			Metrics: map[string]float64{utils.MetaASR: 100}},
+		{StartTime: time.Date(2020, 1, 1, 10, 1, 0, 0, time.UTC),
+			Metrics: map[string]float64{utils.MetaASR: 0}},
+	}
+	if rcv := sq.Buckets(sqPrfl, t0.Add(75*time.Second)); !reflect.DeepEqual(eBkts, rcv) {
+		t.Errorf("Expecting: %s, received: %s", utils.ToJSON(eBkts), utils.ToJSON(rcv))
+	}
+	if asr := sq.SQMetrics[utils.MetaASR].GetFloat64Value(); asr != 50 {
+		t.Errorf("received ASR: %v", asr)
+	}
+	// jumping over one bucket drops the oldest one out of the window
+	if err := sq.rotateBuckets(t0.Add(3 * time.Minute)); err != nil {
+		t.Fatal(err)
+	}
+	if len(sq.SQBuckets) != 1 || !reflect.DeepEqual(eBkts[1], sq.SQBuckets[0]) {
+		t.Errorf("Expecting: %s, received: %s", utils.ToJSON(eBkts[1:]), utils.ToJSON(sq.SQBuckets))
+	}
+	if len(sq.SQBucketMetrics) != 1 {
+		t.Errorf("Expecting one closed bucket, received: %s", utils.ToJSON(sq.SQBucketMetrics))
+	}
+	if asr := sq.SQMetrics[utils.MetaASR].GetFloat64Value(); asr != 0 {
+		t.Errorf("received ASR: %v", asr)
+	}
+	eBkts = []*StatBucket{
+		{StartTime: time.Date(2020, 1, 1, 10, 3, 0, 0, time.UTC),
+			Metrics: map[string]float64{utils.MetaASR: -1}},
+	}
+	if rcv := sq.Buckets(sqPrfl, t0.Add(5*time.Minute)); !reflect.DeepEqual(eBkts, rcv) {
+		t.Errorf("Expecting: %s, received: %s", utils.ToJSON(eBkts), utils.ToJSON(rcv))
+	}
+	if rcv := sq.Buckets(&StatQueueProfile{}, t0); len(rcv) != 0 {
+		t.Errorf("Expecting no buckets, received: %s", utils.ToJSON(rcv))
+	}
+}
+
+func TestStatStoredBuckets(t *testing.T) {
+	bktStart := time.Date(2020, 1, 1, 10, 1, 0, 0, time.UTC)
+	asr, _ := NewASR(0, utils.EmptyString, nil)
+	bktASR, _ := NewASR(0, utils.EmptyString, nil)
+	ev := &utils.CGREvent{Tenant: "cgrates.org", ID: "ev1",
+		Event: map[string]interface{}{utils.AnswerTime: bktStart}}
+	asr.AddEvent(ev)
+	bktASR.AddEvent(ev)
+	closedASR := &StatASR{Answered: 1, Count: 2, Events: make(map[string]*StatWithCompress)}
+	sq := &StatQueue{
+		Tenant:    "cgrates.org",
+		ID:        "TestStatStoredBuckets",
+		SQMetrics: map[string]StatMetric{utils.MetaASR: asr},
+		SQBuckets: []*StatBucket{{
+			StartTime: bktStart.Add(-time.Minute),
+			Metrics:   map[string]float64{utils.MetaASR: 50},
+		}},
+		SQBucketMetrics: []map[string]StatMetric{{utils.MetaASR: closedASR}},
+		BucketStart:     &bktStart,
+		BucketMetrics:   map[string]StatMetric{utils.MetaASR: bktASR},
+	}
+	ms := NewCodecMsgpackMarshaler()
+	ssq, err := NewStoredStatQueue(sq, ms)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rcv, err := ssq.AsStatQueue(ms)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(sq.SQBuckets, rcv.SQBuckets) {
+		t.Errorf("Expecting: %s, received: %s", utils.ToJSON(sq.SQBuckets), utils.ToJSON(rcv.SQBuckets))
+	}
+	if rcv.BucketStart == nil || !rcv.BucketStart.Equal(bktStart) {
+		t.Errorf("Expecting: %v, received: %v", bktStart, rcv.BucketStart)
+	}
+	if bktMetric, has := rcv.BucketMetrics[utils.MetaASR]; !has {
+		t.Errorf("missing bucket metric: %s", utils.ToJSON(rcv.BucketMetrics))
+	} else if val := bktMetric.GetFloat64Value(); val != 100 {
+		t.Errorf("Expecting: 100, received: %v", val)
+	}
+	if len(rcv.SQBucketMetrics) != 1 {
+		t.Errorf("missing closed bucket metrics: %s", utils.ToJSON(rcv.SQBucketMetrics))
+	} else if val := rcv.SQBucketMetrics[0][utils.MetaASR].GetFloat64Value(); val != 50 {
+		t.Errorf("Expecting: 50, received: %v", val)
+	}
+}
@@ -81,6 +81,22 @@ func (sSpls *SortedSuppliers) SortLeastCost() {
 	})
 }
 
+// SortLeastCostQOS is part of sort interface,
+// sort ascendent based on Cost with the suppliers having QOSViolations at the end and fallback on Weight
+func (sSpls *SortedSuppliers) SortLeastCostQOS() {
+	sort.Slice(sSpls.SortedSuppliers, func(i, j int) bool {
+		_, iViolates := sSpls.SortedSuppliers[i].SortingData[utils.QOSViolations]
+		_, jViolates := sSpls.SortedSuppliers[j].SortingData[utils.QOSViolations]
+		if iViolates != jViolates {
+			return jViolates
+		}
+		if sSpls.SortedSuppliers[i].SortingData[utils.Cost].(float64) == sSpls.SortedSuppliers[j].SortingData[utils.Cost].(float64) {
+			return sSpls.SortedSuppliers[i].SortingData[utils.Weight].(float64) > sSpls.SortedSuppliers[j].SortingData[utils.Weight].(float64)
+		}
+		return sSpls.SortedSuppliers[i].SortingData[utils.Cost].(float64) < sSpls.SortedSuppliers[j].SortingData[utils.Cost].(float64)
+	})
+}
+
 // SortHighestCost is part of sort interface,
 // sort descendent based on Cost with fallback on Weight
 func (sSpls *SortedSuppliers) SortHighestCost() {
@@ -156,6 +172,23 @@ func (sSpls *SortedSuppliers) SortLoadDistribution() {
 	})
 }
 
+// SortQuota is part of sort interface,
+// sort ascendent based on the Progress of reaching the commitment with fallback on Weight
+// the suppliers without commitment are sorted last
+func (sSpls *SortedSuppliers) SortQuota() {
+	sort.Slice(sSpls.SortedSuppliers, func(i, j int) bool {
+		iProgress, iHas := sSpls.SortedSuppliers[i].SortingData[utils.Progress]
+		jProgress, jHas := sSpls.SortedSuppliers[j].SortingData[utils.Progress]
+		if iHas != jHas {
+			return iHas
+		}
+		if !iHas || iProgress.(float64) == jProgress.(float64) {
+			return sSpls.SortedSuppliers[i].SortingData[utils.Weight].(float64) > sSpls.SortedSuppliers[j].SortingData[utils.Weight].(float64)
+		}
+		return iProgress.(float64) < jProgress.(float64)
+	})
+}
+
 // Digest returns list of supplierIDs + parameters for easier outside access
 // format suppl1:suppl1params,suppl2:suppl2params
 func (sSpls *SortedSuppliers) Digest() string {
@@ -200,6 +233,8 @@ func NewSupplierSortDispatcher(lcrS *SupplierService) (ssd SupplierSortDispatche
 	ssd[utils.MetaReas] = NewResourceAscendetSorter(lcrS)
 	ssd[utils.MetaReds] = NewResourceDescendentSorter(lcrS)
 	ssd[utils.MetaLoad] = NewLoadDistributionSorter(lcrS)
+	ssd[utils.MetaLCQOS] = NewLeastCostQOSSorter(lcrS)
+	ssd[utils.MetaQuota] = NewQuotaSorter(lcrS)
 	return
 }
 
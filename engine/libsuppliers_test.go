@@ -671,3 +671,94 @@ func TestLibSuppliersSortLoadDistribution(t *testing.T) {
 			eIds, rcv)
 	}
 }
+
+func TestLibSuppliersSortLeastCostQOS(t *testing.T) {
+	sSpls := &SortedSuppliers{
+		SortedSuppliers: []*SortedSupplier{
+			&SortedSupplier{
+				SupplierID: "supplier1",
+				SortingData: map[string]interface{}{
+					utils.Cost:          0.01,
+					utils.Weight:        10.0,
+					utils.QOSViolations: []string{"*asr:20<50"},
+				},
+			},
+			&SortedSupplier{
+				SupplierID: "supplier2",
+				SortingData: map[string]interface{}{
+					utils.Cost:   0.1,
+					utils.Weight: 10.0,
+				},
+			},
+			&SortedSupplier{
+				SupplierID: "supplier3",
+				SortingData: map[string]interface{}{
+					utils.Cost:   0.05,
+					utils.Weight: 10.0,
+				},
+			},
+			&SortedSupplier{
+				SupplierID: "supplier4",
+				SortingData: map[string]interface{}{
+					utils.Cost:          0.01,
+					utils.Weight:        20.0,
+					utils.QOSViolations: []string{"*pdd:5>3"},
+				},
+			},
+		},
+	}
+	sSpls.SortLeastCostQOS()
+	rcv := make([]string, len(sSpls.SortedSuppliers))
+	eIds := []string{"supplier3", "supplier2", "supplier4", "supplier1"}
+	for i, spl := range sSpls.SortedSuppliers {
+		rcv[i] = spl.SupplierID
+	}
+	if !reflect.DeepEqual(eIds, rcv) {
+		t.Errorf("Expecting: %+v, \n received: %+v",
+			eIds, rcv)
+	}
+}
+
+func TestLibSuppliersSortQuota(t *testing.T) {
+	sSpls := &SortedSuppliers{
+		SortedSuppliers: []*SortedSupplier{
+			&SortedSupplier{
+				SupplierID: "supplier1",
+				SortingData: map[string]interface{}{
+					utils.Weight: 40.0,
+				},
+			},
+			&SortedSupplier{
+				SupplierID: "supplier2",
+				SortingData: map[string]interface{}{
+					utils.Weight:   10.0,
+					utils.Progress: 90.0,
+				},
+			},
+			&SortedSupplier{
+				SupplierID: "supplier3",
+				SortingData: map[string]interface{}{
+					utils.Weight:   10.0,
+					utils.Progress: 50.0,
+				},
+			},
+			&SortedSupplier{
+				SupplierID: "supplier4",
+				SortingData: map[string]interface{}{
+					utils.Weight:   20.0,
+					utils.Progress: 90.0,
+				},
+			},
+		},
+	}
+	sSpls.SortQuota()
+	rcv := make([]string, len(sSpls.SortedSuppliers))
+	eIds := []string{"supplier3", "supplier4", "supplier2", "supplier1"}
+	for i, spl := range sSpls.SortedSuppliers {
+		rcv[i] = spl.SupplierID
+	}
+	if !reflect.DeepEqual(eIds, rcv) {
+		t.Errorf("Expecting: %+v, \n received: %+v",
+			eIds, rcv)
+	}
+}
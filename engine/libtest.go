@@ -228,21 +228,21 @@ cgrates.org,vf,,,false,false
 cgrates.org,round,TOPUP10_AT,,false,false
 `
 	ResourcesCSVContent = `
-#Tenant[0],Id[1],FilterIDs[2],ActivationInterval[3],TTL[4],Limit[5],AllocationMessage[6],Blocker[7],Stored[8],Weight[9],Thresholds[10]
+#Tenant[0],Id[1],FilterIDs[2],ActivationInterval[3],TTL[4],Limit[5],AllocationMessage[6],Blocker[7],Stored[8],Weight[9],Thresholds[10],RateInterval[11]
 cgrates.org,ResGroup21,*string:~*req.Account:1001,2014-07-29T15:00:00Z,1s,2,call,true,true,10,
-cgrates.org,ResGroup22,*string:~*req.Account:dan,2014-07-29T15:00:00Z,3600s,2,premium_call,true,true,10,
+cgrates.org,ResGroup22,*string:~*req.Account:dan,2014-07-29T15:00:00Z,3600s,2,premium_call,true,true,10,,1m
 `
 	StatsCSVContent = `
-#Tenant[0],Id[1],FilterIDs[2],ActivationInterval[3],QueueLength[4],TTL[5],MinItems[6],Metrics[7],MetricFilterIDs[8],Stored[9],Blocker[10],Weight[11],ThresholdIDs[12]
+#Tenant[0],Id[1],FilterIDs[2],ActivationInterval[3],QueueLength[4],TTL[5],MinItems[6],Metrics[7],MetricFilterIDs[8],Stored[9],Blocker[10],Weight[11],ThresholdIDs[12],BucketInterval[13],BucketCount[14]
 cgrates.org,TestStats,*string:~*req.Account:1001,2014-07-29T15:00:00Z,100,1s,2,*sum:~*req.Value;*average:~*req.Value,,true,true,20,Th1;Th2
 cgrates.org,TestStats,,,,,2,*sum:~*req.Usage,,true,true,20,
 cgrates.org,TestStats2,FLTR_1,2014-07-29T15:00:00Z,100,1s,2,*sum:~*req.Value;*sum:~*req.Usage;*average:~*req.Value;*average:~*req.Usage,,true,true,20,Th
-cgrates.org,TestStats2,,,,,2,*sum:~*req.Cost;*average:~*req.Cost,,true,true,20,
+cgrates.org,TestStats2,,,,,2,*sum:~*req.Cost;*average:~*req.Cost,,true,true,20,,1m,60
 `
 
 	ThresholdsCSVContent = `
-#Tenant[0],Id[1],FilterIDs[2],ActivationInterval[3],MaxHits[4],MinHits[5],MinSleep[6],Blocker[7],Weight[8],ActionIDs[9],Async[10]
-cgrates.org,Threshold1,*string:~*req.Account:1001;*string:~*req.RunID:*default,2014-07-29T15:00:00Z,12,10,1s,true,10,THRESH1,true
+#Tenant[0],Id[1],FilterIDs[2],ActivationInterval[3],MaxHits[4],MinHits[5],MinSleep[6],Blocker[7],Weight[8],ActionIDs[9],Async[10],WarningFilterIDs[11],CriticalFilterIDs[12],EscalationActionIDs[13],RecoveryActionIDs[14]
+cgrates.org,Threshold1,*string:~*req.Account:1001;*string:~*req.RunID:*default,2014-07-29T15:00:00Z,12,10,1s,true,10,THRESH1,true,*gte:~*req.Usage:1m,*gte:~*req.Usage:1h,ACT_ESCALATE,ACT_RECOVER
 `
 
 	FiltersCSVContent = `
@@ -279,6 +279,19 @@ cgrates.org,D1,,,,*first,,C2,*lt:~*req.Usage:10,10,false,192.168.56.204,
 #Tenant[0],ID[1],Address[2],Transport[3],TLS[4]
 cgrates.org,ALL1,127.0.0.1:2012,*json,true
 cgrates.org,ALL1,127.0.0.1:3012,*json,false
+`
+	CalendarsCSVContent = `
+#ID,Date
+HOLIDAYS_RO,01-01
+HOLIDAYS_RO,12-25
+HOLIDAYS_RO,2020-04-19
+WORKING_SATURDAYS,2020-05-16
+`
+	ExchangeRatesCSVContent = `
+#FromCurrency,ToCurrency,ActivationTime,Rate
+EUR,USD,2014-01-01T00:00:00Z,1.2
+EUR,USD,2020-01-01T00:00:00Z,1.1
+GBP,EUR,2014-01-01T00:00:00Z,1.15
 `
 )
 
@@ -287,7 +300,9 @@ func InitDataDb(cfg *config.CGRConfig) error {
 		cfg.DataDbCfg().DataDbHost, cfg.DataDbCfg().DataDbPort,
 		cfg.DataDbCfg().DataDbName, cfg.DataDbCfg().DataDbUser,
 		cfg.DataDbCfg().DataDbPass, cfg.GeneralCfg().DBDataEncoding,
-		cfg.DataDbCfg().DataDbSentinelName, cfg.DataDbCfg().Items)
+		cfg.DataDbCfg().DataDbSentinelName, cfg.DataDbCfg().RedisCluster,
+		cfg.DataDbCfg().RedisClusterSync, cfg.DataDbCfg().Items,
+		cfg.DataDbCfg().InternalPersistence)
 	if err != nil {
 		return err
 	}
@@ -310,7 +325,8 @@ func InitStorDb(cfg *config.CGRConfig) error {
 		cfg.StorDbCfg().Password, cfg.GeneralCfg().DBDataEncoding, cfg.StorDbCfg().SSLMode,
 		cfg.StorDbCfg().MaxOpenConns, cfg.StorDbCfg().MaxIdleConns,
 		cfg.StorDbCfg().ConnMaxLifetime, cfg.StorDbCfg().StringIndexedFields,
-		cfg.StorDbCfg().PrefixIndexedFields, cfg.StorDbCfg().Items)
+		cfg.StorDbCfg().PrefixIndexedFields, cfg.StorDbCfg().Items,
+		cfg.StorDbCfg().InternalPersistence)
 	if err != nil {
 		return err
 	}
@@ -585,6 +601,10 @@ func GetDefaultEmptyCacheStats() map[string]*ltcache.CacheStats {
 			Items:  0,
 			Groups: 0,
 		},
+		utils.CacheSupplierCosts: {
+			Items:  0,
+			Groups: 0,
+		},
 		utils.CacheSupplierFilterIndexes: {
 			Items:  0,
 			Groups: 0,
@@ -609,6 +629,10 @@ func GetDefaultEmptyCacheStats() map[string]*ltcache.CacheStats {
 			Items:  0,
 			Groups: 0,
 		},
+		utils.CacheExchangeRates: {
+			Items:  0,
+			Groups: 0,
+		},
 		utils.CacheDiameterMessages: {
 			Items:  0,
 			Groups: 0,
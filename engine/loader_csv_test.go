@@ -42,7 +42,7 @@ func init() {
 		ActionsCSVContent, ActionPlansCSVContent, ActionTriggersCSVContent, AccountActionsCSVContent,
 		ResourcesCSVContent, StatsCSVContent, ThresholdsCSVContent, FiltersCSVContent,
 		SuppliersCSVContent, AttributesCSVContent, ChargersCSVContent, DispatcherCSVContent,
-		DispatcherHostCSVContent), testTPID, "", nil, nil)
+		DispatcherHostCSVContent, CalendarsCSVContent, ExchangeRatesCSVContent), testTPID, "", nil, nil)
 	if err != nil {
 		log.Print("error when creating TpReader:", err)
 	}
@@ -52,6 +52,12 @@ func init() {
 	if err := csvr.LoadTimings(); err != nil {
 		log.Print("error in LoadTimings:", err)
 	}
+	if err := csvr.LoadCalendars(); err != nil {
+		log.Print("error in LoadCalendars:", err)
+	}
+	if err := csvr.LoadExchangeRates(); err != nil {
+		log.Print("error in LoadExchangeRates:", err)
+	}
 	if err := csvr.LoadRates(); err != nil {
 		log.Print("error in LoadRates:", err)
 	}
@@ -232,6 +238,112 @@ func TestLoadTimimgs(t *testing.T) {
 	}
 }
 
+func TestLoadCalendars(t *testing.T) {
+	eCals := map[string]utils.StringMap{
+		"HOLIDAYS_RO": utils.StringMap{
+			"01-01":      true,
+			"12-25":      true,
+			"2020-04-19": true,
+		},
+		"WORKING_SATURDAYS": utils.StringMap{
+			"2020-05-16": true,
+		},
+	}
+	if !reflect.DeepEqual(eCals, csvr.calendars) {
+		t.Errorf("Expecting: %s, received: %s", utils.ToJSON(eCals), utils.ToJSON(csvr.calendars))
+	}
+}
+
+func TestLoadExchangeRates(t *testing.T) {
+	eExrs := map[string]*ExchangeRates{
+		"EUR:USD": &ExchangeRates{
+			FromCurrency: "EUR",
+			ToCurrency:   "USD",
+			Rates: []*ExchangeRate{
+				{ActivationTime: time.Date(2014, 1, 1, 0, 0, 0, 0, time.UTC), Rate: 1.2},
+				{ActivationTime: time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC), Rate: 1.1},
+			},
+		},
+		"GBP:EUR": &ExchangeRates{
+			FromCurrency: "GBP",
+			ToCurrency:   "EUR",
+			Rates: []*ExchangeRate{
+				{ActivationTime: time.Date(2014, 1, 1, 0, 0, 0, 0, time.UTC), Rate: 1.15},
+			},
+		},
+	}
+	if !reflect.DeepEqual(eExrs, csvr.exchangeRates) {
+		t.Errorf("Expecting: %s, received: %s", utils.ToJSON(eExrs), utils.ToJSON(csvr.exchangeRates))
+	}
+}
+
+func TestLoadTimingCalendars(t *testing.T) {
+	incl, excl, err := csvr.timingCalendars(&utils.TPTiming{
+		ID:        "WORKDAYS_HOLIDAYS",
+		Calendars: utils.StringMap{"WORKING_SATURDAYS": true, "HOLIDAYS_RO": false},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if eIncl := map[string]utils.StringMap{
+		"WORKING_SATURDAYS": utils.StringMap{"2020-05-16": true},
+	}; !reflect.DeepEqual(eIncl, incl) {
+		t.Errorf("Expecting: %+v, received: %+v", eIncl, incl)
+	}
+	if eExcl := map[string]utils.StringMap{
+		"HOLIDAYS_RO": utils.StringMap{"01-01": true, "12-25": true, "2020-04-19": true},
+	}; !reflect.DeepEqual(eExcl, excl) {
+		t.Errorf("Expecting: %+v, received: %+v", eExcl, excl)
+	}
+	if _, _, err = csvr.timingCalendars(&utils.TPTiming{
+		ID:        "WORKDAYS_HOLIDAYS",
+		Calendars: utils.StringMap{"UNKNOWN": true},
+	}); err == nil {
+		t.Error("Expecting error for unknown calendar")
+	}
+}
+
+func TestLoadCalendarsRatingPlans(t *testing.T) {
+	rp := &RatingPlan{Id: "RP_CALENDARS"}
+	rp.AddRateInterval(utils.ANY, &RateInterval{
+		Timing: &RITiming{
+			WeekDays:  utils.WeekDays{time.Saturday, time.Sunday},
+			StartTime: "00:00:00",
+			ExcludeCalendars: map[string]utils.StringMap{
+				"HOLIDAYS_RO": utils.StringMap{"01-01": true},
+			},
+		},
+		Weight: 10,
+	})
+	tmID := rp.DestinationRates[utils.ANY][0].Timing
+	if err := dm.SetRatingPlan(rp, utils.NonTransactional); err != nil {
+		t.Fatal(err)
+	}
+	defer dm.RemoveRatingPlan(rp.Id, utils.NonTransactional)
+	tpr, err := NewTpReader(dm.dataDB, NewStringCSVStorage(utils.CSV_SEP,
+		"", "", "", "", "", "", "", "", "", "", "", "", "", "", "", "", "", "", "", "",
+		CalendarsCSVContent, ""), testTPID, "", nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = tpr.LoadAll(); err != nil {
+		t.Fatal(err)
+	}
+	eCals := map[string]utils.StringMap{
+		"HOLIDAYS_RO": utils.StringMap{"01-01": true, "12-25": true, "2020-04-19": true},
+	}
+	if rcv, has := tpr.ratingPlans[rp.Id]; !has {
+		t.Errorf("RatingPlan not loaded: %s", utils.ToJSON(tpr.ratingPlans))
+	} else if !reflect.DeepEqual(eCals, rcv.Timings[tmID].ExcludeCalendars) {
+		t.Errorf("Expecting: %s, received: %s", utils.ToJSON(eCals), utils.ToJSON(rcv.Timings))
+	}
+	// stored one is updated only when writing to database
+	if !reflect.DeepEqual(map[string]utils.StringMap{"HOLIDAYS_RO": utils.StringMap{"01-01": true}},
+		rp.Timings[tmID].ExcludeCalendars) {
+		t.Errorf("Stored RatingPlan modified: %s", utils.ToJSON(rp.Timings))
+	}
+}
+
 func TestLoadRates(t *testing.T) {
 	if len(csvr.rates) != 15 {
 		t.Error("Failed to load rates: ", len(csvr.rates))
@@ -988,6 +1100,7 @@ func TestLoadResourceProfiles(t *testing.T) {
 			Stored:            true,
 			Weight:            10,
 			Limit:             "2",
+			RateInterval:      "1m",
 		},
 	}
 	resKey := utils.TenantID{Tenant: "cgrates.org", ID: "ResGroup21"}
@@ -996,6 +1109,10 @@ func TestLoadResourceProfiles(t *testing.T) {
 	} else if !reflect.DeepEqual(eResProfiles[resKey], csvr.resProfiles[resKey]) {
 		t.Errorf("Expecting: %+v, received: %+v", eResProfiles[resKey], csvr.resProfiles[resKey])
 	}
+	resKey = utils.TenantID{Tenant: "cgrates.org", ID: "ResGroup22"}
+	if !reflect.DeepEqual(eResProfiles[resKey], csvr.resProfiles[resKey]) {
+		t.Errorf("Expecting: %+v, received: %+v", eResProfiles[resKey], csvr.resProfiles[resKey])
+	}
 }
 
 func TestLoadStatQueueProfiles(t *testing.T) {
@@ -1059,11 +1176,13 @@ func TestLoadStatQueueProfiles(t *testing.T) {
 					MetricID:  "*average#Cost",
 				},
 			},
-			ThresholdIDs: []string{"Th"},
-			Blocker:      true,
-			Stored:       true,
-			Weight:       20,
-			MinItems:     2,
+			ThresholdIDs:   []string{"Th"},
+			Blocker:        true,
+			Stored:         true,
+			Weight:         20,
+			MinItems:       2,
+			BucketInterval: "1m",
+			BucketCount:    60,
 		},
 	}
 	stKeys := []utils.TenantID{
@@ -1092,6 +1211,11 @@ func TestLoadStatQueueProfiles(t *testing.T) {
 			t.Errorf("Expecting: %s, \n received: %s",
 				utils.ToJSON(eStats[stKey].Metrics),
 				utils.ToJSON(csvr.sqProfiles[stKey].Metrics))
+		} else if eStats[stKey].BucketInterval != csvr.sqProfiles[stKey].BucketInterval ||
+			eStats[stKey].BucketCount != csvr.sqProfiles[stKey].BucketCount {
+			t.Errorf("Expecting buckets: %s/%d, received: %s/%d",
+				eStats[stKey].BucketInterval, eStats[stKey].BucketCount,
+				csvr.sqProfiles[stKey].BucketInterval, csvr.sqProfiles[stKey].BucketCount)
 		}
 	}
 }
@@ -1106,13 +1230,17 @@ func TestLoadThresholdProfiles(t *testing.T) {
 			ActivationInterval: &utils.TPActivationInterval{
 				ActivationTime: "2014-07-29T15:00:00Z",
 			},
-			MaxHits:   12,
-			MinHits:   10,
-			MinSleep:  "1s",
-			Blocker:   true,
-			Weight:    10,
-			ActionIDs: []string{"THRESH1"},
-			Async:     true,
+			MaxHits:             12,
+			MinHits:             10,
+			MinSleep:            "1s",
+			Blocker:             true,
+			Weight:              10,
+			ActionIDs:           []string{"THRESH1"},
+			Async:               true,
+			WarningFilterIDs:    []string{"*gte:~*req.Usage:1m"},
+			CriticalFilterIDs:   []string{"*gte:~*req.Usage:1h"},
+			EscalationActionIDs: []string{"ACT_ESCALATE"},
+			RecoveryActionIDs:   []string{"ACT_RECOVER"},
 		},
 	}
 	eThresholdReverse := map[utils.TenantID]*utils.TPThresholdProfile{
@@ -1124,13 +1252,17 @@ func TestLoadThresholdProfiles(t *testing.T) {
 			ActivationInterval: &utils.TPActivationInterval{
 				ActivationTime: "2014-07-29T15:00:00Z",
 			},
-			MaxHits:   12,
-			MinHits:   10,
-			MinSleep:  "1s",
-			Blocker:   true,
-			Weight:    10,
-			ActionIDs: []string{"THRESH1"},
-			Async:     true,
+			MaxHits:             12,
+			MinHits:             10,
+			MinSleep:            "1s",
+			Blocker:             true,
+			Weight:              10,
+			ActionIDs:           []string{"THRESH1"},
+			Async:               true,
+			WarningFilterIDs:    []string{"*gte:~*req.Usage:1m"},
+			CriticalFilterIDs:   []string{"*gte:~*req.Usage:1h"},
+			EscalationActionIDs: []string{"ACT_ESCALATE"},
+			RecoveryActionIDs:   []string{"ACT_RECOVER"},
 		},
 	}
 	thkey := utils.TenantID{Tenant: "cgrates.org", ID: "Threshold1"}
@@ -87,7 +87,9 @@ func testLoaderITInitDataDB(t *testing.T) {
 	dbConn, err := NewDataDBConn(lCfg.DataDbCfg().DataDbType,
 		lCfg.DataDbCfg().DataDbHost, lCfg.DataDbCfg().DataDbPort, lCfg.DataDbCfg().DataDbName,
 		lCfg.DataDbCfg().DataDbUser, lCfg.DataDbCfg().DataDbPass, lCfg.GeneralCfg().DBDataEncoding,
-		lCfg.DataDbCfg().DataDbSentinelName, lCfg.DataDbCfg().Items)
+		lCfg.DataDbCfg().DataDbSentinelName, lCfg.DataDbCfg().RedisCluster,
+		lCfg.DataDbCfg().RedisClusterSync, lCfg.DataDbCfg().Items,
+		lCfg.DataDbCfg().InternalPersistence)
 	if err != nil {
 		t.Fatal("Error on dataDb connection: ", err.Error())
 	}
@@ -105,7 +107,8 @@ func testLoaderITInitStoreDB(t *testing.T) {
 		lCfg.StorDbCfg().User, lCfg.StorDbCfg().Password, lCfg.GeneralCfg().DBDataEncoding,
 		lCfg.StorDbCfg().SSLMode, lCfg.StorDbCfg().MaxOpenConns, lCfg.StorDbCfg().MaxIdleConns,
 		lCfg.StorDbCfg().ConnMaxLifetime, lCfg.StorDbCfg().StringIndexedFields,
-		lCfg.StorDbCfg().PrefixIndexedFields, lCfg.StorDbCfg().Items)
+		lCfg.StorDbCfg().PrefixIndexedFields, lCfg.StorDbCfg().Items,
+		lCfg.StorDbCfg().InternalPersistence)
 	if err != nil {
 		t.Error("Error on opening database connection: ", err)
 	}
@@ -40,6 +40,10 @@ func csvLoad(s interface{}, values []string) (interface{}, error) {
 		index := field.Tag.Get("index")
 		if index != "" {
 			idx, err := strconv.Atoi(index)
+			if err == nil && len(values) <= idx &&
+				field.Tag.Get("optional") == "true" {
+				continue // optional trailing column missing from the record
+			}
 			if err != nil || len(values) <= idx {
 				return nil, fmt.Errorf("invalid %v.%v index %v", st.Name(), field.Name, index)
 			}
@@ -145,6 +149,22 @@ func getColumnCount(s interface{}) int {
 	return count
 }
 
+// getMinColumnCount returns the number of columns a record needs to have,
+// ignoring the optional trailing ones
+func getMinColumnCount(s interface{}) int {
+	st := reflect.TypeOf(s)
+	numFields := st.NumField()
+	count := 0
+	for i := 0; i < numFields; i++ {
+		field := st.Field(i)
+		if field.Tag.Get("index") != "" &&
+			field.Tag.Get("optional") != "true" {
+			count++
+		}
+	}
+	return count
+}
+
 type TpDestinations []TpDestination
 
 func (tps TpDestinations) AsMapDestinations() (map[string]*Destination, error) {
@@ -212,6 +232,7 @@ func (tps TpTimings) AsMapTPTimings() (map[string]*utils.ApierTPTiming, error) {
 			MonthDays: tp.MonthDays,
 			WeekDays:  tp.WeekDays,
 			Time:      tp.Time,
+			Calendars: tp.Calendars,
 		}
 		result[tp.Tag] = t
 	}
@@ -222,6 +243,9 @@ func MapTPTimings(tps []*utils.ApierTPTiming) (map[string]*utils.TPTiming, error
 	result := make(map[string]*utils.TPTiming)
 	for _, tp := range tps {
 		t := utils.NewTiming(tp.ID, tp.Years, tp.Months, tp.MonthDays, tp.WeekDays, tp.Time)
+		if tp.Calendars != "" {
+			t.Calendars = utils.ParseStringMap(tp.Calendars)
+		}
 		if _, found := result[tp.ID]; found {
 			return nil, fmt.Errorf("duplicate timing tag: %s", tp.ID)
 		}
@@ -247,6 +271,7 @@ func APItoModelTiming(t *utils.ApierTPTiming) (result TpTiming) {
 		MonthDays: t.MonthDays,
 		WeekDays:  t.WeekDays,
 		Time:      t.Time,
+		Calendars: t.Calendars,
 	}
 }
 
@@ -260,6 +285,139 @@ func APItoModelTimings(ts []*utils.ApierTPTiming) (result TpTimings) {
 	return result
 }
 
+type TpCalendars []TpCalendar
+
+// AsTPCalendars converts TpCalendars into *utils.TPCalendar
+func (tps TpCalendars) AsTPCalendars() (result []*utils.TPCalendar) {
+	mc := make(map[string]*utils.TPCalendar)
+	for _, tp := range tps {
+		if c, hasIt := mc[tp.Tag]; !hasIt {
+			mc[tp.Tag] = &utils.TPCalendar{TPid: tp.Tpid, ID: tp.Tag, Dates: []string{tp.Date}}
+		} else {
+			c.Dates = append(c.Dates, tp.Date)
+		}
+	}
+	result = make([]*utils.TPCalendar, len(mc))
+	i := 0
+	for _, c := range mc {
+		result[i] = c
+		i++
+	}
+	return
+}
+
+func APItoModelCalendar(c *utils.TPCalendar) (result TpCalendars) {
+	if c != nil {
+		for _, dt := range c.Dates {
+			result = append(result, TpCalendar{
+				Tpid: c.TPid,
+				Tag:  c.ID,
+				Date: dt,
+			})
+		}
+		if len(c.Dates) == 0 {
+			result = append(result, TpCalendar{
+				Tpid: c.TPid,
+				Tag:  c.ID,
+			})
+		}
+	}
+	return
+}
+
+// MapTPCalendars returns the days within each calendar, validating their format
+func MapTPCalendars(tps []*utils.TPCalendar) (map[string]utils.StringMap, error) {
+	result := make(map[string]utils.StringMap)
+	for _, tp := range tps {
+		if _, found := result[tp.ID]; !found {
+			result[tp.ID] = make(utils.StringMap)
+		}
+		for _, dt := range tp.Dates {
+			date, err := parseCalendarDate(dt)
+			if err != nil {
+				return nil, fmt.Errorf("invalid date: %s for calendar: %s", dt, tp.ID)
+			}
+			result[tp.ID][date] = true
+		}
+	}
+	return result, nil
+}
+
+type TpExchangeRates []TpExchangeRate
+
+// AsTPExchangeRates groups the TpExchangeRates on their currencies
+func (tps TpExchangeRates) AsTPExchangeRates() (result []*utils.TPExchangeRates) {
+	mexr := make(map[string]*utils.TPExchangeRates)
+	for _, tp := range tps {
+		id := utils.ConcatenatedKey(tp.FromCurrency, tp.ToCurrency)
+		exr, hasIt := mexr[id]
+		if !hasIt {
+			exr = &utils.TPExchangeRates{
+				TPid:         tp.Tpid,
+				FromCurrency: tp.FromCurrency,
+				ToCurrency:   tp.ToCurrency,
+			}
+			mexr[id] = exr
+			result = append(result, exr)
+		}
+		exr.Rates = append(exr.Rates, &utils.TPExchangeRate{
+			ActivationTime: tp.ActivationTime,
+			Rate:           tp.Rate,
+		})
+	}
+	return
+}
+
+func APItoModelExchangeRates(exr *utils.TPExchangeRates) (result TpExchangeRates) {
+	if exr != nil {
+		for _, r := range exr.Rates {
+			result = append(result, TpExchangeRate{
+				Tpid:           exr.TPid,
+				FromCurrency:   exr.FromCurrency,
+				ToCurrency:     exr.ToCurrency,
+				ActivationTime: r.ActivationTime,
+				Rate:           r.Rate,
+			})
+		}
+		if len(exr.Rates) == 0 {
+			result = append(result, TpExchangeRate{
+				Tpid:         exr.TPid,
+				FromCurrency: exr.FromCurrency,
+				ToCurrency:   exr.ToCurrency,
+			})
+		}
+	}
+	return
+}
+
+// APItoExchangeRates converts the TPExchangeRates into ExchangeRates sorted on their ActivationTime
+func APItoExchangeRates(tpExr *utils.TPExchangeRates, timezone string) (exr *ExchangeRates, err error) {
+	if tpExr.FromCurrency == "" || tpExr.ToCurrency == "" ||
+		tpExr.FromCurrency == tpExr.ToCurrency {
+		return nil, fmt.Errorf("invalid currencies for exchange rates: %s", tpExr.ID())
+	}
+	exr = &ExchangeRates{
+		FromCurrency: tpExr.FromCurrency,
+		ToCurrency:   tpExr.ToCurrency,
+		Rates:        make([]*ExchangeRate, 0, len(tpExr.Rates)),
+	}
+	for _, r := range tpExr.Rates {
+		if r.ActivationTime == "" { // only the currencies were defined
+			continue
+		}
+		if r.Rate <= 0 {
+			return nil, fmt.Errorf("invalid rate: %v for exchange rates: %s", r.Rate, tpExr.ID())
+		}
+		rate := &ExchangeRate{Rate: r.Rate}
+		if rate.ActivationTime, err = utils.ParseTimeDetectLayout(r.ActivationTime, timezone); err != nil {
+			return nil, fmt.Errorf("invalid activation time: %s for exchange rates: %s", r.ActivationTime, tpExr.ID())
+		}
+		exr.Rates = append(exr.Rates, rate)
+	}
+	exr.Sort()
+	return
+}
+
 type TpRates []TpRate
 
 func (tps TpRates) AsMapRates() (map[string]*utils.TPRate, error) {
@@ -438,8 +596,9 @@ func (tps TpRatingPlans) AsMapTPRatingPlans() (map[string]*utils.TPRatingPlan, e
 	result := make(map[string]*utils.TPRatingPlan)
 	for _, tp := range tps {
 		rp := &utils.TPRatingPlan{
-			TPid: tp.Tpid,
-			ID:   tp.Tag,
+			TPid:     tp.Tpid,
+			ID:       tp.Tag,
+			Currency: tp.Currency,
 		}
 		rpb := &utils.TPRatingPlanBinding{
 			DestinationRatesId: tp.DestratesTag,
@@ -450,6 +609,13 @@ func (tps TpRatingPlans) AsMapTPRatingPlans() (map[string]*utils.TPRatingPlan, e
 			rp.RatingPlanBindings = []*utils.TPRatingPlanBinding{rpb}
 			result[rp.ID] = rp
 		} else {
+			if tp.Currency != "" {
+				if existing.Currency != "" && existing.Currency != tp.Currency {
+					return nil, fmt.Errorf("conflicting currencies: %s and %s for rating plan: %s",
+						existing.Currency, tp.Currency, tp.Tag)
+				}
+				existing.Currency = tp.Currency
+			}
 			existing.RatingPlanBindings = append(existing.RatingPlanBindings, rpb)
 		}
 	}
@@ -498,6 +664,17 @@ func GetRateInterval(rpl *utils.TPRatingPlanBinding, dr *utils.DestinationRate)
 	return
 }
 
+// MapTPRatingPlanCurrencies returns the currency of each rating plan
+func MapTPRatingPlanCurrencies(s []*utils.TPRatingPlan) map[string]string {
+	result := make(map[string]string)
+	for _, e := range s {
+		if e.Currency != "" {
+			result[e.ID] = e.Currency
+		}
+	}
+	return result
+}
+
 func MapTPRatingPlanBindings(s []*utils.TPRatingPlan) map[string][]*utils.TPRatingPlanBinding {
 	result := make(map[string][]*utils.TPRatingPlanBinding)
 	for _, e := range s {
@@ -521,12 +698,14 @@ func APItoModelRatingPlan(rp *utils.TPRatingPlan) (result TpRatingPlans) {
 				DestratesTag: rpb.DestinationRatesId,
 				TimingTag:    rpb.TimingId,
 				Weight:       rpb.Weight,
+				Currency:     rp.Currency,
 			})
 		}
 		if len(rp.RatingPlanBindings) == 0 {
 			result = append(result, TpRatingPlan{
-				Tpid: rp.TPid,
-				Tag:  rp.ID,
+				Tpid:     rp.TPid,
+				Tag:      rp.ID,
+				Currency: rp.Currency,
 			})
 		}
 	}
@@ -1076,7 +1255,7 @@ type TpResources []*TpResource
 func (tps TpResources) CSVHeader() (result []string) {
 	return []string{"#" + utils.Tenant, utils.ID, utils.FilterIDs, utils.ActivationIntervalString,
 		utils.UsageTTL, utils.Limit, utils.AllocationMessage, utils.Blocker, utils.Stored,
-		utils.Weight, utils.ThresholdIDs}
+		utils.Weight, utils.ThresholdIDs, utils.RateInterval}
 }
 
 func (tps TpResources) AsTPResources() (result []*utils.TPResourceProfile) {
@@ -1097,6 +1276,9 @@ func (tps TpResources) AsTPResources() (result []*utils.TPResourceProfile) {
 		if tp.UsageTTL != "" {
 			rl.UsageTTL = tp.UsageTTL
 		}
+		if tp.RateInterval != "" {
+			rl.RateInterval = tp.RateInterval
+		}
 		if tp.Weight != 0 {
 			rl.Weight = tp.Weight
 		}
@@ -1175,6 +1357,7 @@ func APItoModelResource(rl *utils.TPResourceProfile) (mdls TpResources) {
 			Weight:            rl.Weight,
 			Limit:             rl.Limit,
 			AllocationMessage: rl.AllocationMessage,
+			RateInterval:      rl.RateInterval,
 		}
 		if rl.ActivationInterval != nil {
 			if rl.ActivationInterval.ActivationTime != "" {
@@ -1205,6 +1388,7 @@ func APItoModelResource(rl *utils.TPResourceProfile) (mdls TpResources) {
 			mdl.Weight = rl.Weight
 			mdl.Limit = rl.Limit
 			mdl.AllocationMessage = rl.AllocationMessage
+			mdl.RateInterval = rl.RateInterval
 			if rl.ActivationInterval != nil {
 				if rl.ActivationInterval.ActivationTime != "" {
 					mdl.ActivationInterval = rl.ActivationInterval.ActivationTime
@@ -1242,6 +1426,11 @@ func APItoResource(tpRL *utils.TPResourceProfile, timezone string) (rp *Resource
 			return nil, err
 		}
 	}
+	if tpRL.RateInterval != "" {
+		if rp.RateInterval, err = utils.ParseDurationWithNanosecs(tpRL.RateInterval); err != nil {
+			return nil, err
+		}
+	}
 	for i, fltr := range tpRL.FilterIDs {
 		rp.FilterIDs[i] = fltr
 	}
@@ -1277,6 +1466,9 @@ func ResourceProfileToAPI(rp *ResourceProfile) (tpRL *utils.TPResourceProfile) {
 	if rp.UsageTTL != time.Duration(0) {
 		tpRL.UsageTTL = rp.UsageTTL.String()
 	}
+	if rp.RateInterval != time.Duration(0) {
+		tpRL.RateInterval = rp.RateInterval.String()
+	}
 	for i, fli := range rp.FilterIDs {
 		tpRL.FilterIDs[i] = fli
 	}
@@ -1343,6 +1535,12 @@ func (models TpStats) AsTPStats() (result []*utils.TPStatProfile) {
 		if model.QueueLength != 0 {
 			st.QueueLength = model.QueueLength
 		}
+		if model.BucketInterval != "" {
+			st.BucketInterval = model.BucketInterval
+		}
+		if model.BucketCount != 0 {
+			st.BucketCount = model.BucketCount
+		}
 		if model.ThresholdIDs != "" {
 			if _, has := thresholdMap[key.TenantID()]; !has {
 				thresholdMap[key.TenantID()] = make(utils.StringMap)
@@ -1445,6 +1643,8 @@ func APItoModelStats(st *utils.TPStatProfile) (mdls TpStats) {
 					}
 					mdl.ThresholdIDs += val
 				}
+				mdl.BucketInterval = st.BucketInterval
+				mdl.BucketCount = st.BucketCount
 			}
 			for i, val := range metric.FilterIDs {
 				if i != 0 {
@@ -1471,12 +1671,18 @@ func APItoStats(tpST *utils.TPStatProfile, timezone string) (st *StatQueueProfil
 		Blocker:      tpST.Blocker,
 		Weight:       tpST.Weight,
 		ThresholdIDs: make([]string, len(tpST.ThresholdIDs)),
+		BucketCount:  tpST.BucketCount,
 	}
 	if tpST.TTL != "" {
 		if st.TTL, err = utils.ParseDurationWithNanosecs(tpST.TTL); err != nil {
 			return nil, err
 		}
 	}
+	if tpST.BucketInterval != "" {
+		if st.BucketInterval, err = utils.ParseDurationWithNanosecs(tpST.BucketInterval); err != nil {
+			return nil, err
+		}
+	}
 	for i, metric := range tpST.Metrics {
 		st.Metrics[i] = &MetricWithFilters{
 			MetricID:  metric.MetricID,
@@ -1510,6 +1716,7 @@ func StatQueueProfileToAPI(st *StatQueueProfile) (tpST *utils.TPStatProfile) {
 		Weight:             st.Weight,
 		MinItems:           st.MinItems,
 		ThresholdIDs:       make([]string, len(st.ThresholdIDs)),
+		BucketCount:        st.BucketCount,
 	}
 	for i, metric := range st.Metrics {
 		tpST.Metrics[i] = &utils.MetricWithFilters{
@@ -1526,6 +1733,9 @@ func StatQueueProfileToAPI(st *StatQueueProfile) (tpST *utils.TPStatProfile) {
 	if st.TTL != time.Duration(0) {
 		tpST.TTL = st.TTL.String()
 	}
+	if st.BucketInterval != time.Duration(0) {
+		tpST.BucketInterval = st.BucketInterval.String()
+	}
 	for i, fli := range st.FilterIDs {
 		tpST.FilterIDs[i] = fli
 	}
@@ -1550,7 +1760,8 @@ type TpThresholds []*TpThreshold
 func (tps TpThresholds) CSVHeader() (result []string) {
 	return []string{"#" + utils.Tenant, utils.ID, utils.FilterIDs, utils.ActivationIntervalString,
 		utils.MaxHits, utils.MinHits, utils.MinSleep,
-		utils.Blocker, utils.Weight, utils.ActionIDs, utils.Async}
+		utils.Blocker, utils.Weight, utils.ActionIDs, utils.Async,
+		utils.WarningFilterIDs, utils.CriticalFilterIDs, utils.EscalationActionIDs, utils.RecoveryActionIDs}
 }
 
 func (tps TpThresholds) AsTPThreshold() (result []*utils.TPThresholdProfile) {
@@ -1583,6 +1794,22 @@ func (tps TpThresholds) AsTPThreshold() (result []*utils.TPThresholdProfile) {
 		if tp.Weight != 0 {
 			th.Weight = tp.Weight
 		}
+		if tp.WarningFilterIDs != "" {
+			th.WarningFilterIDs = append(th.WarningFilterIDs,
+				strings.Split(tp.WarningFilterIDs, utils.INFIELD_SEP)...)
+		}
+		if tp.CriticalFilterIDs != "" {
+			th.CriticalFilterIDs = append(th.CriticalFilterIDs,
+				strings.Split(tp.CriticalFilterIDs, utils.INFIELD_SEP)...)
+		}
+		if tp.EscalationActionIDs != "" {
+			th.EscalationActionIDs = append(th.EscalationActionIDs,
+				strings.Split(tp.EscalationActionIDs, utils.INFIELD_SEP)...)
+		}
+		if tp.RecoveryActionIDs != "" {
+			th.RecoveryActionIDs = append(th.RecoveryActionIDs,
+				strings.Split(tp.RecoveryActionIDs, utils.INFIELD_SEP)...)
+		}
 		if len(tp.ActivationInterval) != 0 {
 			th.ActivationInterval = new(utils.TPActivationInterval)
 			aiSplt := strings.Split(tp.ActivationInterval, utils.INFIELD_SEP)
@@ -1622,9 +1849,31 @@ func (tps TpThresholds) AsTPThreshold() (result []*utils.TPThresholdProfile) {
 
 func APItoModelTPThreshold(th *utils.TPThresholdProfile) (mdls TpThresholds) {
 	if th != nil {
-		if len(th.ActionIDs) == 0 {
+		stateful := len(th.WarningFilterIDs) != 0 || len(th.CriticalFilterIDs) != 0
+		if len(th.ActionIDs) == 0 && !stateful {
 			return
 		}
+		// the profile parameters are written only on the first row
+		setParams := func(mdl *TpThreshold) {
+			mdl.Blocker = th.Blocker
+			mdl.Weight = th.Weight
+			mdl.MaxHits = th.MaxHits
+			mdl.MinHits = th.MinHits
+			mdl.MinSleep = th.MinSleep
+			mdl.Async = th.Async
+			if th.ActivationInterval != nil {
+				if th.ActivationInterval.ActivationTime != "" {
+					mdl.ActivationInterval = th.ActivationInterval.ActivationTime
+				}
+				if th.ActivationInterval.ExpiryTime != "" {
+					mdl.ActivationInterval += utils.INFIELD_SEP + th.ActivationInterval.ExpiryTime
+				}
+			}
+			mdl.WarningFilterIDs = strings.Join(th.WarningFilterIDs, utils.INFIELD_SEP)
+			mdl.CriticalFilterIDs = strings.Join(th.CriticalFilterIDs, utils.INFIELD_SEP)
+			mdl.EscalationActionIDs = strings.Join(th.EscalationActionIDs, utils.INFIELD_SEP)
+			mdl.RecoveryActionIDs = strings.Join(th.RecoveryActionIDs, utils.INFIELD_SEP)
+		}
 		min := len(th.FilterIDs)
 		if min > len(th.ActionIDs) {
 			min = len(th.ActionIDs)
@@ -1636,20 +1885,7 @@ func APItoModelTPThreshold(th *utils.TPThresholdProfile) (mdls TpThresholds) {
 				ID:     th.ID,
 			}
 			if i == 0 {
-				mdl.Blocker = th.Blocker
-				mdl.Weight = th.Weight
-				mdl.MaxHits = th.MaxHits
-				mdl.MinHits = th.MinHits
-				mdl.MinSleep = th.MinSleep
-				mdl.Async = th.Async
-				if th.ActivationInterval != nil {
-					if th.ActivationInterval.ActivationTime != "" {
-						mdl.ActivationInterval = th.ActivationInterval.ActivationTime
-					}
-					if th.ActivationInterval.ExpiryTime != "" {
-						mdl.ActivationInterval += utils.INFIELD_SEP + th.ActivationInterval.ExpiryTime
-					}
-				}
+				setParams(mdl)
 			}
 			mdl.FilterIDs = th.FilterIDs[i]
 			mdl.ActionIDs = th.ActionIDs[i]
@@ -1663,6 +1899,9 @@ func APItoModelTPThreshold(th *utils.TPThresholdProfile) (mdls TpThresholds) {
 					Tenant: th.Tenant,
 					ID:     th.ID,
 				}
+				if i == 0 { // stateful threshold without ActionIDs
+					setParams(mdl)
+				}
 				mdl.FilterIDs = th.FilterIDs[i]
 				mdls = append(mdls, mdl)
 			}
@@ -1675,25 +1914,21 @@ func APItoModelTPThreshold(th *utils.TPThresholdProfile) (mdls TpThresholds) {
 					ID:     th.ID,
 				}
 				if min == 0 && i == 0 {
-					mdl.Blocker = th.Blocker
-					mdl.Weight = th.Weight
-					mdl.MaxHits = th.MaxHits
-					mdl.MinHits = th.MinHits
-					mdl.MinSleep = th.MinSleep
-					mdl.Async = th.Async
-					if th.ActivationInterval != nil {
-						if th.ActivationInterval.ActivationTime != "" {
-							mdl.ActivationInterval = th.ActivationInterval.ActivationTime
-						}
-						if th.ActivationInterval.ExpiryTime != "" {
-							mdl.ActivationInterval += utils.INFIELD_SEP + th.ActivationInterval.ExpiryTime
-						}
-					}
+					setParams(mdl)
 				}
 				mdl.ActionIDs = th.ActionIDs[i]
 				mdls = append(mdls, mdl)
 			}
 		}
+		if len(mdls) == 0 { // stateful threshold without FilterIDs and ActionIDs
+			mdl := &TpThreshold{
+				Tpid:   th.TPid,
+				Tenant: th.Tenant,
+				ID:     th.ID,
+			}
+			setParams(mdl)
+			mdls = append(mdls, mdl)
+		}
 	}
 	return
 }
@@ -1710,6 +1945,22 @@ func APItoThresholdProfile(tpTH *utils.TPThresholdProfile, timezone string) (th
 		ActionIDs: make([]string, len(tpTH.ActionIDs)),
 		FilterIDs: make([]string, len(tpTH.FilterIDs)),
 	}
+	if len(tpTH.WarningFilterIDs) != 0 {
+		th.WarningFilterIDs = make([]string, len(tpTH.WarningFilterIDs))
+		copy(th.WarningFilterIDs, tpTH.WarningFilterIDs)
+	}
+	if len(tpTH.CriticalFilterIDs) != 0 {
+		th.CriticalFilterIDs = make([]string, len(tpTH.CriticalFilterIDs))
+		copy(th.CriticalFilterIDs, tpTH.CriticalFilterIDs)
+	}
+	if len(tpTH.EscalationActionIDs) != 0 {
+		th.EscalationActionIDs = make([]string, len(tpTH.EscalationActionIDs))
+		copy(th.EscalationActionIDs, tpTH.EscalationActionIDs)
+	}
+	if len(tpTH.RecoveryActionIDs) != 0 {
+		th.RecoveryActionIDs = make([]string, len(tpTH.RecoveryActionIDs))
+		copy(th.RecoveryActionIDs, tpTH.RecoveryActionIDs)
+	}
 	if tpTH.MinSleep != "" {
 		if th.MinSleep, err = utils.ParseDurationWithNanosecs(tpTH.MinSleep); err != nil {
 			return nil, err
@@ -1746,6 +1997,22 @@ func ThresholdProfileToAPI(th *ThresholdProfile) (tpTH *utils.TPThresholdProfile
 	if th.MinSleep != time.Duration(0) {
 		tpTH.MinSleep = th.MinSleep.String()
 	}
+	if len(th.WarningFilterIDs) != 0 {
+		tpTH.WarningFilterIDs = make([]string, len(th.WarningFilterIDs))
+		copy(tpTH.WarningFilterIDs, th.WarningFilterIDs)
+	}
+	if len(th.CriticalFilterIDs) != 0 {
+		tpTH.CriticalFilterIDs = make([]string, len(th.CriticalFilterIDs))
+		copy(tpTH.CriticalFilterIDs, th.CriticalFilterIDs)
+	}
+	if len(th.EscalationActionIDs) != 0 {
+		tpTH.EscalationActionIDs = make([]string, len(th.EscalationActionIDs))
+		copy(tpTH.EscalationActionIDs, th.EscalationActionIDs)
+	}
+	if len(th.RecoveryActionIDs) != 0 {
+		tpTH.RecoveryActionIDs = make([]string, len(th.RecoveryActionIDs))
+		copy(tpTH.RecoveryActionIDs, th.RecoveryActionIDs)
+	}
 	for i, fli := range th.FilterIDs {
 		tpTH.FilterIDs[i] = fli
 	}
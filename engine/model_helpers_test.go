@@ -20,6 +20,7 @@ package engine
 
 import (
 	"reflect"
+	"sort"
 	"testing"
 	"time"
 
@@ -35,6 +36,44 @@ func TestModelHelperCsvLoad(t *testing.T) {
 	}
 }
 
+func TestModelHelperCsvLoadOptional(t *testing.T) {
+	rec := []string{"cgrates.org", "Stats1", "", "", "100", "1s", "0",
+		"*tcc", "", "false", "false", "20", "*none"}
+	if getColumnCount(TpStat{}) != 15 || getMinColumnCount(TpStat{}) != 13 {
+		t.Errorf("wrong column count: %d, min: %d",
+			getColumnCount(TpStat{}), getMinColumnCount(TpStat{}))
+	}
+	if l, err := csvLoad(TpStat{}, rec); err != nil {
+		t.Error(err)
+	} else if tpSt := l.(TpStat); tpSt.ID != "Stats1" ||
+		tpSt.BucketInterval != "" || tpSt.BucketCount != 0 {
+		t.Errorf("model load failed: %+v", tpSt)
+	}
+	if l, err := csvLoad(TpStat{}, append(rec, "1m", "60")); err != nil {
+		t.Error(err)
+	} else if tpSt := l.(TpStat); tpSt.BucketInterval != "1m" || tpSt.BucketCount != 60 {
+		t.Errorf("model load failed: %+v", tpSt)
+	}
+	if _, err := csvLoad(TpStat{}, rec[:12]); err == nil {
+		t.Error("expecting error for missing mandatory column")
+	}
+}
+
+func TestModelHelperCsvLoadExchangeRate(t *testing.T) {
+	for rate, eRate := range map[string]float64{"1": 1, "1.15": 1.15, ".5": 0.5} {
+		if l, err := csvLoad(TpExchangeRate{}, []string{"EUR", "USD", "", rate}); err != nil {
+			t.Error(err)
+		} else if rcv := l.(TpExchangeRate).Rate; rcv != eRate {
+			t.Errorf("expecting rate: %v, received: %v", eRate, rcv)
+		}
+	}
+	for _, rate := range []string{"1,2", "1.", "a1.2", "1.2b", ""} {
+		if _, err := csvLoad(TpExchangeRate{}, []string{"EUR", "USD", "", rate}); err == nil {
+			t.Errorf("expecting error for rate: %q", rate)
+		}
+	}
+}
+
 func TestModelHelperCsvDump(t *testing.T) {
 	tpd := TpDestination{
 		Tag:    "TEST_DEST",
@@ -157,6 +196,69 @@ func TestTPDestinationRateAsExportSlice(t *testing.T) {
 
 }
 
+func TestMapTPCalendars(t *testing.T) {
+	tps := []*utils.TPCalendar{
+		&utils.TPCalendar{
+			TPid:  "TEST_TPID",
+			ID:    "HOLIDAYS",
+			Dates: []string{"12-25", " 2020-04-20"},
+		},
+	}
+	eCals := map[string]utils.StringMap{
+		"HOLIDAYS": utils.StringMap{"12-25": true, "2020-04-20": true},
+	}
+	if cals, err := MapTPCalendars(tps); err != nil {
+		t.Error(err)
+	} else if !reflect.DeepEqual(eCals, cals) {
+		t.Errorf("Expecting: %+v, received: %+v", eCals, cals)
+	}
+	tps[0].Dates = append(tps[0].Dates, "2020-13-01")
+	if _, err := MapTPCalendars(tps); err == nil ||
+		err.Error() != "invalid date: 2020-13-01 for calendar: HOLIDAYS" {
+		t.Errorf("Unexpected error: %v", err)
+	}
+}
+
+func TestAPItoExchangeRates(t *testing.T) {
+	tpExr := &utils.TPExchangeRates{
+		TPid:         "TEST_TPID",
+		FromCurrency: "EUR",
+		ToCurrency:   "USD",
+		Rates: []*utils.TPExchangeRate{
+			{ActivationTime: "2020-01-01T00:00:00Z", Rate: 1.1},
+			{ActivationTime: "2014-01-01T00:00:00Z", Rate: 1.2},
+		},
+	}
+	eExr := &ExchangeRates{
+		FromCurrency: "EUR",
+		ToCurrency:   "USD",
+		Rates: []*ExchangeRate{
+			{ActivationTime: time.Date(2014, 1, 1, 0, 0, 0, 0, time.UTC), Rate: 1.2},
+			{ActivationTime: time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC), Rate: 1.1},
+		},
+	}
+	if exr, err := APItoExchangeRates(tpExr, utils.EmptyString); err != nil {
+		t.Error(err)
+	} else if !reflect.DeepEqual(eExr, exr) {
+		t.Errorf("Expecting: %s, received: %s", utils.ToJSON(eExr), utils.ToJSON(exr))
+	}
+	tpExr.Rates[0].Rate = 0
+	if _, err := APItoExchangeRates(tpExr, utils.EmptyString); err == nil ||
+		err.Error() != "invalid rate: 0 for exchange rates: EUR:USD" {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	tpExr.Rates[0] = &utils.TPExchangeRate{ActivationTime: "notatime", Rate: 1.1}
+	if _, err := APItoExchangeRates(tpExr, utils.EmptyString); err == nil ||
+		err.Error() != "invalid activation time: notatime for exchange rates: EUR:USD" {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	tpExr.ToCurrency = "EUR"
+	if _, err := APItoExchangeRates(tpExr, utils.EmptyString); err == nil ||
+		err.Error() != "invalid currencies for exchange rates: EUR:EUR" {
+		t.Errorf("Unexpected error: %v", err)
+	}
+}
+
 func TestApierTPTimingAsExportSlice(t *testing.T) {
 	tpTiming := &utils.ApierTPTiming{
 		TPid:      "TEST_TPID",
@@ -165,9 +267,10 @@ func TestApierTPTimingAsExportSlice(t *testing.T) {
 		Months:    "*any",
 		MonthDays: "*any",
 		WeekDays:  "1;2;4",
-		Time:      "00:00:01"}
+		Time:      "00:00:01",
+		Calendars: "!HOLIDAYS"}
 	expectedSlc := [][]string{
-		[]string{"TEST_TIMING", "*any", "*any", "*any", "1;2;4", "00:00:01"},
+		[]string{"TEST_TIMING", "*any", "*any", "*any", "1;2;4", "00:00:01", "!HOLIDAYS"},
 	}
 	ms := APItoModelTiming(tpTiming)
 	var slc [][]string
@@ -185,8 +288,9 @@ func TestApierTPTimingAsExportSlice(t *testing.T) {
 
 func TestTPRatingPlanAsExportSlice(t *testing.T) {
 	tpRpln := &utils.TPRatingPlan{
-		TPid: "TEST_TPID",
-		ID:   "TEST_RPLAN",
+		TPid:     "TEST_TPID",
+		ID:       "TEST_RPLAN",
+		Currency: "EUR",
 		RatingPlanBindings: []*utils.TPRatingPlanBinding{
 			&utils.TPRatingPlanBinding{
 				DestinationRatesId: "TEST_DSTRATE1",
@@ -198,8 +302,8 @@ func TestTPRatingPlanAsExportSlice(t *testing.T) {
 				Weight:             20.0},
 		}}
 	expectedSlc := [][]string{
-		[]string{"TEST_RPLAN", "TEST_DSTRATE1", "TEST_TIMING1", "10"},
-		[]string{"TEST_RPLAN", "TEST_DSTRATE2", "TEST_TIMING2", "20"},
+		[]string{"TEST_RPLAN", "TEST_DSTRATE1", "TEST_TIMING1", "10", "EUR"},
+		[]string{"TEST_RPLAN", "TEST_DSTRATE2", "TEST_TIMING2", "20", "EUR"},
 	}
 
 	ms := APItoModelRatingPlan(tpRpln)
@@ -216,6 +320,27 @@ func TestTPRatingPlanAsExportSlice(t *testing.T) {
 	}
 }
 
+func TestTpRatingPlansAsMapTPRatingPlansCurrency(t *testing.T) {
+	tps := TpRatingPlans{
+		TpRatingPlan{Tpid: "TEST_TPID", Tag: "RP_EUR", DestratesTag: "DR_1", TimingTag: "*any", Weight: 10},
+		TpRatingPlan{Tpid: "TEST_TPID", Tag: "RP_EUR", DestratesTag: "DR_2", TimingTag: "*any", Weight: 10, Currency: "EUR"},
+		TpRatingPlan{Tpid: "TEST_TPID", Tag: "RP_NONE", DestratesTag: "DR_1", TimingTag: "*any", Weight: 10},
+	}
+	rps, err := tps.AsTPRatingPlans()
+	if err != nil {
+		t.Fatal(err)
+	}
+	eCurrencies := map[string]string{"RP_EUR": "EUR"}
+	if rcv := MapTPRatingPlanCurrencies(rps); !reflect.DeepEqual(eCurrencies, rcv) {
+		t.Errorf("Expecting: %+v, received: %+v", eCurrencies, rcv)
+	}
+	tps = append(tps, TpRatingPlan{Tpid: "TEST_TPID", Tag: "RP_EUR", DestratesTag: "DR_3", TimingTag: "*any", Weight: 10, Currency: "USD"})
+	if _, err := tps.AsTPRatingPlans(); err == nil ||
+		err.Error() != "conflicting currencies: EUR and USD for rating plan: RP_EUR" {
+		t.Errorf("Received error: %v", err)
+	}
+}
+
 func TestTPRatingProfileAsExportSlice(t *testing.T) {
 	tpRpf := &utils.TPRatingProfile{
 		TPid:     "TEST_TPID",
@@ -540,6 +665,7 @@ func TestAPItoResource(t *testing.T) {
 		Limit:              "2",
 		ThresholdIDs:       []string{"TRes1"},
 		AllocationMessage:  "asd",
+		RateInterval:       "1m",
 	}
 	eRL := &ResourceProfile{
 		Tenant:            "cgrates.org",
@@ -551,6 +677,7 @@ func TestAPItoResource(t *testing.T) {
 		ThresholdIDs:      []string{"TRes1"},
 		AllocationMessage: tpRL.AllocationMessage,
 		Limit:             2,
+		RateInterval:      time.Minute,
 	}
 	at, _ := utils.ParseTimeDetectLayout("2014-07-29T15:00:00Z", "UTC")
 	eRL.ActivationInterval = &utils.ActivationInterval{ActivationTime: at}
@@ -571,6 +698,7 @@ func TestResourceProfileToAPI(t *testing.T) {
 		Limit:              "2",
 		ThresholdIDs:       []string{"TRes1"},
 		AllocationMessage:  "asd",
+		RateInterval:       "1m0s",
 	}
 	rp := &ResourceProfile{
 		Tenant: "cgrates.org",
@@ -583,6 +711,7 @@ func TestResourceProfileToAPI(t *testing.T) {
 		ThresholdIDs:      []string{"TRes1"},
 		AllocationMessage: "asd",
 		Limit:             2,
+		RateInterval:      time.Minute,
 	}
 
 	if rcv := ResourceProfileToAPI(rp); !reflect.DeepEqual(expected, rcv) {
@@ -600,6 +729,7 @@ func TestAPItoModelResource(t *testing.T) {
 		Limit:              "2",
 		ThresholdIDs:       []string{"TRes1"},
 		AllocationMessage:  "test",
+		RateInterval:       "1m",
 	}
 	expModel := &TpResource{
 		Tpid:               testTPID,
@@ -610,6 +740,7 @@ func TestAPItoModelResource(t *testing.T) {
 		Limit:              "2",
 		ThresholdIDs:       "TRes1",
 		AllocationMessage:  "test",
+		RateInterval:       "1m",
 	}
 	rcv := APItoModelResource(tpRL)
 	if len(rcv) != 1 {
@@ -702,11 +833,13 @@ func TestAPItoTPStats(t *testing.T) {
 				MetricID: "*tcc",
 			},
 		},
-		MinItems:     1,
-		ThresholdIDs: []string{"THRESH1", "THRESH2"},
-		Stored:       false,
-		Blocker:      false,
-		Weight:       20.0,
+		MinItems:       1,
+		ThresholdIDs:   []string{"THRESH1", "THRESH2"},
+		Stored:         false,
+		Blocker:        false,
+		Weight:         20.0,
+		BucketInterval: "1m",
+		BucketCount:    60,
 	}
 	eTPs := &StatQueueProfile{ID: tps.ID,
 		QueueLength: tps.QueueLength,
@@ -721,12 +854,14 @@ func TestAPItoTPStats(t *testing.T) {
 				MetricID: "*tcc",
 			},
 		},
-		ThresholdIDs: []string{"THRESH1", "THRESH2"},
-		FilterIDs:    []string{"FLTR_1"},
-		Stored:       tps.Stored,
-		Blocker:      tps.Blocker,
-		Weight:       20.0,
-		MinItems:     tps.MinItems,
+		ThresholdIDs:   []string{"THRESH1", "THRESH2"},
+		FilterIDs:      []string{"FLTR_1"},
+		Stored:         tps.Stored,
+		Blocker:        tps.Blocker,
+		Weight:         20.0,
+		MinItems:       tps.MinItems,
+		BucketInterval: time.Minute,
+		BucketCount:    60,
 	}
 	if eTPs.TTL, err = utils.ParseDurationWithNanosecs(tps.TTL); err != nil {
 		t.Errorf("Got error: %+v", err)
@@ -1094,6 +1229,53 @@ func TestAPItoModelTPThreshold5(t *testing.T) {
 	}
 }
 
+func TestAPItoModelTPThresholdStateful(t *testing.T) {
+	th := &utils.TPThresholdProfile{
+		TPid:                "TP1",
+		Tenant:              "cgrates.org",
+		ID:                  "TH_1",
+		FilterIDs:           []string{"FLTR_1", "FLTR_2"},
+		MaxHits:             -1,
+		Weight:              20.0,
+		WarningFilterIDs:    []string{"*gte:~*req.Usage:1m"},
+		CriticalFilterIDs:   []string{"*gte:~*req.Usage:1h", "*string:~*req.Account:1001"},
+		EscalationActionIDs: []string{"ACT_ESCALATE"},
+		RecoveryActionIDs:   []string{"ACT_RECOVER"},
+	}
+	models := TpThresholds{
+		&TpThreshold{
+			Tpid:                "TP1",
+			Tenant:              "cgrates.org",
+			ID:                  "TH_1",
+			FilterIDs:           "FLTR_1",
+			MaxHits:             -1,
+			Weight:              20.0,
+			WarningFilterIDs:    "*gte:~*req.Usage:1m",
+			CriticalFilterIDs:   "*gte:~*req.Usage:1h;*string:~*req.Account:1001",
+			EscalationActionIDs: "ACT_ESCALATE",
+			RecoveryActionIDs:   "ACT_RECOVER",
+		},
+		&TpThreshold{
+			Tpid:      "TP1",
+			Tenant:    "cgrates.org",
+			ID:        "TH_1",
+			FilterIDs: "FLTR_2",
+		},
+	}
+	rcv := APItoModelTPThreshold(th)
+	if !reflect.DeepEqual(models, rcv) {
+		t.Errorf("Expecting : %+v, received: %+v", utils.ToJSON(models), utils.ToJSON(rcv))
+	}
+	if rcvTH := rcv.AsTPThreshold(); len(rcvTH) != 1 {
+		t.Errorf("Expecting one profile, received: %+v", utils.ToJSON(rcvTH))
+	} else {
+		sort.Strings(rcvTH[0].FilterIDs)
+		if !reflect.DeepEqual(th, rcvTH[0]) {
+			t.Errorf("Expecting : %+v, received: %+v", utils.ToJSON(th), utils.ToJSON(rcvTH[0]))
+		}
+	}
+}
+
 func TestAPItoTPThreshold(t *testing.T) {
 	tps := &utils.TPThresholdProfile{
 		TPid:               testTPID,
@@ -1192,6 +1374,50 @@ func TestTPFilterAsTPFilter(t *testing.T) {
 	}
 }
 
+func TestTPFilterAsTPFilterOr(t *testing.T) {
+	tps := []*TpFilter{
+		&TpFilter{
+			Tpid:   "TEST_TPID",
+			Tenant: "cgrates.org",
+			ID:     "FLTR_OR",
+			Type:   utils.MetaOr,
+			Values: "FLTR_DST_49;*string:~*req.Account:1002",
+		},
+	}
+	eTPs := []*utils.TPFilterProfile{
+		&utils.TPFilterProfile{
+			TPid:   tps[0].Tpid,
+			Tenant: tps[0].Tenant,
+			ID:     tps[0].ID,
+			Filters: []*utils.TPFilter{
+				&utils.TPFilter{
+					Type:   utils.MetaOr,
+					Values: []string{"FLTR_DST_49", "*string:~*req.Account:1002"},
+				},
+			},
+		},
+	}
+	rcvTPs := TpFilterS(tps).AsTPFilter()
+	if !reflect.DeepEqual(eTPs, rcvTPs) {
+		t.Errorf("\nExpecting:\n%+v\nReceived:\n%+v", utils.ToIJSON(eTPs), utils.ToIJSON(rcvTPs))
+	}
+	eFltr := &Filter{
+		Tenant: "cgrates.org",
+		ID:     "FLTR_OR",
+		Rules: []*FilterRule{
+			&FilterRule{
+				Type:   utils.MetaOr,
+				Values: []string{"FLTR_DST_49", "*string:~*req.Account:1002"},
+			},
+		},
+	}
+	if fltr, err := APItoFilter(rcvTPs[0], "UTC"); err != nil {
+		t.Error(err)
+	} else if !reflect.DeepEqual(eFltr, fltr) {
+		t.Errorf("Expecting: %+v, received: %+v", utils.ToJSON(eFltr), utils.ToJSON(fltr))
+	}
+}
+
 func TestTPFilterAsTPFilter2(t *testing.T) {
 	tps := []*TpFilter{
 		&TpFilter{
@@ -38,9 +38,28 @@ type TpTiming struct {
 	MonthDays string `index:"3" re:"\*any\s*,\s*|(?:\d{1,4};?)+\s*,\s*|\s*,\s*"`
 	WeekDays  string `index:"4" re:"\*any\s*,\s*|(?:\d{1,4};?)+\s*,\s*|\s*,\s*"`
 	Time      string `index:"5" re:"\d{2}:\d{2}:\d{2}|\*asap"`
+	Calendars string `index:"6" re:"" optional:"true"`
 	CreatedAt time.Time
 }
 
+type TpCalendar struct {
+	Id        int64
+	Tpid      string
+	Tag       string `index:"0" re:""`
+	Date      string `index:"1" re:""`
+	CreatedAt time.Time
+}
+
+type TpExchangeRate struct {
+	Id             int64
+	Tpid           string
+	FromCurrency   string  `index:"0" re:""`
+	ToCurrency     string  `index:"1" re:""`
+	ActivationTime string  `index:"2" re:""`
+	Rate           float64 `index:"3" re:"^\\d*\\.?\\d+$"`
+	CreatedAt      time.Time
+}
+
 type TpDestination struct {
 	Id        int64
 	Tpid      string
@@ -81,6 +100,7 @@ type TpRatingPlan struct {
 	DestratesTag string  `index:"1" re:"\w+\s*,\s*|\*any"`
 	TimingTag    string  `index:"2" re:"\w+\s*,\s*|\*any"`
 	Weight       float64 `index:"3" re:"\d+.?\d*"`
+	Currency     string  `index:"4" re:"" optional:"true"`
 	CreatedAt    time.Time
 }
 
@@ -209,6 +229,7 @@ type TpResource struct {
 	Stored             bool    `index:"8" re:""`
 	Weight             float64 `index:"9" re:"\d+\.?\d*"`
 	ThresholdIDs       string  `index:"10" re:""`
+	RateInterval       string  `index:"11" re:"" optional:"true"`
 	CreatedAt          time.Time
 }
 
@@ -228,24 +249,30 @@ type TpStat struct {
 	Blocker            bool    `index:"10" re:""`
 	Weight             float64 `index:"11" re:"\d+\.?\d*"`
 	ThresholdIDs       string  `index:"12" re:""`
+	BucketInterval     string  `index:"13" re:"" optional:"true"`
+	BucketCount        int     `index:"14" re:"" optional:"true"`
 	CreatedAt          time.Time
 }
 
 type TpThreshold struct {
-	PK                 uint `gorm:"primary_key"`
-	Tpid               string
-	Tenant             string  `index:"0" re:""`
-	ID                 string  `index:"1" re:""`
-	FilterIDs          string  `index:"2" re:""`
-	ActivationInterval string  `index:"3" re:""`
-	MaxHits            int     `index:"4" re:""`
-	MinHits            int     `index:"5" re:""`
-	MinSleep           string  `index:"6" re:""`
-	Blocker            bool    `index:"7" re:""`
-	Weight             float64 `index:"8" re:"\d+\.?\d*"`
-	ActionIDs          string  `index:"9" re:""`
-	Async              bool    `index:"10" re:""`
-	CreatedAt          time.Time
+	PK                  uint `gorm:"primary_key"`
+	Tpid                string
+	Tenant              string  `index:"0" re:""`
+	ID                  string  `index:"1" re:""`
+	FilterIDs           string  `index:"2" re:""`
+	ActivationInterval  string  `index:"3" re:""`
+	MaxHits             int     `index:"4" re:""`
+	MinHits             int     `index:"5" re:""`
+	MinSleep            string  `index:"6" re:""`
+	Blocker             bool    `index:"7" re:""`
+	Weight              float64 `index:"8" re:"\d+\.?\d*"`
+	ActionIDs           string  `index:"9" re:""`
+	Async               bool    `index:"10" re:""`
+	WarningFilterIDs    string  `index:"11" re:"" optional:"true"`
+	CriticalFilterIDs   string  `index:"12" re:"" optional:"true"`
+	EscalationActionIDs string  `index:"13" re:"" optional:"true"`
+	RecoveryActionIDs   string  `index:"14" re:"" optional:"true"`
+	CreatedAt           time.Time
 }
 
 type TpFilter struct {
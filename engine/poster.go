@@ -22,18 +22,19 @@ import (
 	"net/url"
 	"strings"
 	"sync"
+
+	"github.com/cgrates/cgrates/utils"
 )
 
 const (
-	defaultQueueID      = "cgrates_cdrs"
-	defaultExchangeType = "direct"
-	queueID             = "queue_id"
-	exchange            = "exchange"
-	exchangeType        = "exchange_type"
-	routingKey          = "routing_key"
-
-	awsToken   = "aws_token"
-	folderPath = "folder_path"
+	defaultQueueID      = utils.DefaultQueueID
+	defaultExchangeType = utils.AMQPDefaultExchangeType
+	queueID             = utils.QueueID
+	exchange            = utils.AMQPExchange
+	exchangeType        = utils.AMQPExchangeType
+	routingKey          = utils.AMQPRoutingKey
+
+	folderPath = utils.FolderPath
 )
 
 func init() {
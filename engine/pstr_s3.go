@@ -21,12 +21,10 @@ package engine
 import (
 	"bytes"
 	"fmt"
-	"strings"
 	"sync"
 	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
-	"github.com/aws/aws-sdk-go/aws/credentials"
 	"github.com/aws/aws-sdk-go/aws/session"
 	"github.com/aws/aws-sdk-go/service/s3/s3manager"
 	"github.com/cgrates/cgrates/utils"
@@ -44,11 +42,7 @@ func NewS3Poster(dialURL string, attempts int) (Poster, error) {
 // S3Poster is a s3 poster
 type S3Poster struct {
 	sync.Mutex
-	dialURL    string
-	awsRegion  string
-	awsID      string
-	awsKey     string
-	awsToken   string
+	awsOpts    *AWSOptions
 	attempts   int
 	queueID    string
 	folderPath string
@@ -61,8 +55,7 @@ func (pstr *S3Poster) Close() {}
 func (pstr *S3Poster) parseURL(dialURL string) {
 	qry := utils.GetUrlRawArguments(dialURL)
 
-	pstr.dialURL = strings.Split(dialURL, "?")[0]
-	pstr.dialURL = strings.TrimSuffix(pstr.dialURL, "/") // used to remove / to point to correct endpoint
+	pstr.awsOpts = NewAWSOptions(dialURL)
 	pstr.queueID = defaultQueueID
 	if val, has := qry[queueID]; has {
 		pstr.queueID = val
@@ -70,18 +63,6 @@ func (pstr *S3Poster) parseURL(dialURL string) {
 	if val, has := qry[folderPath]; has {
 		pstr.folderPath = val
 	}
-	if val, has := qry[utils.AWSRegion]; has {
-		pstr.awsRegion = val
-	}
-	if val, has := qry[utils.AWSKey]; has {
-		pstr.awsID = val
-	}
-	if val, has := qry[utils.AWSSecret]; has {
-		pstr.awsKey = val
-	}
-	if val, has := qry[awsToken]; has {
-		pstr.awsToken = val
-	}
 }
 
 // Post is the method being called when we need to post anything in the queue
@@ -134,20 +115,7 @@ func (pstr *S3Poster) newPosterSession() (s *s3manager.Uploader, err error) {
 	defer pstr.Unlock()
 	if pstr.session == nil {
 		var ses *session.Session
-		cfg := aws.Config{Endpoint: aws.String(pstr.dialURL)}
-		if len(pstr.awsRegion) != 0 {
-			cfg.Region = aws.String(pstr.awsRegion)
-		}
-		if len(pstr.awsID) != 0 &&
-			len(pstr.awsKey) != 0 {
-			cfg.Credentials = credentials.NewStaticCredentials(pstr.awsID, pstr.awsKey, pstr.awsToken)
-		}
-		ses, err = session.NewSessionWithOptions(
-			session.Options{
-				Config: cfg,
-			},
-		)
-		if err != nil {
+		if ses, err = pstr.awsOpts.NewSession(); err != nil {
 			return nil, err
 		}
 		pstr.session = ses
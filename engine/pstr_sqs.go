@@ -20,13 +20,11 @@ package engine
 
 import (
 	"fmt"
-	"strings"
 	"sync"
 	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/awserr"
-	"github.com/aws/aws-sdk-go/aws/credentials"
 	"github.com/aws/aws-sdk-go/aws/session"
 	"github.com/aws/aws-sdk-go/service/sqs"
 	"github.com/cgrates/cgrates/utils"
@@ -44,14 +42,10 @@ func NewSQSPoster(dialURL string, attempts int) (Poster, error) {
 // SQSPoster is a poster for sqs
 type SQSPoster struct {
 	sync.Mutex
-	dialURL   string
-	awsRegion string
-	awsID     string
-	awsKey    string
-	awsToken  string
-	attempts  int
-	queueURL  *string
-	queueID   string
+	awsOpts  *AWSOptions
+	attempts int
+	queueURL *string
+	queueID  string
 	// getQueueOnce    sync.Once
 	session *session.Session
 }
@@ -62,24 +56,11 @@ func (pstr *SQSPoster) Close() {}
 func (pstr *SQSPoster) parseURL(dialURL string) {
 	qry := utils.GetUrlRawArguments(dialURL)
 
-	pstr.dialURL = strings.Split(dialURL, "?")[0]
-	pstr.dialURL = strings.TrimSuffix(pstr.dialURL, "/") // used to remove / to point to correct endpoint
+	pstr.awsOpts = NewAWSOptions(dialURL)
 	pstr.queueID = defaultQueueID
 	if val, has := qry[queueID]; has {
 		pstr.queueID = val
 	}
-	if val, has := qry[utils.AWSRegion]; has {
-		pstr.awsRegion = val
-	}
-	if val, has := qry[utils.AWSKey]; has {
-		pstr.awsID = val
-	}
-	if val, has := qry[utils.AWSSecret]; has {
-		pstr.awsKey = val
-	}
-	if val, has := qry[awsToken]; has {
-		pstr.awsToken = val
-	}
 	pstr.getQueueURL()
 }
 
@@ -158,20 +139,7 @@ func (pstr *SQSPoster) newPosterSession() (s *sqs.SQS, err error) {
 	defer pstr.Unlock()
 	if pstr.session == nil {
 		var ses *session.Session
-		cfg := aws.Config{Endpoint: aws.String(pstr.dialURL)}
-		if len(pstr.awsRegion) != 0 {
-			cfg.Region = aws.String(pstr.awsRegion)
-		}
-		if len(pstr.awsID) != 0 &&
-			len(pstr.awsKey) != 0 {
-			cfg.Credentials = credentials.NewStaticCredentials(pstr.awsID, pstr.awsKey, pstr.awsToken)
-		}
-		ses, err = session.NewSessionWithOptions(
-			session.Options{
-				Config: cfg,
-			},
-		)
-		if err != nil {
+		if ses, err = pstr.awsOpts.NewSession(); err != nil {
 			return nil, err
 		}
 		pstr.session = ses
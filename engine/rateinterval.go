@@ -44,11 +44,33 @@ type RITiming struct {
 	Months             utils.Months
 	MonthDays          utils.MonthDays
 	WeekDays           utils.WeekDays
-	StartTime, EndTime string // ##:##:## format
+	StartTime, EndTime string                     // ##:##:## format
+	IncludeCalendars   map[string]utils.StringMap // days within the included calendars, active independent of the rules above
+	ExcludeCalendars   map[string]utils.StringMap // days within the excluded calendars, never active
 	cronString         string
 	tag                string // loading validation only
 }
 
+// formats of the calendar days
+const (
+	calendarDateLayout       = "2006-01-02"
+	calendarYearlyDateLayout = "01-02" // days repeating each year
+)
+
+// parseCalendarDate validates a calendar day, returning it in the layout used for matching
+func parseCalendarDate(dt string) (date string, err error) {
+	dt = strings.TrimSpace(dt)
+	layout := calendarDateLayout
+	if len(dt) == len(calendarYearlyDateLayout) {
+		layout = calendarYearlyDateLayout
+	}
+	var t time.Time
+	if t, err = time.Parse(layout, dt); err != nil {
+		return
+	}
+	return t.Format(layout), nil
+}
+
 func (rit *RITiming) CronString() string {
 	if rit.cronString != "" {
 		return rit.cronString
@@ -156,8 +178,32 @@ func (rit *RITiming) getLeftMargin(t time.Time) (rigthtTime time.Time) {
 	return time.Date(year, month, day, hour, min, sec, nsec, loc)
 }
 
-// Returns wheter the Timing is active at the specified time
-func (rit *RITiming) IsActiveAt(t time.Time) bool {
+// hasDayRules returns true if the Timing restricts the days via Years, Months, MonthDays or WeekDays
+func (rit *RITiming) hasDayRules() bool {
+	return len(rit.Years) != 0 ||
+		len(rit.Months) != 0 ||
+		len(rit.MonthDays) != 0 ||
+		len(rit.WeekDays) != 0
+}
+
+// isDayActive checks the day of t against the calendars and the day rules
+func (rit *RITiming) isDayActive(t time.Time) bool {
+	if len(rit.IncludeCalendars) != 0 || len(rit.ExcludeCalendars) != 0 {
+		date, yearlyDate := t.Format(calendarDateLayout), t.Format(calendarYearlyDateLayout)
+		for _, dates := range rit.ExcludeCalendars {
+			if dates.HasKey(date) || dates.HasKey(yearlyDate) {
+				return false
+			}
+		}
+		for _, dates := range rit.IncludeCalendars {
+			if dates.HasKey(date) || dates.HasKey(yearlyDate) {
+				return true
+			}
+		}
+		if len(rit.IncludeCalendars) != 0 && !rit.hasDayRules() { // calendar only timing
+			return false
+		}
+	}
 	// check for years
 	if len(rit.Years) > 0 && !rit.Years.Contains(t.Year()) {
 		return false
@@ -174,6 +220,14 @@ func (rit *RITiming) IsActiveAt(t time.Time) bool {
 	if len(rit.WeekDays) > 0 && !rit.WeekDays.Contains(t.Weekday()) {
 		return false
 	}
+	return true
+}
+
+// Returns wheter the Timing is active at the specified time
+func (rit *RITiming) IsActiveAt(t time.Time) bool {
+	if !rit.isDayActive(t) {
+		return false
+	}
 	//log.Print("Time: ", t)
 
 	//log.Print("Left Margin: ", rit.getLeftMargin(t))
@@ -200,11 +254,33 @@ func (rit *RITiming) IsBlank() bool {
 		len(rit.Months) == 0 &&
 		len(rit.MonthDays) == 0 &&
 		len(rit.WeekDays) == 0 &&
+		len(rit.IncludeCalendars) == 0 &&
+		len(rit.ExcludeCalendars) == 0 &&
 		rit.StartTime == "00:00:00"
 }
 
 func (rit *RITiming) Stringify() string {
-	return utils.Sha1(fmt.Sprintf("%v", rit))[:8]
+	str := fmt.Sprintf("&{%v %v %v %v %v %v %v %v}",
+		rit.Years, rit.Months, rit.MonthDays, rit.WeekDays,
+		rit.StartTime, rit.EndTime, rit.cronString, rit.tag)
+	if len(rit.IncludeCalendars) != 0 || len(rit.ExcludeCalendars) != 0 {
+		// calendar IDs only so the days can be updated without changing the id of the timing
+		str += fmt.Sprintf("%v%v", calendarIDs(rit.IncludeCalendars), calendarIDs(rit.ExcludeCalendars))
+	}
+	return utils.Sha1(str)[:8]
+}
+
+// SetCalendarDates updates the days of the calendar if referenced by the timing
+func (rit *RITiming) SetCalendarDates(calID string, dates utils.StringMap) (updated bool) {
+	if _, has := rit.IncludeCalendars[calID]; has {
+		rit.IncludeCalendars[calID] = dates
+		updated = true
+	}
+	if _, has := rit.ExcludeCalendars[calID]; has {
+		rit.ExcludeCalendars[calID] = dates
+		updated = true
+	}
+	return
 }
 
 // Separate structure used for rating plan size optimization
@@ -215,11 +291,15 @@ type RIRate struct {
 	MaxCost          float64
 	MaxCostStrategy  string
 	Rates            RateGroups // GroupRateInterval (start time): Rate
+	Currency         string     // currency of the rating plan, empty for the implicit one
 	tag              string     // loading validation only
 }
 
 func (rir *RIRate) Stringify() string {
 	str := fmt.Sprintf("%v %v %v %v %v", rir.ConnectFee, rir.RoundingMethod, rir.RoundingDecimals, rir.MaxCost, rir.MaxCostStrategy)
+	if rir.Currency != "" {
+		str += " " + rir.Currency
+	}
 	for _, r := range rir.Rates {
 		str += r.Stringify()
 	}
@@ -363,7 +443,9 @@ func (i *RateInterval) Equal(o *RateInterval) bool {
 		reflect.DeepEqual(i.Timing.MonthDays, o.Timing.MonthDays) &&
 		reflect.DeepEqual(i.Timing.WeekDays, o.Timing.WeekDays) &&
 		i.Timing.StartTime == o.Timing.StartTime &&
-		i.Timing.EndTime == o.Timing.EndTime
+		i.Timing.EndTime == o.Timing.EndTime &&
+		reflect.DeepEqual(i.Timing.IncludeCalendars, o.Timing.IncludeCalendars) &&
+		reflect.DeepEqual(i.Timing.ExcludeCalendars, o.Timing.ExcludeCalendars)
 }
 
 func (i *RateInterval) GetCost(duration, startSecond time.Duration) float64 {
@@ -471,6 +553,30 @@ func (rit *RITiming) Clone() (cln *RITiming) {
 		StartTime: rit.StartTime,
 		EndTime:   rit.EndTime,
 	}
+	cln.IncludeCalendars = cloneCalendars(rit.IncludeCalendars)
+	cln.ExcludeCalendars = cloneCalendars(rit.ExcludeCalendars)
+	return
+}
+
+// calendarIDs returns the sorted IDs of the calendars within a RITiming
+func calendarIDs(cals map[string]utils.StringMap) (ids []string) {
+	ids = make([]string, 0, len(cals))
+	for calID := range cals {
+		ids = append(ids, calID)
+	}
+	sort.Strings(ids)
+	return
+}
+
+// cloneCalendars clones the days within the calendars of a RITiming
+func cloneCalendars(cals map[string]utils.StringMap) (cln map[string]utils.StringMap) {
+	if cals == nil {
+		return
+	}
+	cln = make(map[string]utils.StringMap, len(cals))
+	for calID, dates := range cals {
+		cln[calID] = dates.Clone()
+	}
 	return
 }
 
@@ -485,6 +591,7 @@ func (rit *RIRate) Clone() (cln *RIRate) {
 		RoundingDecimals: rit.RoundingDecimals,
 		MaxCost:          rit.MaxCost,
 		MaxCostStrategy:  rit.MaxCostStrategy,
+		Currency:         rit.Currency,
 	}
 	if rit.Rates != nil {
 		cln.Rates = make([]*Rate, len(rit.Rates))
@@ -172,6 +172,65 @@ func TestRateIntervalEverything(t *testing.T) {
 	}
 }
 
+func TestRateIntervalExcludeDates(t *testing.T) {
+	i := &RateInterval{
+		Timing: &RITiming{
+			WeekDays:  utils.WeekDays{time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday},
+			StartTime: "08:00:00",
+			EndTime:   "18:00:00",
+			ExcludeCalendars: map[string]utils.StringMap{
+				"HOLIDAYS": utils.StringMap{"12-25": true, "2020-04-20": true},
+			},
+		},
+	}
+	d := time.Date(2020, time.April, 21, 10, 0, 0, 0, time.UTC)
+	if !i.Contains(d, false) {
+		t.Errorf("Date %+v shoud be in interval %+v", d, i)
+	}
+	d = time.Date(2020, time.April, 20, 10, 0, 0, 0, time.UTC)
+	if i.Contains(d, false) {
+		t.Errorf("Date %+v shoud not be in interval %+v", d, i)
+	}
+	d = time.Date(2019, time.December, 25, 10, 0, 0, 0, time.UTC)
+	if i.Contains(d, false) {
+		t.Errorf("Date %+v shoud not be in interval %+v", d, i)
+	}
+}
+
+func TestRateIntervalIncludeDates(t *testing.T) {
+	i := &RateInterval{
+		Timing: &RITiming{
+			WeekDays:  utils.WeekDays{time.Saturday, time.Sunday},
+			StartTime: "00:00:00",
+			IncludeCalendars: map[string]utils.StringMap{
+				"HOLIDAYS": utils.StringMap{"12-25": true},
+			},
+		},
+	}
+	d := time.Date(2019, time.December, 25, 10, 0, 0, 0, time.UTC) // Wednesday
+	if !i.Contains(d, false) {
+		t.Errorf("Date %+v shoud be in interval %+v", d, i)
+	}
+	d = time.Date(2019, time.December, 28, 10, 0, 0, 0, time.UTC) // Saturday
+	if !i.Contains(d, false) {
+		t.Errorf("Date %+v shoud be in interval %+v", d, i)
+	}
+	d = time.Date(2019, time.December, 26, 10, 0, 0, 0, time.UTC)
+	if i.Contains(d, false) {
+		t.Errorf("Date %+v shoud not be in interval %+v", d, i)
+	}
+	// calendar only timing
+	i.Timing.WeekDays = nil
+	d = time.Date(2019, time.December, 28, 10, 0, 0, 0, time.UTC)
+	if i.Contains(d, false) {
+		t.Errorf("Date %+v shoud not be in interval %+v", d, i)
+	}
+	d = time.Date(2020, time.December, 25, 10, 0, 0, 0, time.UTC)
+	if !i.Contains(d, false) {
+		t.Errorf("Date %+v shoud be in interval %+v", d, i)
+	}
+}
+
 func TestRateIntervalEqual(t *testing.T) {
 	i1 := &RateInterval{
 		Timing: &RITiming{
@@ -29,6 +29,7 @@ The struture that is saved to storage.
 */
 type RatingPlan struct {
 	Id               string
+	Currency         string // currency of the costs, empty for the implicit one
 	Timings          map[string]*RITiming
 	Ratings          map[string]*RIRate
 	DestinationRates map[string]RPRateList
@@ -170,3 +171,37 @@ func (rp *RatingPlan) getFirstUnsaneTiming() string {
 	}
 	return ""
 }
+
+// WithCalendars returns a copy of the RatingPlan with the days of the calendars
+// updated within the Timings referencing them, nil if none references the calendars
+func (rp *RatingPlan) WithCalendars(cals map[string]utils.StringMap) (cln *RatingPlan) {
+	for tmID, tm := range rp.Timings {
+		if len(tm.IncludeCalendars) == 0 && len(tm.ExcludeCalendars) == 0 {
+			continue
+		}
+		tmCln := tm.Clone()
+		var updated bool
+		for calID, dates := range cals {
+			if tmCln.SetCalendarDates(calID, dates) {
+				updated = true
+			}
+		}
+		if !updated {
+			continue
+		}
+		if cln == nil { // the Timings are shared with the cached RatingPlan
+			cln = &RatingPlan{
+				Id:               rp.Id,
+				Currency:         rp.Currency,
+				Timings:          make(map[string]*RITiming, len(rp.Timings)),
+				Ratings:          rp.Ratings,
+				DestinationRates: rp.DestinationRates,
+			}
+			for id, t := range rp.Timings {
+				cln.Timings[id] = t
+			}
+		}
+		cln.Timings[tmID] = tmCln
+	}
+	return
+}
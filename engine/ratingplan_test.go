@@ -324,6 +324,54 @@ func TestRatingPlanSaneTimingsGood(t *testing.T) {
 	}
 }
 
+func TestRatingPlanWithCalendars(t *testing.T) {
+	rp := &RatingPlan{Id: "RP_CALENDARS"}
+	rp.AddRateInterval("GERMANY", &RateInterval{
+		Timing: &RITiming{
+			WeekDays:  utils.WeekDays{time.Saturday, time.Sunday},
+			StartTime: "00:00:00",
+			IncludeCalendars: map[string]utils.StringMap{
+				"HOLIDAYS": utils.StringMap{"12-25": true},
+			},
+		},
+		Weight: 10,
+	}, &RateInterval{
+		Timing: &RITiming{
+			WeekDays:  utils.WeekDays{time.Monday, time.Tuesday},
+			StartTime: "00:00:00",
+		},
+		Weight: 10,
+	})
+	if cln := rp.WithCalendars(map[string]utils.StringMap{
+		"OTHER": utils.StringMap{"01-01": true}}); cln != nil {
+		t.Errorf("Expecting nil, received: %s", utils.ToJSON(cln))
+	}
+	tmID := rp.DestinationRates["GERMANY"][0].Timing
+	cln := rp.WithCalendars(map[string]utils.StringMap{
+		"HOLIDAYS": utils.StringMap{"12-25": true, "01-01": true}})
+	if cln == nil {
+		t.Fatal("Expecting updated RatingPlan")
+	}
+	if len(cln.Timings) != 2 {
+		t.Errorf("Expecting 2 timings, received: %s", utils.ToJSON(cln.Timings))
+	}
+	eCals := map[string]utils.StringMap{
+		"HOLIDAYS": utils.StringMap{"12-25": true, "01-01": true},
+	}
+	if !reflect.DeepEqual(eCals, cln.Timings[tmID].IncludeCalendars) {
+		t.Errorf("Expecting: %+v, received: %+v", eCals, cln.Timings[tmID].IncludeCalendars)
+	}
+	if cln.Timings[tmID].Stringify() != tmID {
+		t.Errorf("Expecting timing id: %s, received: %s", tmID, cln.Timings[tmID].Stringify())
+	}
+	eCals = map[string]utils.StringMap{
+		"HOLIDAYS": utils.StringMap{"12-25": true},
+	}
+	if !reflect.DeepEqual(eCals, rp.Timings[tmID].IncludeCalendars) {
+		t.Errorf("Original RatingPlan modified: %+v", rp.Timings[tmID].IncludeCalendars)
+	}
+}
+
 func TestRatingPlanSaneRatingsEqual(t *testing.T) {
 	rpl := &RatingPlan{
 		Ratings: map[string]*RIRate{
@@ -46,8 +46,9 @@ type ResourceProfile struct {
 	AllocationMessage  string                    // message returned by the winning resource on allocation
 	Blocker            bool                      // blocker flag to stop processing on filters matched
 	Stored             bool
-	Weight             float64  // Weight to sort the resources
-	ThresholdIDs       []string // Thresholds to check after changing Limit
+	Weight             float64       // Weight to sort the resources
+	ThresholdIDs       []string      // Thresholds to check after changing Limit
+	RateInterval       time.Duration // limit the units allocated within this sliding window instead of the ones in use, 0 to disable
 }
 
 // ResourceProfileWithArgDispatcher is used in replicatorV1 for dispatcher
@@ -85,6 +86,34 @@ func (ru *ResourceUsage) Clone() (cln *ResourceUsage) {
 	return
 }
 
+// updateResourceUsages is the operation executed atomically by DataDB on the usages shared between engines:
+// removes the usages expired at now, then with a nil ru removes the one having ruID,
+// otherwise records ru if it fits within limit (a nil limit records without checking)
+// recording the ID of an active usage is an error, the usage held already being kept
+func updateResourceUsages(usages map[string]*ResourceUsage, ruID string,
+	ru *ResourceUsage, limit *float64, now time.Time) (recorded bool, err error) {
+	var tUsage float64
+	for id, u := range usages {
+		if !u.isActive(now) ||
+			(ru == nil && id == ruID) {
+			delete(usages, id)
+			continue
+		}
+		tUsage += u.Units
+	}
+	if ru == nil {
+		return
+	}
+	if _, hasID := usages[ru.ID]; hasID {
+		return false, fmt.Errorf("duplicate resource usage with id: %s", ru.TenantID())
+	}
+	if limit != nil && *limit < tUsage+ru.Units {
+		return
+	}
+	usages[ru.ID] = ru
+	return true, nil
+}
+
 // Resource represents a resource in the system
 // not thread safe, needs locking at process level
 type Resource struct {
@@ -109,6 +138,11 @@ func (r *Resource) TenantID() string {
 	return utils.ConcatenatedKey(r.Tenant, r.ID)
 }
 
+// rateBased returns true if the resource limits the allocation rate instead of the concurrent usage
+func (r *Resource) rateBased() bool {
+	return r.rPrf != nil && r.rPrf.RateInterval > 0
+}
+
 // removeExpiredUnits removes units which are expired from the resource
 func (r *Resource) removeExpiredUnits() {
 	var firstActive int
@@ -161,6 +195,10 @@ func (r *Resource) TotalUsage() (tU float64) {
 
 // recordUsage records a new usage
 func (r *Resource) recordUsage(ru *ResourceUsage) (err error) {
+	if r.rateBased() { // each allocation is counted on its own until it leaves the window
+		ru = ru.Clone()
+		ru.ID = utils.ConcatenatedKey(ru.ID, utils.GenUUID())
+	}
 	if _, hasID := r.Usages[ru.ID]; hasID {
 		return fmt.Errorf("duplicate resource usage with id: %s", ru.TenantID())
 	}
@@ -183,6 +221,9 @@ func (r *Resource) recordUsage(ru *ResourceUsage) (err error) {
 
 // clearUsage clears the usage for an ID
 func (r *Resource) clearUsage(ruID string) (err error) {
+	if r.rateBased() { // the allocations are released only by leaving the window
+		return
+	}
 	ru, hasIt := r.Usages[ruID]
 	if !hasIt {
 		return fmt.Errorf("cannot find usage record with id: %s", ruID)
@@ -202,6 +243,41 @@ func (r *Resource) clearUsage(ruID string) (err error) {
 	return
 }
 
+// sharedUsage returns the usage as it should be recorded by this resource in the shared state
+// returns nil if the resource does not record usages
+func (r *Resource) sharedUsage(ru *ResourceUsage) *ResourceUsage {
+	if r.ttl == nil || *r.ttl == -1 {
+		return ru
+	}
+	if *r.ttl == 0 {
+		return nil // no recording for ttl of 0
+	}
+	ru = ru.Clone() // don't influence the initial ru
+	ru.ExpiryTime = time.Now().Add(*r.ttl)
+	if r.rateBased() { // each allocation is counted on its own until it leaves the window
+		ru.ID = utils.ConcatenatedKey(ru.ID, utils.GenUUID())
+	}
+	return ru
+}
+
+// setUsages replaces the usages with the ones received from the shared state
+func (r *Resource) setUsages(usages map[string]*ResourceUsage) {
+	if usages == nil {
+		usages = make(map[string]*ResourceUsage)
+	}
+	r.Usages = usages
+	r.tUsage = nil
+	r.TTLIdx = nil
+	for ruID, ru := range usages {
+		if !ru.ExpiryTime.IsZero() {
+			r.TTLIdx = append(r.TTLIdx, ruID)
+		}
+	}
+	sort.Slice(r.TTLIdx, func(i, j int) bool {
+		return r.Usages[r.TTLIdx[i]].ExpiryTime.Before(r.Usages[r.TTLIdx[j]].ExpiryTime)
+	})
+}
+
 // Resources is an orderable list of Resources based on Weight
 type Resources []*Resource
 
@@ -307,6 +383,106 @@ func (rs Resources) allocateResource(ru *ResourceUsage, dryRun bool) (alcMessage
 	return
 }
 
+// allocateSharedResource is the allocateResource working on the usages shared between engines in dataDB
+// the usage is checked and recorded atomically on the first resource with enough units,
+// then recorded on the others
+func (rs Resources) allocateSharedResource(dm *DataManager, ru *ResourceUsage,
+	dryRun bool) (alcMessage string, err error) {
+	if len(rs) == 0 {
+		return "", utils.ErrResourceUnavailable
+	}
+	lockIDs := utils.PrefixSliceItems(rs.tenatIDs(), utils.ResourcesPrefix)
+	guardian.Guardian.Guard(func() (gRes interface{}, gErr error) {
+		alcIdx := -1 // index of the resource which allowed the usage
+		for i, r := range rs {
+			if r.rPrf == nil {
+				err = fmt.Errorf("empty configuration for resourceID: %s", r.TenantID())
+				return
+			}
+			var usages map[string]*ResourceUsage
+			var allocated bool
+			if rRU := r.sharedUsage(ru); dryRun || rRU == nil {
+				if usages, err = dm.GetResourceUsages(r.Tenant, r.ID); err != nil {
+					return
+				}
+				r.setUsages(usages)
+				allocated = r.rPrf.Limit >= r.totalUsage()+ru.Units
+			} else {
+				if usages, allocated, err = dm.AllocateResourceUsage(r.Tenant, r.ID,
+					rRU, utils.Float64Pointer(r.rPrf.Limit)); err != nil {
+					return
+				}
+				r.setUsages(usages)
+			}
+			if allocated {
+				alcIdx = i
+				break
+			}
+		}
+		if alcIdx == -1 {
+			err = utils.ErrResourceUnavailable
+			return
+		}
+		if alcMessage = rs[alcIdx].rPrf.AllocationMessage; alcMessage == "" {
+			alcMessage = rs[alcIdx].rPrf.ID
+		}
+		if dryRun {
+			return
+		}
+		for i, r := range rs {
+			rRU := r.sharedUsage(ru)
+			if i == alcIdx || rRU == nil {
+				continue
+			}
+			var usages map[string]*ResourceUsage
+			if usages, _, err = dm.AllocateResourceUsage(r.Tenant, r.ID, rRU, nil); err != nil {
+				utils.Logger.Warning(fmt.Sprintf("<ResourceLimits>, err: %s", err.Error()))
+				return
+			}
+			r.setUsages(usages)
+		}
+		return
+	}, config.CgrConfig().GeneralCfg().LockingTimeout, lockIDs...)
+	return
+}
+
+// refreshSharedUsages loads the usages shared between engines into the resources
+func (rs Resources) refreshSharedUsages(dm *DataManager) (err error) {
+	lockIDs := utils.PrefixSliceItems(rs.tenatIDs(), utils.ResourcesPrefix)
+	guardian.Guardian.Guard(func() (gRes interface{}, gErr error) {
+		for _, r := range rs {
+			var usages map[string]*ResourceUsage
+			if usages, err = dm.GetResourceUsages(r.Tenant, r.ID); err != nil {
+				return
+			}
+			r.setUsages(usages)
+		}
+		return
+	}, config.CgrConfig().GeneralCfg().LockingTimeout, lockIDs...)
+	return
+}
+
+// clearSharedUsage gives back the units to the pool shared between engines
+func (rs Resources) clearSharedUsage(dm *DataManager, ruID string) (err error) {
+	lockIDs := utils.PrefixSliceItems(rs.tenatIDs(), utils.ResourcesPrefix)
+	guardian.Guardian.Guard(func() (gRes interface{}, gErr error) {
+		for _, r := range rs {
+			if r.rateBased() { // the allocations are released only by leaving the window
+				continue
+			}
+			usages, errClear := dm.ReleaseResourceUsage(r.Tenant, r.ID, ruID)
+			if errClear != nil {
+				utils.Logger.Warning(fmt.Sprintf("<ResourceLimits>, clear ruID: %s, err: %s", ruID, errClear.Error()))
+				err = errClear
+				continue
+			}
+			r.setUsages(usages)
+		}
+		return
+	}, config.CgrConfig().GeneralCfg().LockingTimeout, lockIDs...)
+	return
+}
+
 // NewResourceService  returns a new ResourceService
 func NewResourceService(dm *DataManager, cgrcfg *config.CGRConfig,
 	filterS *FilterS, connMgr *ConnManager) (*ResourceService, error) {
@@ -456,6 +632,14 @@ func (rS *ResourceService) processThresholds(r *Resource, argDispatcher *utils.A
 	return
 }
 
+// allocateResource allocates the usage in the matching resources, out of the shared state if configured
+func (rS *ResourceService) allocateResource(rs Resources, ru *ResourceUsage, dryRun bool) (string, error) {
+	if rS.cgrcfg.ResourceSCfg().SharedState {
+		return rs.allocateSharedResource(rS.dm, ru, dryRun)
+	}
+	return rs.allocateResource(ru, dryRun)
+}
+
 // matchingResourcesForEvent returns ordered list of matching resources which are active by the time of the call
 func (rS *ResourceService) matchingResourcesForEvent(ev *utils.CGREvent,
 	evUUID string, usageTTL *time.Duration) (rs Resources, err error) {
@@ -510,10 +694,13 @@ func (rS *ResourceService) matchingResourcesForEvent(ev *utils.CGREvent,
 			if err != nil {
 				return nil, err
 			}
-			if rPrf.Stored && r.dirty == nil {
+			if rPrf.Stored && r.dirty == nil &&
+				!rS.cgrcfg.ResourceSCfg().SharedState { // shared usages are stored on each change
 				r.dirty = utils.BoolPointer(false)
 			}
-			if usageTTL != nil {
+			if rPrf.RateInterval > 0 { // the allocations expire once out of the window
+				r.ttl = utils.DurationPointer(rPrf.RateInterval)
+			} else if usageTTL != nil {
 				if *usageTTL != 0 {
 					r.ttl = usageTTL
 				}
@@ -584,6 +771,11 @@ func (rS *ResourceService) V1ResourcesForEvent(args utils.ArgRSv1ResourceUsage,
 	if mtcRLs, err = rS.matchingResourcesForEvent(args.CGREvent, args.UsageID, args.UsageTTL); err != nil {
 		return err
 	}
+	if rS.cgrcfg.ResourceSCfg().SharedState {
+		if err = mtcRLs.refreshSharedUsages(rS.dm); err != nil {
+			return
+		}
+	}
 	*reply = mtcRLs
 	return
 }
@@ -623,7 +815,7 @@ func (rS *ResourceService) V1AuthorizeResources(args utils.ArgRSv1ResourceUsage,
 		return err
 	}
 	var alcMessage string
-	if alcMessage, err = mtcRLs.allocateResource(
+	if alcMessage, err = rS.allocateResource(mtcRLs,
 		&ResourceUsage{
 			Tenant: args.CGREvent.Tenant,
 			ID:     args.UsageID,
@@ -674,7 +866,7 @@ func (rS *ResourceService) V1AllocateResource(args utils.ArgRSv1ResourceUsage, r
 	}
 
 	var alcMsg string
-	if alcMsg, err = mtcRLs.allocateResource(
+	if alcMsg, err = rS.allocateResource(mtcRLs,
 		&ResourceUsage{Tenant: args.CGREvent.Tenant, ID: args.UsageID,
 			Units: args.Units}, false); err != nil {
 		return
@@ -682,6 +874,10 @@ func (rS *ResourceService) V1AllocateResource(args utils.ArgRSv1ResourceUsage, r
 
 	// index it for storing
 	for _, r := range mtcRLs {
+		if rS.cgrcfg.ResourceSCfg().SharedState { // already stored by the allocation
+			rS.processThresholds(r, args.ArgDispatcher)
+			continue
+		}
 		if rS.cgrcfg.ResourceSCfg().StoreInterval == 0 || r.dirty == nil {
 			continue
 		}
@@ -735,7 +931,11 @@ func (rS *ResourceService) V1ReleaseResource(args utils.ArgRSv1ResourceUsage, re
 		args.UsageTTL); err != nil {
 		return err
 	}
-	mtcRLs.clearUsage(args.UsageID)
+	if rS.cgrcfg.ResourceSCfg().SharedState {
+		mtcRLs.clearSharedUsage(rS.dm, args.UsageID)
+	} else {
+		mtcRLs.clearUsage(args.UsageID)
+	}
 
 	// Handle storing
 	if rS.cgrcfg.ResourceSCfg().StoreInterval != -1 {
@@ -765,10 +965,17 @@ func (rS *ResourceService) V1GetResource(arg *utils.TenantIDWithArgDispatcher, r
 	if missing := utils.MissingStructFields(arg, []string{"Tenant", "ID"}); len(missing) != 0 { //Params missing
 		return utils.NewErrMandatoryIeMissing(missing...)
 	}
-	if res, err := rS.dm.GetResource(arg.Tenant, arg.ID, true, true, utils.NonTransactional); err != nil {
+	res, err := rS.dm.GetResource(arg.Tenant, arg.ID, true, true, utils.NonTransactional)
+	if err != nil {
 		return err
-	} else {
-		*reply = *res
+	}
+	*reply = *res
+	if rS.cgrcfg.ResourceSCfg().SharedState { // the cached resource is not updated outside the locks
+		usages, err := rS.dm.GetResourceUsages(arg.Tenant, arg.ID)
+		if err != nil {
+			return err
+		}
+		reply.setUsages(usages)
 	}
 	return nil
 }
@@ -785,3 +785,177 @@ func TestResourceCaching(t *testing.T) {
 		t.Errorf("Expecting: %+v, received: %+v", resources[0].ttl, mres[0].ttl)
 	}
 }
+
+func TestUpdateResourceUsages(t *testing.T) {
+	now := time.Now()
+	usages := map[string]*ResourceUsage{
+		"RU1": {Tenant: "cgrates.org", ID: "RU1", Units: 1},
+		"RU2": {Tenant: "cgrates.org", ID: "RU2", Units: 2, ExpiryTime: now.Add(-time.Second)},
+		"RU3": {Tenant: "cgrates.org", ID: "RU3", Units: 3, ExpiryTime: now.Add(time.Minute)},
+	}
+	ru := &ResourceUsage{Tenant: "cgrates.org", ID: "RU4", Units: 2}
+	if recorded, err := updateResourceUsages(usages, ru.ID, ru, utils.Float64Pointer(5), now); err != nil {
+		t.Error(err)
+	} else if recorded {
+		t.Error("Expected the usage to not be recorded over the limit")
+	}
+	eUsages := map[string]*ResourceUsage{
+		"RU1": {Tenant: "cgrates.org", ID: "RU1", Units: 1},
+		"RU3": {Tenant: "cgrates.org", ID: "RU3", Units: 3, ExpiryTime: now.Add(time.Minute)},
+	}
+	if !reflect.DeepEqual(eUsages, usages) {
+		t.Errorf("Expecting: %s, received: %s", utils.ToJSON(eUsages), utils.ToJSON(usages))
+	}
+	if recorded, err := updateResourceUsages(usages, ru.ID, ru, utils.Float64Pointer(6), now); err != nil {
+		t.Error(err)
+	} else if !recorded {
+		t.Error("Expected the usage to be recorded")
+	}
+	dupRU := &ResourceUsage{Tenant: "cgrates.org", ID: "RU4", Units: 3}
+	expErr := "duplicate resource usage with id: cgrates.org:RU4"
+	if _, err := updateResourceUsages(usages, dupRU.ID, dupRU, utils.Float64Pointer(7), now); err == nil ||
+		err.Error() != expErr {
+		t.Errorf("Expected error: %s, received: %v", expErr, err)
+	}
+	if recorded, err := updateResourceUsages(usages, "RU5",
+		&ResourceUsage{ID: "RU5", Units: 10}, nil, now); err != nil {
+		t.Error(err)
+	} else if !recorded {
+		t.Error("Expected the usage to be recorded without limit")
+	}
+	if recorded, err := updateResourceUsages(usages, "RU1", nil, nil, now); err != nil {
+		t.Error(err)
+	} else if recorded {
+		t.Error("Expected nothing recorded on removal")
+	}
+	eUsages = map[string]*ResourceUsage{
+		"RU3": {Tenant: "cgrates.org", ID: "RU3", Units: 3, ExpiryTime: now.Add(time.Minute)},
+		"RU4": {Tenant: "cgrates.org", ID: "RU4", Units: 2},
+		"RU5": {ID: "RU5", Units: 10},
+	}
+	if !reflect.DeepEqual(eUsages, usages) {
+		t.Errorf("Expecting: %s, received: %s", utils.ToJSON(eUsages), utils.ToJSON(usages))
+	}
+}
+
+func TestResourceAllocateSharedResource(t *testing.T) {
+	cfg, _ := config.NewDefaultCGRConfig()
+	dm := NewDataManager(NewInternalDB(nil, nil, true, cfg.DataDbCfg().Items),
+		config.CgrConfig().CacheCfg(), nil)
+	rPrf := &ResourceProfile{
+		Tenant:            "cgrates.org",
+		ID:                "RES_SHARED",
+		AllocationMessage: "ALLOC_SHARED",
+		Limit:             2,
+		UsageTTL:          -1,
+	}
+	// the same resource as seen by two engines
+	engine1 := Resources{{Tenant: "cgrates.org", ID: "RES_SHARED",
+		Usages: make(map[string]*ResourceUsage), ttl: utils.DurationPointer(-1), rPrf: rPrf}}
+	engine2 := Resources{{Tenant: "cgrates.org", ID: "RES_SHARED",
+		Usages: make(map[string]*ResourceUsage), ttl: utils.DurationPointer(-1), rPrf: rPrf}}
+	if alcMsg, err := engine1.allocateSharedResource(dm,
+		&ResourceUsage{Tenant: "cgrates.org", ID: "RU1", Units: 1}, false); err != nil {
+		t.Error(err)
+	} else if alcMsg != "ALLOC_SHARED" {
+		t.Errorf("Wrong allocation message: %v", alcMsg)
+	}
+	if _, err := engine2.allocateSharedResource(dm,
+		&ResourceUsage{Tenant: "cgrates.org", ID: "RU2", Units: 1}, false); err != nil {
+		t.Error(err)
+	}
+	if _, err := engine1.allocateSharedResource(dm,
+		&ResourceUsage{Tenant: "cgrates.org", ID: "RU3", Units: 1}, true); err != utils.ErrResourceUnavailable {
+		t.Errorf("Expected: %v, received: %v", utils.ErrResourceUnavailable, err)
+	}
+	if _, err := engine1.allocateSharedResource(dm,
+		&ResourceUsage{Tenant: "cgrates.org", ID: "RU3", Units: 1}, false); err != utils.ErrResourceUnavailable {
+		t.Errorf("Expected: %v, received: %v", utils.ErrResourceUnavailable, err)
+	}
+	if usage := engine1[0].TotalUsage(); usage != 2 {
+		t.Errorf("Expected the usages of both engines, received: %v", usage)
+	}
+	if err := engine2.clearSharedUsage(dm, "RU1"); err != nil {
+		t.Error(err)
+	}
+	if _, err := engine1.allocateSharedResource(dm,
+		&ResourceUsage{Tenant: "cgrates.org", ID: "RU3", Units: 1}, false); err != nil {
+		t.Error(err)
+	}
+	if err := engine2.refreshSharedUsages(dm); err != nil {
+		t.Error(err)
+	} else if _, has := engine2[0].Usages["RU3"]; !has || engine2[0].TotalUsage() != 2 {
+		t.Errorf("Unexpected usages: %s", utils.ToJSON(engine2[0].Usages))
+	}
+	// the usage held by engine1 is not replaced
+	expErr := "duplicate resource usage with id: cgrates.org:RU3"
+	if _, err := engine2.allocateSharedResource(dm,
+		&ResourceUsage{Tenant: "cgrates.org", ID: "RU3", Units: 2}, false); err == nil ||
+		err.Error() != expErr {
+		t.Errorf("Expected error: %s, received: %v", expErr, err)
+	}
+	if usages, err := dm.GetResourceUsages("cgrates.org", "RES_SHARED"); err != nil {
+		t.Error(err)
+	} else if ru, has := usages["RU3"]; !has || ru.Units != 1 {
+		t.Errorf("Unexpected usages: %s", utils.ToJSON(usages))
+	}
+}
+
+func TestResourceRateBasedAllocate(t *testing.T) {
+	cfg, _ := config.NewDefaultCGRConfig()
+	dm := NewDataManager(NewInternalDB(nil, nil, true, cfg.DataDbCfg().Items),
+		config.CgrConfig().CacheCfg(), nil)
+	rPrf := &ResourceProfile{
+		Tenant:            "cgrates.org",
+		ID:                "RES_CPS",
+		AllocationMessage: "CPS",
+		Limit:             2,
+		RateInterval:      50 * time.Millisecond,
+	}
+	newRs := func() Resources {
+		return Resources{{Tenant: "cgrates.org", ID: "RES_CPS", Usages: make(map[string]*ResourceUsage),
+			ttl: utils.DurationPointer(rPrf.RateInterval), rPrf: rPrf}}
+	}
+	local, shared := newRs(), newRs()
+	for _, tc := range []struct {
+		name     string
+		rs       Resources
+		allocate func(ru *ResourceUsage, dryRun bool) (string, error)
+		release  func(ruID string) error
+	}{
+		{"local", local,
+			func(ru *ResourceUsage, dryRun bool) (string, error) { return local.allocateResource(ru, dryRun) },
+			local.clearUsage},
+		{"shared", shared,
+			func(ru *ResourceUsage, dryRun bool) (string, error) {
+				return shared.allocateSharedResource(dm, ru, dryRun)
+			},
+			func(ruID string) error { return shared.clearSharedUsage(dm, ruID) }},
+	} {
+		for _, ruID := range []string{"CALL1", "CALL1"} { // each allocation counts, even for the same usage
+			if alcMsg, err := tc.allocate(&ResourceUsage{Tenant: "cgrates.org", ID: ruID, Units: 1}, false); err != nil {
+				t.Errorf("%s: %v", tc.name, err)
+			} else if alcMsg != "CPS" {
+				t.Errorf("%s: wrong allocation message: %v", tc.name, alcMsg)
+			}
+		}
+		if _, err := tc.allocate(&ResourceUsage{Tenant: "cgrates.org", ID: "CALL2", Units: 1},
+			true); err != utils.ErrResourceUnavailable {
+			t.Errorf("%s: expected: %v, received: %v", tc.name, utils.ErrResourceUnavailable, err)
+		}
+		if err := tc.release("CALL1"); err != nil { // releasing does not give back the units
+			t.Errorf("%s: %v", tc.name, err)
+		}
+		if _, err := tc.allocate(&ResourceUsage{Tenant: "cgrates.org", ID: "CALL2", Units: 1},
+			false); err != utils.ErrResourceUnavailable {
+			t.Errorf("%s: expected: %v, received: %v", tc.name, utils.ErrResourceUnavailable, err)
+		}
+		if usage := tc.rs[0].TotalUsage(); usage != 2 {
+			t.Errorf("%s: expected usage: 2, received: %v", tc.name, usage)
+		}
+		time.Sleep(60 * time.Millisecond) // out of the window
+		if _, err := tc.allocate(&ResourceUsage{Tenant: "cgrates.org", ID: "CALL2", Units: 1}, false); err != nil {
+			t.Errorf("%s: %v", tc.name, err)
+		}
+	}
+}
@@ -112,10 +112,12 @@ func (rs *Responder) GetCost(arg *CallDescriptorWithArgDispatcher, reply *CallCo
 //GetCostOnRatingPlans is used by SupplierS to calculate the cost
 // Receive a list of RatingPlans and pick the first without error
 func (rs *Responder) GetCostOnRatingPlans(arg *utils.GetCostOnRatingPlansArgs, reply *map[string]interface{}) (err error) {
+	// unique category so the concurrent calls for the same subject do not overwrite each other's RatingProfile
+	tmpCtgr := utils.ConcatenatedKey(utils.MetaTmp, utils.GenUUID())
 	for _, rp := range arg.RatingPlanIDs { // loop through RatingPlans until we find one without errors
 		rPrfl := &RatingProfile{
 			Id: utils.ConcatenatedKey(utils.META_OUT,
-				arg.Tenant, utils.MetaTmp, arg.Subject),
+				arg.Tenant, tmpCtgr, arg.Subject),
 			RatingPlanActivations: RatingPlanActivations{
 				&RatingPlanActivation{
 					ActivationTime: arg.SetupTime,
@@ -127,7 +129,7 @@ func (rs *Responder) GetCostOnRatingPlans(arg *utils.GetCostOnRatingPlansArgs, r
 		Cache.Set(utils.CacheRatingProfilesTmp, rPrfl.Id, rPrfl, nil,
 			true, utils.NonTransactional)
 		cd := &CallDescriptor{
-			Category:      utils.MetaTmp,
+			Category:      tmpCtgr,
 			Tenant:        arg.Tenant,
 			Subject:       arg.Subject,
 			Account:       arg.Account,
@@ -154,6 +156,39 @@ func (rs *Responder) GetCostOnRatingPlans(arg *utils.GetCostOnRatingPlansArgs, r
 	return
 }
 
+// GetCostsOnRatingPlans is used by SupplierS to calculate the cost on multiple lists of RatingPlans in one call
+// the lists are rated concurrently and the errors are returned individually for each list
+func (rs *Responder) GetCostsOnRatingPlans(arg *utils.GetCostsOnRatingPlansArgs,
+	reply *map[string]*utils.CostOnRatingPlans) (err error) {
+	costs := make(map[string]*utils.CostOnRatingPlans, len(arg.RatingPlanIDs))
+	var costsMux sync.Mutex
+	var wg sync.WaitGroup
+	for id, rpIDs := range arg.RatingPlanIDs {
+		wg.Add(1)
+		go func(id string, rpIDs []string) {
+			defer wg.Done()
+			cost := new(utils.CostOnRatingPlans)
+			if err := rs.GetCostOnRatingPlans(&utils.GetCostOnRatingPlansArgs{
+				Account:       arg.Account,
+				Subject:       arg.Subject,
+				Destination:   arg.Destination,
+				Tenant:        arg.Tenant,
+				SetupTime:     arg.SetupTime,
+				Usage:         arg.Usage,
+				RatingPlanIDs: rpIDs,
+			}, &cost.CostData); err != nil {
+				cost.Error = err.Error()
+			}
+			costsMux.Lock()
+			costs[id] = cost
+			costsMux.Unlock()
+		}(id, rpIDs)
+	}
+	wg.Wait()
+	*reply = costs
+	return
+}
+
 func (rs *Responder) Debit(arg *CallDescriptorWithArgDispatcher, reply *CallCost) (err error) {
 	// RPC caching
 	if arg.CgrID != utils.EmptyString && config.CgrConfig().CacheCfg().Partitions[utils.CacheRPCResponses].Limit != 0 {
@@ -474,3 +474,51 @@ func TestResponderGetMaxSessionTimeMaxUsageVOICE(t *testing.T) {
 		t.Errorf("Expected %+v, received : %+v", utils.ErrMaxUsageExceeded, err)
 	}
 }
+
+func TestResponderGetCostsOnRatingPlans(t *testing.T) {
+	args := &utils.GetCostsOnRatingPlansArgs{
+		Account:     "1001",
+		Subject:     "1001",
+		Destination: "447956933443",
+		Tenant:      "cgrates.org",
+		SetupTime:   time.Date(2020, 4, 20, 10, 0, 0, 0, time.UTC),
+		Usage:       time.Minute,
+		RatingPlanIDs: map[string][]string{
+			"UK":      {"RP_UK"},
+			"UK_PKG":  {"RP_UK_Mobile_BIG5_PKG"},
+			"NO_RATE": {"RP_DATA_NOT_EXISTING"},
+		},
+	}
+	var costs map[string]*utils.CostOnRatingPlans
+	if err := rsponder.GetCostsOnRatingPlans(args, &costs); err != nil {
+		t.Fatal(err)
+	}
+	if len(costs) != 3 {
+		t.Fatalf("Expecting 3 costs, received: %s", utils.ToJSON(costs))
+	}
+	// the lists rated concurrently should cost the same as the ones rated one by one
+	for id, rpIDs := range args.RatingPlanIDs {
+		var eCostData map[string]interface{}
+		if err := rsponder.GetCostOnRatingPlans(&utils.GetCostOnRatingPlansArgs{
+			Account:       args.Account,
+			Subject:       args.Subject,
+			Destination:   args.Destination,
+			Tenant:        args.Tenant,
+			SetupTime:     args.SetupTime,
+			Usage:         args.Usage,
+			RatingPlanIDs: rpIDs,
+		}, &eCostData); err != nil {
+			if costs[id].Error != err.Error() {
+				t.Errorf("Expecting for %s error: %v, received: %+v", id, err, costs[id].Error)
+			}
+			continue
+		}
+		if !reflect.DeepEqual(eCostData, costs[id].CostData) {
+			t.Errorf("Expecting for %s: %+v, received: %+v", id, eCostData, costs[id].CostData)
+		}
+	}
+	if costs["UK"].CostData == nil || costs["UK_PKG"].CostData == nil ||
+		costs["UK"].CostData[utils.Cost] == costs["UK_PKG"].CostData[utils.Cost] {
+		t.Errorf("Expecting different costs, received: %s", utils.ToJSON(costs))
+	}
+}
@@ -0,0 +1,104 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package engine
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/cgrates/cgrates/utils"
+)
+
+func NewLeastCostQOSSorter(spS *SupplierService) *LeastCostQOSSorter {
+	return &LeastCostQOSSorter{spS: spS,
+		sorting: utils.MetaLCQOS}
+}
+
+// LeastCostQOSSorter sorts suppliers based on their cost,
+// penalising or excluding the ones not meeting the quality thresholds
+type LeastCostQOSSorter struct {
+	sorting string
+	spS     *SupplierService
+}
+
+func (lcq *LeastCostQOSSorter) SortSuppliers(prflID string, suppls []*Supplier,
+	ev *utils.CGREvent, extraOpts *optsGetSuppliers) (sortedSuppls *SortedSuppliers, err error) {
+	sortedSuppls = &SortedSuppliers{ProfileID: prflID,
+		Sorting:         lcq.sorting,
+		SortedSuppliers: make([]*SortedSupplier, 0)}
+	opts := *extraOpts
+	opts.sortingParameters = nil // the sorting parameters are the thresholds compiled on suppliers
+	for _, s := range suppls {
+		if len(s.RatingPlanIDs) == 0 {
+			utils.Logger.Warning(
+				fmt.Sprintf("<%s> supplier: <%s> - empty RatingPlanIDs",
+					utils.SupplierS, s.ID))
+			return nil, utils.NewErrMandatoryIeMissing("RatingPlanIDs")
+		}
+		srtSpl, pass, err := lcq.spS.populateSortingData(ev, s, &opts)
+		if err != nil {
+			return nil, err
+		} else if !pass || srtSpl == nil {
+			continue
+		}
+		thds, _ := s.cacheSupplier[utils.MetaQOSThresholds].(map[string]float64)
+		if violations := qosViolations(srtSpl.SortingData, thds); len(violations) != 0 {
+			if exclude, _ := s.cacheSupplier[utils.MetaExclude].(bool); exclude {
+				utils.Logger.Info(
+					fmt.Sprintf("<%s> excluding supplier: <%s> for profile: <%s>, quality violations: %v",
+						utils.SupplierS, s.ID, prflID, violations))
+				continue
+			}
+			srtSpl.SortingData[utils.QOSViolations] = violations
+		}
+		sortedSuppls.SortedSuppliers = append(sortedSuppls.SortedSuppliers, srtSpl)
+	}
+	sortedSuppls.SortLeastCostQOS()
+	return
+}
+
+// qosViolations returns the list of metrics not meeting their threshold, sorted for consistency
+// *pdd threshold is considered the maximum value accepted while for the rest of metrics it is the minimum
+// a metric which is not available is considered a violation
+func qosViolations(sortingData map[string]interface{}, thds map[string]float64) (violations []string) {
+	for metric, thdVal := range thds {
+		val, has := sortingData[metric]
+		if !has {
+			violations = append(violations, fmt.Sprintf("%s:%s", metric, utils.NOT_AVAILABLE))
+			continue
+		}
+		metricVal, err := utils.IfaceAsFloat64(val)
+		if err != nil || metricVal == STATS_NA {
+			violations = append(violations, fmt.Sprintf("%s:%s", metric, utils.NOT_AVAILABLE))
+			continue
+		}
+		switch metric {
+		default:
+			if metricVal < thdVal {
+				violations = append(violations, fmt.Sprintf("%s:%v<%v", metric, metricVal, thdVal))
+			}
+		case utils.MetaPDD: // in case of pdd the smallest value is the best
+			if metricVal > thdVal {
+				violations = append(violations, fmt.Sprintf("%s:%v>%v", metric, metricVal, thdVal))
+			}
+		}
+	}
+	sort.Strings(violations)
+	return
+}
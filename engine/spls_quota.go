@@ -0,0 +1,102 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package engine
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/cgrates/cgrates/utils"
+)
+
+func NewQuotaSorter(spS *SupplierService) *QuotaSorter {
+	return &QuotaSorter{spS: spS,
+		sorting: utils.MetaQuota}
+}
+
+// QuotaSorter orders suppliers so the volume delivered converges on their commitments
+type QuotaSorter struct {
+	sorting string
+	spS     *SupplierService
+}
+
+func (qs *QuotaSorter) SortSuppliers(prflID string,
+	suppls []*Supplier, suplEv *utils.CGREvent, extraOpts *optsGetSuppliers) (sortedSuppls *SortedSuppliers, err error) {
+	sortedSuppls = &SortedSuppliers{ProfileID: prflID,
+		Sorting:         qs.sorting,
+		SortedSuppliers: make([]*SortedSupplier, 0)}
+	quotas := make(map[string]*supplierQuota)
+	var totalVolume float64
+	for _, s := range suppls {
+		// the volume delivered is counted out of StatS (ie: *tcd or *sum#~*req.Usage)
+		if len(s.StatIDs) == 0 {
+			utils.Logger.Warning(
+				fmt.Sprintf("<%s> supplier: <%s> - empty StatIDs",
+					utils.SupplierS, s.ID))
+			return nil, utils.NewErrMandatoryIeMissing("StatIDs")
+		}
+		srtSpl, pass, err := qs.spS.populateSortingData(suplEv, s, extraOpts)
+		if err != nil {
+			return nil, err
+		} else if !pass || srtSpl == nil {
+			continue
+		}
+		totalVolume += srtSpl.SortingData[utils.Load].(float64)
+		if quota, has := s.cacheSupplier[utils.MetaQuota].(*supplierQuota); has {
+			quotas[s.ID] = quota
+		}
+		sortedSuppls.SortedSuppliers = append(sortedSuppls.SortedSuppliers, srtSpl)
+	}
+	// the progress is computed only after the total volume is known
+	for _, srtSpl := range sortedSuppls.SortedSuppliers {
+		volume := srtSpl.SortingData[utils.Load].(float64)
+		share := 0.0
+		if totalVolume != 0 {
+			share = volume * 100 / totalVolume
+		}
+		srtSpl.SortingData[utils.Share] = share
+		quota, has := quotas[srtSpl.SupplierID]
+		if !has { // without commitment the supplier will be sorted last
+			continue
+		}
+		srtSpl.SortingData[utils.Target] = quota.value
+		if quota.percentage {
+			srtSpl.SortingData[utils.Progress] = share * 100 / quota.value
+		} else {
+			srtSpl.SortingData[utils.Progress] = volume * 100 / quota.value
+		}
+	}
+	sortedSuppls.SortQuota()
+	return
+}
+
+// quotaPeriodStart returns the start of the calendar period containing now
+// the weeks are starting on Monday
+func quotaPeriodStart(period string, now time.Time) time.Time {
+	y, m, d := now.Date()
+	switch period {
+	case utils.MetaWeekly:
+		d -= (int(now.Weekday()) + 6) % 7
+	case utils.MetaMonthly:
+		d = 1
+	case utils.MetaYearly:
+		m, d = time.January, 1
+	}
+	return time.Date(y, m, d, 0, 0, 0, 0, now.Location())
+}
@@ -20,6 +20,8 @@ package engine
 
 import (
 	"fmt"
+	"math"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
@@ -57,10 +59,21 @@ func NewStatMetric(metricID string, minItems int, filterIDs []string) (sm StatMe
 		utils.MetaSum:      NewStatSum,
 		utils.MetaAverage:  NewStatAverage,
 		utils.MetaDistinct: NewStatDistinct,
+		utils.MetaMin:      NewStatMin,
+		utils.MetaMax:      NewStatMax,
+		utils.MetaStdDev:   NewStatStdDev,
 	}
 	// split the metricID
 	// in case of *sum we have *sum:~*req.FieldName
 	metricSplit := utils.SplitConcatenatedKey(metricID)
+	if strings.HasPrefix(metricSplit[0], utils.MetaPercentile) { // *percentile95:~*req.FieldName
+		var extraParams string
+		if len(metricSplit[1:]) > 0 {
+			extraParams = metricSplit[1]
+		}
+		return NewStatPercentile(minItems, extraParams, filterIDs,
+			strings.TrimPrefix(metricSplit[0], utils.MetaPercentile))
+	}
 	if _, has := metrics[metricSplit[0]]; !has {
 		return nil, fmt.Errorf("unsupported metric type <%s>", metricSplit[0])
 	}
@@ -83,6 +96,7 @@ type StatMetric interface {
 	GetFilterIDs() (filterIDs []string)
 	Compress(queueLen int64, defaultID string) (eventIDs []string)
 	GetCompressFactor(events map[string]int) map[string]int
+	Merge(oMetric StatMetric)
 }
 
 func NewASR(minItems int, extraParams string, filterIDs []string) (StatMetric, error) {
@@ -223,6 +237,17 @@ func (asr *StatASR) GetCompressFactor(events map[string]int) map[string]int {
 	return events
 }
 
+// Merge adds the aggregated values of oMetric, without its events, as part of StatMetric interface
+func (asr *StatASR) Merge(oMetric StatMetric) {
+	o, canCast := oMetric.(*StatASR)
+	if !canCast {
+		return
+	}
+	asr.Answered += o.Answered
+	asr.Count += o.Count
+	asr.val = nil
+}
+
 func NewACD(minItems int, extraParams string, filterIDs []string) (StatMetric, error) {
 	return &StatACD{Events: make(map[string]*DurationWithCompress), MinItems: minItems, FilterIDs: filterIDs}, nil
 }
@@ -350,6 +375,17 @@ func (acd *StatACD) GetCompressFactor(events map[string]int) map[string]int {
 	return events
 }
 
+// Merge adds the aggregated values of oMetric, without its events, as part of StatMetric interface
+func (acd *StatACD) Merge(oMetric StatMetric) {
+	o, canCast := oMetric.(*StatACD)
+	if !canCast {
+		return
+	}
+	acd.Sum += o.Sum
+	acd.Count += o.Count
+	acd.val = nil
+}
+
 func NewTCD(minItems int, extraParams string, filterIDs []string) (StatMetric, error) {
 	return &StatTCD{Events: make(map[string]*DurationWithCompress), MinItems: minItems, FilterIDs: filterIDs}, nil
 }
@@ -478,6 +514,17 @@ func (tcd *StatTCD) GetCompressFactor(events map[string]int) map[string]int {
 	return events
 }
 
+// Merge adds the aggregated values of oMetric, without its events, as part of StatMetric interface
+func (tcd *StatTCD) Merge(oMetric StatMetric) {
+	o, canCast := oMetric.(*StatTCD)
+	if !canCast {
+		return
+	}
+	tcd.Sum += o.Sum
+	tcd.Count += o.Count
+	tcd.val = nil
+}
+
 func NewACC(minItems int, extraParams string, filterIDs []string) (StatMetric, error) {
 	return &StatACC{Events: make(map[string]*StatWithCompress), MinItems: minItems, FilterIDs: filterIDs}, nil
 }
@@ -602,6 +649,17 @@ func (acc *StatACC) GetCompressFactor(events map[string]int) map[string]int {
 	return events
 }
 
+// Merge adds the aggregated values of oMetric, without its events, as part of StatMetric interface
+func (acc *StatACC) Merge(oMetric StatMetric) {
+	o, canCast := oMetric.(*StatACC)
+	if !canCast {
+		return
+	}
+	acc.Sum += o.Sum
+	acc.Count += o.Count
+	acc.val = nil
+}
+
 func NewTCC(minItems int, extraParams string, filterIDs []string) (StatMetric, error) {
 	return &StatTCC{Events: make(map[string]*StatWithCompress), MinItems: minItems, FilterIDs: filterIDs}, nil
 }
@@ -728,6 +786,17 @@ func (tcc *StatTCC) GetCompressFactor(events map[string]int) map[string]int {
 	return events
 }
 
+// Merge adds the aggregated values of oMetric, without its events, as part of StatMetric interface
+func (tcc *StatTCC) Merge(oMetric StatMetric) {
+	o, canCast := oMetric.(*StatTCC)
+	if !canCast {
+		return
+	}
+	tcc.Sum += o.Sum
+	tcc.Count += o.Count
+	tcc.val = nil
+}
+
 func NewPDD(minItems int, extraParams string, filterIDs []string) (StatMetric, error) {
 	return &StatPDD{Events: make(map[string]*DurationWithCompress), MinItems: minItems, FilterIDs: filterIDs}, nil
 }
@@ -855,6 +924,17 @@ func (pdd *StatPDD) GetCompressFactor(events map[string]int) map[string]int {
 	return events
 }
 
+// Merge adds the aggregated values of oMetric, without its events, as part of StatMetric interface
+func (pdd *StatPDD) Merge(oMetric StatMetric) {
+	o, canCast := oMetric.(*StatPDD)
+	if !canCast {
+		return
+	}
+	pdd.Sum += o.Sum
+	pdd.Count += o.Count
+	pdd.val = nil
+}
+
 func NewDDC(minItems int, extraParams string, filterIDs []string) (StatMetric, error) {
 	return &StatDDC{Events: make(map[string]map[string]int64), FieldValues: make(map[string]map[string]struct{}),
 		MinItems: minItems, FilterIDs: filterIDs}, nil
@@ -989,6 +1069,20 @@ func (ddc *StatDDC) GetCompressFactor(events map[string]int) map[string]int {
 	return events
 }
 
+// Merge adds the aggregated values of oMetric, without its events, as part of StatMetric interface
+func (ddc *StatDDC) Merge(oMetric StatMetric) {
+	o, canCast := oMetric.(*StatDDC)
+	if !canCast {
+		return
+	}
+	for fieldValue := range o.FieldValues {
+		if _, has := ddc.FieldValues[fieldValue]; !has {
+			ddc.FieldValues[fieldValue] = make(map[string]struct{})
+		}
+	}
+	ddc.Count += o.Count
+}
+
 func NewStatSum(minItems int, extraParams string, filterIDs []string) (StatMetric, error) {
 	return &StatSum{Events: make(map[string]*StatWithCompress),
 		MinItems: minItems, FieldName: extraParams, FilterIDs: filterIDs}, nil
@@ -1007,7 +1101,7 @@ type StatSum struct {
 // getValue returns tcd.val
 func (sum *StatSum) getValue() float64 {
 	if sum.val == nil {
-		if len(sum.Events) == 0 || sum.Count < int64(sum.MinItems) {
+		if sum.Count == 0 || sum.Count < int64(sum.MinItems) {
 			sum.val = utils.Float64Pointer(STATS_NA)
 		} else {
 			sum.val = utils.Float64Pointer(utils.Round(sum.Sum,
@@ -1126,6 +1220,17 @@ func (sum *StatSum) GetCompressFactor(events map[string]int) map[string]int {
 	return events
 }
 
+// Merge adds the aggregated values of oMetric, without its events, as part of StatMetric interface
+func (sum *StatSum) Merge(oMetric StatMetric) {
+	o, canCast := oMetric.(*StatSum)
+	if !canCast {
+		return
+	}
+	sum.Sum += o.Sum
+	sum.Count += o.Count
+	sum.val = nil
+}
+
 func NewStatAverage(minItems int, extraParams string, filterIDs []string) (StatMetric, error) {
 	return &StatAverage{Events: make(map[string]*StatWithCompress),
 		MinItems: minItems, FieldName: extraParams, FilterIDs: filterIDs}, nil
@@ -1264,6 +1369,17 @@ func (avg *StatAverage) GetCompressFactor(events map[string]int) map[string]int
 	return events
 }
 
+// Merge adds the aggregated values of oMetric, without its events, as part of StatMetric interface
+func (avg *StatAverage) Merge(oMetric StatMetric) {
+	o, canCast := oMetric.(*StatAverage)
+	if !canCast {
+		return
+	}
+	avg.Sum += o.Sum
+	avg.Count += o.Count
+	avg.val = nil
+}
+
 func NewStatDistinct(minItems int, extraParams string, filterIDs []string) (StatMetric, error) {
 	return &StatDistinct{Events: make(map[string]map[string]int64), FieldValues: make(map[string]map[string]struct{}),
 		MinItems: minItems, FieldName: extraParams, FilterIDs: filterIDs}, nil
@@ -1403,3 +1519,444 @@ func (dst *StatDistinct) GetCompressFactor(events map[string]int) map[string]int
 	}
 	return events
 }
+
+// Merge adds the aggregated values of oMetric, without its events, as part of StatMetric interface
+func (dst *StatDistinct) Merge(oMetric StatMetric) {
+	o, canCast := oMetric.(*StatDistinct)
+	if !canCast {
+		return
+	}
+	for fieldValue := range o.FieldValues {
+		if _, has := dst.FieldValues[fieldValue]; !has {
+			dst.FieldValues[fieldValue] = make(map[string]struct{})
+		}
+	}
+	dst.Count += o.Count
+}
+
+// statFieldAsFloat64 returns the value of the metric field out of the event
+// fieldName is either a ~*req. path or a constant value
+func statFieldAsFloat64(ev *utils.CGREvent, fieldName string) (val float64, err error) {
+	if !strings.HasPrefix(fieldName, utils.DynamicDataPrefix+utils.MetaReq+utils.NestingSep) { // ~*req.
+		return utils.IfaceAsFloat64(fieldName)
+	}
+	//Remove the dynamic prefix and check in event for field
+	field := fieldName[6:]
+	if val, err = ev.FieldAsFloat64(field); err != nil {
+		if err == utils.ErrNotFound {
+			err = utils.ErrPrefix(err, field)
+		}
+	}
+	return
+}
+
+// maxCompressedStatValues limits the number of distinct values kept by the distribution metrics after compress
+const maxCompressedStatValues = 100
+
+// statEntry is one value of the distribution together with its event ID
+type statEntry struct {
+	id string
+	*StatWithCompress
+}
+
+// sortedStatEntries returns the events in ascending order of their values
+func sortedStatEntries(events map[string]*StatWithCompress) (entries []statEntry) {
+	entries = make([]statEntry, 0, len(events))
+	for id, ev := range events {
+		entries = append(entries, statEntry{id: id, StatWithCompress: ev})
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		if entries[i].Stat == entries[j].Stat {
+			return entries[i].id < entries[j].id
+		}
+		return entries[i].Stat < entries[j].Stat
+	})
+	return
+}
+
+// compressedStatID returns the ID kept when merging two events, preferring the defaultID so the IDs are predictable
+func compressedStatID(id1, id2, defaultID string) string {
+	if id2 == defaultID || (id1 != defaultID && id2 < id1) {
+		return id2
+	}
+	return id1
+}
+
+// compressStatsByValue merges the events having the same value into one event
+// if too many distinct values remain, the neighbouring values are merged into their weighted average
+// keeping the minimum and maximum values unchanged
+func compressStatsByValue(events map[string]*StatWithCompress, defaultID string) (eventIDs []string) {
+	byValue := make(map[float64]string) // map[Stat]eventID
+	for _, entry := range sortedStatEntries(events) {
+		keptID, has := byValue[entry.Stat]
+		if !has {
+			byValue[entry.Stat] = entry.id
+			continue
+		}
+		dropID := entry.id
+		if newID := compressedStatID(keptID, entry.id, defaultID); newID != keptID {
+			byValue[entry.Stat] = newID
+			dropID = keptID
+		}
+		events[byValue[entry.Stat]].CompressFactor += events[dropID].CompressFactor
+		delete(events, dropID)
+	}
+	if len(events) > maxCompressedStatValues {
+		mergeNeighbourStats(events, defaultID, maxCompressedStatValues)
+	}
+	for id := range events {
+		eventIDs = append(eventIDs, id)
+	}
+	return
+}
+
+// mergeNeighbourStats reduces the events to maxLen values, grouping the neighbouring values of similar weight
+func mergeNeighbourStats(events map[string]*StatWithCompress, defaultID string, maxLen int) {
+	entries := sortedStatEntries(events)
+	inner := entries[1 : len(entries)-1] // the extremes are kept as they are
+	var weight int
+	for _, entry := range inner {
+		weight += entry.CompressFactor
+	}
+	groups := maxLen - 2
+	var cumWeight, grpIdx int
+	var grp []statEntry
+	for i, entry := range inner {
+		grp = append(grp, entry)
+		cumWeight += entry.CompressFactor
+		if i != len(inner)-1 &&
+			cumWeight*groups/weight == grpIdx { // the next entry falls in the same group
+			continue
+		}
+		mergeStatEntries(events, grp, defaultID)
+		grp = grp[:0]
+		grpIdx = cumWeight * groups / weight
+	}
+}
+
+// mergeStatEntries merges the entries into one event having their weighted average as value
+func mergeStatEntries(events map[string]*StatWithCompress, entries []statEntry, defaultID string) {
+	if len(entries) < 2 {
+		return
+	}
+	keptID := entries[0].id
+	var sum float64
+	var cf int
+	for _, entry := range entries {
+		keptID = compressedStatID(keptID, entry.id, defaultID)
+		sum += entry.Stat * float64(entry.CompressFactor)
+		cf += entry.CompressFactor
+		delete(events, entry.id)
+	}
+	events[keptID] = &StatWithCompress{Stat: sum / float64(cf), CompressFactor: cf}
+}
+
+// statsCompressFactor updates the events compress factor with the ones of the metric
+func statsCompressFactor(metricEvents map[string]*StatWithCompress, events map[string]int) map[string]int {
+	for id, val := range metricEvents {
+		if _, has := events[id]; !has {
+			events[id] = val.CompressFactor
+		}
+		if events[id] < val.CompressFactor {
+			events[id] = val.CompressFactor
+		}
+	}
+	return events
+}
+
+// statFloat64AsString formats the value of a metric
+func statFloat64AsString(val float64) string {
+	if val == STATS_NA {
+		return utils.NOT_AVAILABLE
+	}
+	return strconv.FormatFloat(val, 'f', -1, 64)
+}
+
+func newStatValues(minItems int, fieldName string, filterIDs []string) statValues {
+	return statValues{Events: make(map[string]*StatWithCompress),
+		MinItems: minItems, FieldName: fieldName, FilterIDs: filterIDs}
+}
+
+// statValues keeps the distribution of the values used by the *min, *max, *stddev and *percentile metrics
+type statValues struct {
+	FilterIDs []string
+	Count     int64
+	Events    map[string]*StatWithCompress // map[EventTenantID]Value
+	MinItems  int
+	FieldName string
+	val       *float64 // cached metric value
+}
+
+// cachedValue returns the cached metric value, computing it out of the values if needed
+func (sv *statValues) cachedValue(compute func() float64) float64 {
+	if sv.val == nil {
+		if (sv.MinItems > 0 && sv.Count < int64(sv.MinItems)) || (sv.Count == 0) {
+			sv.val = utils.Float64Pointer(STATS_NA)
+		} else {
+			sv.val = utils.Float64Pointer(utils.Round(compute(),
+				config.CgrConfig().GeneralCfg().RoundingDecimals, utils.ROUNDING_MIDDLE))
+		}
+	}
+	return *sv.val
+}
+
+// AddEvent is part of StatMetric interface
+func (sv *statValues) AddEvent(ev *utils.CGREvent) (err error) {
+	var val float64
+	if val, err = statFieldAsFloat64(ev, sv.FieldName); err != nil {
+		return
+	}
+	if v, has := sv.Events[ev.ID]; !has {
+		sv.Events[ev.ID] = &StatWithCompress{Stat: val, CompressFactor: 1}
+	} else { // average it with the previous values received for the same event
+		v.Stat = (v.Stat*float64(v.CompressFactor) + val) / float64(v.CompressFactor+1)
+		v.CompressFactor = v.CompressFactor + 1
+	}
+	sv.Count += 1
+	sv.val = nil
+	return
+}
+
+// RemEvent is part of StatMetric interface
+func (sv *statValues) RemEvent(evID string) (err error) {
+	val, has := sv.Events[evID]
+	if !has {
+		return utils.ErrNotFound
+	}
+	if val.CompressFactor <= 1 {
+		delete(sv.Events, evID)
+	} else {
+		val.CompressFactor = val.CompressFactor - 1
+	}
+	sv.Count -= 1
+	sv.val = nil
+	return
+}
+
+// GetFilterIDs is part of StatMetric interface
+func (sv *statValues) GetFilterIDs() []string {
+	return sv.FilterIDs
+}
+
+// Compress is part of StatMetric interface
+func (sv *statValues) Compress(queueLen int64, defaultID string) (eventIDs []string) {
+	if sv.Count < queueLen {
+		for id := range sv.Events {
+			eventIDs = append(eventIDs, id)
+		}
+		return
+	}
+	sv.val = nil
+	return compressStatsByValue(sv.Events, defaultID)
+}
+
+// GetCompressFactor is part of StatMetric interface
+func (sv *statValues) GetCompressFactor(events map[string]int) map[string]int {
+	return statsCompressFactor(sv.Events, events)
+}
+
+// getStatValues gives access to the values of the distribution metrics
+func (sv *statValues) getStatValues() *statValues {
+	return sv
+}
+
+// Merge adds the values of oMetric as part of StatMetric interface
+// the values are indexed on themselves instead of the event IDs
+func (sv *statValues) Merge(oMetric StatMetric) {
+	o, canCast := oMetric.(interface{ getStatValues() *statValues })
+	if !canCast {
+		return
+	}
+	for _, oVal := range o.getStatValues().Events {
+		valID := strconv.FormatFloat(oVal.Stat, 'f', -1, 64)
+		if v, has := sv.Events[valID]; !has {
+			sv.Events[valID] = &StatWithCompress{Stat: oVal.Stat, CompressFactor: oVal.CompressFactor}
+		} else { // the value might have been merged with its neighbours before
+			v.Stat = (v.Stat*float64(v.CompressFactor) + oVal.Stat*float64(oVal.CompressFactor)) /
+				float64(v.CompressFactor+oVal.CompressFactor)
+			v.CompressFactor += oVal.CompressFactor
+		}
+	}
+	if len(sv.Events) > maxCompressedStatValues {
+		mergeNeighbourStats(sv.Events, utils.EmptyString, maxCompressedStatValues)
+	}
+	sv.Count += o.getStatValues().Count
+	sv.val = nil
+}
+
+func NewStatMin(minItems int, extraParams string, filterIDs []string) (StatMetric, error) {
+	return &StatMin{statValues: newStatValues(minItems, extraParams, filterIDs)}, nil
+}
+
+// StatMin implements the minimum value metric
+type StatMin struct {
+	statValues
+}
+
+// getValue returns mn.val
+func (mn *StatMin) getValue() float64 {
+	return mn.cachedValue(func() (min float64) {
+		min = math.Inf(1)
+		for _, ev := range mn.Events {
+			min = math.Min(min, ev.Stat)
+		}
+		return
+	})
+}
+
+func (mn *StatMin) GetStringValue(fmtOpts string) (valStr string) {
+	return statFloat64AsString(mn.getValue())
+}
+
+func (mn *StatMin) GetValue() (v interface{}) {
+	return mn.getValue()
+}
+
+func (mn *StatMin) GetFloat64Value() (v float64) {
+	return mn.getValue()
+}
+
+func (mn *StatMin) Marshal(ms Marshaler) (marshaled []byte, err error) {
+	return ms.Marshal(mn)
+}
+
+func (mn *StatMin) LoadMarshaled(ms Marshaler, marshaled []byte) (err error) {
+	return ms.Unmarshal(marshaled, mn)
+}
+
+func NewStatMax(minItems int, extraParams string, filterIDs []string) (StatMetric, error) {
+	return &StatMax{statValues: newStatValues(minItems, extraParams, filterIDs)}, nil
+}
+
+// StatMax implements the maximum value metric
+type StatMax struct {
+	statValues
+}
+
+// getValue returns mx.val
+func (mx *StatMax) getValue() float64 {
+	return mx.cachedValue(func() (max float64) {
+		max = math.Inf(-1)
+		for _, ev := range mx.Events {
+			max = math.Max(max, ev.Stat)
+		}
+		return
+	})
+}
+
+func (mx *StatMax) GetStringValue(fmtOpts string) (valStr string) {
+	return statFloat64AsString(mx.getValue())
+}
+
+func (mx *StatMax) GetValue() (v interface{}) {
+	return mx.getValue()
+}
+
+func (mx *StatMax) GetFloat64Value() (v float64) {
+	return mx.getValue()
+}
+
+func (mx *StatMax) Marshal(ms Marshaler) (marshaled []byte, err error) {
+	return ms.Marshal(mx)
+}
+
+func (mx *StatMax) LoadMarshaled(ms Marshaler, marshaled []byte) (err error) {
+	return ms.Unmarshal(marshaled, mx)
+}
+
+func NewStatStdDev(minItems int, extraParams string, filterIDs []string) (StatMetric, error) {
+	return &StatStdDev{statValues: newStatValues(minItems, extraParams, filterIDs)}, nil
+}
+
+// StatStdDev implements the (population) standard deviation metric
+type StatStdDev struct {
+	statValues
+}
+
+// getValue returns std.val
+func (std *StatStdDev) getValue() float64 {
+	return std.cachedValue(func() float64 {
+		var sum, sumSq float64
+		for _, ev := range std.Events {
+			sum += ev.Stat * float64(ev.CompressFactor)
+			sumSq += ev.Stat * ev.Stat * float64(ev.CompressFactor)
+		}
+		mean := sum / float64(std.Count)
+		variance := sumSq/float64(std.Count) - mean*mean
+		if variance < 0 { // floating point errors
+			variance = 0
+		}
+		return math.Sqrt(variance)
+	})
+}
+
+func (std *StatStdDev) GetStringValue(fmtOpts string) (valStr string) {
+	return statFloat64AsString(std.getValue())
+}
+
+func (std *StatStdDev) GetValue() (v interface{}) {
+	return std.getValue()
+}
+
+func (std *StatStdDev) GetFloat64Value() (v float64) {
+	return std.getValue()
+}
+
+func (std *StatStdDev) Marshal(ms Marshaler) (marshaled []byte, err error) {
+	return ms.Marshal(std)
+}
+
+func (std *StatStdDev) LoadMarshaled(ms Marshaler, marshaled []byte) (err error) {
+	return ms.Unmarshal(marshaled, std)
+}
+
+// NewStatPercentile returns the metric for the percent percentile (ie. 95 for *percentile95)
+func NewStatPercentile(minItems int, extraParams string, filterIDs []string, percent string) (StatMetric, error) {
+	prcnt, err := strconv.ParseFloat(percent, 64)
+	if err != nil || prcnt <= 0 || prcnt > 100 {
+		return nil, fmt.Errorf("invalid percentile <%s>", percent)
+	}
+	return &StatPercentile{statValues: newStatValues(minItems, extraParams, filterIDs),
+		Percent: prcnt}, nil
+}
+
+// StatPercentile implements the percentile metric using the nearest-rank method
+type StatPercentile struct {
+	statValues
+	Percent float64
+}
+
+// getValue returns prc.val
+func (prc *StatPercentile) getValue() float64 {
+	return prc.cachedValue(func() (val float64) {
+		rank := int(math.Ceil(prc.Percent / 100 * float64(prc.Count)))
+		var cumCF int
+		for _, entry := range sortedStatEntries(prc.Events) {
+			val = entry.Stat
+			if cumCF += entry.CompressFactor; cumCF >= rank {
+				break
+			}
+		}
+		return
+	})
+}
+
+func (prc *StatPercentile) GetStringValue(fmtOpts string) (valStr string) {
+	return statFloat64AsString(prc.getValue())
+}
+
+func (prc *StatPercentile) GetValue() (v interface{}) {
+	return prc.getValue()
+}
+
+func (prc *StatPercentile) GetFloat64Value() (v float64) {
+	return prc.getValue()
+}
+
+func (prc *StatPercentile) Marshal(ms Marshaler) (marshaled []byte, err error) {
+	return ms.Marshal(prc)
+}
+
+func (prc *StatPercentile) LoadMarshaled(ms Marshaler, marshaled []byte) (err error) {
+	return ms.Unmarshal(marshaled, prc)
+}
@@ -18,6 +18,7 @@ along with this program.  If not, see <http://www.gnu.org/licenses/>
 package engine
 
 import (
+	"fmt"
 	"reflect"
 	"sort"
 	"testing"
@@ -3051,3 +3052,256 @@ func TestStatDistrictMarshal(t *testing.T) {
 		t.Errorf("Expected: %s , recived: %s", utils.ToJSON(statDistinct), utils.ToJSON(nStatDistinct))
 	}
 }
+
+func TestStatDistributionMetrics(t *testing.T) {
+	metrics := make(map[string]StatMetric)
+	for _, metricID := range []string{
+		utils.MetaMin + utils.InInFieldSep + "~*req.PDD",
+		utils.MetaMax + utils.InInFieldSep + "~*req.PDD",
+		utils.MetaStdDev + utils.InInFieldSep + "~*req.PDD",
+		utils.MetaPercentile + "50" + utils.InInFieldSep + "~*req.PDD",
+		utils.MetaPercentile + "95" + utils.InInFieldSep + "~*req.PDD",
+	} {
+		metric, err := NewStatMetric(metricID, 2, []string{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		metrics[metricID] = metric
+	}
+	for _, metric := range metrics {
+		if strVal := metric.GetStringValue(""); strVal != utils.NOT_AVAILABLE {
+			t.Errorf("wrong value: %s", strVal)
+		}
+	}
+	for i, pdd := range []float64{2, 4, 4, 4, 5, 5, 7, 9, 10, 100} {
+		for _, metric := range metrics {
+			if err := metric.AddEvent(&utils.CGREvent{Tenant: "cgrates.org",
+				ID:    utils.ConcatenatedKey("EVENT", utils.IfaceAsString(i)),
+				Event: map[string]interface{}{utils.PDD: pdd}}); err != nil {
+				t.Error(err)
+			}
+		}
+	}
+	eVals := map[string]float64{
+		utils.MetaMin + utils.InInFieldSep + "~*req.PDD":               2,
+		utils.MetaMax + utils.InInFieldSep + "~*req.PDD":               100,
+		utils.MetaStdDev + utils.InInFieldSep + "~*req.PDD":            28.42886,
+		utils.MetaPercentile + "50" + utils.InInFieldSep + "~*req.PDD": 5,
+		utils.MetaPercentile + "95" + utils.InInFieldSep + "~*req.PDD": 100,
+	}
+	for metricID, eVal := range eVals {
+		if val := metrics[metricID].GetFloat64Value(); val != eVal {
+			t.Errorf("%s expecting: %v, received: %v", metricID, eVal, val)
+		}
+	}
+	for _, metric := range metrics {
+		if err := metric.RemEvent("EVENT:9"); err != nil {
+			t.Error(err)
+		}
+	}
+	eVals = map[string]float64{
+		utils.MetaMin + utils.InInFieldSep + "~*req.PDD":               2,
+		utils.MetaMax + utils.InInFieldSep + "~*req.PDD":               10,
+		utils.MetaStdDev + utils.InInFieldSep + "~*req.PDD":            2.45452,
+		utils.MetaPercentile + "50" + utils.InInFieldSep + "~*req.PDD": 5,
+		utils.MetaPercentile + "95" + utils.InInFieldSep + "~*req.PDD": 10,
+	}
+	for metricID, eVal := range eVals {
+		if val := metrics[metricID].GetFloat64Value(); val != eVal {
+			t.Errorf("%s expecting: %v, received: %v", metricID, eVal, val)
+		}
+		if strVal := metrics[metricID].GetStringValue(""); strVal != utils.IfaceAsString(eVal) {
+			t.Errorf("%s expecting: %v, received: %v", metricID, eVal, strVal)
+		}
+	}
+	if err := metrics[utils.MetaMax+utils.InInFieldSep+"~*req.PDD"].RemEvent("EVENT:9"); err != utils.ErrNotFound {
+		t.Errorf("Expecting: %v, received: %v", utils.ErrNotFound, err)
+	}
+}
+
+func TestNewStatPercentileErrors(t *testing.T) {
+	for _, metricID := range []string{utils.MetaPercentile, utils.MetaPercentile + "0",
+		utils.MetaPercentile + "101", utils.MetaPercentile + "p95"} {
+		if _, err := NewStatMetric(metricID+utils.InInFieldSep+"~*req.PDD", 0, nil); err == nil {
+			t.Errorf("Expecting error for metric: %s", metricID)
+		}
+	}
+	if metric, err := NewStatMetric(utils.MetaPercentile+"99.9"+utils.InInFieldSep+"~*req.PDD", 0, nil); err != nil {
+		t.Error(err)
+	} else if prc := metric.(*StatPercentile).Percent; prc != 99.9 {
+		t.Errorf("Expecting: 99.9, received: %v", prc)
+	}
+}
+
+func TestStatPercentileCompress(t *testing.T) {
+	prc, _ := NewStatPercentile(0, "~*req.PDD", []string{}, "50")
+	for i, pdd := range []float64{3, 1, 3, 2} {
+		prc.AddEvent(&utils.CGREvent{Tenant: "cgrates.org",
+			ID:    utils.ConcatenatedKey("EVENT", utils.IfaceAsString(i)),
+			Event: map[string]interface{}{utils.PDD: pdd}})
+	}
+	expIDs := []string{"EVENT:0", "EVENT:1", "EVENT:2", "EVENT:3"}
+	rply := prc.Compress(10, "EVENT:3")
+	sort.Strings(rply)
+	if !reflect.DeepEqual(expIDs, rply) {
+		t.Errorf("Expected: %s , received: %s", utils.ToJSON(expIDs), utils.ToJSON(rply))
+	}
+	expIDs = []string{"EVENT:0", "EVENT:1", "EVENT:3"}
+	rply = prc.Compress(4, "EVENT:3")
+	sort.Strings(rply)
+	if !reflect.DeepEqual(expIDs, rply) {
+		t.Errorf("Expected: %s , received: %s", utils.ToJSON(expIDs), utils.ToJSON(rply))
+	}
+	eEvents := map[string]*StatWithCompress{
+		"EVENT:0": &StatWithCompress{Stat: 3, CompressFactor: 2},
+		"EVENT:1": &StatWithCompress{Stat: 1, CompressFactor: 1},
+		"EVENT:3": &StatWithCompress{Stat: 2, CompressFactor: 1},
+	}
+	if !reflect.DeepEqual(eEvents, prc.(*StatPercentile).Events) {
+		t.Errorf("Expected: %s , received: %s", utils.ToJSON(eEvents), utils.ToJSON(prc.(*StatPercentile).Events))
+	}
+	if val := prc.GetFloat64Value(); val != 2 {
+		t.Errorf("Expected: 2, received: %v", val)
+	}
+	eCF := map[string]int{"EVENT:0": 2, "EVENT:1": 1, "EVENT:3": 1}
+	if rply := prc.GetCompressFactor(make(map[string]int)); !reflect.DeepEqual(eCF, rply) {
+		t.Errorf("Expected: %s , received: %s", utils.ToJSON(eCF), utils.ToJSON(rply))
+	}
+}
+
+func TestStatDistributionCompressCount(t *testing.T) {
+	for i := 0; i < 50; i++ { // the map iteration order changes between runs
+		mx, _ := NewStatMax(0, "~*req.PDD", []string{})
+		for j, id := range []string{"EV_9", "EV_1", "EV_5", "EV_3", "EV_7", "EV_2"} {
+			if err := mx.AddEvent(&utils.CGREvent{Tenant: "cgrates.org", ID: id,
+				Event: map[string]interface{}{utils.PDD: j % 2}}); err != nil {
+				t.Fatal(err)
+			}
+		}
+		mx.AddEvent(&utils.CGREvent{Tenant: "cgrates.org", ID: "EV_1",
+			Event: map[string]interface{}{utils.PDD: 1}}) // EV_1 has CompressFactor 2
+		rply := mx.Compress(5, "EV_7")
+		sort.Strings(rply)
+		if exp := []string{"EV_1", "EV_7"}; !reflect.DeepEqual(exp, rply) {
+			t.Fatalf("Expected: %s , received: %s", exp, rply)
+		}
+		var cf int
+		for _, val := range mx.GetCompressFactor(make(map[string]int)) {
+			cf += val
+		}
+		if cf != 7 {
+			t.Fatalf("Expected total compress factor 7, received: %d", cf)
+		}
+	}
+}
+
+func TestStatDistributionCompressBounded(t *testing.T) {
+	prc, _ := NewStatPercentile(0, "~*req.PDD", []string{}, "50")
+	mn, _ := NewStatMin(0, "~*req.PDD", []string{})
+	mx, _ := NewStatMax(0, "~*req.PDD", []string{})
+	for i := 0; i < 1000; i++ {
+		ev := &utils.CGREvent{Tenant: "cgrates.org", ID: utils.ConcatenatedKey("EVENT", utils.IfaceAsString(i)),
+			Event: map[string]interface{}{utils.PDD: float64(i)}}
+		for _, metric := range []StatMetric{prc, mn, mx} {
+			if err := metric.AddEvent(ev); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+	for _, metric := range []StatMetric{prc, mn, mx} {
+		if ids := metric.Compress(1000, "EVENT:999"); len(ids) > maxCompressedStatValues {
+			t.Errorf("Expected at most %d values, received: %d", maxCompressedStatValues, len(ids))
+		}
+		var cf int
+		for _, val := range metric.GetCompressFactor(make(map[string]int)) {
+			cf += val
+		}
+		if cf != 1000 {
+			t.Errorf("Expected total compress factor 1000, received: %d", cf)
+		}
+	}
+	if val := mn.GetFloat64Value(); val != 0 {
+		t.Errorf("Expected min 0, received: %v", val)
+	}
+	if val := mx.GetFloat64Value(); val != 999 {
+		t.Errorf("Expected max 999, received: %v", val)
+	}
+	if val := prc.GetFloat64Value(); val < 490 || val > 510 {
+		t.Errorf("Expected median around 500, received: %v", val)
+	}
+}
+
+func TestStatPercentileMarshal(t *testing.T) {
+	prc, _ := NewStatPercentile(2, "~*req.PDD", []string{}, "95")
+	prc.AddEvent(&utils.CGREvent{Tenant: "cgrates.org", ID: "EVENT_1",
+		Event: map[string]interface{}{utils.PDD: 5}})
+	var nPrc StatPercentile
+	expected := []byte(`{"FilterIDs":[],"Count":1,"Events":{"EVENT_1":{"Stat":5,"CompressFactor":1}},"MinItems":2,"FieldName":"~*req.PDD","Percent":95}`)
+	if b, err := prc.Marshal(&jMarshaler); err != nil {
+		t.Error(err)
+	} else if !reflect.DeepEqual(expected, b) {
+		t.Errorf("Expected: %s , recived: %s", string(expected), string(b))
+	} else if err := nPrc.LoadMarshaled(&jMarshaler, b); err != nil {
+		t.Error(err)
+	} else if !reflect.DeepEqual(prc, &nPrc) {
+		t.Errorf("Expected: %s , recived: %s", utils.ToJSON(prc), utils.ToJSON(nPrc))
+	}
+}
+
+func TestStatMetricsMerge(t *testing.T) {
+	bkt1ACD, _ := NewACD(0, "", []string{})
+	bkt2ACD, _ := NewACD(0, "", []string{})
+	bkt1DDC, _ := NewDDC(0, "", []string{})
+	bkt2DDC, _ := NewDDC(0, "", []string{})
+	bkt1Prc, _ := NewStatPercentile(0, "~*req.PDD", []string{}, "50")
+	bkt2Prc, _ := NewStatPercentile(0, "~*req.PDD", []string{}, "50")
+	for i, bkt := range []map[string]StatMetric{
+		{utils.MetaACD: bkt1ACD, utils.MetaDDC: bkt1DDC, "prc": bkt1Prc},
+		{utils.MetaACD: bkt2ACD, utils.MetaDDC: bkt2DDC, "prc": bkt2Prc},
+	} {
+		for j := 0; j < 2; j++ {
+			ev := &utils.CGREvent{Tenant: "cgrates.org", ID: fmt.Sprintf("EVENT_%d_%d", i, j),
+				Event: map[string]interface{}{
+					utils.Usage:       time.Duration(10*(i+1)) * time.Second,
+					utils.Destination: fmt.Sprintf("100%d", j),
+					utils.PDD:         i*2 + j,
+				}}
+			for _, metric := range bkt {
+				if err := metric.AddEvent(ev); err != nil {
+					t.Fatal(err)
+				}
+			}
+		}
+	}
+	acd, _ := NewACD(0, "", []string{})
+	ddc, _ := NewDDC(0, "", []string{})
+	prc, _ := NewStatPercentile(0, "~*req.PDD", []string{}, "50")
+	for _, bkt := range []map[string]StatMetric{
+		{utils.MetaACD: bkt1ACD, utils.MetaDDC: bkt1DDC, "prc": bkt1Prc},
+		{utils.MetaACD: bkt2ACD, utils.MetaDDC: bkt2DDC, "prc": bkt2Prc},
+	} {
+		acd.Merge(bkt[utils.MetaACD])
+		ddc.Merge(bkt[utils.MetaDDC])
+		prc.Merge(bkt["prc"])
+	}
+	if val := acd.GetValue(); val != 15*time.Second {
+		t.Errorf("Expected: %v, received: %v", 15*time.Second, val)
+	} else if events := acd.(*StatACD).Events; len(events) != 0 {
+		t.Errorf("Expected no events, received: %s", utils.ToJSON(events))
+	}
+	if val := ddc.GetFloat64Value(); val != 2 {
+		t.Errorf("Expected: 2, received: %v", val)
+	} else if events := ddc.(*StatDDC).Events; len(events) != 0 {
+		t.Errorf("Expected no events, received: %s", utils.ToJSON(events))
+	}
+	if val := prc.GetFloat64Value(); val != 1 {
+		t.Errorf("Expected: 1, received: %v", val)
+	}
+	if _, has := prc.(*StatPercentile).Events["EVENT_0_0"]; has {
+		t.Errorf("Expected the values indexed on themselves, received: %s", utils.ToJSON(prc))
+	}
+	acd.Merge(ddc) // different metrics are not merged
+	if val := acd.GetValue(); val != 15*time.Second {
+		t.Errorf("Expected: %v, received: %v", 15*time.Second, val)
+	}
+}
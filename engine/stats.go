@@ -410,6 +410,44 @@ func (sS *StatService) V1GetQueueFloatMetrics(args *utils.TenantID, reply *map[s
 	return
 }
 
+// StatQueueHistory is the reply of V1GetQueueHistory
+type StatQueueHistory struct {
+	Metrics map[string]float64 // metrics over the whole window
+	Buckets []*StatBucket      // per bucket metrics, oldest first
+}
+
+// V1GetQueueHistory returns the window metrics together with the per bucket ones
+func (sS *StatService) V1GetQueueHistory(args *utils.TenantID, reply *StatQueueHistory) (err error) {
+	if missing := utils.MissingStructFields(args, []string{utils.Tenant, utils.ID}); len(missing) != 0 { //Params missing
+		return utils.NewErrMandatoryIeMissing(missing...)
+	}
+	sqPrfl, err := sS.dm.GetStatQueueProfile(args.Tenant, args.ID, true, true, utils.NonTransactional)
+	if err != nil {
+		if err != utils.ErrNotFound {
+			err = utils.NewErrServerError(err)
+		}
+		return err
+	}
+	sq, err := sS.dm.GetStatQueue(args.Tenant, args.ID, true, true, "")
+	if err != nil {
+		if err != utils.ErrNotFound {
+			err = utils.NewErrServerError(err)
+		}
+		return err
+	}
+	sq.RLock()
+	hist := StatQueueHistory{
+		Metrics: make(map[string]float64, len(sq.SQMetrics)),
+		Buckets: sq.Buckets(sqPrfl, time.Now()),
+	}
+	for metricID, metric := range sq.SQMetrics {
+		hist.Metrics[metricID] = metric.GetFloat64Value()
+	}
+	sq.RUnlock()
+	*reply = hist
+	return
+}
+
 // V1GetQueueIDs returns list of queueIDs registered for a tenant
 func (sS *StatService) V1GetQueueIDs(tenant string, qIDs *[]string) (err error) {
 	prfx := utils.StatQueuePrefix + tenant + ":"
@@ -401,3 +401,59 @@ func TestStatQueuesV1ProcessEvent(t *testing.T) {
 		t.Errorf("Expecting: %+v, received: %+v", expected, reply)
 	}
 }
+
+func TestStatQueuesV1GetQueueHistory(t *testing.T) {
+	sqPrf := &StatQueueProfile{
+		Tenant:         "cgrates.org",
+		ID:             "StatQueueProfileBuckets",
+		BucketInterval: time.Hour,
+		BucketCount:    2,
+		Metrics: []*MetricWithFilters{
+			&MetricWithFilters{
+				MetricID: "*sum:~*req.Cost",
+			},
+		},
+		ThresholdIDs: []string{utils.META_NONE},
+		Weight:       10,
+	}
+	sumMetric, _ := NewStatMetric("*sum:~*req.Cost", 0, nil)
+	sq := &StatQueue{Tenant: "cgrates.org", ID: "StatQueueProfileBuckets",
+		SQMetrics: map[string]StatMetric{"*sum:~*req.Cost": sumMetric}}
+	if err := dmSTS.SetStatQueueProfile(sqPrf, true); err != nil {
+		t.Error(err)
+	}
+	if err := dmSTS.SetStatQueue(sq); err != nil {
+		t.Error(err)
+	}
+	ev := &StatsArgsProcessEvent{
+		StatIDs: []string{"StatQueueProfileBuckets"},
+		CGREvent: &utils.CGREvent{
+			Tenant: "cgrates.org",
+			ID:     "TestStatQueuesV1GetQueueHistory",
+			Event:  map[string]interface{}{utils.COST: 10.0},
+		},
+	}
+	var reply []string
+	if err := statService.V1ProcessEvent(ev, &reply); err != nil {
+		t.Error(err)
+	}
+	var hist StatQueueHistory
+	if err := statService.V1GetQueueHistory(&utils.TenantID{Tenant: "cgrates.org",
+		ID: "StatQueueProfileBuckets"}, &hist); err != nil {
+		t.Fatal(err)
+	}
+	if eMetrics := map[string]float64{"*sum:~*req.Cost": 10}; !reflect.DeepEqual(eMetrics, hist.Metrics) {
+		t.Errorf("Expecting: %+v, received: %+v", eMetrics, hist.Metrics)
+	}
+	if len(hist.Buckets) == 0 {
+		t.Fatalf("no buckets received")
+	}
+	if bkt := hist.Buckets[len(hist.Buckets)-1]; bkt.Metrics["*sum:~*req.Cost"] != 10 ||
+		!bkt.StartTime.Equal(time.Now().Truncate(time.Hour)) {
+		t.Errorf("received bucket: %s", utils.ToJSON(bkt))
+	}
+	if err := statService.V1GetQueueHistory(&utils.TenantID{Tenant: "cgrates.org",
+		ID: "NotExisting"}, &hist); err != utils.ErrNotFound {
+		t.Errorf("Expecting: %v, received: %v", utils.ErrNotFound, err)
+	}
+}
@@ -72,7 +72,8 @@ func testSetCDR(cfg *config.CGRConfig) error {
 		cfg.StorDbCfg().Password, cfg.GeneralCfg().DBDataEncoding, cfg.StorDbCfg().SSLMode,
 		cfg.StorDbCfg().MaxOpenConns, cfg.StorDbCfg().MaxIdleConns,
 		cfg.StorDbCfg().ConnMaxLifetime, cfg.StorDbCfg().StringIndexedFields,
-		cfg.StorDbCfg().PrefixIndexedFields, cfg.StorDbCfg().Items)
+		cfg.StorDbCfg().PrefixIndexedFields, cfg.StorDbCfg().Items,
+		cfg.StorDbCfg().InternalPersistence)
 	if err != nil {
 		return err
 	}
@@ -179,7 +180,8 @@ func testSMCosts(cfg *config.CGRConfig) error {
 		cfg.StorDbCfg().Password, cfg.GeneralCfg().DBDataEncoding, cfg.StorDbCfg().SSLMode,
 		cfg.StorDbCfg().MaxOpenConns, cfg.StorDbCfg().MaxIdleConns,
 		cfg.StorDbCfg().ConnMaxLifetime, cfg.StorDbCfg().StringIndexedFields,
-		cfg.StorDbCfg().PrefixIndexedFields, cfg.StorDbCfg().Items)
+		cfg.StorDbCfg().PrefixIndexedFields, cfg.StorDbCfg().Items,
+		cfg.StorDbCfg().InternalPersistence)
 	if err != nil {
 		return fmt.Errorf("testSMCosts #2 err: %v", err)
 	}
@@ -242,7 +244,8 @@ func testGetCDRs(cfg *config.CGRConfig) error {
 		cfg.StorDbCfg().Password, cfg.GeneralCfg().DBDataEncoding, cfg.StorDbCfg().SSLMode,
 		cfg.StorDbCfg().MaxOpenConns, cfg.StorDbCfg().MaxIdleConns,
 		cfg.StorDbCfg().ConnMaxLifetime, cfg.StorDbCfg().StringIndexedFields,
-		cfg.StorDbCfg().PrefixIndexedFields, cfg.StorDbCfg().Items)
+		cfg.StorDbCfg().PrefixIndexedFields, cfg.StorDbCfg().Items,
+		cfg.StorDbCfg().InternalPersistence)
 	if err != nil {
 		return fmt.Errorf("testGetCDRs #2: %v", err)
 	}
@@ -65,6 +65,8 @@ type CSVStorage struct {
 	chargerProfilesFn        []string
 	dispatcherProfilesFn     []string
 	dispatcherHostsFn        []string
+	calendarsFn              []string
+	exchangeRatesFn          []string
 }
 
 // NewCSVStorage creates a CSV storege that takes the data from the paths specified
@@ -74,7 +76,8 @@ func NewCSVStorage(sep rune,
 	actionsFn, actiontimingsFn, actiontriggersFn, accountactionsFn,
 	resProfilesFn, statsFn, thresholdsFn,
 	filterFn, suppProfilesFn, attributeProfilesFn,
-	chargerProfilesFn, dispatcherProfilesFn, dispatcherHostsFn []string) *CSVStorage {
+	chargerProfilesFn, dispatcherProfilesFn, dispatcherHostsFn,
+	calendarsFn, exchangeRatesFn []string) *CSVStorage {
 	return &CSVStorage{
 		sep:                      sep,
 		generator:                NewCsvFile,
@@ -98,6 +101,8 @@ func NewCSVStorage(sep rune,
 		chargerProfilesFn:        chargerProfilesFn,
 		dispatcherProfilesFn:     dispatcherProfilesFn,
 		dispatcherHostsFn:        dispatcherHostsFn,
+		calendarsFn:              calendarsFn,
+		exchangeRatesFn:          exchangeRatesFn,
 	}
 }
 
@@ -127,6 +132,8 @@ func NewFileCSVStorage(sep rune, dataPath string) *CSVStorage {
 	chargersPaths := appendName(allFoldersPath, utils.ChargersCsv)
 	dispatcherprofilesPaths := appendName(allFoldersPath, utils.DispatcherProfilesCsv)
 	dispatcherhostsPaths := appendName(allFoldersPath, utils.DispatcherHostsCsv)
+	calendarsPaths := appendName(allFoldersPath, utils.CalendarsCsv)
+	exchangeRatesPaths := appendName(allFoldersPath, utils.ExchangeRatesCsv)
 	return NewCSVStorage(sep,
 		destinationsPaths,
 		timingsPaths,
@@ -148,6 +155,8 @@ func NewFileCSVStorage(sep rune, dataPath string) *CSVStorage {
 		chargersPaths,
 		dispatcherprofilesPaths,
 		dispatcherhostsPaths,
+		calendarsPaths,
+		exchangeRatesPaths,
 	)
 }
 
@@ -159,14 +168,15 @@ func NewStringCSVStorage(sep rune,
 	accountactionsFn, resProfilesFn, statsFn,
 	thresholdsFn, filterFn, suppProfilesFn,
 	attributeProfilesFn, chargerProfilesFn,
-	dispatcherProfilesFn, dispatcherHostsFn string) *CSVStorage {
+	dispatcherProfilesFn, dispatcherHostsFn, calendarsFn, exchangeRatesFn string) *CSVStorage {
 	c := NewCSVStorage(sep, []string{destinationsFn}, []string{timingsFn},
 		[]string{ratesFn}, []string{destinationratesFn}, []string{destinationratetimingsFn},
 		[]string{ratingprofilesFn}, []string{sharedgroupsFn}, []string{actionsFn},
 		[]string{actiontimingsFn}, []string{actiontriggersFn}, []string{accountactionsFn},
 		[]string{resProfilesFn}, []string{statsFn}, []string{thresholdsFn}, []string{filterFn},
 		[]string{suppProfilesFn}, []string{attributeProfilesFn}, []string{chargerProfilesFn},
-		[]string{dispatcherProfilesFn}, []string{dispatcherHostsFn})
+		[]string{dispatcherProfilesFn}, []string{dispatcherHostsFn}, []string{calendarsFn},
+		[]string{exchangeRatesFn})
 	c.generator = NewCsvString
 	return c
 }
@@ -207,7 +217,9 @@ func NewGoogleCSVStorage(sep rune, spreadsheetID string) (*CSVStorage, error) {
 		getIfExist(utils.Attributes),
 		getIfExist(utils.Chargers),
 		getIfExist(utils.DispatcherProfiles),
-		getIfExist(utils.DispatcherHosts))
+		getIfExist(utils.DispatcherHosts),
+		getIfExist(utils.Calendars),
+		getIfExist(utils.ExchangeRates))
 	c.generator = func() csvReaderCloser {
 		return &csvGoogle{
 			spreadsheetID: spreadsheetID,
@@ -239,6 +251,8 @@ func NewURLCSVStorage(sep rune, dataPath string) *CSVStorage {
 	var chargersPaths []string
 	var dispatcherprofilesPaths []string
 	var dispatcherhostsPaths []string
+	var calendarsPaths []string
+	var exchangeRatesPaths []string
 
 	for _, baseURL := range strings.Split(dataPath, utils.INFIELD_SEP) {
 		if !strings.HasSuffix(baseURL, utils.CSVSuffix) {
@@ -262,6 +276,8 @@ func NewURLCSVStorage(sep rune, dataPath string) *CSVStorage {
 			chargersPaths = append(chargersPaths, joinURL(baseURL, utils.ChargersCsv))
 			dispatcherprofilesPaths = append(dispatcherprofilesPaths, joinURL(baseURL, utils.DispatcherProfilesCsv))
 			dispatcherhostsPaths = append(dispatcherhostsPaths, joinURL(baseURL, utils.DispatcherHostsCsv))
+			calendarsPaths = append(calendarsPaths, joinURL(baseURL, utils.CalendarsCsv))
+			exchangeRatesPaths = append(exchangeRatesPaths, joinURL(baseURL, utils.ExchangeRatesCsv))
 			continue
 		}
 		switch {
@@ -305,6 +321,10 @@ func NewURLCSVStorage(sep rune, dataPath string) *CSVStorage {
 			dispatcherprofilesPaths = append(dispatcherprofilesPaths, baseURL)
 		case strings.HasSuffix(baseURL, utils.DispatcherHostsCsv):
 			dispatcherhostsPaths = append(dispatcherhostsPaths, baseURL)
+		case strings.HasSuffix(baseURL, utils.CalendarsCsv):
+			calendarsPaths = append(calendarsPaths, baseURL)
+		case strings.HasSuffix(baseURL, utils.ExchangeRatesCsv):
+			exchangeRatesPaths = append(exchangeRatesPaths, baseURL)
 		}
 	}
 
@@ -329,6 +349,8 @@ func NewURLCSVStorage(sep rune, dataPath string) *CSVStorage {
 		chargersPaths,
 		dispatcherprofilesPaths,
 		dispatcherhostsPaths,
+		calendarsPaths,
+		exchangeRatesPaths,
 	)
 	c.generator = func() csvReaderCloser {
 		return &csvURL{}
@@ -368,9 +390,14 @@ func appendName(paths []string, fileName string) (out []string) {
 
 func (csvs *CSVStorage) proccesData(listType interface{}, fns []string, process func(interface{})) error {
 	collumnCount := getColumnCount(listType)
+	minCollumnCount := getMinColumnCount(listType)
+	nrFields := collumnCount
+	if minCollumnCount != collumnCount {
+		nrFields = -1 // optional columns, the record length is checked below
+	}
 	for _, fileName := range fns {
 		csvReader := csvs.generator()
-		err := csvReader.Open(fileName, csvs.sep, collumnCount)
+		err := csvReader.Open(fileName, csvs.sep, nrFields)
 		if err != nil {
 			// maybe a log to view if failed to open file
 			continue // try read the rest
@@ -382,6 +409,10 @@ func (csvs *CSVStorage) proccesData(listType interface{}, fns []string, process
 					log.Printf("bad line in %s, %s\n", fileName, err.Error())
 					return err
 				}
+				if len(record) < minCollumnCount || len(record) > collumnCount {
+					log.Printf("bad line in %s, %s\n", fileName, csv.ErrFieldCount.Error())
+					return csv.ErrFieldCount
+				}
 				if item, err := csvLoad(listType, record); err != nil {
 					log.Printf("error loading %s: %v", "", err)
 					return err
@@ -409,6 +440,30 @@ func (csvs *CSVStorage) GetTPTimings(tpid, id string) ([]*utils.ApierTPTiming, e
 	return tpTimings.AsTPTimings(), nil
 }
 
+func (csvs *CSVStorage) GetTPCalendars(tpid, id string) ([]*utils.TPCalendar, error) {
+	var tpCals TpCalendars
+	if err := csvs.proccesData(TpCalendar{}, csvs.calendarsFn, func(tp interface{}) {
+		c := tp.(TpCalendar)
+		c.Tpid = tpid
+		tpCals = append(tpCals, c)
+	}); err != nil {
+		return nil, err
+	}
+	return tpCals.AsTPCalendars(), nil
+}
+
+func (csvs *CSVStorage) GetTPExchangeRates(tpid, fromCurrency, toCurrency string) ([]*utils.TPExchangeRates, error) {
+	var tpExrs TpExchangeRates
+	if err := csvs.proccesData(TpExchangeRate{}, csvs.exchangeRatesFn, func(tp interface{}) {
+		exr := tp.(TpExchangeRate)
+		exr.Tpid = tpid
+		tpExrs = append(tpExrs, exr)
+	}); err != nil {
+		return nil, err
+	}
+	return tpExrs.AsTPExchangeRates(), nil
+}
+
 func (csvs *CSVStorage) GetTPDestinations(tpid, id string) ([]*utils.TPDestination, error) {
 	var tpDests TpDestinations
 	if err := csvs.proccesData(TpDestination{}, csvs.destinationsFn, func(tp interface{}) {
@@ -861,8 +916,12 @@ func (c *csvGoogle) Read() (record []string, err error) {
 	if err != nil {
 		return
 	}
-	record = make([]string, c.nrFields)
-	for i := 0; i < c.nrFields; i++ {
+	nrFields := c.nrFields
+	if nrFields < 0 { // variable number of fields
+		nrFields = len(row)
+	}
+	record = make([]string, nrFields)
+	for i := 0; i < nrFields; i++ {
 		if i < len(row) {
 			record[i] = utils.IfaceAsString(row[i])
 			if i == 0 && strings.HasPrefix(record[i], "#") {
@@ -88,6 +88,8 @@ type DataDB interface {
 	GetResourceDrv(string, string) (*Resource, error)
 	SetResourceDrv(*Resource) error
 	RemoveResourceDrv(string, string) error
+	UpdateResourceUsagesDrv(tenant, id, ruID string, ru *ResourceUsage,
+		limit *float64) (usages map[string]*ResourceUsage, recorded bool, err error)
 	GetTimingDrv(string) (*utils.TPTiming, error)
 	SetTimingDrv(*utils.TPTiming) error
 	RemoveTimingDrv(string) error
@@ -133,6 +135,12 @@ type DataDB interface {
 	GetDispatcherHostDrv(string, string) (*DispatcherHost, error)
 	SetDispatcherHostDrv(*DispatcherHost) error
 	RemoveDispatcherHostDrv(string, string) error
+	GetStoredSessionsDrv(nodeID string) (sSs []*StoredSession, err error)
+	SetStoredSessionDrv(sS *StoredSession) (err error)
+	RemoveStoredSessionDrv(nodeID, cgrID string) (err error)
+	GetExchangeRatesDrv(fromCurrency, toCurrency string) (exr *ExchangeRates, err error)
+	SetExchangeRatesDrv(exr *ExchangeRates) (err error)
+	RemoveExchangeRatesDrv(fromCurrency, toCurrency string) (err error)
 }
 
 type StorDB interface {
@@ -163,6 +171,8 @@ type LoadReader interface {
 	GetTpTableIds(string, string, utils.TPDistinctIds,
 		map[string]string, *utils.PaginatorWithSearch) ([]string, error)
 	GetTPTimings(string, string) ([]*utils.ApierTPTiming, error)
+	GetTPCalendars(string, string) ([]*utils.TPCalendar, error)
+	GetTPExchangeRates(string, string, string) ([]*utils.TPExchangeRates, error)
 	GetTPDestinations(string, string) ([]*utils.TPDestination, error)
 	GetTPRates(string, string) ([]*utils.TPRate, error)
 	GetTPDestinationRates(string, string, *utils.Paginator) ([]*utils.TPDestinationRate, error)
@@ -187,6 +197,8 @@ type LoadReader interface {
 type LoadWriter interface {
 	RemTpData(string, string, map[string]string) error
 	SetTPTimings([]*utils.ApierTPTiming) error
+	SetTPCalendars([]*utils.TPCalendar) error
+	SetTPExchangeRates([]*utils.TPExchangeRates) error
 	SetTPDestinations([]*utils.TPDestination) error
 	SetTPRates([]*utils.TPRate) error
 	SetTPDestinationRates([]*utils.TPDestinationRate) error
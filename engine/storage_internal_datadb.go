@@ -21,6 +21,7 @@ package engine
 import (
 	"errors"
 	"fmt"
+	"sort"
 	"sync"
 	"time"
 
@@ -190,6 +191,15 @@ func newInternalDBCfg(itemsCacheCfg map[string]*config.ItemOpt, isDataDB bool) m
 				TTL:       itemsCacheCfg[utils.CacheLoadIDs].TTL,
 				StaticTTL: itemsCacheCfg[utils.CacheLoadIDs].StaticTTL,
 			},
+			utils.CacheStoredSessions: &ltcache.CacheConfig{
+				MaxItems: -1,
+			},
+			utils.CacheResourceUsages: &ltcache.CacheConfig{
+				MaxItems: -1,
+			},
+			utils.CacheExchangeRates: &ltcache.CacheConfig{
+				MaxItems: -1,
+			},
 		}
 	} else {
 		return map[string]*ltcache.CacheConfig{
@@ -203,6 +213,16 @@ func newInternalDBCfg(itemsCacheCfg map[string]*config.ItemOpt, isDataDB bool) m
 				TTL:       itemsCacheCfg[utils.TBLTPTimings].TTL,
 				StaticTTL: itemsCacheCfg[utils.TBLTPTimings].StaticTTL,
 			},
+			utils.TBLTPCalendars: &ltcache.CacheConfig{
+				MaxItems:  itemsCacheCfg[utils.TBLTPCalendars].Limit,
+				TTL:       itemsCacheCfg[utils.TBLTPCalendars].TTL,
+				StaticTTL: itemsCacheCfg[utils.TBLTPCalendars].StaticTTL,
+			},
+			utils.TBLTPExchangeRates: &ltcache.CacheConfig{
+				MaxItems:  itemsCacheCfg[utils.TBLTPExchangeRates].Limit,
+				TTL:       itemsCacheCfg[utils.TBLTPExchangeRates].TTL,
+				StaticTTL: itemsCacheCfg[utils.TBLTPExchangeRates].StaticTTL,
+			},
 			utils.TBLTPDestinations: &ltcache.CacheConfig{
 				MaxItems:  itemsCacheCfg[utils.TBLTPDestinations].Limit,
 				TTL:       itemsCacheCfg[utils.TBLTPDestinations].TTL,
@@ -314,8 +334,9 @@ func newInternalDBCfg(itemsCacheCfg map[string]*config.ItemOpt, isDataDB bool) m
 
 type InternalDB struct {
 	tasks               []*Task
-	db                  *ltcache.TransCache
+	db                  *internalDBStore
 	mu                  sync.RWMutex
+	rsuMux              sync.Mutex // makes the resource usages updates atomic
 	stringIndexedFields []string
 	prefixIndexedFields []string
 	indexedFieldsMutex  sync.RWMutex   // used for reload
@@ -326,9 +347,16 @@ type InternalDB struct {
 // NewInternalDB constructs an InternalDB
 func NewInternalDB(stringIndexedFields, prefixIndexedFields []string,
 	isDataDB bool, itemsCacheCfg map[string]*config.ItemOpt) (iDB *InternalDB) {
+	return newInternalDB(stringIndexedFields, prefixIndexedFields,
+		newInternalDBCfg(itemsCacheCfg, isDataDB))
+}
+
+// newInternalDB constructs an InternalDB out of the partitions config
+func newInternalDB(stringIndexedFields, prefixIndexedFields []string,
+	tcCfg map[string]*ltcache.CacheConfig) (iDB *InternalDB) {
 	ms, _ := NewMarshaler(config.CgrConfig().GeneralCfg().DBDataEncoding)
 	iDB = &InternalDB{
-		db:                  ltcache.NewTransCache(newInternalDBCfg(itemsCacheCfg, isDataDB)),
+		db:                  &internalDBStore{TransCache: ltcache.NewTransCache(tcCfg)},
 		stringIndexedFields: stringIndexedFields,
 		prefixIndexedFields: prefixIndexedFields,
 		cnter:               utils.NewCounter(time.Now().UnixNano(), 0),
@@ -337,6 +365,40 @@ func NewInternalDB(stringIndexedFields, prefixIndexedFields []string,
 	return
 }
 
+// NewPersistentInternalDB constructs an InternalDB which restores its data from disk
+// and persists the writes in case persistCfg is enabled
+func NewPersistentInternalDB(stringIndexedFields, prefixIndexedFields []string,
+	isDataDB bool, itemsCacheCfg map[string]*config.ItemOpt,
+	persistCfg *config.InternalPersistenceCfg) (iDB *InternalDB, err error) {
+	if !persistCfg.Enabled() {
+		return NewInternalDB(stringIndexedFields, prefixIndexedFields, isDataDB, itemsCacheCfg), nil
+	}
+	name := utils.StorDB
+	if isDataDB {
+		name = utils.DataDB
+	}
+	tcCfg := newInternalDBCfg(itemsCacheCfg, isDataDB)
+	if _, has := tcCfg[utils.TBLVersions]; !has { // versions are not partitioned in DataDB, give them own partition so their removals can be logged
+		tcCfg[utils.TBLVersions] = &ltcache.CacheConfig{MaxItems: ltcache.UnlimitedCaching}
+	}
+	cacheIDs := make([]string, 0, len(tcCfg))
+	for cacheID := range tcCfg {
+		cacheIDs = append(cacheIDs, cacheID)
+	}
+	sort.Strings(cacheIDs)
+	ms, _ := NewMarshaler(config.CgrConfig().GeneralCfg().DBDataEncoding)
+	prst := newInternalDBPersister(persistCfg, name, cacheIDs, ms)
+	for cacheID, chCfg := range tcCfg {
+		chCfg.OnEvicted = prst.onEvicted(cacheID)
+	}
+	iDB = newInternalDB(stringIndexedFields, prefixIndexedFields, tcCfg)
+	if err = prst.restore(iDB.db.TransCache); err != nil {
+		return nil, fmt.Errorf("cannot restore %s from <%s>, error: %s", name, persistCfg.Path, err.Error())
+	}
+	iDB.db.prst = prst
+	return
+}
+
 // SetStringIndexedFields set the stringIndexedFields, used at StorDB reload (is thread safe)
 func (iDB *InternalDB) SetStringIndexedFields(stringIndexedFields []string) {
 	iDB.indexedFieldsMutex.Lock()
@@ -351,7 +413,15 @@ func (iDB *InternalDB) SetPrefixIndexedFields(prefixIndexedFields []string) {
 	iDB.indexedFieldsMutex.Unlock()
 }
 
-func (iDB *InternalDB) Close() {}
+func (iDB *InternalDB) Close() {
+	if iDB.db.prst == nil {
+		return
+	}
+	if err := iDB.db.prst.close(iDB.db.TransCache); err != nil {
+		utils.Logger.Warning(fmt.Sprintf("<%s> error closing <%s>: %s",
+			utils.InternalDB, iDB.db.prst.cfg.Path, err.Error()))
+	}
+}
 
 func (iDB *InternalDB) Flush(_ string) error {
 	iDB.db.Clear(nil)
@@ -1019,6 +1089,41 @@ func (iDB *InternalDB) SetResourceDrv(r *Resource) (err error) {
 func (iDB *InternalDB) RemoveResourceDrv(tenant, id string) (err error) {
 	iDB.db.Remove(utils.CacheResources, utils.ConcatenatedKey(tenant, id),
 		cacheCommit(utils.NonTransactional), utils.NonTransactional)
+	iDB.rsuMux.Lock()
+	iDB.db.Remove(utils.CacheResourceUsages, utils.ConcatenatedKey(tenant, id),
+		cacheCommit(utils.NonTransactional), utils.NonTransactional)
+	iDB.rsuMux.Unlock()
+	return
+}
+
+func (iDB *InternalDB) UpdateResourceUsagesDrv(tenant, id, ruID string, ru *ResourceUsage,
+	limit *float64) (usages map[string]*ResourceUsage, recorded bool, err error) {
+	tntID := utils.ConcatenatedKey(tenant, id)
+	iDB.rsuMux.Lock()
+	defer iDB.rsuMux.Unlock()
+	usages = make(map[string]*ResourceUsage)
+	if x, ok := iDB.db.Get(utils.CacheResourceUsages, tntID); ok && x != nil {
+		for ruID, u := range x.(map[string]*ResourceUsage) { // work on a copy since the stored map is shared
+			usages[ruID] = u.Clone()
+		}
+	}
+	if ru != nil {
+		ru = ru.Clone()
+	}
+	if recorded, err = updateResourceUsages(usages, ruID, ru, limit, time.Now()); err != nil {
+		return nil, false, err
+	}
+	if len(usages) == 0 {
+		iDB.db.Remove(utils.CacheResourceUsages, tntID,
+			cacheCommit(utils.NonTransactional), utils.NonTransactional)
+		return
+	}
+	stored := make(map[string]*ResourceUsage)
+	for ruID, u := range usages {
+		stored[ruID] = u.Clone()
+	}
+	iDB.db.Set(utils.CacheResourceUsages, tntID, stored, nil,
+		cacheCommit(utils.NonTransactional), utils.NonTransactional)
 	return
 }
 
@@ -1362,6 +1467,48 @@ func (iDB *InternalDB) RemoveDispatcherHostDrv(tenant, id string) (err error) {
 	return
 }
 
+func (iDB *InternalDB) GetStoredSessionsDrv(nodeID string) (sSs []*StoredSession, err error) {
+	for _, id := range iDB.db.GetItemIDs(utils.CacheStoredSessions,
+		nodeID+utils.CONCATENATED_KEY_SEP) {
+		if x, ok := iDB.db.Get(utils.CacheStoredSessions, id); ok && x != nil {
+			sSs = append(sSs, x.(*StoredSession))
+		}
+	}
+	return
+}
+
+func (iDB *InternalDB) SetStoredSessionDrv(sS *StoredSession) (err error) {
+	iDB.db.Set(utils.CacheStoredSessions, sS.NodeCGRID(), sS, nil,
+		cacheCommit(utils.NonTransactional), utils.NonTransactional)
+	return
+}
+
+func (iDB *InternalDB) RemoveStoredSessionDrv(nodeID, cgrID string) (err error) {
+	iDB.db.Remove(utils.CacheStoredSessions, utils.ConcatenatedKey(nodeID, cgrID),
+		cacheCommit(utils.NonTransactional), utils.NonTransactional)
+	return
+}
+
+func (iDB *InternalDB) GetExchangeRatesDrv(fromCurrency, toCurrency string) (exr *ExchangeRates, err error) {
+	x, ok := iDB.db.Get(utils.CacheExchangeRates, utils.ConcatenatedKey(fromCurrency, toCurrency))
+	if !ok || x == nil {
+		return nil, utils.ErrNotFound
+	}
+	return x.(*ExchangeRates).Clone(), nil
+}
+
+func (iDB *InternalDB) SetExchangeRatesDrv(exr *ExchangeRates) (err error) {
+	iDB.db.Set(utils.CacheExchangeRates, exr.ID(), exr.Clone(), nil,
+		cacheCommit(utils.NonTransactional), utils.NonTransactional)
+	return
+}
+
+func (iDB *InternalDB) RemoveExchangeRatesDrv(fromCurrency, toCurrency string) (err error) {
+	iDB.db.Remove(utils.CacheExchangeRates, utils.ConcatenatedKey(fromCurrency, toCurrency),
+		cacheCommit(utils.NonTransactional), utils.NonTransactional)
+	return
+}
+
 func (iDB *InternalDB) RemoveLoadIDsDrv() (err error) {
 	return utils.ErrNotImplemented
 }
@@ -0,0 +1,649 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package engine
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"os"
+	"path"
+	"reflect"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/cgrates/utils"
+	"github.com/cgrates/ltcache"
+)
+
+const (
+	internalDBLogExt      = ".log"
+	internalDBSnapshotExt = ".snapshot"
+	internalDBTmpExt      = ".tmp"
+	internalDBLockExt     = ".lock"
+	internalDBFrameHdrLen = 8 // payload length + crc32 of the payload
+)
+
+var errInternalDBTornFrame = errors.New("torn frame")
+
+// internalDBItemTypes indexes the type of the values stored in each InternalDB partition,
+// used to decode the persisted items
+var internalDBItemTypes = map[string]reflect.Type{
+	utils.TBLVersions:              reflect.TypeOf(Versions{}),
+	utils.CacheDestinations:        reflect.TypeOf(new(Destination)),
+	utils.CacheReverseDestinations: reflect.TypeOf(utils.StringMap{}),
+	utils.CacheActions:             reflect.TypeOf(Actions{}),
+	utils.CacheActionPlans:         reflect.TypeOf(new(ActionPlan)),
+	utils.CacheAccountActionPlans:  reflect.TypeOf([]string{}),
+	utils.CacheActionTriggers:      reflect.TypeOf(ActionTriggers{}),
+	utils.CacheRatingPlans:         reflect.TypeOf(new(RatingPlan)),
+	utils.CacheRatingProfiles:      reflect.TypeOf(new(RatingProfile)),
+	utils.CacheAccounts:            reflect.TypeOf(new(Account)),
+	utils.CacheSharedGroups:        reflect.TypeOf(new(SharedGroup)),
+	utils.CacheTimings:             reflect.TypeOf(new(utils.TPTiming)),
+	utils.CacheFilters:             reflect.TypeOf(new(Filter)),
+	utils.CacheResourceProfiles:    reflect.TypeOf(new(ResourceProfile)),
+	utils.CacheResources:           reflect.TypeOf(new(Resource)),
+	utils.CacheStatQueueProfiles:   reflect.TypeOf(new(StatQueueProfile)),
+	utils.CacheThresholdProfiles:   reflect.TypeOf(new(ThresholdProfile)),
+	utils.CacheThresholds:          reflect.TypeOf(new(Threshold)),
+	utils.CacheSupplierProfiles:    reflect.TypeOf(new(SupplierProfile)),
+	utils.CacheAttributeProfiles:   reflect.TypeOf(new(AttributeProfile)),
+	utils.CacheChargerProfiles:     reflect.TypeOf(new(ChargerProfile)),
+	utils.CacheDispatcherProfiles:  reflect.TypeOf(new(DispatcherProfile)),
+	utils.CacheDispatcherHosts:     reflect.TypeOf(new(DispatcherHost)),
+	utils.CacheLoadIDs:             reflect.TypeOf(map[string]int64{}),
+	utils.CacheStoredSessions:      reflect.TypeOf(new(StoredSession)),
+	utils.CacheResourceUsages:      reflect.TypeOf(map[string]*ResourceUsage{}),
+	utils.CacheExchangeRates:       reflect.TypeOf(new(ExchangeRates)),
+	utils.TBLTPTimings:             reflect.TypeOf(new(utils.ApierTPTiming)),
+	utils.TBLTPCalendars:           reflect.TypeOf(new(utils.TPCalendar)),
+	utils.TBLTPExchangeRates:       reflect.TypeOf(new(utils.TPExchangeRates)),
+	utils.TBLTPDestinations:        reflect.TypeOf(new(utils.TPDestination)),
+	utils.TBLTPRates:               reflect.TypeOf(new(utils.TPRate)),
+	utils.TBLTPDestinationRates:    reflect.TypeOf(new(utils.TPDestinationRate)),
+	utils.TBLTPRatingPlans:         reflect.TypeOf(new(utils.TPRatingPlan)),
+	utils.TBLTPRateProfiles:        reflect.TypeOf(new(utils.TPRatingProfile)),
+	utils.TBLTPSharedGroups:        reflect.TypeOf(new(utils.TPSharedGroups)),
+	utils.TBLTPActions:             reflect.TypeOf(new(utils.TPActions)),
+	utils.TBLTPActionPlans:         reflect.TypeOf(new(utils.TPActionPlan)),
+	utils.TBLTPActionTriggers:      reflect.TypeOf(new(utils.TPActionTriggers)),
+	utils.TBLTPAccountActions:      reflect.TypeOf(new(utils.TPAccountActions)),
+	utils.TBLTPResources:           reflect.TypeOf(new(utils.TPResourceProfile)),
+	utils.TBLTPStats:               reflect.TypeOf(new(utils.TPStatProfile)),
+	utils.TBLTPThresholds:          reflect.TypeOf(new(utils.TPThresholdProfile)),
+	utils.TBLTPFilters:             reflect.TypeOf(new(utils.TPFilterProfile)),
+	utils.TBLTPSuppliers:           reflect.TypeOf(new(utils.TPSupplierProfile)),
+	utils.TBLTPAttributes:          reflect.TypeOf(new(utils.TPAttributeProfile)),
+	utils.TBLTPChargers:            reflect.TypeOf(new(utils.TPChargerProfile)),
+	utils.TBLTPDispatchers:         reflect.TypeOf(new(utils.TPDispatcherProfile)),
+	utils.TBLTPDispatcherHosts:     reflect.TypeOf(new(utils.TPDispatcherHost)),
+	utils.CDRsTBL:                  reflect.TypeOf(new(CDR)),
+	utils.SessionCostsTBL:          reflect.TypeOf(new(SMCost)),
+}
+
+func init() {
+	for idxCacheID := range utils.CacheIndexesToPrefix {
+		internalDBItemTypes[idxCacheID] = reflect.TypeOf(map[string]utils.StringMap{})
+	}
+}
+
+// internalDBRecord is one write operation persisted in the log or in the snapshot
+type internalDBRecord struct {
+	Op       string // <*set|*remove|*clear>
+	CacheID  string // empty on *clear for all partitions
+	ItemID   string
+	GroupIDs []string
+	Value    []byte
+}
+
+// internalDBStore is the storage behind InternalDB, logging the committed
+// writes on disk when the persistence is enabled
+type internalDBStore struct {
+	*ltcache.TransCache
+	prst *internalDBPersister // nil if the persistence is disabled
+}
+
+// Set will add/edit an item, persisting it if committed
+func (st *internalDBStore) Set(chID, itmID string, value interface{},
+	groupIDs []string, commit bool, transID string) {
+	if st.prst == nil || !commit {
+		st.TransCache.Set(chID, itmID, value, groupIDs, commit, transID)
+		return
+	}
+	st.prst.Lock()
+	st.TransCache.Set(chID, itmID, value, groupIDs, commit, transID)
+	st.prst.logEvicted() // the items evicted happened before the set
+	st.prst.logSet(chID, itmID, value, groupIDs)
+	st.prst.Unlock()
+}
+
+// Remove removes an item, persisting the removal if committed
+func (st *internalDBStore) Remove(chID, itmID string, commit bool, transID string) {
+	if st.prst == nil || !commit {
+		st.TransCache.Remove(chID, itmID, commit, transID)
+		return
+	}
+	st.prst.Lock()
+	st.TransCache.Remove(chID, itmID, commit, transID) // logged out of onEvicted
+	st.prst.logEvicted()
+	st.prst.Unlock()
+}
+
+// Clear removes all items in one or more partitions
+func (st *internalDBStore) Clear(chIDs []string) {
+	if st.prst == nil {
+		st.TransCache.Clear(chIDs)
+		return
+	}
+	st.prst.Lock()
+	st.prst.setClearing(chIDs, true) // logged as one *clear record instead of item removals
+	st.TransCache.Clear(chIDs)
+	st.prst.setClearing(chIDs, false)
+	st.prst.logEvicted()
+	if chIDs == nil {
+		st.prst.logRecord(&internalDBRecord{Op: utils.MetaClear})
+	}
+	for _, chID := range chIDs {
+		st.prst.logRecord(&internalDBRecord{Op: utils.MetaClear, CacheID: chID})
+	}
+	st.prst.Unlock()
+}
+
+// newInternalDBPersister constructs an internalDBPersister, files are named after name inside cfg.Path
+func newInternalDBPersister(cfg *config.InternalPersistenceCfg, name string,
+	cacheIDs []string, ms Marshaler) *internalDBPersister {
+	return &internalDBPersister{
+		cfg:      cfg,
+		ms:       ms,
+		cacheIDs: cacheIDs,
+		logPath:  path.Join(cfg.Path, name+internalDBLogExt),
+		snapPath: path.Join(cfg.Path, name+internalDBSnapshotExt),
+		lockPath: path.Join(cfg.Path, name+internalDBLockExt),
+		groups:   make(map[string]map[string][]string),
+		clearing: make(map[string]bool),
+		evChan:   make(chan struct{}, 1),
+		stopChan: make(chan struct{}),
+	}
+}
+
+// internalDBPersister writes the InternalDB operations into an append-only log,
+// compacted periodically into a snapshot
+type internalDBPersister struct {
+	sync.Mutex // serializes the writes with the log and the snapshots
+	cfg        *config.InternalPersistenceCfg
+	ms         Marshaler
+	cacheIDs   []string // partitions included in the snapshot
+	logPath    string
+	snapPath   string
+	lockPath   string
+	logFile    *os.File
+	lockFile   *os.File                       // exclusive lock on the files so no other process can write them
+	groups     map[string]map[string][]string // groupIDs of the items, ltcache does not expose them
+	dirty      bool                           // log written since last fsync
+	stopChan   chan struct{}
+	loopDone   chan struct{}
+
+	evMux     sync.Mutex          // protects the fields below, onEvicted is called by ltcache under its own locks
+	evicted   []*internalDBRecord // items removed out of ltcache (evictions, expiries, removals) not yet logged
+	replaying bool                // ignore the removals done while replaying
+	clearing  map[string]bool     // ignore the removals of the partitions being cleared
+	evChan    chan struct{}       // signals the loop to log the evicted items
+}
+
+// onEvicted returns the function called by ltcache when removing an item out of the partition chID,
+// queueing the removal so the items evicted or expired are not restored after restart
+func (p *internalDBPersister) onEvicted(chID string) func(itmID string, value interface{}) {
+	return func(itmID string, _ interface{}) {
+		p.evMux.Lock()
+		if !p.replaying && !p.clearing[chID] {
+			p.evicted = append(p.evicted,
+				&internalDBRecord{Op: utils.MetaRemove, CacheID: chID, ItemID: itmID})
+			select {
+			case p.evChan <- struct{}{}:
+			default: // already signaled
+			}
+		}
+		p.evMux.Unlock()
+	}
+}
+
+// setReplaying marks the replay of the files on startup
+func (p *internalDBPersister) setReplaying(replaying bool) {
+	p.evMux.Lock()
+	p.replaying = replaying
+	p.evMux.Unlock()
+}
+
+// setClearing marks the partitions which are cleared, all for nil chIDs
+func (p *internalDBPersister) setClearing(chIDs []string, clearing bool) {
+	if chIDs == nil {
+		chIDs = p.cacheIDs
+	}
+	p.evMux.Lock()
+	for _, chID := range chIDs {
+		if clearing {
+			p.clearing[chID] = true
+		} else {
+			delete(p.clearing, chID)
+		}
+	}
+	p.evMux.Unlock()
+}
+
+// logEvicted writes the queued removals in the log, needs to be called with the lock held
+func (p *internalDBPersister) logEvicted() {
+	p.evMux.Lock()
+	evicted := p.evicted
+	p.evicted = nil
+	p.evMux.Unlock()
+	for _, rec := range evicted {
+		p.logRecord(rec)
+	}
+}
+
+// lock takes the exclusive lock on the files,
+// failing if they are used by another process (ie: cgr-loader while cgr-engine is running)
+func (p *internalDBPersister) lock() (err error) {
+	if p.lockFile, err = os.OpenFile(p.lockPath, os.O_CREATE|os.O_RDWR, 0644); err != nil {
+		return
+	}
+	if err = syscall.Flock(int(p.lockFile.Fd()), syscall.LOCK_EX|syscall.LOCK_NB); err != nil {
+		p.lockFile.Close()
+		p.lockFile = nil
+		if err == syscall.EWOULDBLOCK {
+			err = fmt.Errorf("<%s> is locked by another process", p.lockPath)
+		}
+	}
+	return
+}
+
+// unlock releases the lock on the files
+func (p *internalDBPersister) unlock() (err error) {
+	if p.lockFile == nil {
+		return
+	}
+	err = p.lockFile.Close() // closing the file releases the lock
+	p.lockFile = nil
+	return
+}
+
+// restore replays the snapshot and the log into db, compacts them and starts the persistence
+func (p *internalDBPersister) restore(db *ltcache.TransCache) (err error) {
+	p.Lock()
+	defer p.Unlock()
+	if err = os.MkdirAll(p.cfg.Path, 0755); err != nil {
+		return
+	}
+	if err = p.lock(); err != nil {
+		return
+	}
+	defer func() {
+		if err != nil {
+			p.unlock()
+		}
+	}()
+	p.setReplaying(true)
+	if _, err = p.replay(p.snapPath, db); err != nil {
+		if err == errInternalDBTornFrame { // the snapshot is renamed only once complete
+			err = fmt.Errorf("corrupted snapshot <%s>", p.snapPath)
+		}
+		return
+	}
+	var goodOffset int64
+	if goodOffset, err = p.replay(p.logPath, db); err != nil {
+		if err != errInternalDBTornFrame {
+			return
+		}
+		utils.Logger.Warning(fmt.Sprintf("<%s> discarding incomplete write at offset %d in <%s>",
+			utils.InternalDB, goodOffset, p.logPath))
+		if err = os.Truncate(p.logPath, goodOffset); err != nil {
+			return
+		}
+	}
+	p.setReplaying(false)
+	if p.logFile, err = os.OpenFile(p.logPath,
+		os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644); err != nil {
+		return
+	}
+	if err = p.snapshot(db); err != nil {
+		p.logFile.Close()
+		return
+	}
+	p.loopDone = make(chan struct{})
+	go p.loop(db)
+	return
+}
+
+// replay applies the records in the file at fPath to db,
+// returning the offset after the last complete record
+func (p *internalDBPersister) replay(fPath string, db *ltcache.TransCache) (offset int64, err error) {
+	f, err := os.Open(fPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			err = nil
+		}
+		return
+	}
+	defer f.Close()
+	hdr := make([]byte, internalDBFrameHdrLen)
+	for {
+		if _, err = io.ReadFull(f, hdr); err != nil {
+			if err == io.EOF {
+				err = nil
+			} else if err == io.ErrUnexpectedEOF {
+				err = errInternalDBTornFrame
+			}
+			return
+		}
+		payload := make([]byte, binary.BigEndian.Uint32(hdr[:4]))
+		if _, err = io.ReadFull(f, payload); err != nil {
+			if err == io.EOF || err == io.ErrUnexpectedEOF {
+				err = errInternalDBTornFrame
+			}
+			return
+		}
+		if crc32.ChecksumIEEE(payload) != binary.BigEndian.Uint32(hdr[4:]) {
+			return offset, errInternalDBTornFrame
+		}
+		var rec internalDBRecord
+		if err = p.ms.Unmarshal(payload, &rec); err != nil {
+			return
+		}
+		if err = p.apply(&rec, db); err != nil {
+			return
+		}
+		offset += int64(internalDBFrameHdrLen + len(payload))
+	}
+}
+
+// apply executes one record on db
+func (p *internalDBPersister) apply(rec *internalDBRecord, db *ltcache.TransCache) (err error) {
+	switch rec.Op {
+	case utils.MetaSet:
+		var val interface{}
+		if val, err = p.decodeValue(rec.CacheID, rec.Value); err != nil {
+			return
+		}
+		db.Set(rec.CacheID, rec.ItemID, val, rec.GroupIDs, true, utils.NonTransactional)
+	case utils.MetaRemove:
+		db.Remove(rec.CacheID, rec.ItemID, true, utils.NonTransactional)
+	case utils.MetaClear:
+		var chIDs []string
+		if rec.CacheID != utils.EmptyString {
+			chIDs = []string{rec.CacheID}
+		}
+		db.Clear(chIDs)
+	default:
+		return fmt.Errorf("unsupported operation <%s>", rec.Op)
+	}
+	p.trackGroups(rec)
+	return
+}
+
+// trackGroups remembers the groupIDs of the items so they can be written in the snapshot
+func (p *internalDBPersister) trackGroups(rec *internalDBRecord) {
+	switch {
+	case rec.Op == utils.MetaClear && rec.CacheID == utils.EmptyString:
+		p.groups = make(map[string]map[string][]string)
+	case rec.Op == utils.MetaClear:
+		delete(p.groups, rec.CacheID)
+	case rec.Op == utils.MetaSet && len(rec.GroupIDs) != 0:
+		if _, has := p.groups[rec.CacheID]; !has {
+			p.groups[rec.CacheID] = make(map[string][]string)
+		}
+		p.groups[rec.CacheID][rec.ItemID] = rec.GroupIDs
+	default:
+		delete(p.groups[rec.CacheID], rec.ItemID)
+	}
+}
+
+func (p *internalDBPersister) encodeValue(value interface{}) (b []byte, err error) {
+	if value == nil {
+		return
+	}
+	if sq, isSQ := value.(*StatQueue); isSQ { // metrics are interfaces, store them the same way as the other DataDBs
+		if value, err = NewStoredStatQueue(sq, p.ms); err != nil {
+			return
+		}
+	}
+	return p.ms.Marshal(value)
+}
+
+func (p *internalDBPersister) decodeValue(chID string, b []byte) (value interface{}, err error) {
+	if len(b) == 0 {
+		return
+	}
+	if chID == utils.CacheStatQueues {
+		var ssq StoredStatQueue
+		if err = p.ms.Unmarshal(b, &ssq); err != nil {
+			return
+		}
+		return ssq.AsStatQueue(p.ms)
+	}
+	typ, has := internalDBItemTypes[chID]
+	if !has {
+		return nil, fmt.Errorf("unsupported partition <%s>", chID)
+	}
+	val := reflect.New(typ)
+	if err = p.ms.Unmarshal(b, val.Interface()); err != nil {
+		return
+	}
+	return val.Elem().Interface(), nil
+}
+
+// logSet writes a *set record in the log
+func (p *internalDBPersister) logSet(chID, itmID string, value interface{}, groupIDs []string) {
+	b, err := p.encodeValue(value)
+	if err != nil {
+		utils.Logger.Warning(fmt.Sprintf("<%s> cannot persist item <%s> in partition <%s>, error: %s",
+			utils.InternalDB, itmID, chID, err.Error()))
+		return
+	}
+	p.logRecord(&internalDBRecord{Op: utils.MetaSet, CacheID: chID,
+		ItemID: itmID, GroupIDs: groupIDs, Value: b})
+}
+
+// logRecord appends the record to the log, syncing it based on the fsync policy
+func (p *internalDBPersister) logRecord(rec *internalDBRecord) {
+	p.trackGroups(rec)
+	frame, err := p.frame(rec)
+	if err == nil {
+		_, err = p.logFile.Write(frame)
+	}
+	if err == nil && p.cfg.Fsync == utils.MetaAlways {
+		err = p.logFile.Sync()
+	}
+	if err != nil {
+		utils.Logger.Warning(fmt.Sprintf("<%s> cannot write <%s> operation for item <%s> in <%s>, error: %s",
+			utils.InternalDB, rec.Op, rec.ItemID, p.logPath, err.Error()))
+		return
+	}
+	p.dirty = p.cfg.Fsync != utils.MetaAlways
+}
+
+// frame encodes the record as: payload length, payload crc32, payload
+func (p *internalDBPersister) frame(rec *internalDBRecord) (frame []byte, err error) {
+	var payload []byte
+	if payload, err = p.ms.Marshal(rec); err != nil {
+		return
+	}
+	frame = make([]byte, internalDBFrameHdrLen, internalDBFrameHdrLen+len(payload))
+	binary.BigEndian.PutUint32(frame[:4], uint32(len(payload)))
+	binary.BigEndian.PutUint32(frame[4:], crc32.ChecksumIEEE(payload))
+	return append(frame, payload...), nil
+}
+
+// snapshot writes the content of db into a new snapshot and truncates the log,
+// needs to be called with the lock held
+func (p *internalDBPersister) snapshot(db *ltcache.TransCache) (err error) {
+	tmpPath := p.snapPath + internalDBTmpExt
+	var f *os.File
+	if f, err = os.OpenFile(tmpPath, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0644); err != nil {
+		return
+	}
+	if err = p.writeSnapshot(f, db); err != nil {
+		f.Close()
+		os.Remove(tmpPath)
+		return
+	}
+	if err = f.Sync(); err != nil {
+		f.Close()
+		os.Remove(tmpPath)
+		return
+	}
+	if err = f.Close(); err != nil {
+		os.Remove(tmpPath)
+		return
+	}
+	if err = os.Rename(tmpPath, p.snapPath); err != nil {
+		return
+	}
+	if err = syncDir(p.cfg.Path); err != nil {
+		return
+	}
+	// replaying the old log over the new snapshot gives the same data so a crash here is safe
+	if err = p.logFile.Truncate(0); err != nil {
+		return
+	}
+	if err = p.logFile.Sync(); err != nil {
+		return
+	}
+	p.dirty = false
+	return
+}
+
+func (p *internalDBPersister) writeSnapshot(w io.Writer, db *ltcache.TransCache) (err error) {
+	for _, chID := range p.cacheIDs {
+		for _, itmID := range db.GetItemIDs(chID, utils.EmptyString) {
+			x, ok := db.Get(chID, itmID)
+			if !ok {
+				continue
+			}
+			var b, frame []byte
+			if b, err = p.encodeValue(x); err != nil {
+				return fmt.Errorf("cannot encode item <%s> in partition <%s>, error: %s",
+					itmID, chID, err.Error())
+			}
+			if frame, err = p.frame(&internalDBRecord{Op: utils.MetaSet, CacheID: chID,
+				ItemID: itmID, GroupIDs: p.groups[chID][itmID], Value: b}); err != nil {
+				return
+			}
+			if _, err = w.Write(frame); err != nil {
+				return
+			}
+		}
+	}
+	return
+}
+
+// sync flushes the log to disk if it was written since last sync
+func (p *internalDBPersister) sync() (err error) {
+	if !p.dirty {
+		return
+	}
+	if err = p.logFile.Sync(); err != nil {
+		return
+	}
+	p.dirty = false
+	return
+}
+
+// loop syncs the log and takes the snapshots at the configured intervals
+func (p *internalDBPersister) loop(db *ltcache.TransCache) {
+	defer close(p.loopDone)
+	var syncChan, snapChan <-chan time.Time
+	if p.cfg.Fsync == utils.MetaInterval && p.cfg.FsyncInterval > 0 {
+		syncTicker := time.NewTicker(p.cfg.FsyncInterval)
+		defer syncTicker.Stop()
+		syncChan = syncTicker.C
+	}
+	if p.cfg.SnapshotInterval > 0 {
+		snapTicker := time.NewTicker(p.cfg.SnapshotInterval)
+		defer snapTicker.Stop()
+		snapChan = snapTicker.C
+	}
+	for {
+		select {
+		case <-p.stopChan:
+			return
+		case <-p.evChan:
+			p.Lock()
+			p.logEvicted()
+			p.Unlock()
+		case <-syncChan:
+			p.Lock()
+			if err := p.sync(); err != nil {
+				utils.Logger.Warning(fmt.Sprintf("<%s> cannot sync <%s>, error: %s",
+					utils.InternalDB, p.logPath, err.Error()))
+			}
+			p.Unlock()
+		case <-snapChan:
+			p.Lock()
+			if err := p.snapshot(db); err != nil {
+				utils.Logger.Warning(fmt.Sprintf("<%s> cannot write snapshot <%s>, error: %s",
+					utils.InternalDB, p.snapPath, err.Error()))
+			}
+			p.Unlock()
+		}
+	}
+}
+
+// close stops the loop, compacts the log into a last snapshot and closes the log
+func (p *internalDBPersister) close(db *ltcache.TransCache) (err error) {
+	if p.loopDone == nil { // not started
+		return
+	}
+	close(p.stopChan)
+	<-p.loopDone
+	p.Lock()
+	defer p.Unlock()
+	p.logEvicted()
+	if err = p.snapshot(db); err != nil {
+		if errSync := p.sync(); errSync != nil {
+			utils.Logger.Warning(fmt.Sprintf("<%s> cannot sync <%s>, error: %s",
+				utils.InternalDB, p.logPath, errSync.Error()))
+		}
+	}
+	if errClose := p.logFile.Close(); err == nil {
+		err = errClose
+	}
+	if errUnlock := p.unlock(); err == nil {
+		err = errUnlock
+	}
+	p.loopDone = nil
+	return
+}
+
+// syncDir makes the rename of the files inside dir durable
+func syncDir(dir string) (err error) {
+	var d *os.File
+	if d, err = os.Open(dir); err != nil {
+		return
+	}
+	if err = d.Sync(); err != nil {
+		d.Close()
+		return
+	}
+	return d.Close()
+}
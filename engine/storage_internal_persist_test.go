@@ -0,0 +1,299 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package engine
+
+import (
+	"io/ioutil"
+	"os"
+	"path"
+	"reflect"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/cgrates/utils"
+)
+
+func testInternalPersistenceCfg(t *testing.T) (cfg *config.InternalPersistenceCfg) {
+	dir, err := ioutil.TempDir("", "internal_db")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &config.InternalPersistenceCfg{
+		Path:          dir,
+		Fsync:         utils.MetaAlways,
+		FsyncInterval: time.Second,
+	}
+}
+
+// testInternalDBCrash closes the files of iDB without compacting the log, as after a crash
+func testInternalDBCrash(iDB *InternalDB) {
+	prst := iDB.db.prst
+	close(prst.stopChan)
+	<-prst.loopDone
+	prst.logFile.Close()
+	prst.unlock()
+}
+
+func TestInternalDBPersistenceRestore(t *testing.T) {
+	prstCfg := testInternalPersistenceCfg(t)
+	defer os.RemoveAll(prstCfg.Path)
+	iDB, err := NewPersistentInternalDB(nil, nil, true,
+		config.CgrConfig().DataDbCfg().Items, prstCfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	acc := &Account{
+		ID: "cgrates.org:1001",
+		BalanceMap: map[string]Balances{
+			utils.MONETARY: Balances{&Balance{ID: "MONETARY1", Value: 10, Weight: 10}},
+		},
+	}
+	dst := &Destination{Id: "DST_1001", Prefixes: []string{"1001", "1002"}}
+	sq := &StatQueue{
+		Tenant: "cgrates.org",
+		ID:     "SQ_1",
+		SQItems: []SQItem{
+			{EventID: "cgrates.org:ev1"},
+		},
+		SQMetrics: map[string]StatMetric{
+			utils.MetaASR: &StatASR{
+				Answered: 1,
+				Count:    1,
+				Events: map[string]*StatWithCompress{
+					"cgrates.org:ev1": &StatWithCompress{Stat: 1, CompressFactor: 1},
+				},
+			},
+		},
+	}
+	if err = iDB.SetAccountDrv(acc); err != nil {
+		t.Error(err)
+	}
+	if err = iDB.SetDestinationDrv(dst, utils.NonTransactional); err != nil {
+		t.Error(err)
+	}
+	if err = iDB.SetReverseDestinationDrv(dst, utils.NonTransactional); err != nil {
+		t.Error(err)
+	}
+	if err = iDB.SetStatQueueDrv(nil, sq); err != nil {
+		t.Error(err)
+	}
+	if err = iDB.SetVersions(CurrentDataDBVersions(), true); err != nil {
+		t.Error(err)
+	}
+	if err = iDB.SetAccountDrv(&Account{ID: "cgrates.org:1002"}); err != nil {
+		t.Error(err)
+	}
+	if err = iDB.RemoveAccountDrv("cgrates.org:1002"); err != nil {
+		t.Error(err)
+	}
+	iDB.Close()
+	if fi, err := os.Stat(path.Join(prstCfg.Path, utils.DataDB+internalDBLogExt)); err != nil {
+		t.Error(err)
+	} else if fi.Size() != 0 {
+		t.Errorf("expecting the log compacted on close, size: %d", fi.Size())
+	}
+
+	if iDB, err = NewPersistentInternalDB(nil, nil, true,
+		config.CgrConfig().DataDbCfg().Items, prstCfg); err != nil {
+		t.Fatal(err)
+	}
+	defer iDB.Close()
+	if rcv, err := iDB.GetAccountDrv(acc.ID); err != nil {
+		t.Error(err)
+	} else if eAcc := acc.Clone(); !reflect.DeepEqual(eAcc, rcv) {
+		t.Errorf("expecting: %s, received: %s", utils.ToJSON(eAcc), utils.ToJSON(rcv))
+	}
+	if _, err := iDB.GetAccountDrv("cgrates.org:1002"); err != utils.ErrNotFound {
+		t.Errorf("expecting: %v, received: %v", utils.ErrNotFound, err)
+	}
+	if rcv, err := iDB.GetDestinationDrv(dst.Id, true, utils.NonTransactional); err != nil {
+		t.Error(err)
+	} else if !reflect.DeepEqual(dst, rcv) {
+		t.Errorf("expecting: %s, received: %s", utils.ToJSON(dst), utils.ToJSON(rcv))
+	}
+	if rcv, err := iDB.GetReverseDestinationDrv("1002", true, utils.NonTransactional); err != nil {
+		t.Error(err)
+	} else if !reflect.DeepEqual([]string{dst.Id}, rcv) {
+		t.Errorf("expecting: %+v, received: %+v", []string{dst.Id}, rcv)
+	}
+	if rcv, err := iDB.GetStatQueueDrv(sq.Tenant, sq.ID); err != nil {
+		t.Error(err)
+	} else if asr := rcv.SQMetrics[utils.MetaASR].GetValue(); asr != 100.0 {
+		t.Errorf("expecting ASR: 100, received: %+v", asr)
+	}
+	if rcv, err := iDB.GetVersions(utils.EmptyString); err != nil {
+		t.Error(err)
+	} else if !reflect.DeepEqual(CurrentDataDBVersions(), rcv) {
+		t.Errorf("expecting: %+v, received: %+v", CurrentDataDBVersions(), rcv)
+	}
+}
+
+func TestInternalDBPersistenceTornWrite(t *testing.T) {
+	prstCfg := testInternalPersistenceCfg(t)
+	defer os.RemoveAll(prstCfg.Path)
+	iDB, err := NewPersistentInternalDB(nil, nil, false,
+		config.CgrConfig().StorDbCfg().Items, prstCfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cdr := &CDR{
+		CGRID:       "CGRID1",
+		RunID:       utils.MetaDefault,
+		OriginID:    "ORIGIN1",
+		Tenant:      "cgrates.org",
+		Account:     "1001",
+		Destination: "1002",
+		Usage:       time.Minute,
+		Cost:        0.6,
+	}
+	if err = iDB.SetCDR(cdr, false); err != nil {
+		t.Error(err)
+	}
+	// simulate a crash in the middle of the next write
+	prst := iDB.db.prst
+	close(prst.stopChan)
+	<-prst.loopDone
+	frame, err := prst.frame(&internalDBRecord{Op: utils.MetaRemove,
+		CacheID: utils.CDRsTBL, ItemID: "CGRID1:*default:ORIGIN1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = prst.logFile.Write(frame[:len(frame)-1]); err != nil {
+		t.Fatal(err)
+	}
+	prst.logFile.Close()
+	prst.unlock()
+
+	if iDB, err = NewPersistentInternalDB(nil, nil, false,
+		config.CgrConfig().StorDbCfg().Items, prstCfg); err != nil {
+		t.Fatal(err)
+	}
+	defer iDB.Close()
+	if rcv, _, err := iDB.GetCDRs(&utils.CDRsFilter{Accounts: []string{"1001"}}, false); err != nil {
+		t.Error(err)
+	} else if len(rcv) != 1 || !reflect.DeepEqual(cdr, rcv[0]) {
+		t.Errorf("expecting: %s, received: %s", utils.ToJSON(cdr), utils.ToJSON(rcv))
+	}
+	eIDs := []string{"CGRID1:*default:ORIGIN1"}
+	if rcv := iDB.db.GetGroupItemIDs(utils.CDRsTBL,
+		utils.ConcatenatedKey(utils.Destination, "100")); !reflect.DeepEqual(eIDs, rcv) {
+		t.Errorf("expecting: %+v, received: %+v", eIDs, rcv)
+	}
+}
+
+func TestInternalDBPersisterReplayOrder(t *testing.T) {
+	prstCfg := testInternalPersistenceCfg(t)
+	defer os.RemoveAll(prstCfg.Path)
+	iDB, err := NewPersistentInternalDB(nil, nil, true,
+		config.CgrConfig().DataDbCfg().Items, prstCfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, id := range []string{"1001", "1002", "1003"} {
+		if err = iDB.SetAccountActionPlansDrv(id, []string{"AP_" + id}, true); err != nil {
+			t.Error(err)
+		}
+	}
+	if err = iDB.Flush(utils.EmptyString); err != nil {
+		t.Error(err)
+	}
+	if err = iDB.SetAccountActionPlansDrv("1004", []string{"AP_1004"}, true); err != nil {
+		t.Error(err)
+	}
+	// keep the log as after a crash, without compacting it
+	testInternalDBCrash(iDB)
+
+	if iDB, err = NewPersistentInternalDB(nil, nil, true,
+		config.CgrConfig().DataDbCfg().Items, prstCfg); err != nil {
+		t.Fatal(err)
+	}
+	defer iDB.Close()
+	keys, err := iDB.GetKeysForPrefix(utils.AccountActionPlansPrefix)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sort.Strings(keys)
+	if eKeys := []string{utils.AccountActionPlansPrefix + "1004"}; !reflect.DeepEqual(eKeys, keys) {
+		t.Errorf("expecting: %+v, received: %+v", eKeys, keys)
+	}
+}
+
+func TestInternalDBPersistenceLock(t *testing.T) {
+	prstCfg := testInternalPersistenceCfg(t)
+	defer os.RemoveAll(prstCfg.Path)
+	iDB, err := NewPersistentInternalDB(nil, nil, true,
+		config.CgrConfig().DataDbCfg().Items, prstCfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// ie: cgr-loader started while cgr-engine is running
+	if _, err = NewPersistentInternalDB(nil, nil, true,
+		config.CgrConfig().DataDbCfg().Items, prstCfg); err == nil {
+		t.Fatal("expecting error for the files locked")
+	}
+	iDB.Close()
+	if iDB, err = NewPersistentInternalDB(nil, nil, true,
+		config.CgrConfig().DataDbCfg().Items, prstCfg); err != nil {
+		t.Fatal(err)
+	}
+	iDB.Close()
+}
+
+func TestInternalDBPersistenceEvictions(t *testing.T) {
+	prstCfg := testInternalPersistenceCfg(t)
+	defer os.RemoveAll(prstCfg.Path)
+	items := make(map[string]*config.ItemOpt)
+	for itmID, itm := range config.CgrConfig().DataDbCfg().Items {
+		items[itmID] = itm
+	}
+	items[utils.CacheAccounts] = &config.ItemOpt{Limit: 1}
+	items[utils.CacheDestinations] = &config.ItemOpt{Limit: -1, TTL: 10 * time.Millisecond}
+	iDB, err := NewPersistentInternalDB(nil, nil, true, items, prstCfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = iDB.SetDestinationDrv(&Destination{Id: "DST_1001", Prefixes: []string{"1001"}},
+		utils.NonTransactional); err != nil {
+		t.Error(err)
+	}
+	if err = iDB.SetAccountDrv(&Account{ID: "cgrates.org:1001"}); err != nil {
+		t.Error(err)
+	}
+	if err = iDB.SetAccountDrv(&Account{ID: "cgrates.org:1002"}); err != nil { // evicts 1001
+		t.Error(err)
+	}
+	time.Sleep(50 * time.Millisecond) // DST_1001 expires
+	testInternalDBCrash(iDB)
+
+	if iDB, err = NewPersistentInternalDB(nil, nil, true, items, prstCfg); err != nil {
+		t.Fatal(err)
+	}
+	defer iDB.Close()
+	if _, err := iDB.GetAccountDrv("cgrates.org:1001"); err != utils.ErrNotFound {
+		t.Errorf("expecting: %v, received: %v", utils.ErrNotFound, err)
+	}
+	if _, err := iDB.GetAccountDrv("cgrates.org:1002"); err != nil {
+		t.Error(err)
+	}
+	if _, err := iDB.GetDestinationDrv("DST_1001", true, utils.NonTransactional); err != utils.ErrNotFound {
+		t.Errorf("expecting: %v, received: %v", utils.ErrNotFound, err)
+	}
+}
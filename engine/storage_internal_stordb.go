@@ -90,6 +90,53 @@ func (iDB *InternalDB) GetTPTimings(tpid, id string) (timings []*utils.ApierTPTi
 	return
 }
 
+func (iDB *InternalDB) GetTPCalendars(tpid, id string) (cals []*utils.TPCalendar, err error) {
+	key := tpid
+	if id != utils.EmptyString {
+		key += utils.CONCATENATED_KEY_SEP + id
+	}
+	ids := iDB.db.GetItemIDs(utils.TBLTPCalendars, key)
+	for _, id := range ids {
+		x, ok := iDB.db.Get(utils.TBLTPCalendars, id)
+		if !ok || x == nil {
+			return nil, utils.ErrNotFound
+		}
+		cals = append(cals, x.(*utils.TPCalendar))
+	}
+
+	if len(cals) == 0 {
+		return nil, utils.ErrNotFound
+	}
+	return
+}
+
+func (iDB *InternalDB) GetTPExchangeRates(tpid, fromCurrency, toCurrency string) (exrs []*utils.TPExchangeRates, err error) {
+	key := tpid
+	if fromCurrency != utils.EmptyString {
+		key += utils.CONCATENATED_KEY_SEP + fromCurrency
+		if toCurrency != utils.EmptyString {
+			key += utils.CONCATENATED_KEY_SEP + toCurrency
+		}
+	}
+	ids := iDB.db.GetItemIDs(utils.TBLTPExchangeRates, key)
+	for _, id := range ids {
+		x, ok := iDB.db.Get(utils.TBLTPExchangeRates, id)
+		if !ok || x == nil {
+			return nil, utils.ErrNotFound
+		}
+		exr := x.(*utils.TPExchangeRates)
+		if toCurrency != utils.EmptyString && exr.ToCurrency != toCurrency {
+			continue
+		}
+		exrs = append(exrs, exr)
+	}
+
+	if len(exrs) == 0 {
+		return nil, utils.ErrNotFound
+	}
+	return
+}
+
 func (iDB *InternalDB) GetTPDestinations(tpid, id string) (dsts []*utils.TPDestination, err error) {
 	key := tpid
 	if id != utils.EmptyString {
@@ -572,7 +619,9 @@ func (iDB *InternalDB) RemTpData(table, tpid string, args map[string]string) (er
 		return iDB.Flush(utils.EmptyString)
 	}
 	key := tpid
-	if args != nil {
+	if table == utils.TBLTPExchangeRates && args != nil { // the currencies need to be in order within the key
+		key = utils.ConcatenatedKey(tpid, args["from_currency"], args["to_currency"])
+	} else if args != nil {
 		for _, val := range args {
 			key += utils.CONCATENATED_KEY_SEP + val
 		}
@@ -595,6 +644,28 @@ func (iDB *InternalDB) SetTPTimings(timings []*utils.ApierTPTiming) (err error)
 	}
 	return
 }
+func (iDB *InternalDB) SetTPCalendars(cals []*utils.TPCalendar) (err error) {
+	if len(cals) == 0 {
+		return nil
+	}
+	for _, cal := range cals {
+		iDB.db.Set(utils.TBLTPCalendars, utils.ConcatenatedKey(cal.TPid, cal.ID), cal, nil,
+			cacheCommit(utils.NonTransactional), utils.NonTransactional)
+	}
+	return
+}
+
+func (iDB *InternalDB) SetTPExchangeRates(exrs []*utils.TPExchangeRates) (err error) {
+	if len(exrs) == 0 {
+		return nil
+	}
+	for _, exr := range exrs {
+		iDB.db.Set(utils.TBLTPExchangeRates, utils.ConcatenatedKey(exr.TPid, exr.ID()), exr, nil,
+			cacheCommit(utils.NonTransactional), utils.NonTransactional)
+	}
+	return
+}
+
 func (iDB *InternalDB) SetTPDestinations(dests []*utils.TPDestination) (err error) {
 	if len(dests) == 0 {
 		return nil
@@ -75,6 +75,9 @@ const (
 	ColDpp  = "dispatcher_profiles"
 	ColDph  = "dispatcher_hosts"
 	ColLID  = "load_ids"
+	ColSes  = "sessions"
+	ColRsu  = "resource_usages"
+	ColExr  = "exchange_rates"
 )
 
 var (
@@ -193,6 +196,9 @@ func NewMongoStorage(host, port, db, user, pass, mrshlerStr, storageType string,
 		if empty {
 			return ms.EnsureIndexes()
 		}
+		if isDataDB && !utils.IsSliceMember(cols, ColRsu) { // the atomic usage updates rely on its unique index
+			return ms.ensureIndexesForCol(ColRsu)
+		}
 		return nil
 	}); err != nil {
 		return nil, err
@@ -276,6 +282,19 @@ func isNotFound(err error) bool {
 	return de.Code == 26 || de.Message == "ns not found"
 }
 
+func isDuplicateKey(err error) bool {
+	we, ok := err.(mongo.WriteException)
+	if !ok {
+		return false
+	}
+	for _, e := range we.WriteErrors {
+		if e.Code == 11000 {
+			return true
+		}
+	}
+	return false
+}
+
 func (ms *MongoStorage) ensureIndexesForCol(col string) (err error) { // exported for migrator
 	if err = ms.dropAllIndexesForCol(col); err != nil && !isNotFound(err) { // make sure you do not have indexes
 		return
@@ -294,8 +313,29 @@ func (ms *MongoStorage) ensureIndexesForCol(col string) (err error) { // exporte
 		if err = ms.enusureIndex(col, true, "id"); err != nil {
 			return
 		}
+	case ColSes:
+		if err = ms.enusureIndex(col, true, "nodeid", "cgrid"); err != nil {
+			return
+		}
+	case ColExr:
+		if err = ms.enusureIndex(col, true, "fromcurrency", "tocurrency"); err != nil {
+			return
+		}
+	case ColRsu:
+		if err = ms.enusureIndex(col, true, "tenant", "id"); err != nil {
+			return
+		}
+		if err = ms.query(func(sctx mongo.SessionContext) error { // remove the usages once the last one expired
+			_, err := ms.getCol(col).Indexes().CreateOne(sctx, mongo.IndexModel{
+				Keys:    bson.M{"expireat": 1},
+				Options: options.Index().SetExpireAfterSeconds(0),
+			})
+			return err
+		}); err != nil {
+			return
+		}
 		//StorDB
-	case utils.TBLTPTimings, utils.TBLTPDestinations,
+	case utils.TBLTPTimings, utils.TBLTPCalendars, utils.TBLTPDestinations,
 		utils.TBLTPDestinationRates, utils.TBLTPRatingPlans,
 		utils.TBLTPSharedGroups, utils.TBLTPActions,
 		utils.TBLTPActionPlans, utils.TBLTPActionTriggers,
@@ -310,6 +350,11 @@ func (ms *MongoStorage) ensureIndexesForCol(col string) (err error) { // exporte
 			"category", "subject", "loadid"); err != nil {
 			return
 		}
+	case utils.TBLTPExchangeRates:
+		if err = ms.enusureIndex(col, true, "tpid", "fromcurrency",
+			"tocurrency"); err != nil {
+			return
+		}
 	case utils.CDRsTBL:
 		if err = ms.enusureIndex(col, true, CGRIDLow, RunIDLow,
 			OriginIDLow); err != nil {
@@ -351,19 +396,19 @@ func (ms *MongoStorage) EnsureIndexes(cols ...string) (err error) {
 		for _, col := range []string{ColAct, ColApl, ColAAp, ColAtr,
 			ColRpl, ColDst, ColRds, ColLht, ColRFI, ColRsP, ColRes, ColSqs, ColSqp,
 			ColTps, ColThs, ColSpp, ColAttr, ColFlt, ColCpp, ColDpp,
-			ColRpf, ColShg, ColAcc} {
+			ColRpf, ColShg, ColAcc, ColSes, ColRsu, ColExr} {
 			if err = ms.ensureIndexesForCol(col); err != nil {
 				return
 			}
 		}
 	}
 	if ms.storageType == utils.StorDB {
-		for _, col := range []string{utils.TBLTPTimings, utils.TBLTPDestinations,
+		for _, col := range []string{utils.TBLTPTimings, utils.TBLTPCalendars, utils.TBLTPDestinations,
 			utils.TBLTPDestinationRates, utils.TBLTPRatingPlans,
 			utils.TBLTPSharedGroups, utils.TBLTPActions,
 			utils.TBLTPActionPlans, utils.TBLTPActionTriggers,
 			utils.TBLTPStats, utils.TBLTPResources,
-			utils.TBLTPRateProfiles, utils.CDRsTBL, utils.SessionCostsTBL} {
+			utils.TBLTPRateProfiles, utils.TBLTPExchangeRates, utils.CDRsTBL, utils.SessionCostsTBL} {
 			if err = ms.ensureIndexesForCol(col); err != nil {
 				return
 			}
@@ -622,6 +667,26 @@ func (ms *MongoStorage) getField3(sctx mongo.SessionContext, col, prefix, field
 	return result, iter.Close(sctx)
 }
 
+// getExchangeRatesKeys returns the keys of the exchange rates with the ID starting with idPrefix
+func (ms *MongoStorage) getExchangeRatesKeys(sctx mongo.SessionContext, idPrefix string) (result []string, err error) {
+	exrCurrencies := struct{ FromCurrency, ToCurrency string }{}
+	iter, err := ms.getCol(ColExr).Find(sctx, bson.M{},
+		options.Find().SetProjection(bson.M{"fromcurrency": 1, "tocurrency": 1}),
+	)
+	if err != nil {
+		return
+	}
+	for iter.Next(sctx) {
+		if err = iter.Decode(&exrCurrencies); err != nil {
+			return
+		}
+		if id := utils.ConcatenatedKey(exrCurrencies.FromCurrency, exrCurrencies.ToCurrency); strings.HasPrefix(id, idPrefix) {
+			result = append(result, utils.ExchangeRatesPrefix+id)
+		}
+	}
+	return result, iter.Close(sctx)
+}
+
 // GetKeysForPrefix implementation
 func (ms *MongoStorage) GetKeysForPrefix(prefix string) (result []string, err error) {
 	var category, subject string
@@ -697,6 +762,8 @@ func (ms *MongoStorage) GetKeysForPrefix(prefix string) (result []string, err er
 			result, err = ms.getField3(sctx, ColRFI, utils.ChargerFilterIndexes, "key")
 		case utils.DispatcherFilterIndexes:
 			result, err = ms.getField3(sctx, ColRFI, utils.DispatcherFilterIndexes, "key")
+		case utils.ExchangeRatesPrefix:
+			result, err = ms.getExchangeRatesKeys(sctx, prefix[keyLen:])
 		default:
 			err = fmt.Errorf("unsupported prefix in GetKeysForPrefix: %s", prefix)
 		}
@@ -1667,10 +1734,88 @@ func (ms *MongoStorage) RemoveResourceDrv(tenant, id string) (err error) {
 		if dr.DeletedCount == 0 {
 			return utils.ErrNotFound
 		}
+		if err != nil {
+			return err
+		}
+		_, err = ms.getCol(ColRsu).DeleteOne(sctx, bson.M{"tenant": tenant, "id": id})
 		return err
 	})
 }
 
+// mongoResourceUsagesRetries limits the updates retried on concurrent changes of the same usages
+const mongoResourceUsagesRetries = 10
+
+// mongoResourceUsages is the document holding the usages of one resource
+type mongoResourceUsages struct {
+	Tenant   string
+	ID       string
+	Version  int64 // changed on each update so concurrent updates can be detected
+	Usages   []*ResourceUsage
+	ExpireAt *time.Time // expiry of the last usage, nil if one of them does not expire
+}
+
+// UpdateResourceUsagesDrv updates the usages with compare and swap on the document version, retrying on concurrent updates
+func (ms *MongoStorage) UpdateResourceUsagesDrv(tenant, id, ruID string, ru *ResourceUsage,
+	limit *float64) (usages map[string]*ResourceUsage, recorded bool, err error) {
+	for retry, updated := 0, false; !updated; retry++ {
+		if retry == mongoResourceUsagesRetries {
+			return nil, false, fmt.Errorf("too many concurrent updates of the usages for resource: %s",
+				utils.ConcatenatedKey(tenant, id))
+		}
+		err = ms.query(func(sctx mongo.SessionContext) (err error) {
+			doc := new(mongoResourceUsages)
+			if err = ms.getCol(ColRsu).FindOne(sctx,
+				bson.M{"tenant": tenant, "id": id}).Decode(doc); err != nil {
+				if err != mongo.ErrNoDocuments {
+					return
+				}
+				err = nil
+			}
+			usages = make(map[string]*ResourceUsage)
+			for _, u := range doc.Usages {
+				usages[u.ID] = u
+			}
+			if recorded, err = updateResourceUsages(usages, ruID, ru, limit, time.Now()); err != nil {
+				return
+			}
+			newDoc := &mongoResourceUsages{
+				Tenant:  tenant,
+				ID:      id,
+				Version: doc.Version + 1,
+				Usages:  make([]*ResourceUsage, 0, len(usages)),
+			}
+			var lastExpiry time.Time
+			var noExpiry bool
+			for _, u := range usages {
+				newDoc.Usages = append(newDoc.Usages, u)
+				if u.ExpiryTime.IsZero() {
+					noExpiry = true
+				} else if u.ExpiryTime.After(lastExpiry) {
+					lastExpiry = u.ExpiryTime
+				}
+			}
+			if !noExpiry && !lastExpiry.IsZero() {
+				newDoc.ExpireAt = &lastExpiry
+			}
+			var ur *mongo.UpdateResult
+			if ur, err = ms.getCol(ColRsu).ReplaceOne(sctx,
+				bson.M{"tenant": tenant, "id": id, "version": doc.Version}, newDoc,
+				options.Replace().SetUpsert(true)); err != nil {
+				if isDuplicateKey(err) { // updated in the meantime by someone else
+					err = nil
+				}
+				return
+			}
+			updated = ur.MatchedCount != 0 || ur.UpsertedCount != 0
+			return
+		})
+		if err != nil {
+			return nil, false, err
+		}
+	}
+	return
+}
+
 func (ms *MongoStorage) GetTimingDrv(id string) (t *utils.TPTiming, err error) {
 	t = new(utils.TPTiming)
 	err = ms.query(func(sctx mongo.SessionContext) (err error) {
@@ -2256,6 +2401,77 @@ func (ms *MongoStorage) RemoveDispatcherHostDrv(tenant, id string) (err error) {
 	})
 }
 
+func (ms *MongoStorage) GetStoredSessionsDrv(nodeID string) (sSs []*StoredSession, err error) {
+	err = ms.query(func(sctx mongo.SessionContext) (err error) {
+		cur, err := ms.getCol(ColSes).Find(sctx, bson.M{"nodeid": nodeID})
+		if err != nil {
+			return err
+		}
+		for cur.Next(sctx) {
+			sS := new(StoredSession)
+			if err = cur.Decode(sS); err != nil {
+				return
+			}
+			sSs = append(sSs, sS)
+		}
+		return cur.Close(sctx)
+	})
+	return
+}
+
+func (ms *MongoStorage) SetStoredSessionDrv(sS *StoredSession) (err error) {
+	return ms.query(func(sctx mongo.SessionContext) (err error) {
+		_, err = ms.getCol(ColSes).UpdateOne(sctx, bson.M{"nodeid": sS.NodeID, "cgrid": sS.CGRID},
+			bson.M{"$set": sS},
+			options.Update().SetUpsert(true),
+		)
+		return err
+	})
+}
+
+func (ms *MongoStorage) RemoveStoredSessionDrv(nodeID, cgrID string) (err error) {
+	return ms.query(func(sctx mongo.SessionContext) (err error) {
+		_, err = ms.getCol(ColSes).DeleteOne(sctx, bson.M{"nodeid": nodeID, "cgrid": cgrID})
+		return err
+	})
+}
+
+func (ms *MongoStorage) GetExchangeRatesDrv(fromCurrency, toCurrency string) (exr *ExchangeRates, err error) {
+	exr = new(ExchangeRates)
+	err = ms.query(func(sctx mongo.SessionContext) (err error) {
+		cur := ms.getCol(ColExr).FindOne(sctx, bson.M{"fromcurrency": fromCurrency, "tocurrency": toCurrency})
+		if err := cur.Decode(exr); err != nil {
+			exr = nil
+			if err == mongo.ErrNoDocuments {
+				return utils.ErrNotFound
+			}
+			return err
+		}
+		return nil
+	})
+	return
+}
+
+func (ms *MongoStorage) SetExchangeRatesDrv(exr *ExchangeRates) (err error) {
+	return ms.query(func(sctx mongo.SessionContext) (err error) {
+		_, err = ms.getCol(ColExr).UpdateOne(sctx, bson.M{"fromcurrency": exr.FromCurrency, "tocurrency": exr.ToCurrency},
+			bson.M{"$set": exr},
+			options.Update().SetUpsert(true),
+		)
+		return err
+	})
+}
+
+func (ms *MongoStorage) RemoveExchangeRatesDrv(fromCurrency, toCurrency string) (err error) {
+	return ms.query(func(sctx mongo.SessionContext) (err error) {
+		dr, err := ms.getCol(ColExr).DeleteOne(sctx, bson.M{"fromcurrency": fromCurrency, "tocurrency": toCurrency})
+		if dr.DeletedCount == 0 {
+			return utils.ErrNotFound
+		}
+		return err
+	})
+}
+
 func (ms *MongoStorage) GetItemLoadIDsDrv(itemIDPrefix string) (loadIDs map[string]int64, err error) {
 	fop := options.FindOne()
 	if itemIDPrefix != "" {
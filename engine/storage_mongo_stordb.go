@@ -81,6 +81,12 @@ func (ms *MongoStorage) GetTpIds(colName string) (tpids []string, err error) {
 	return tpids, nil
 }
 
+// mongoTPExchangeRatesFields converts the exchange rates columns used in SQL into the fields used here
+var mongoTPExchangeRatesFields = map[string]string{
+	"from_currency": "fromcurrency",
+	"to_currency":   "tocurrency",
+}
+
 func (ms *MongoStorage) GetTpTableIds(tpid, table string, distinct utils.TPDistinctIds,
 	filter map[string]string, pag *utils.PaginatorWithSearch) ([]string, error) {
 	findMap := bson.M{}
@@ -118,6 +124,9 @@ func (ms *MongoStorage) GetTpTableIds(tpid, table string, distinct utils.TPDisti
 		if d == "tag" { // convert the tag used in SQL into id used here
 			distinct[i] = "id"
 		}
+		if fld, has := mongoTPExchangeRatesFields[d]; has {
+			distinct[i] = fld
+		}
 		selectors[distinct[i]] = 1
 	}
 	fop.SetProjection(selectors)
@@ -182,6 +191,63 @@ func (ms *MongoStorage) GetTPTimings(tpid, id string) ([]*utils.ApierTPTiming, e
 	return results, err
 }
 
+func (ms *MongoStorage) GetTPCalendars(tpid, id string) ([]*utils.TPCalendar, error) {
+	filter := bson.M{"tpid": tpid}
+	if id != "" {
+		filter["id"] = id
+	}
+	var results []*utils.TPCalendar
+	err := ms.query(func(sctx mongo.SessionContext) (err error) {
+		cur, err := ms.getCol(utils.TBLTPCalendars).Find(sctx, filter)
+		if err != nil {
+			return err
+		}
+		for cur.Next(sctx) {
+			var el utils.TPCalendar
+			err := cur.Decode(&el)
+			if err != nil {
+				return err
+			}
+			results = append(results, &el)
+		}
+		if len(results) == 0 {
+			return utils.ErrNotFound
+		}
+		return cur.Close(sctx)
+	})
+	return results, err
+}
+
+func (ms *MongoStorage) GetTPExchangeRates(tpid, fromCurrency, toCurrency string) ([]*utils.TPExchangeRates, error) {
+	filter := bson.M{"tpid": tpid}
+	if fromCurrency != "" {
+		filter["fromcurrency"] = fromCurrency
+	}
+	if toCurrency != "" {
+		filter["tocurrency"] = toCurrency
+	}
+	var results []*utils.TPExchangeRates
+	err := ms.query(func(sctx mongo.SessionContext) (err error) {
+		cur, err := ms.getCol(utils.TBLTPExchangeRates).Find(sctx, filter)
+		if err != nil {
+			return err
+		}
+		for cur.Next(sctx) {
+			var el utils.TPExchangeRates
+			err := cur.Decode(&el)
+			if err != nil {
+				return err
+			}
+			results = append(results, &el)
+		}
+		if len(results) == 0 {
+			return utils.ErrNotFound
+		}
+		return cur.Close(sctx)
+	})
+	return results, err
+}
+
 func (ms *MongoStorage) GetTPDestinations(tpid, id string) ([]*utils.TPDestination, error) {
 	filter := bson.M{"tpid": tpid}
 	if id != "" {
@@ -583,6 +649,12 @@ func (ms *MongoStorage) RemTpData(table, tpid string, args map[string]string) er
 		args["id"] = args["tag"]
 		delete(args, "tag")
 	}
+	for sqlFld, fld := range mongoTPExchangeRatesFields {
+		if val, has := args[sqlFld]; has {
+			args[fld] = val
+			delete(args, sqlFld)
+		}
+	}
 	if tpid != "" {
 		args["tpid"] = tpid
 	}
@@ -613,6 +685,43 @@ func (ms *MongoStorage) SetTPTimings(tps []*utils.ApierTPTiming) error {
 	})
 }
 
+func (ms *MongoStorage) SetTPCalendars(tpCals []*utils.TPCalendar) (err error) {
+	if len(tpCals) == 0 {
+		return nil
+	}
+	return ms.query(func(sctx mongo.SessionContext) (err error) {
+		for _, tp := range tpCals {
+			_, err = ms.getCol(utils.TBLTPCalendars).UpdateOne(sctx, bson.M{"tpid": tp.TPid, "id": tp.ID},
+				bson.M{"$set": tp},
+				options.Update().SetUpsert(true),
+			)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (ms *MongoStorage) SetTPExchangeRates(tpExrs []*utils.TPExchangeRates) (err error) {
+	if len(tpExrs) == 0 {
+		return nil
+	}
+	return ms.query(func(sctx mongo.SessionContext) (err error) {
+		for _, tp := range tpExrs {
+			_, err = ms.getCol(utils.TBLTPExchangeRates).UpdateOne(sctx,
+				bson.M{"tpid": tp.TPid, "fromcurrency": tp.FromCurrency, "tocurrency": tp.ToCurrency},
+				bson.M{"$set": tp},
+				options.Update().SetUpsert(true),
+			)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
 func (ms *MongoStorage) SetTPDestinations(tpDsts []*utils.TPDestination) (err error) {
 	if len(tpDsts) == 0 {
 		return nil
@@ -25,6 +25,7 @@ import (
 	"fmt"
 	"io/ioutil"
 	"strconv"
+	"strings"
 	"sync"
 	"time"
 
@@ -32,13 +33,16 @@ import (
 	"github.com/cgrates/cgrates/guardian"
 	"github.com/cgrates/cgrates/utils"
 	"github.com/cgrates/ltcache"
+	"github.com/mediocregopher/radix.v2/cluster"
 	"github.com/mediocregopher/radix.v2/pool"
 	"github.com/mediocregopher/radix.v2/redis"
 	"github.com/mediocregopher/radix.v2/sentinel"
+	"github.com/mediocregopher/radix.v2/util"
 )
 
 type RedisStorage struct {
 	dbPool        *pool.Pool
+	cluster       *cluster.Cluster
 	maxConns      int
 	ms            Marshaler
 	sentinelName  string
@@ -80,8 +84,13 @@ const (
 	redis_HMSET    = "HMSET"
 )
 
+// NewRedisStorage connects to Redis in one of the three supported modes:
+// single instance, Sentinel (sentinelName not empty) or Cluster (isCluster true).
+// In Cluster mode address is a comma separated list of seed nodes and
+// clusterSync is the minimum interval between two topology refreshes
 func NewRedisStorage(address string, db int, pass, mrshlerStr string,
-	maxConns int, sentinelName string) (*RedisStorage, error) {
+	maxConns int, sentinelName string, isCluster bool,
+	clusterSync time.Duration) (*RedisStorage, error) {
 
 	df := func(network, addr string) (*redis.Client, error) {
 		client, err := redis.Dial(network, addr)
@@ -108,6 +117,34 @@ func NewRedisStorage(address string, db int, pass, mrshlerStr string,
 		return nil, err
 	}
 
+	if isCluster {
+		if db != 0 { // Redis Cluster only supports the database 0
+			return nil, fmt.Errorf("Redis Cluster supports only db 0, received: %d", db)
+		}
+		addrs := utils.InfieldSplit(address)
+		var c *cluster.Cluster
+		for _, addr := range addrs { // first seed node which answers gives us the topology
+			if c, err = cluster.NewWithOpts(cluster.Opts{
+				Addr:          addr,
+				PoolSize:      maxConns,
+				ResetThrottle: clusterSync,
+				Dialer:        df,
+			}); err == nil {
+				break
+			}
+			utils.Logger.Warning(fmt.Sprintf("<RedisStorage> could not connect to cluster node at address: %s because error: %s ",
+				addr, err.Error()))
+		}
+		if err != nil {
+			return nil, err
+		}
+		return &RedisStorage{
+			cluster:  c,
+			maxConns: maxConns,
+			ms:       ms,
+		}, nil
+	}
+
 	if sentinelName != "" {
 		var err error
 		addrs := utils.InfieldSplit(address)
@@ -171,6 +208,9 @@ func reconnectSentinel(addr, sentinelName string, db int, pass string, maxConns
 // This CMD function get a connection from the pool.
 // Handles automatic failover in case of network disconnects
 func (rs *RedisStorage) Cmd(cmd string, args ...interface{}) *redis.Resp {
+	if rs.cluster != nil { // the cluster client routes by key and follows MOVED/ASK redirections
+		return rs.cluster.Cmd(cmd, args...)
+	}
 	if rs.sentinelName != "" {
 		var err error
 		for i := range rs.sentinelInsts {
@@ -235,17 +275,57 @@ func (rs *RedisStorage) Close() {
 	if rs.dbPool != nil {
 		rs.dbPool.Empty()
 	}
+	if rs.cluster != nil {
+		rs.cluster.Close()
+	}
 }
 
 func (rs *RedisStorage) Flush(ignore string) error {
+	if rs.cluster != nil {
+		return rs.cmdOnMasters(func(conn *redis.Client) error {
+			return conn.Cmd(redis_FLUSHDB).Err
+		})
+	}
 	return rs.Cmd(redis_FLUSHDB).Err
 }
 
+// cmdOnMasters executes f on a connection to each master of the cluster
+func (rs *RedisStorage) cmdOnMasters(f func(conn *redis.Client) error) (err error) {
+	var conns map[string]*redis.Client
+	if conns, err = rs.cluster.GetEvery(); err != nil {
+		return
+	}
+	for _, conn := range conns {
+		if err == nil {
+			err = f(conn)
+		}
+		rs.cluster.Put(conn)
+	}
+	return
+}
+
+// keys returns the keys matching pattern
+// in Cluster mode the keys are gathered from all masters since KEYS is not routed
+func (rs *RedisStorage) keys(pattern string) (keys []string, err error) {
+	if rs.cluster == nil {
+		return rs.Cmd(redis_KEYS, pattern).List()
+	}
+	err = rs.cmdOnMasters(func(conn *redis.Client) error {
+		nodeKeys, err := conn.Cmd(redis_KEYS, pattern).List()
+		keys = append(keys, nodeKeys...)
+		return err
+	})
+	return
+}
+
 func (rs *RedisStorage) Marshaler() Marshaler {
 	return rs.ms
 }
 
 func (rs *RedisStorage) SelectDatabase(dbName string) (err error) {
+	if rs.cluster != nil { // Redis Cluster works only with the database 0
+		return utils.ErrNotImplemented
+	}
 	return rs.Cmd(redis_SELECT, dbName).Err
 }
 
@@ -372,11 +452,10 @@ func (rs *RedisStorage) getKeysForFilterIndexesKeys(fkeys []string) (keys []stri
 }
 
 func (rs *RedisStorage) RebbuildActionPlanKeys() error {
-	r := rs.Cmd(redis_KEYS, utils.ACTION_PLAN_PREFIX+"*")
-	if r.Err != nil {
-		return r.Err
+	keys, err := rs.keys(utils.ACTION_PLAN_PREFIX + "*")
+	if err != nil {
+		return err
 	}
-	keys, _ := r.List()
 	for _, key := range keys {
 		if err := rs.Cmd(redis_SADD, utils.ActionPlanIndexes, key).Err; err != nil {
 			return err
@@ -386,16 +465,17 @@ func (rs *RedisStorage) RebbuildActionPlanKeys() error {
 }
 
 func (rs *RedisStorage) GetKeysForPrefix(prefix string) ([]string, error) {
-	var r *redis.Resp
+	var keys []string
+	var err error
 	if prefix == utils.ACTION_PLAN_PREFIX { // so we can avoid the full scan on scheduler reloads
-		r = rs.Cmd(redis_SMEMBERS, utils.ActionPlanIndexes)
+		keys, err = rs.Cmd(redis_SMEMBERS, utils.ActionPlanIndexes).List()
 	} else {
-		r = rs.Cmd(redis_KEYS, prefix+"*")
+		keys, err = rs.keys(prefix + "*")
 	}
-	if r.Err != nil {
-		return nil, r.Err
+	if err != nil {
+		return nil, err
 	}
-	if keys, _ := r.List(); len(keys) != 0 {
+	if len(keys) != 0 {
 		if filterIndexesPrefixMap.HasKey(prefix) {
 			return rs.getKeysForFilterIndexesKeys(keys)
 		}
@@ -458,7 +538,7 @@ func (rs *RedisStorage) SetRatingPlanDrv(rp *RatingPlan) (err error) {
 }
 
 func (rs *RedisStorage) RemoveRatingPlanDrv(key string) error {
-	keys, err := rs.Cmd(redis_KEYS, utils.RATING_PLAN_PREFIX+key+"*").List()
+	keys, err := rs.keys(utils.RATING_PLAN_PREFIX + key + "*")
 	if err != nil {
 		return err
 	}
@@ -498,7 +578,7 @@ func (rs *RedisStorage) SetRatingProfileDrv(rpf *RatingProfile) (err error) {
 }
 
 func (rs *RedisStorage) RemoveRatingProfileDrv(key string) error {
-	keys, err := rs.Cmd(redis_KEYS, utils.RATING_PROFILE_PREFIX+key+"*").List()
+	keys, err := rs.keys(utils.RATING_PROFILE_PREFIX + key + "*")
 	if err != nil {
 		return err
 	}
@@ -1146,6 +1226,117 @@ func (rs *RedisStorage) RemoveResourceDrv(tenant, id string) (err error) {
 	if err = rs.Cmd(redis_DEL, key).Err; err != nil {
 		return
 	}
+	return rs.Cmd(redis_DEL, utils.ResourceUsagesPrefix+utils.ConcatenatedKey(tenant, id)).Err
+}
+
+// redisResourceUsagesScript is updateResourceUsages executed atomically on the hash holding the usages of one resource
+// KEYS[1]: the usages key
+// ARGV: ruID, units (empty to not record), expiry (0 for none), limit (empty to not check), times in milliseconds
+// now is taken from the Redis clock so all the engines expire the usages at the same time
+// each usage is stored as "units:expiry" and the key expires together with its last usage
+// replies with recorded as 1, 0 or -1 for a duplicate usage, followed by the usages
+const redisResourceUsagesScript = `
+redis.replicate_commands()
+local time = redis.call('TIME')
+local now = tonumber(time[1]) * 1000 + math.floor(tonumber(time[2]) / 1000)
+local recorded = 0
+local tUsage = 0
+local usages = redis.call('HGETALL', KEYS[1])
+for i = 1, #usages, 2 do
+	local units, expiry = string.match(usages[i+1], '^([^:]+):([^:]+)$')
+	expiry = tonumber(expiry)
+	if (expiry ~= 0 and expiry <= now) or
+		(ARGV[2] == '' and usages[i] == ARGV[1]) then
+		redis.call('HDEL', KEYS[1], usages[i])
+	else
+		if usages[i] == ARGV[1] then
+			recorded = -1
+		end
+		tUsage = tUsage + tonumber(units)
+	end
+end
+if recorded == 0 and ARGV[2] ~= '' and
+	(ARGV[4] == '' or tUsage + tonumber(ARGV[2]) <= tonumber(ARGV[4])) then
+	redis.call('HSET', KEYS[1], ARGV[1], ARGV[2] .. ':' .. ARGV[3])
+	recorded = 1
+end
+usages = redis.call('HGETALL', KEYS[1])
+local lastExpiry = 0
+for i = 2, #usages, 2 do
+	local expiry = tonumber(string.match(usages[i], ':([^:]+)$'))
+	if expiry == 0 then
+		lastExpiry = -1
+		break
+	end
+	if expiry > lastExpiry then
+		lastExpiry = expiry
+	end
+end
+if lastExpiry > 0 then
+	redis.call('PEXPIREAT', KEYS[1], lastExpiry)
+elseif lastExpiry == -1 then
+	redis.call('PERSIST', KEYS[1])
+end
+return {recorded, usages}
+`
+
+func (rs *RedisStorage) UpdateResourceUsagesDrv(tenant, id, ruID string, ru *ResourceUsage,
+	limit *float64) (usages map[string]*ResourceUsage, recorded bool, err error) {
+	var units, expiry, lmt string
+	expiry = "0"
+	if ru != nil {
+		units = strconv.FormatFloat(ru.Units, 'f', -1, 64)
+		if !ru.ExpiryTime.IsZero() {
+			expiry = strconv.FormatInt(ru.ExpiryTime.UnixNano()/int64(time.Millisecond), 10)
+		}
+	}
+	if limit != nil {
+		lmt = strconv.FormatFloat(*limit, 'f', -1, 64)
+	}
+	var c util.Cmder = rs
+	if rs.cluster != nil { // the script needs to run on the node owning the key
+		c = rs.cluster
+	}
+	var rply []*redis.Resp
+	if rply, err = util.LuaEval(c, redisResourceUsagesScript, 1,
+		utils.ResourceUsagesPrefix+utils.ConcatenatedKey(tenant, id),
+		ruID, units, expiry, lmt).Array(); err != nil {
+		return
+	}
+	if len(rply) != 2 {
+		return nil, false, fmt.Errorf("unexpected reply for usages of resource: %s", utils.ConcatenatedKey(tenant, id))
+	}
+	var rcrd int
+	if rcrd, err = rply[0].Int(); err != nil {
+		return
+	}
+	if rcrd == -1 {
+		return nil, false, fmt.Errorf("duplicate resource usage with id: %s", ru.TenantID())
+	}
+	recorded = rcrd == 1
+	var fields []string
+	if fields, err = rply[1].List(); err != nil {
+		return
+	}
+	usages = make(map[string]*ResourceUsage)
+	for i := 0; i+1 < len(fields); i += 2 {
+		vals := strings.Split(fields[i+1], utils.CONCATENATED_KEY_SEP)
+		if len(vals) != 2 {
+			return nil, false, fmt.Errorf("malformed usage: %q for resource: %s", fields[i+1], utils.ConcatenatedKey(tenant, id))
+		}
+		u := &ResourceUsage{Tenant: tenant, ID: fields[i]}
+		if u.Units, err = strconv.ParseFloat(vals[0], 64); err != nil {
+			return
+		}
+		var expMs int64
+		if expMs, err = strconv.ParseInt(vals[1], 10, 64); err != nil {
+			return
+		}
+		if expMs != 0 {
+			u.ExpiryTime = time.Unix(0, expMs*int64(time.Millisecond))
+		}
+		usages[u.ID] = u
+	}
 	return
 }
 
@@ -1217,13 +1408,47 @@ func (rs *RedisStorage) GetFilterIndexesDrv(cacheID, itemIDPrefix, filterType st
 	return
 }
 
+// redisSameSlotKey builds a key derived from key which hashes in the same
+// Redis Cluster slot as key, so both can be used in a multi-key command (ie: RENAME)
+func redisSameSlotKey(prefix, key, suffix string) string {
+	if start := strings.IndexByte(key, '{'); start != -1 {
+		if end := strings.IndexByte(key[start+1:], '}'); end > 0 { // key already has a hash tag
+			return prefix + utils.ConcatenatedKey(key, suffix)
+		}
+	}
+	if strings.IndexByte(key, '}') == -1 { // the key can be used as hash tag
+		return prefix + utils.ConcatenatedKey("{"+key+"}", suffix)
+	}
+	return prefix + utils.ConcatenatedKey("{"+redisSlotTag(cluster.Slot(key))+"}"+key, suffix)
+}
+
+var (
+	redisSlotTags     []string
+	redisSlotTagsOnce sync.Once
+)
+
+// redisSlotTag returns a hash tag which maps to the given cluster slot
+func redisSlotTag(slot uint16) string {
+	redisSlotTagsOnce.Do(func() {
+		redisSlotTags = make([]string, cluster.NumSlots)
+		for i, found := 0, 0; found < cluster.NumSlots; i++ {
+			tag := strconv.Itoa(i)
+			if s := cluster.Slot(tag); redisSlotTags[s] == "" {
+				redisSlotTags[s] = tag
+				found++
+			}
+		}
+	})
+	return redisSlotTags[slot]
+}
+
 //SetFilterIndexesDrv stores Indexes into DataDB
 func (rs *RedisStorage) SetFilterIndexesDrv(cacheID, itemIDPrefix string,
 	indexes map[string]utils.StringMap, commit bool, transactionID string) (err error) {
 	originKey := utils.CacheInstanceToPrefix[cacheID] + itemIDPrefix
 	dbKey := originKey
 	if transactionID != "" {
-		dbKey = "tmp_" + utils.ConcatenatedKey(dbKey, transactionID)
+		dbKey = redisSameSlotKey("tmp_", dbKey, transactionID)
 	}
 	if commit && transactionID != "" {
 		return rs.Cmd(redis_RENAME, dbKey, originKey).Err
@@ -1644,6 +1869,68 @@ func (rs *RedisStorage) RemoveDispatcherHostDrv(tenant, id string) (err error) {
 	return
 }
 
+func (rs *RedisStorage) GetStoredSessionsDrv(nodeID string) (sSs []*StoredSession, err error) {
+	var keys []string
+	if keys, err = rs.GetKeysForPrefix(utils.SessionsPrefix +
+		nodeID + utils.CONCATENATED_KEY_SEP); err != nil {
+		return
+	}
+	for _, key := range keys {
+		var values []byte
+		if values, err = rs.Cmd(redis_GET, key).Bytes(); err != nil {
+			if err == redis.ErrRespNil { // removed in the meantime
+				err = nil
+				continue
+			}
+			return
+		}
+		var sS *StoredSession
+		if err = rs.ms.Unmarshal(values, &sS); err != nil {
+			return
+		}
+		sSs = append(sSs, sS)
+	}
+	return
+}
+
+func (rs *RedisStorage) SetStoredSessionDrv(sS *StoredSession) (err error) {
+	result, err := rs.ms.Marshal(sS)
+	if err != nil {
+		return err
+	}
+	return rs.Cmd(redis_SET, utils.SessionsPrefix+sS.NodeCGRID(), result).Err
+}
+
+func (rs *RedisStorage) RemoveStoredSessionDrv(nodeID, cgrID string) (err error) {
+	return rs.Cmd(redis_DEL, utils.SessionsPrefix+utils.ConcatenatedKey(nodeID, cgrID)).Err
+}
+
+func (rs *RedisStorage) GetExchangeRatesDrv(fromCurrency, toCurrency string) (exr *ExchangeRates, err error) {
+	var values []byte
+	if values, err = rs.Cmd(redis_GET, utils.ExchangeRatesPrefix+
+		utils.ConcatenatedKey(fromCurrency, toCurrency)).Bytes(); err != nil {
+		if err == redis.ErrRespNil {
+			err = utils.ErrNotFound
+		}
+		return
+	}
+	err = rs.ms.Unmarshal(values, &exr)
+	return
+}
+
+func (rs *RedisStorage) SetExchangeRatesDrv(exr *ExchangeRates) (err error) {
+	var result []byte
+	if result, err = rs.ms.Marshal(exr); err != nil {
+		return
+	}
+	return rs.Cmd(redis_SET, utils.ExchangeRatesPrefix+exr.ID(), result).Err
+}
+
+func (rs *RedisStorage) RemoveExchangeRatesDrv(fromCurrency, toCurrency string) (err error) {
+	return rs.Cmd(redis_DEL, utils.ExchangeRatesPrefix+
+		utils.ConcatenatedKey(fromCurrency, toCurrency)).Err
+}
+
 func (rs *RedisStorage) GetStorageType() string {
 	return utils.REDIS
 }
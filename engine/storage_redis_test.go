@@ -0,0 +1,45 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package engine
+
+import (
+	"testing"
+
+	"github.com/mediocregopher/radix.v2/cluster"
+)
+
+func TestRedisSameSlotKey(t *testing.T) {
+	for _, key := range []string{
+		"rfi_cgrates.org:*sessions",
+		"rfi_{cgrates.org}:*sessions",
+		"rfi_{}:*sessions",
+		"rfi_}cgrates.org{:*sessions",
+	} {
+		tmpKey := redisSameSlotKey("tmp_", key, "transactionID")
+		if tmpKey == key {
+			t.Errorf("Expected a different key for: %q", key)
+		}
+		if cluster.Slot(tmpKey) != cluster.Slot(key) {
+			t.Errorf("Expected key: %q in the same slot as: %q", tmpKey, key)
+		}
+	}
+	if rcv := redisSameSlotKey("tmp_", "rfi_cgrates.org", "1"); rcv != "tmp_{rfi_cgrates.org}:1" {
+		t.Errorf("Received: %q", rcv)
+	}
+}
@@ -105,7 +105,8 @@ func (self *SQLStorage) IsDBEmpty() (resp bool, err error) {
 		utils.TBLTPAccountActions, utils.TBLTPResources, utils.TBLTPStats, utils.TBLTPThresholds,
 		utils.TBLTPFilters, utils.SessionCostsTBL, utils.CDRsTBL, utils.TBLTPActionPlans,
 		utils.TBLVersions, utils.TBLTPSuppliers, utils.TBLTPAttributes, utils.TBLTPChargers,
-		utils.TBLTPDispatchers, utils.TBLTPDispatcherHosts,
+		utils.TBLTPDispatchers, utils.TBLTPDispatcherHosts, utils.TBLTPCalendars,
+		utils.TBLTPExchangeRates,
 	}
 	for _, tbl := range tbls {
 		if self.db.HasTable(tbl) {
@@ -124,7 +125,7 @@ func (self *SQLStorage) GetTpIds(colName string) ([]string, error) {
 	qryStr := fmt.Sprintf(" (SELECT tpid FROM %s)", colName)
 	if colName == "" {
 		qryStr = fmt.Sprintf(
-			"(SELECT tpid FROM %s) UNION (SELECT tpid FROM %s) UNION (SELECT tpid FROM %s) UNION (SELECT tpid FROM %s) UNION (SELECT tpid FROM %s) UNION (SELECT tpid FROM %s) UNION (SELECT tpid FROM %s) UNION (SELECT tpid FROM %s) UNION (SELECT tpid FROM %s) UNION (SELECT tpid FROM %s) UNION (SELECT tpid FROM %s) UNION (SELECT tpid FROM %s) UNION (SELECT tpid FROM %s) UNION (SELECT tpid FROM %s) UNION (SELECT tpid FROM %s) UNION (SELECT tpid FROM %s) UNION (SELECT tpid FROM %s) UNION (SELECT tpid FROM %s) UNION (SELECT tpid FROM %s) UNION (SELECT tpid FROM %s)",
+			"(SELECT tpid FROM %s) UNION (SELECT tpid FROM %s) UNION (SELECT tpid FROM %s) UNION (SELECT tpid FROM %s) UNION (SELECT tpid FROM %s) UNION (SELECT tpid FROM %s) UNION (SELECT tpid FROM %s) UNION (SELECT tpid FROM %s) UNION (SELECT tpid FROM %s) UNION (SELECT tpid FROM %s) UNION (SELECT tpid FROM %s) UNION (SELECT tpid FROM %s) UNION (SELECT tpid FROM %s) UNION (SELECT tpid FROM %s) UNION (SELECT tpid FROM %s) UNION (SELECT tpid FROM %s) UNION (SELECT tpid FROM %s) UNION (SELECT tpid FROM %s) UNION (SELECT tpid FROM %s) UNION (SELECT tpid FROM %s) UNION (SELECT tpid FROM %s) UNION (SELECT tpid FROM %s)",
 			utils.TBLTPTimings,
 			utils.TBLTPDestinations,
 			utils.TBLTPRates,
@@ -145,6 +146,8 @@ func (self *SQLStorage) GetTpIds(colName string) ([]string, error) {
 			utils.TBLTPChargers,
 			utils.TBLTPDispatchers,
 			utils.TBLTPDispatcherHosts,
+			utils.TBLTPCalendars,
+			utils.TBLTPExchangeRates,
 		)
 	}
 	rows, err = self.Db.Query(qryStr)
@@ -239,7 +242,8 @@ func (self *SQLStorage) RemTpData(table, tpid string, args map[string]string) er
 			utils.TBLTPActionTriggers, utils.TBLTPAccountActions,
 			utils.TBLTPResources, utils.TBLTPStats, utils.TBLTPFilters,
 			utils.TBLTPSuppliers, utils.TBLTPAttributes,
-			utils.TBLTPChargers, utils.TBLTPDispatchers, utils.TBLTPDispatcherHosts} {
+			utils.TBLTPChargers, utils.TBLTPDispatchers, utils.TBLTPDispatcherHosts,
+			utils.TBLTPCalendars, utils.TBLTPExchangeRates} {
 			if err := tx.Table(tblName).Where("tpid = ?", tpid).Delete(nil).Error; err != nil {
 				tx.Rollback()
 				return err
@@ -283,6 +287,51 @@ func (self *SQLStorage) SetTPTimings(timings []*utils.ApierTPTiming) error {
 	return nil
 }
 
+func (self *SQLStorage) SetTPCalendars(cals []*utils.TPCalendar) error {
+	if len(cals) == 0 {
+		return nil
+	}
+	tx := self.db.Begin()
+	for _, cal := range cals {
+		// Remove previous
+		if err := tx.Where(&TpCalendar{Tpid: cal.TPid, Tag: cal.ID}).Delete(TpCalendar{}).Error; err != nil {
+			tx.Rollback()
+			return err
+		}
+		for _, c := range APItoModelCalendar(cal) {
+			if err := tx.Save(&c).Error; err != nil {
+				tx.Rollback()
+				return err
+			}
+		}
+	}
+	tx.Commit()
+	return nil
+}
+
+func (self *SQLStorage) SetTPExchangeRates(exrs []*utils.TPExchangeRates) error {
+	if len(exrs) == 0 {
+		return nil
+	}
+	tx := self.db.Begin()
+	for _, exr := range exrs {
+		// Remove previous
+		if err := tx.Where(&TpExchangeRate{Tpid: exr.TPid, FromCurrency: exr.FromCurrency,
+			ToCurrency: exr.ToCurrency}).Delete(TpExchangeRate{}).Error; err != nil {
+			tx.Rollback()
+			return err
+		}
+		for _, e := range APItoModelExchangeRates(exr) {
+			if err := tx.Save(&e).Error; err != nil {
+				tx.Rollback()
+				return err
+			}
+		}
+	}
+	tx.Commit()
+	return nil
+}
+
 func (self *SQLStorage) SetTPDestinations(dests []*utils.TPDestination) error {
 	if len(dests) == 0 {
 		return nil
@@ -1246,6 +1295,41 @@ func (self *SQLStorage) GetTPTimings(tpid, id string) ([]*utils.ApierTPTiming, e
 	return ts, nil
 }
 
+func (self *SQLStorage) GetTPCalendars(tpid, id string) ([]*utils.TPCalendar, error) {
+	var tpCals TpCalendars
+	q := self.db.Where("tpid = ?", tpid)
+	if len(id) != 0 {
+		q = q.Where("tag = ?", id)
+	}
+	if err := q.Find(&tpCals).Error; err != nil {
+		return nil, err
+	}
+	cs := tpCals.AsTPCalendars()
+	if len(cs) == 0 {
+		return cs, utils.ErrNotFound
+	}
+	return cs, nil
+}
+
+func (self *SQLStorage) GetTPExchangeRates(tpid, fromCurrency, toCurrency string) ([]*utils.TPExchangeRates, error) {
+	var tpExrs TpExchangeRates
+	q := self.db.Where("tpid = ?", tpid)
+	if len(fromCurrency) != 0 {
+		q = q.Where("from_currency = ?", fromCurrency)
+	}
+	if len(toCurrency) != 0 {
+		q = q.Where("to_currency = ?", toCurrency)
+	}
+	if err := q.Find(&tpExrs).Error; err != nil {
+		return nil, err
+	}
+	exrs := tpExrs.AsTPExchangeRates()
+	if len(exrs) == 0 {
+		return exrs, utils.ErrNotFound
+	}
+	return exrs, nil
+}
+
 func (self *SQLStorage) GetTPRatingPlans(tpid, id string, pagination *utils.Paginator) ([]*utils.TPRatingPlan, error) {
 	var tpRatingPlans TpRatingPlans
 	q := self.db.Where("tpid = ?", tpid)
@@ -33,8 +33,9 @@ import (
 
 // NewDataDBConn creates a DataDB connection
 func NewDataDBConn(dbType, host, port, name, user,
-	pass, marshaler, sentinelName string,
-	itemsCacheCfg map[string]*config.ItemOpt) (d DataDB, err error) {
+	pass, marshaler, sentinelName string, isCluster bool,
+	clusterSync time.Duration, itemsCacheCfg map[string]*config.ItemOpt,
+	persistCfg *config.InternalPersistenceCfg) (d DataDB, err error) {
 	switch dbType {
 	case utils.REDIS:
 		var dbNo int
@@ -46,11 +47,15 @@ func NewDataDBConn(dbType, host, port, name, user,
 		if port != "" && strings.Index(host, ":") == -1 {
 			host += ":" + port
 		}
-		d, err = NewRedisStorage(host, dbNo, pass, marshaler, utils.REDIS_MAX_CONNS, sentinelName)
+		d, err = NewRedisStorage(host, dbNo, pass, marshaler, utils.REDIS_MAX_CONNS,
+			sentinelName, isCluster, clusterSync)
 	case utils.MONGO:
 		d, err = NewMongoStorage(host, port, name, user, pass, marshaler, utils.DataDB, nil, true)
 	case utils.INTERNAL:
-		d = NewInternalDB(nil, nil, true, itemsCacheCfg)
+		var iDB *InternalDB
+		if iDB, err = NewPersistentInternalDB(nil, nil, true, itemsCacheCfg, persistCfg); err == nil {
+			d = iDB
+		}
 	default:
 		err = fmt.Errorf("unsupported db_type <%s>", dbType)
 	}
@@ -61,7 +66,8 @@ func NewDataDBConn(dbType, host, port, name, user,
 func NewStorDBConn(dbType, host, port, name, user, pass, marshaler, sslmode string,
 	maxConn, maxIdleConn, connMaxLifetime int,
 	stringIndexedFields, prefixIndexedFields []string,
-	itemsCacheCfg map[string]*config.ItemOpt) (db StorDB, err error) {
+	itemsCacheCfg map[string]*config.ItemOpt,
+	persistCfg *config.InternalPersistenceCfg) (db StorDB, err error) {
 	switch dbType {
 	case utils.MONGO:
 		db, err = NewMongoStorage(host, port, name, user, pass, marshaler, utils.StorDB, stringIndexedFields, false)
@@ -70,7 +76,11 @@ func NewStorDBConn(dbType, host, port, name, user, pass, marshaler, sslmode stri
 	case utils.MYSQL:
 		db, err = NewMySQLStorage(host, port, name, user, pass, maxConn, maxIdleConn, connMaxLifetime)
 	case utils.INTERNAL:
-		db = NewInternalDB(stringIndexedFields, prefixIndexedFields, false, itemsCacheCfg)
+		var iDB *InternalDB
+		if iDB, err = NewPersistentInternalDB(stringIndexedFields, prefixIndexedFields,
+			false, itemsCacheCfg, persistCfg); err == nil {
+			db = iDB
+		}
 	default:
 		err = fmt.Errorf("unknown db '%s' valid options are [%s, %s, %s, %s]",
 			dbType, utils.MYSQL, utils.MONGO, utils.POSTGRES, utils.INTERNAL)
@@ -89,6 +99,21 @@ type SMCost struct {
 	CostDetails *EventCost
 }
 
+// StoredSession is one active session persisted by SessionS so it can be restored after restart
+type StoredSession struct {
+	NodeID  string // NodeID of the SessionS owning the session
+	CGRID   string
+	Session []byte // session encoded by SessionS
+
+	ReservedUsage     *time.Duration // usage committed by default, set only for the events reserved in advance
+	ReservationExpiry *time.Time     // when the reservation is released, nil if it does not expire
+}
+
+// NodeCGRID returns the key used to store the session
+func (sS *StoredSession) NodeCGRID() string {
+	return utils.ConcatenatedKey(sS.NodeID, sS.CGRID)
+}
+
 type AttrCDRSStoreSMCost struct {
 	Cost           *SMCost
 	CheckDuplicate bool
@@ -19,6 +19,7 @@ along with this program.  If not, see <http://www.gnu.org/licenses/>
 package engine
 
 import (
+	"errors"
 	"fmt"
 	"sort"
 	"strconv"
@@ -45,6 +46,12 @@ type Supplier struct {
 	lazyCheckRules []*FilterRule
 }
 
+// supplierQuota is the commitment of a supplier within the *quota strategy
+type supplierQuota struct {
+	value      float64
+	percentage bool // value is a percentage out of the total volume delivered instead of an absolute one
+}
+
 // SupplierProfile represents the configuration of a Supplier profile
 type SupplierProfile struct {
 	Tenant             string
@@ -92,6 +99,99 @@ func (sp *SupplierProfile) compileCacheParameters() error {
 			}
 		}
 	}
+	if sp.Sorting == utils.MetaQuota {
+		// construct the map for quotas
+		// []string{"supplierID:60%", "supplierID:Quota", "*period:*monthly"}
+		quotaMap := make(map[string]*supplierQuota)
+		var period string
+		for _, param := range sp.SortingParameters {
+			splitted := strings.Split(param, utils.CONCATENATED_KEY_SEP)
+			if len(splitted) != 2 {
+				return fmt.Errorf("invalid %s sorting parameter: <%s>", utils.MetaQuota, param)
+			}
+			if splitted[0] == utils.MetaPeriod {
+				if !utils.IsSliceMember([]string{utils.MetaDaily, utils.MetaWeekly,
+					utils.MetaMonthly, utils.MetaYearly}, splitted[1]) {
+					return fmt.Errorf("invalid %s sorting parameter: <%s>", utils.MetaQuota, param)
+				}
+				period = splitted[1]
+				continue
+			}
+			quota := new(supplierQuota)
+			quotaStr := splitted[1]
+			if strings.HasSuffix(quotaStr, utils.PercentageSign) {
+				quota.percentage = true
+				quotaStr = strings.TrimSuffix(quotaStr, utils.PercentageSign)
+			}
+			var err error
+			if quota.value, err = strconv.ParseFloat(quotaStr, 64); err != nil {
+				return err
+			}
+			if quota.value <= 0 {
+				return fmt.Errorf("invalid %s sorting parameter: <%s>", utils.MetaQuota, param)
+			}
+			quotaMap[splitted[0]] = quota
+		}
+		// add the quota for each supplier, in case that quota isn't defined for specific suppliers check for default
+		var percentSum float64
+		for _, supplier := range sp.Suppliers {
+			supplier.cacheSupplier = make(map[string]interface{})
+			if period != utils.EmptyString {
+				supplier.cacheSupplier[utils.MetaPeriod] = period
+			}
+			quota, has := quotaMap[supplier.ID]
+			if !has {
+				if quota, has = quotaMap[utils.MetaDefault]; !has {
+					continue
+				}
+			}
+			supplier.cacheSupplier[utils.MetaQuota] = quota
+			if quota.percentage {
+				percentSum += quota.value
+			}
+		}
+		if percentSum > 100 {
+			return fmt.Errorf("%s percentages sum up to %v%%, more than 100%%", utils.MetaQuota, percentSum)
+		}
+	}
+	if sp.Sorting == utils.MetaLCQOS {
+		// construct the map for metric thresholds
+		// []string{"supplierID:MetricID:Threshold", "*exclude"}
+		var exclude bool
+		thdMap := make(map[string]map[string]float64)
+		for _, param := range sp.SortingParameters {
+			if param == utils.MetaExclude {
+				exclude = true
+				continue
+			}
+			splitted := strings.Split(param, utils.CONCATENATED_KEY_SEP)
+			if len(splitted) < 3 {
+				return fmt.Errorf("invalid %s sorting parameter: <%s>", utils.MetaLCQOS, param)
+			}
+			thdVal, err := strconv.ParseFloat(splitted[len(splitted)-1], 64)
+			if err != nil {
+				return err
+			}
+			if _, has := thdMap[splitted[0]]; !has {
+				thdMap[splitted[0]] = make(map[string]float64)
+			}
+			thdMap[splitted[0]][strings.Join(splitted[1:len(splitted)-1], utils.CONCATENATED_KEY_SEP)] = thdVal
+		}
+		// add the thresholds for each supplier, the ones for *default being overwritten by the supplier specific ones
+		for _, supplier := range sp.Suppliers {
+			thds := make(map[string]float64)
+			for metric, thdVal := range thdMap[utils.MetaDefault] {
+				thds[metric] = thdVal
+			}
+			for metric, thdVal := range thdMap[supplier.ID] {
+				thds[metric] = thdVal
+			}
+			supplier.cacheSupplier = map[string]interface{}{
+				utils.MetaQOSThresholds: thds,
+				utils.MetaExclude:       exclude,
+			}
+		}
+	}
 	return nil
 }
 
@@ -210,59 +310,153 @@ func (spS *SupplierService) matchingSupplierProfilesForEvent(ev *utils.CGREvent,
 // costForEvent will compute cost out of accounts and rating plans for event
 // returns map[string]interface{} with cost and relevant matching information inside
 func (spS *SupplierService) costForEvent(ev *utils.CGREvent,
-	acntIDs, rpIDs []string) (costData map[string]interface{}, err error) {
+	acntIDs, rpIDs []string, rpCosts map[string]*utils.CostOnRatingPlans) (costData map[string]interface{}, err error) {
+	var args *utils.GetCostOnRatingPlansArgs
+	if args, err = spS.costArgsForEvent(ev); err != nil {
+		return
+	}
+	if len(acntIDs) != 0 {
+		if err := spS.connMgr.Call(spS.cgrcfg.SupplierSCfg().ResponderSConns, nil, utils.ResponderGetMaxSessionTimeOnAccounts,
+			&utils.GetMaxSessionTimeOnAccountsArgs{
+				Tenant:      args.Tenant,
+				Subject:     args.Subject,
+				Destination: args.Destination,
+				SetupTime:   args.SetupTime,
+				Usage:       args.Usage,
+				AccountIDs:  acntIDs,
+			}, &costData); err != nil {
+			return nil, err
+		}
+	}
+	if len(rpIDs) != 0 {
+		var rpCostData map[string]interface{}
+		if rpCostData, err = spS.costOnRatingPlans(args, rpIDs, rpCosts); err != nil {
+			return nil, err
+		}
+		if len(rpCostData) != 0 { // the cost on RatingPlans has priority over the one on accounts
+			costData = rpCostData
+		}
+	}
+	return
+}
+
+// costArgsForEvent extracts out of the event the arguments needed for cost calculation
+func (spS *SupplierService) costArgsForEvent(ev *utils.CGREvent) (args *utils.GetCostOnRatingPlansArgs, err error) {
 	if err = ev.CheckMandatoryFields([]string{utils.Account,
 		utils.Destination, utils.SetupTime}); err != nil {
 		return
 	}
-	var acnt, subj, dst string
-	if acnt, err = ev.FieldAsString(utils.Account); err != nil {
-		return
+	args = &utils.GetCostOnRatingPlansArgs{Tenant: ev.Tenant}
+	if args.Account, err = ev.FieldAsString(utils.Account); err != nil {
+		return nil, err
 	}
-	if subj, err = ev.FieldAsString(utils.Account); err != nil {
+	if args.Subject, err = ev.FieldAsString(utils.Account); err != nil {
 		if err != utils.ErrNotFound {
-			return
+			return nil, err
 		}
-		subj = acnt
+		args.Subject = args.Account
 	}
-	if dst, err = ev.FieldAsString(utils.Destination); err != nil {
-		return
+	if args.Destination, err = ev.FieldAsString(utils.Destination); err != nil {
+		return nil, err
 	}
-	var sTime time.Time
-	if sTime, err = ev.FieldAsTime(utils.SetupTime, spS.cgrcfg.GeneralCfg().DefaultTimezone); err != nil {
-		return
+	if args.SetupTime, err = ev.FieldAsTime(utils.SetupTime, spS.cgrcfg.GeneralCfg().DefaultTimezone); err != nil {
+		return nil, err
 	}
-	var usage time.Duration
-	if usage, err = ev.FieldAsDuration(utils.Usage); err != nil {
+	if args.Usage, err = ev.FieldAsDuration(utils.Usage); err != nil {
 		if err != utils.ErrNotFound {
-			return
+			return nil, err
 		}
 		// in case usage is missing from event we decide to use 1 minute as default
-		usage = time.Duration(1 * time.Minute)
+		args.Usage = time.Duration(1 * time.Minute)
 		err = nil
 	}
-	if err := spS.connMgr.Call(spS.cgrcfg.SupplierSCfg().ResponderSConns, nil, utils.ResponderGetMaxSessionTimeOnAccounts,
-		&utils.GetMaxSessionTimeOnAccountsArgs{
-			Tenant:      ev.Tenant,
-			Subject:     subj,
-			Destination: dst,
-			SetupTime:   sTime,
-			Usage:       usage,
-			AccountIDs:  acntIDs,
-		}, &costData); err != nil {
+	return
+}
+
+// costCacheKey returns the key used to cache the cost of an event on a list of RatingPlans
+// the full destination is used since the prefix matched is only known after rating
+// and the SetupTime is truncated to the cost_time_bucket
+func (spS *SupplierService) costCacheKey(args *utils.GetCostOnRatingPlansArgs, rpIDs []string) string {
+	sTime := args.SetupTime
+	if bucket := spS.cgrcfg.SupplierSCfg().CostTimeBucket; bucket > 0 {
+		sTime = sTime.Truncate(bucket)
+	}
+	return utils.ConcatenatedKey(args.Tenant, args.Account, args.Subject, args.Destination,
+		strconv.FormatInt(sTime.UnixNano(), 10), args.Usage.String(),
+		strings.Join(rpIDs, utils.INFIELD_SEP))
+}
+
+// costOnRatingPlans returns the cost on a list of RatingPlans
+// looking first into the costs rated in batch, then into cache and querying RALs as last resort
+func (spS *SupplierService) costOnRatingPlans(args *utils.GetCostOnRatingPlansArgs,
+	rpIDs []string, rpCosts map[string]*utils.CostOnRatingPlans) (costData map[string]interface{}, err error) {
+	cacheKey := spS.costCacheKey(args, rpIDs)
+	if cost, has := rpCosts[cacheKey]; has {
+		if cost.Error != utils.EmptyString {
+			return nil, errors.New(cost.Error)
+		}
+		return cost.CostData, nil
+	}
+	if itm, has := Cache.Get(utils.CacheSupplierCosts, cacheKey); has {
+		return itm.(map[string]interface{}), nil
+	}
+	rpArgs := *args
+	rpArgs.RatingPlanIDs = rpIDs
+	if err = spS.connMgr.Call(spS.cgrcfg.SupplierSCfg().ResponderSConns, nil, utils.ResponderGetCostOnRatingPlans,
+		&rpArgs, &costData); err != nil {
 		return nil, err
 	}
-	if err := spS.connMgr.Call(spS.cgrcfg.SupplierSCfg().ResponderSConns, nil, utils.ResponderGetCostOnRatingPlans,
-		&utils.GetCostOnRatingPlansArgs{
-			Tenant:        ev.Tenant,
-			Account:       acnt,
-			Subject:       subj,
-			Destination:   dst,
-			SetupTime:     sTime,
-			Usage:         usage,
+	Cache.Set(utils.CacheSupplierCosts, cacheKey, costData,
+		nil, true, utils.NonTransactional)
+	return
+}
+
+// batchCostsOnRatingPlans rates in one call to RALs the suppliers with RatingPlans not found in cache
+// the errors are ignored here since the suppliers will be rated individually as fallback
+func (spS *SupplierService) batchCostsOnRatingPlans(ev *utils.CGREvent,
+	suppls []*Supplier) (rpCosts map[string]*utils.CostOnRatingPlans) {
+	args, err := spS.costArgsForEvent(ev)
+	if err != nil {
+		return
+	}
+	rpIDs := make(map[string][]string)
+	for _, spl := range suppls {
+		if len(spl.RatingPlanIDs) == 0 {
+			continue
+		}
+		cacheKey := spS.costCacheKey(args, spl.RatingPlanIDs)
+		if _, has := rpIDs[cacheKey]; has {
+			continue
+		}
+		if _, has := Cache.Get(utils.CacheSupplierCosts, cacheKey); has {
+			continue
+		}
+		rpIDs[cacheKey] = spl.RatingPlanIDs
+	}
+	if len(rpIDs) < 2 { // nothing to gain out of batching
+		return
+	}
+	if err = spS.connMgr.Call(spS.cgrcfg.SupplierSCfg().ResponderSConns, nil, utils.ResponderGetCostsOnRatingPlans,
+		&utils.GetCostsOnRatingPlansArgs{
+			Account:       args.Account,
+			Subject:       args.Subject,
+			Destination:   args.Destination,
+			Tenant:        args.Tenant,
+			SetupTime:     args.SetupTime,
+			Usage:         args.Usage,
 			RatingPlanIDs: rpIDs,
-		}, &costData); err != nil {
-		return nil, err
+		}, &rpCosts); err != nil {
+		utils.Logger.Warning(
+			fmt.Sprintf("<%s> error: %s rating suppliers in batch, falling back on individual rating",
+				utils.SupplierS, err.Error()))
+		return nil
+	}
+	for cacheKey, cost := range rpCosts {
+		if cost.Error != utils.EmptyString {
+			continue
+		}
+		Cache.Set(utils.CacheSupplierCosts, cacheKey, cost.CostData,
+			nil, true, utils.NonTransactional)
 	}
 	return
 }
@@ -344,6 +538,56 @@ func (spS *SupplierService) statMetricsForLoadDistribution(statIDs []string, ten
 	return
 }
 
+// statMetricsSince will query a list of statIDs and return the sum of metrics
+// out of the buckets started after since, erroring if the history does not reach since
+func (spS *SupplierService) statMetricsSince(statIDs []string, tenant string, since time.Time) (result float64, err error) {
+	if len(spS.cgrcfg.SupplierSCfg().StatSConns) == 0 {
+		return
+	}
+	for _, statID := range statIDs {
+		// check if we get an ID in the following form (StatID:MetricID)
+		statWithMetric := strings.Split(statID, utils.InInFieldSep)
+		var hist StatQueueHistory
+		if err = spS.connMgr.Call(
+			spS.cgrcfg.SupplierSCfg().StatSConns, nil,
+			utils.StatSv1GetQueueHistory,
+			&utils.TenantIDWithArgDispatcher{
+				TenantID: &utils.TenantID{
+					Tenant: tenant, ID: statWithMetric[0]}},
+			&hist); err != nil {
+			if err.Error() != utils.ErrNotFound.Error() {
+				utils.Logger.Warning(
+					fmt.Sprintf("<SupplierS> error: %s getting history for stat : %s",
+						err.Error(), statWithMetric[0]))
+			}
+			err = nil
+			continue
+		}
+		if len(hist.Buckets) == 0 || hist.Buckets[0].StartTime.After(since) { // volume within the period would be incomplete
+			return 0, fmt.Errorf("<%s> error: history of statID: %s does not cover the period started at: %s",
+				utils.SupplierS, statWithMetric[0], since)
+		}
+		for _, bkt := range hist.Buckets {
+			if bkt.StartTime.Before(since) {
+				continue
+			}
+			if len(statWithMetric) == 2 { // in case we have MetricID defined with StatID we consider only that metric
+				metricVal, has := bkt.Metrics[statWithMetric[1]]
+				if !has {
+					return 0, fmt.Errorf("<%s> error: %s metric %s for statID: %s",
+						utils.SupplierS, utils.ErrNotFound, statWithMetric[1], statWithMetric[0])
+				}
+				result += metricVal
+				continue
+			}
+			for _, metricVal := range bkt.Metrics {
+				result += metricVal
+			}
+		}
+	}
+	return
+}
+
 // resourceUsage returns sum of all resource usages out of list
 func (spS *SupplierService) resourceUsage(resIDs []string, tenant string) (tUsage float64, err error) {
 	if len(spS.cgrcfg.SupplierSCfg().ResourceSConns) != 0 {
@@ -372,7 +616,7 @@ func (spS *SupplierService) populateSortingData(ev *utils.CGREvent, spl *Supplie
 	}
 	//calculate costData if we have fields
 	if len(spl.AccountIDs) != 0 || len(spl.RatingPlanIDs) != 0 {
-		costData, err := spS.costForEvent(ev, spl.AccountIDs, spl.RatingPlanIDs)
+		costData, err := spS.costForEvent(ev, spl.AccountIDs, spl.RatingPlanIDs, extraOpts.rpCosts)
 		if err != nil {
 			if extraOpts.ignoreErrors {
 				utils.Logger.Warning(
@@ -397,10 +641,17 @@ func (spS *SupplierService) populateSortingData(ev *utils.CGREvent, spl *Supplie
 		}
 	}
 	//calculate metrics
-	//in case we have *load strategy we use statMetricsForLoadDistribution function to calculate the result
+	//in case we have *load or *quota strategy we use statMetricsForLoadDistribution function to calculate the result
 	if len(spl.StatIDs) != 0 {
-		if extraOpts.sortingStragety == utils.MetaLoad {
-			metricSum, err := spS.statMetricsForLoadDistribution(spl.StatIDs, ev.Tenant) //create metric map for suppier
+		if extraOpts.sortingStragety == utils.MetaLoad ||
+			extraOpts.sortingStragety == utils.MetaQuota {
+			var metricSum float64
+			var err error
+			if period, has := spl.cacheSupplier[utils.MetaPeriod].(string); has { // *quota counting only the volume within the current period
+				metricSum, err = spS.statMetricsSince(spl.StatIDs, ev.Tenant, quotaPeriodStart(period, time.Now()))
+			} else {
+				metricSum, err = spS.statMetricsForLoadDistribution(spl.StatIDs, ev.Tenant) //create metric map for suppier
+			}
 			if err != nil {
 				if extraOpts.ignoreErrors {
 					utils.Logger.Warning(
@@ -513,6 +764,8 @@ func (spS *SupplierService) sortedSuppliersForEvent(args *ArgsGetSuppliers) (sor
 		supplNew = append(supplNew, suppl)
 	}
 
+	extraOpts.rpCosts = spS.batchCostsOnRatingPlans(args.CGREvent, supplNew)
+
 	sortedSuppliers, err := spS.sorter.SortSuppliers(splPrfl.ID, splPrfl.Sorting,
 		supplNew, args.CGREvent, extraOpts)
 	if err != nil {
@@ -571,6 +824,7 @@ type optsGetSuppliers struct {
 	maxCost           float64
 	sortingParameters []string //used for QOS strategy
 	sortingStragety   string
+	rpCosts           map[string]*utils.CostOnRatingPlans // costs on RatingPlans rated in batch, indexed on cost cache key
 }
 
 // V1GetSupplierProfilesForEvent returns the list of valid supplier IDs
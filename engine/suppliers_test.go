@@ -24,6 +24,7 @@ import (
 
 	"github.com/cgrates/cgrates/config"
 	"github.com/cgrates/cgrates/utils"
+	"github.com/cgrates/rpcclient"
 )
 
 var (
@@ -651,3 +652,393 @@ func TestSuppliersMatchWithIndexFalse(t *testing.T) {
 		t.Errorf("Expecting: %+v, received: %+v", sppTest[2], sprf[0])
 	}
 }
+
+func TestSuppliersCompileLeastCostQOS(t *testing.T) {
+	sp := &SupplierProfile{
+		Tenant:  "cgrates.org",
+		ID:      "SPP_LCQOS",
+		Sorting: utils.MetaLCQOS,
+		SortingParameters: []string{
+			"*default:*asr:50",
+			"*default:*pdd:3",
+			"supplier2:*asr:30",
+			utils.MetaExclude,
+		},
+		Suppliers: []*Supplier{{ID: "supplier1"}, {ID: "supplier2"}},
+	}
+	if err := sp.Compile(); err != nil {
+		t.Fatal(err)
+	}
+	eThds := map[string]float64{utils.MetaASR: 50, utils.MetaPDD: 3}
+	if rcv := sp.Suppliers[0].cacheSupplier[utils.MetaQOSThresholds]; !reflect.DeepEqual(eThds, rcv) {
+		t.Errorf("Expecting: %+v, received: %+v", eThds, rcv)
+	}
+	eThds = map[string]float64{utils.MetaASR: 30, utils.MetaPDD: 3}
+	if rcv := sp.Suppliers[1].cacheSupplier[utils.MetaQOSThresholds]; !reflect.DeepEqual(eThds, rcv) {
+		t.Errorf("Expecting: %+v, received: %+v", eThds, rcv)
+	}
+	if exclude := sp.Suppliers[1].cacheSupplier[utils.MetaExclude]; exclude != true {
+		t.Errorf("Expecting exclude, received: %+v", exclude)
+	}
+	sp.SortingParameters = []string{"*asr:50"}
+	if err := sp.Compile(); err == nil {
+		t.Error("Expecting error for invalid sorting parameter")
+	}
+}
+
+func TestSuppliersQOSViolations(t *testing.T) {
+	thds := map[string]float64{utils.MetaASR: 50, utils.MetaPDD: 3, utils.MetaACD: 60}
+	sortingData := map[string]interface{}{
+		utils.Cost:    0.1,
+		utils.MetaASR: 40.0,
+		utils.MetaPDD: 2.0,
+	}
+	eViolations := []string{"*acd:N/A", "*asr:40<50"}
+	if rcv := qosViolations(sortingData, thds); !reflect.DeepEqual(eViolations, rcv) {
+		t.Errorf("Expecting: %+v, received: %+v", eViolations, rcv)
+	}
+	sortingData = map[string]interface{}{
+		utils.MetaASR: 60.0,
+		utils.MetaPDD: 5.0,
+		utils.MetaACD: STATS_NA,
+	}
+	eViolations = []string{"*acd:N/A", "*pdd:5>3"}
+	if rcv := qosViolations(sortingData, thds); !reflect.DeepEqual(eViolations, rcv) {
+		t.Errorf("Expecting: %+v, received: %+v", eViolations, rcv)
+	}
+	sortingData[utils.MetaPDD] = 3.0
+	sortingData[utils.MetaACD] = 60.0
+	if rcv := qosViolations(sortingData, thds); len(rcv) != 0 {
+		t.Errorf("Expecting no violations, received: %+v", rcv)
+	}
+}
+
+func TestSuppliersCompileQuota(t *testing.T) {
+	sp := &SupplierProfile{
+		Tenant:            "cgrates.org",
+		ID:                "SPP_QUOTA",
+		Sorting:           utils.MetaQuota,
+		SortingParameters: []string{"supplier1:60%", "*default:1000"},
+		Suppliers:         []*Supplier{{ID: "supplier1"}, {ID: "supplier2"}},
+	}
+	if err := sp.Compile(); err != nil {
+		t.Fatal(err)
+	}
+	eQuota := &supplierQuota{value: 60, percentage: true}
+	if rcv := sp.Suppliers[0].cacheSupplier[utils.MetaQuota]; !reflect.DeepEqual(eQuota, rcv) {
+		t.Errorf("Expecting: %+v, received: %+v", eQuota, rcv)
+	}
+	eQuota = &supplierQuota{value: 1000}
+	if rcv := sp.Suppliers[1].cacheSupplier[utils.MetaQuota]; !reflect.DeepEqual(eQuota, rcv) {
+		t.Errorf("Expecting: %+v, received: %+v", eQuota, rcv)
+	}
+	sp.SortingParameters = []string{"supplier1:-10%"}
+	if err := sp.Compile(); err == nil {
+		t.Error("Expecting error for invalid quota")
+	}
+	sp.SortingParameters = []string{"supplier1:60%", "*default:50%"}
+	if err := sp.Compile(); err == nil {
+		t.Error("Expecting error for percentages over 100")
+	}
+	sp.SortingParameters = []string{"supplier1:60%", "*period:*hourly"}
+	if err := sp.Compile(); err == nil {
+		t.Error("Expecting error for invalid period")
+	}
+	sp.SortingParameters = []string{"supplier1:60%", "*default:40%", "*period:*monthly"}
+	if err := sp.Compile(); err != nil {
+		t.Fatal(err)
+	}
+	for _, spl := range sp.Suppliers {
+		if rcv := spl.cacheSupplier[utils.MetaPeriod]; rcv != utils.MetaMonthly {
+			t.Errorf("Expecting: %+v, received: %+v", utils.MetaMonthly, rcv)
+		}
+	}
+}
+
+func TestSuppliersQuotaPeriodStart(t *testing.T) {
+	now := time.Date(2020, 4, 23, 15, 4, 5, 0, time.UTC) // Thursday
+	for period, eStart := range map[string]time.Time{
+		utils.MetaDaily:   time.Date(2020, 4, 23, 0, 0, 0, 0, time.UTC),
+		utils.MetaWeekly:  time.Date(2020, 4, 20, 0, 0, 0, 0, time.UTC),
+		utils.MetaMonthly: time.Date(2020, 4, 1, 0, 0, 0, 0, time.UTC),
+		utils.MetaYearly:  time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC),
+	} {
+		if rcv := quotaPeriodStart(period, now); !rcv.Equal(eStart) {
+			t.Errorf("Expecting %s start: %v, received: %v", period, eStart, rcv)
+		}
+	}
+	sunday := time.Date(2020, 4, 26, 23, 0, 0, 0, time.UTC)
+	if rcv := quotaPeriodStart(utils.MetaWeekly, sunday); !rcv.Equal(time.Date(2020, 4, 20, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("Unexpected week start: %v", rcv)
+	}
+}
+
+// testQuotaStatSMock returns the volume delivered by each supplier
+type testQuotaStatSMock map[string]float64
+
+func (sm testQuotaStatSMock) Call(method string, args interface{}, rply interface{}) error {
+	if method != utils.StatSv1GetQueueFloatMetrics {
+		return rpcclient.ErrUnsupporteServiceMethod
+	}
+	*rply.(*map[string]float64) = map[string]float64{
+		utils.MetaTCD: sm[args.(*utils.TenantIDWithArgDispatcher).ID]}
+	return nil
+}
+
+func TestSuppliersQuotaSorter(t *testing.T) {
+	cfg, _ := config.NewDefaultCGRConfig()
+	cfg.SupplierSCfg().StatSConns = []string{utils.ConcatenatedKey(utils.MetaInternal, utils.MetaStatS)}
+	statsChan := make(chan rpcclient.ClientConnector, 1)
+	statsChan <- testQuotaStatSMock{"STATS_SPL1": 500, "STATS_SPL2": 300, "STATS_SPL3": 200}
+	spS := &SupplierService{cgrcfg: cfg,
+		connMgr: NewConnManager(cfg, map[string]chan rpcclient.ClientConnector{
+			utils.ConcatenatedKey(utils.MetaInternal, utils.MetaStatS): statsChan,
+		})}
+	sp := &SupplierProfile{
+		Tenant:            "cgrates.org",
+		ID:                "SPP_QUOTA",
+		Sorting:           utils.MetaQuota,
+		SortingParameters: []string{"supplier1:60%", "supplier2:20%", "supplier3:1000"},
+		Suppliers: []*Supplier{
+			{ID: "supplier1", StatIDs: []string{"STATS_SPL1"}, Weight: 10},
+			{ID: "supplier2", StatIDs: []string{"STATS_SPL2"}, Weight: 20},
+			{ID: "supplier3", StatIDs: []string{"STATS_SPL3"}, Weight: 30},
+			{ID: "supplier4", StatIDs: []string{"STATS_SPL4"}, Weight: 40},
+		},
+	}
+	if err := sp.Compile(); err != nil {
+		t.Fatal(err)
+	}
+	sortedSpls, err := NewQuotaSorter(spS).SortSuppliers(sp.ID, sp.Suppliers,
+		&utils.CGREvent{Tenant: "cgrates.org", Event: map[string]interface{}{}},
+		&optsGetSuppliers{sortingStragety: utils.MetaQuota})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// supplier1: 50% out of 60%, supplier2: 30% out of 20%, supplier3: 200 out of 1000, supplier4 without quota
+	eIDs := []string{"supplier3", "supplier1", "supplier2", "supplier4"}
+	if rcv := sortedSpls.SupplierIDs(); !reflect.DeepEqual(eIDs, rcv) {
+		t.Errorf("Expecting: %+v, received: %+v", eIDs, rcv)
+	}
+	eSortingData := map[string]interface{}{
+		utils.Weight:   10.0,
+		utils.Load:     500.0,
+		utils.Share:    50.0,
+		utils.Target:   60.0,
+		utils.Progress: 50.0 * 100 / 60,
+	}
+	if rcv := sortedSpls.SortedSuppliers[1].SortingData; !reflect.DeepEqual(eSortingData, rcv) {
+		t.Errorf("Expecting: %+v, received: %+v", eSortingData, rcv)
+	}
+}
+
+// testCostsRatingMock counts the calls to RALs for the supplier costs
+type testCostsRatingMock struct {
+	calls map[string]int
+}
+
+func (rm *testCostsRatingMock) Call(method string, args interface{}, rply interface{}) error {
+	rm.calls[method]++
+	costFor := func(rpIDs []string) map[string]interface{} {
+		return map[string]interface{}{
+			utils.Cost:         float64(len(rpIDs[0])) / 10,
+			utils.RatingPlanID: rpIDs[0],
+		}
+	}
+	switch method {
+	case utils.ResponderGetCostOnRatingPlans:
+		*rply.(*map[string]interface{}) = costFor(args.(*utils.GetCostOnRatingPlansArgs).RatingPlanIDs)
+	case utils.ResponderGetCostsOnRatingPlans:
+		costs := make(map[string]*utils.CostOnRatingPlans)
+		for key, rpIDs := range args.(*utils.GetCostsOnRatingPlansArgs).RatingPlanIDs {
+			costs[key] = &utils.CostOnRatingPlans{CostData: costFor(rpIDs)}
+		}
+		*rply.(*map[string]*utils.CostOnRatingPlans) = costs
+	default:
+		return rpcclient.ErrUnsupporteServiceMethod
+	}
+	return nil
+}
+
+func TestSuppliersCostsBatchAndCache(t *testing.T) {
+	cfg, _ := config.NewDefaultCGRConfig()
+	cfg.SupplierSCfg().ResponderSConns = []string{utils.ConcatenatedKey(utils.MetaInternal, utils.MetaResponder)}
+	cfg.CacheCfg().Partitions[utils.CacheSupplierCosts].Limit = -1
+	bakCache := Cache
+	Cache = NewCacheS(cfg, nil)
+	defer func() { Cache = bakCache }()
+	ralsMock := &testCostsRatingMock{calls: make(map[string]int)}
+	ralsChan := make(chan rpcclient.ClientConnector, 1)
+	ralsChan <- ralsMock
+	spS := &SupplierService{cgrcfg: cfg,
+		connMgr: NewConnManager(cfg, map[string]chan rpcclient.ClientConnector{
+			utils.ConcatenatedKey(utils.MetaInternal, utils.MetaResponder): ralsChan,
+		})}
+	suppls := []*Supplier{
+		{ID: "supplier1", RatingPlanIDs: []string{"RP_1"}, Weight: 10},
+		{ID: "supplier2", RatingPlanIDs: []string{"RP_22"}, Weight: 20},
+		{ID: "supplier3", RatingPlanIDs: []string{"RP_1"}, Weight: 30},
+		{ID: "supplier4", RatingPlanIDs: []string{"RP_333"}, Weight: 40},
+	}
+	newEv := func(sTime time.Time) *utils.CGREvent {
+		return &utils.CGREvent{
+			Tenant: "cgrates.org",
+			Event: map[string]interface{}{
+				utils.Account:     "1001",
+				utils.Destination: "1002",
+				utils.SetupTime:   sTime,
+				utils.Usage:       time.Minute,
+			},
+		}
+	}
+	sortSuppliers := func(ev *utils.CGREvent) *SortedSuppliers {
+		opts := &optsGetSuppliers{rpCosts: spS.batchCostsOnRatingPlans(ev, suppls)}
+		sortedSpls, err := NewLeastCostSorter(spS).SortSuppliers("SPP_LC", suppls, ev, opts)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return sortedSpls
+	}
+	sortedSpls := sortSuppliers(newEv(time.Date(2020, 4, 20, 10, 0, 10, 0, time.UTC)))
+	eIDs := []string{"supplier3", "supplier1", "supplier2", "supplier4"}
+	if rcv := sortedSpls.SupplierIDs(); !reflect.DeepEqual(eIDs, rcv) {
+		t.Errorf("Expecting: %+v, received: %+v", eIDs, rcv)
+	}
+	// the 3 distinct lists of RatingPlans are rated in one call
+	eCalls := map[string]int{utils.ResponderGetCostsOnRatingPlans: 1}
+	if !reflect.DeepEqual(eCalls, ralsMock.calls) {
+		t.Errorf("Expecting: %+v, received: %+v", eCalls, ralsMock.calls)
+	}
+	// same time bucket, the costs are served out of cache
+	if rcv := sortSuppliers(newEv(time.Date(2020, 4, 20, 10, 0, 50, 0, time.UTC))).SupplierIDs(); !reflect.DeepEqual(eIDs, rcv) {
+		t.Errorf("Expecting: %+v, received: %+v", eIDs, rcv)
+	}
+	if !reflect.DeepEqual(eCalls, ralsMock.calls) {
+		t.Errorf("Expecting: %+v, received: %+v", eCalls, ralsMock.calls)
+	}
+	// only one list of RatingPlans missing from cache, rated individually
+	Cache.Remove(utils.CacheSupplierCosts, spS.costCacheKey(&utils.GetCostOnRatingPlansArgs{
+		Tenant: "cgrates.org", Account: "1001", Subject: "1001", Destination: "1002",
+		SetupTime: time.Date(2020, 4, 20, 10, 0, 0, 0, time.UTC), Usage: time.Minute},
+		[]string{"RP_22"}), true, utils.NonTransactional)
+	sortSuppliers(newEv(time.Date(2020, 4, 20, 10, 0, 30, 0, time.UTC)))
+	eCalls[utils.ResponderGetCostOnRatingPlans] = 1
+	if !reflect.DeepEqual(eCalls, ralsMock.calls) {
+		t.Errorf("Expecting: %+v, received: %+v", eCalls, ralsMock.calls)
+	}
+	// the RatingPlans reload flushes the costs
+	if err := dm.CacheDataFromDB(utils.RATING_PLAN_PREFIX, []string{}, true); err != nil {
+		t.Fatal(err)
+	}
+	sortSuppliers(newEv(time.Date(2020, 4, 20, 10, 0, 30, 0, time.UTC)))
+	eCalls[utils.ResponderGetCostsOnRatingPlans] = 2
+	if !reflect.DeepEqual(eCalls, ralsMock.calls) {
+		t.Errorf("Expecting: %+v, received: %+v", eCalls, ralsMock.calls)
+	}
+	// as well as clearing the Destinations out of cache
+	var reply string
+	if err := Cache.V1Clear(&utils.AttrCacheIDsWithArgDispatcher{
+		CacheIDs: []string{utils.CacheDestinations}}, &reply); err != nil {
+		t.Fatal(err)
+	}
+	sortSuppliers(newEv(time.Date(2020, 4, 20, 10, 0, 30, 0, time.UTC)))
+	eCalls[utils.ResponderGetCostsOnRatingPlans] = 3
+	if !reflect.DeepEqual(eCalls, ralsMock.calls) {
+		t.Errorf("Expecting: %+v, received: %+v", eCalls, ralsMock.calls)
+	}
+}
+
+// testQuotaHistStatSMock returns the buckets of the volume delivered by each supplier
+type testQuotaHistStatSMock map[string][]*StatBucket
+
+func (sm testQuotaHistStatSMock) Call(method string, args interface{}, rply interface{}) error {
+	if method != utils.StatSv1GetQueueHistory {
+		return rpcclient.ErrUnsupporteServiceMethod
+	}
+	*rply.(*StatQueueHistory) = StatQueueHistory{
+		Buckets: sm[args.(*utils.TenantIDWithArgDispatcher).ID]}
+	return nil
+}
+
+func TestSuppliersQuotaSorterPeriod(t *testing.T) {
+	cfg, _ := config.NewDefaultCGRConfig()
+	cfg.SupplierSCfg().StatSConns = []string{utils.ConcatenatedKey(utils.MetaInternal, utils.MetaStatS)}
+	dayStart := quotaPeriodStart(utils.MetaDaily, time.Now())
+	statsChan := make(chan rpcclient.ClientConnector, 1)
+	statsChan <- testQuotaHistStatSMock{
+		"STATS_SPL1": {
+			{StartTime: dayStart.Add(-time.Hour), Metrics: map[string]float64{utils.MetaTCD: 1000}}, // previous period
+			{StartTime: dayStart, Metrics: map[string]float64{utils.MetaTCD: 100}},
+		},
+		"STATS_SPL2": {
+			{StartTime: dayStart, Metrics: map[string]float64{utils.MetaTCD: 200}},
+			{StartTime: dayStart.Add(time.Hour), Metrics: map[string]float64{utils.MetaTCD: 100}},
+		},
+	}
+	Cache.Clear([]string{utils.CacheRPCConnections}) // do not reuse the mocks of other tests
+	spS := &SupplierService{cgrcfg: cfg,
+		connMgr: NewConnManager(cfg, map[string]chan rpcclient.ClientConnector{
+			utils.ConcatenatedKey(utils.MetaInternal, utils.MetaStatS): statsChan,
+		})}
+	sp := &SupplierProfile{
+		Tenant:            "cgrates.org",
+		ID:                "SPP_QUOTA",
+		Sorting:           utils.MetaQuota,
+		SortingParameters: []string{"*default:50%", "*period:*daily"},
+		Suppliers: []*Supplier{
+			{ID: "supplier1", StatIDs: []string{"STATS_SPL1:*tcd"}, Weight: 10},
+			{ID: "supplier2", StatIDs: []string{"STATS_SPL2"}, Weight: 20},
+		},
+	}
+	if err := sp.Compile(); err != nil {
+		t.Fatal(err)
+	}
+	sortedSpls, err := NewQuotaSorter(spS).SortSuppliers(sp.ID, sp.Suppliers,
+		&utils.CGREvent{Tenant: "cgrates.org", Event: map[string]interface{}{}},
+		&optsGetSuppliers{sortingStragety: utils.MetaQuota})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// the volume of supplier1 from the previous day is not counted
+	eIDs := []string{"supplier1", "supplier2"}
+	if rcv := sortedSpls.SupplierIDs(); !reflect.DeepEqual(eIDs, rcv) {
+		t.Errorf("Expecting: %+v, received: %+v", eIDs, rcv)
+	}
+	if rcv := sortedSpls.SortedSuppliers[0].SortingData[utils.Load]; rcv != 100.0 {
+		t.Errorf("Expecting Load 100, received: %+v", rcv)
+	}
+	if rcv := sortedSpls.SortedSuppliers[1].SortingData[utils.Share]; rcv != 75.0 {
+		t.Errorf("Expecting Share 75, received: %+v", rcv)
+	}
+}
+
+func TestSuppliersStatMetricsSinceIncomplete(t *testing.T) {
+	cfg, _ := config.NewDefaultCGRConfig()
+	cfg.SupplierSCfg().StatSConns = []string{utils.ConcatenatedKey(utils.MetaInternal, utils.MetaStatS)}
+	dayStart := quotaPeriodStart(utils.MetaDaily, time.Now())
+	statsChan := make(chan rpcclient.ClientConnector, 1)
+	statsChan <- testQuotaHistStatSMock{
+		"STATS_FULL": {
+			{StartTime: dayStart, Metrics: map[string]float64{utils.MetaTCD: 100}},
+		},
+		"STATS_EMPTY": {},
+		"STATS_LATE": {
+			{StartTime: dayStart.Add(time.Hour), Metrics: map[string]float64{utils.MetaTCD: 100}},
+		},
+	}
+	Cache.Clear([]string{utils.CacheRPCConnections}) // do not reuse the mocks of other tests
+	spS := &SupplierService{cgrcfg: cfg,
+		connMgr: NewConnManager(cfg, map[string]chan rpcclient.ClientConnector{
+			utils.ConcatenatedKey(utils.MetaInternal, utils.MetaStatS): statsChan,
+		})}
+	if rcv, err := spS.statMetricsSince([]string{"STATS_FULL"}, "cgrates.org", dayStart); err != nil {
+		t.Error(err)
+	} else if rcv != 100.0 {
+		t.Errorf("Expecting 100, received: %+v", rcv)
+	}
+	for _, statID := range []string{"STATS_EMPTY", "STATS_LATE"} {
+		if _, err := spS.statMetricsSince([]string{"STATS_FULL", statID}, "cgrates.org", dayStart); err == nil {
+			t.Errorf("Expecting error for statID: %s", statID)
+		}
+	}
+}
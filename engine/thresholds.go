@@ -41,23 +41,62 @@ type ThresholdProfileWithArgDispatcher struct {
 }
 
 type ThresholdProfile struct {
-	Tenant             string
-	ID                 string
-	FilterIDs          []string
-	ActivationInterval *utils.ActivationInterval // Time when this limit becomes active and expires
-	MaxHits            int
-	MinHits            int
-	MinSleep           time.Duration
-	Blocker            bool    // blocker flag to stop processing on filters matched
-	Weight             float64 // Weight to sort the thresholds
-	ActionIDs          []string
-	Async              bool
+	Tenant              string
+	ID                  string
+	FilterIDs           []string
+	ActivationInterval  *utils.ActivationInterval // Time when this limit becomes active and expires
+	MaxHits             int
+	MinHits             int
+	MinSleep            time.Duration
+	Blocker             bool    // blocker flag to stop processing on filters matched
+	Weight              float64 // Weight to sort the thresholds
+	ActionIDs           []string
+	Async               bool
+	WarningFilterIDs    []string // filters on the matched event raising the state to *warning
+	CriticalFilterIDs   []string // filters on the matched event raising the state to *critical
+	EscalationActionIDs []string // executed when the state goes up
+	RecoveryActionIDs   []string // executed when the state goes down
 }
 
 func (tp *ThresholdProfile) TenantID() string {
 	return utils.ConcatenatedKey(tp.Tenant, tp.ID)
 }
 
+// stateful returns true if the profile computes a state out of the matched events
+func (tp *ThresholdProfile) stateful() bool {
+	return len(tp.WarningFilterIDs) != 0 || len(tp.CriticalFilterIDs) != 0
+}
+
+// thresholdStateLevels orders the states so we can tell escalation from recovery
+var thresholdStateLevels = map[string]int{
+	utils.MetaOK:       0,
+	utils.MetaWarning:  1,
+	utils.MetaCritical: 2,
+}
+
+// evaluateState returns the state for the event, critical filters being checked first
+func (tp *ThresholdProfile) evaluateState(tnt string, evNm config.DataProvider,
+	filterS *FilterS) (state string, err error) {
+	for _, lvl := range []struct {
+		state     string
+		filterIDs []string
+	}{
+		{utils.MetaCritical, tp.CriticalFilterIDs},
+		{utils.MetaWarning, tp.WarningFilterIDs},
+	} {
+		if len(lvl.filterIDs) == 0 {
+			continue
+		}
+		var pass bool
+		if pass, err = filterS.Pass(tnt, lvl.filterIDs, evNm); err != nil {
+			return
+		} else if pass {
+			return lvl.state, nil
+		}
+	}
+	return utils.MetaOK, nil
+}
+
 // ThresholdWithArgDispatcher is used in replicatorV1 for dispatcher
 type ThresholdWithArgDispatcher struct {
 	*Threshold
@@ -66,10 +105,12 @@ type ThresholdWithArgDispatcher struct {
 
 // Threshold is the unit matched by filters
 type Threshold struct {
-	Tenant string
-	ID     string
-	Hits   int       // number of hits for this threshold
-	Snooze time.Time // prevent threshold to run too early
+	Tenant    string
+	ID        string
+	Hits      int       // number of hits for this threshold
+	Snooze    time.Time // prevent threshold to run too early
+	State     string    // current state of a stateful threshold, empty meaning *ok
+	StateTime time.Time // when the State was last changed
 
 	tPrfl *ThresholdProfile
 	dirty *bool // needs save
@@ -91,16 +132,53 @@ func (t *Threshold) ProcessEvent(args *ArgsProcessEvent, dm *DataManager) (err e
 	if t.tPrfl.MaxHits != -1 && t.Hits > t.tPrfl.MaxHits {
 		return
 	}
-	acnt, _ := args.FieldAsString(utils.Account)
+	return t.executeActions(args.CGREvent, t.tPrfl.ActionIDs)
+}
+
+// processState computes the state of a stateful threshold out of the event,
+// executing the escalation or recovery actions when the state changes
+// with recoveryOnly the state can only go down (ie: event not passing the FilterIDs)
+func (t *Threshold) processState(args *ArgsProcessEvent, evNm config.DataProvider,
+	filterS *FilterS, recoveryOnly bool) (changed bool, err error) {
+	var state string
+	if state, err = t.tPrfl.evaluateState(args.Tenant, evNm, filterS); err != nil {
+		return
+	}
+	prevState := t.State
+	if prevState == "" {
+		prevState = utils.MetaOK
+	}
+	if state == prevState ||
+		(recoveryOnly && thresholdStateLevels[state] > thresholdStateLevels[prevState]) {
+		return
+	}
+	changed = true
+	t.State = state
+	t.StateTime = time.Now()
+	actionIDs := t.tPrfl.RecoveryActionIDs
+	if thresholdStateLevels[state] > thresholdStateLevels[prevState] {
+		actionIDs = t.tPrfl.EscalationActionIDs
+	}
+	ev := args.CGREvent.Clone()
+	ev.Event[utils.ThresholdID] = t.ID
+	ev.Event[utils.ThresholdState] = state
+	ev.Event[utils.PreviousThresholdState] = prevState
+	err = t.executeActions(ev, actionIDs)
+	return
+}
+
+// executeActions executes the action sets, passing the event as ExtraData
+func (t *Threshold) executeActions(ev *utils.CGREvent, actionIDs []string) (err error) {
+	acnt, _ := ev.FieldAsString(utils.Account)
 	var acntID string
 	if acnt != "" {
-		acntID = utils.ConcatenatedKey(args.Tenant, acnt)
+		acntID = utils.ConcatenatedKey(ev.Tenant, acnt)
 	}
-	for _, actionSetID := range t.tPrfl.ActionIDs {
+	for _, actionSetID := range actionIDs {
 		at := &ActionTiming{
 			Uuid:      utils.GenUUID(),
 			ActionsID: actionSetID,
-			ExtraData: args.CGREvent,
+			ExtraData: ev,
 		}
 		if acntID != "" {
 			at.accountIDs = utils.NewStringMap(acntID)
@@ -235,6 +313,13 @@ func (tS *ThresholdService) StoreThreshold(t *Threshold) (err error) {
 
 // matchingThresholdsForEvent returns ordered list of matching thresholds which are active for an Event
 func (tS *ThresholdService) matchingThresholdsForEvent(args *ArgsProcessEvent) (ts Thresholds, err error) {
+	ts, _, err = tS.matchingThresholds(args)
+	return
+}
+
+// matchingThresholds returns the thresholds matching the event together with the
+// stateful ones out of *ok which do not pass their FilterIDs but can still recover
+func (tS *ThresholdService) matchingThresholds(args *ArgsProcessEvent) (ts, rcvTs Thresholds, err error) {
 	matchingTs := make(map[string]*Threshold)
 	var tIDs []string
 	if len(args.ThresholdIDs) != 0 {
@@ -248,7 +333,7 @@ func (tS *ThresholdService) matchingThresholdsForEvent(args *ArgsProcessEvent) (
 			tS.cgrcfg.ThresholdSCfg().NestedFields,
 		)
 		if err != nil {
-			return nil, err
+			return nil, nil, err
 		}
 		tIDs = tIDsMap.Slice()
 	}
@@ -260,7 +345,7 @@ func (tS *ThresholdService) matchingThresholdsForEvent(args *ArgsProcessEvent) (
 			if err == utils.ErrNotFound {
 				continue
 			}
-			return nil, err
+			return nil, nil, err
 		}
 		if tPrfl.ActivationInterval != nil && args.Time != nil &&
 			!tPrfl.ActivationInterval.IsActiveAtTime(*args.Time) { // not active
@@ -268,13 +353,32 @@ func (tS *ThresholdService) matchingThresholdsForEvent(args *ArgsProcessEvent) (
 		}
 		if pass, err := tS.filterS.Pass(args.Tenant, tPrfl.FilterIDs,
 			evNm); err != nil {
-			return nil, err
+			return nil, nil, err
 		} else if !pass {
+			if !tPrfl.stateful() {
+				continue
+			}
+			// the state is evaluated before the FilterIDs so it can recover
+			t, err := tS.dm.GetThreshold(tPrfl.Tenant, tPrfl.ID, true, true, "")
+			if err != nil {
+				if err == utils.ErrNotFound {
+					continue
+				}
+				return nil, nil, err
+			}
+			if t.State == "" || t.State == utils.MetaOK {
+				continue
+			}
+			if t.dirty == nil {
+				t.dirty = utils.BoolPointer(false)
+			}
+			t.tPrfl = tPrfl
+			rcvTs = append(rcvTs, t)
 			continue
 		}
 		t, err := tS.dm.GetThreshold(tPrfl.Tenant, tPrfl.ID, true, true, "")
 		if err != nil {
-			return nil, err
+			return nil, nil, err
 		}
 		if t.dirty == nil || tPrfl.MaxHits == -1 || t.Hits < tPrfl.MaxHits {
 			t.dirty = utils.BoolPointer(false)
@@ -307,15 +411,46 @@ type ArgsProcessEvent struct {
 
 // processEvent processes a new event, dispatching to matching thresholds
 func (tS *ThresholdService) processEvent(args *ArgsProcessEvent) (thresholdsIDs []string, err error) {
-	matchTs, err := tS.matchingThresholdsForEvent(args)
+	matchTs, rcvTs, err := tS.matchingThresholds(args)
 	if err != nil {
 		return nil, err
 	}
+	evNm := config.NewNavigableMap(nil)
+	evNm.Set([]string{utils.MetaReq}, args.Event, false, false)
 	var withErrors bool
+	for _, t := range rcvTs {
+		var changed bool
+		if changed, err = t.processState(args, evNm, tS.filterS, true); err != nil {
+			utils.Logger.Warning(
+				fmt.Sprintf("<ThresholdService> threshold: %s, failed processing state for event: %s, error: %s",
+					t.TenantID(), args.CGREvent.TenantID(), err.Error()))
+			withErrors = true
+		}
+		if !changed {
+			continue
+		}
+		if tS.cgrcfg.ThresholdSCfg().StoreInterval == -1 {
+			*t.dirty = true
+			tS.StoreThreshold(t)
+		} else {
+			*t.dirty = true // mark it to be saved
+			tS.stMux.Lock()
+			tS.storedTdIDs[t.TenantID()] = true
+			tS.stMux.Unlock()
+		}
+	}
 	var tIDs []string
 	for _, t := range matchTs {
 		tIDs = append(tIDs, t.ID)
 		t.Hits++
+		if t.tPrfl.stateful() {
+			if _, err = t.processState(args, evNm, tS.filterS, false); err != nil {
+				utils.Logger.Warning(
+					fmt.Sprintf("<ThresholdService> threshold: %s, failed processing state for event: %s, error: %s",
+						t.TenantID(), args.CGREvent.TenantID(), err.Error()))
+				withErrors = true
+			}
+		}
 		err = t.ProcessEvent(args, tS.dm)
 		if err != nil {
 			utils.Logger.Warning(
@@ -324,7 +459,8 @@ func (tS *ThresholdService) processEvent(args *ArgsProcessEvent) (thresholdsIDs
 			withErrors = true
 			continue
 		}
-		if t.dirty == nil || t.Hits == t.tPrfl.MaxHits { // one time threshold
+		if !t.tPrfl.stateful() && // the stateful ones are kept for their state once MaxHits is reached
+			(t.dirty == nil || t.Hits == t.tPrfl.MaxHits) { // one time threshold
 			if err = tS.dm.RemoveThreshold(t.Tenant, t.ID, utils.NonTransactional); err != nil {
 				utils.Logger.Warning(
 					fmt.Sprintf("<ThresholdService> failed removing from database non-recurrent threshold: %s, error: %s",
@@ -422,6 +558,35 @@ func (tS *ThresholdService) V1GetThreshold(tntID *utils.TenantID, t *Threshold)
 	return
 }
 
+// ThresholdState is the state of a Threshold as returned by the API
+type ThresholdState struct {
+	Tenant    string
+	ID        string
+	State     string
+	StateTime time.Time
+}
+
+// V1GetThresholdState returns the state of a Threshold
+func (tS *ThresholdService) V1GetThresholdState(tntID *utils.TenantID, reply *ThresholdState) (err error) {
+	if missing := utils.MissingStructFields(tntID, []string{utils.Tenant, utils.ID}); len(missing) != 0 { //Params missing
+		return utils.NewErrMandatoryIeMissing(missing...)
+	}
+	var thd *Threshold
+	if thd, err = tS.dm.GetThreshold(tntID.Tenant, tntID.ID, true, true, ""); err != nil {
+		return
+	}
+	*reply = ThresholdState{
+		Tenant:    thd.Tenant,
+		ID:        thd.ID,
+		State:     thd.State,
+		StateTime: thd.StateTime,
+	}
+	if reply.State == "" {
+		reply.State = utils.MetaOK
+	}
+	return
+}
+
 // Reload stops the backupLoop and restarts it
 func (tS *ThresholdService) Reload() {
 	close(tS.stopBackup)
@@ -385,3 +385,126 @@ func TestThresholdsProcessEvent2(t *testing.T) {
 		}
 	}
 }
+
+func TestThresholdsProcessEventStateful(t *testing.T) {
+	cfg, _ := config.NewDefaultCGRConfig()
+	cfg.ThresholdSCfg().StoreInterval = -1
+	data := NewInternalDB(nil, nil, true, cfg.DataDbCfg().Items)
+	dm := NewDataManager(data, cfg.CacheCfg(), nil)
+	tS, _ := NewThresholdService(dm, cfg, &FilterS{dm: dm, cfg: cfg})
+	thPrf := &ThresholdProfile{
+		Tenant:            "cgrates.org",
+		ID:                "TH_ASR",
+		FilterIDs:         []string{"*string:~*req.StatID:STATS_ASR"},
+		MaxHits:           -1,
+		WarningFilterIDs:  []string{"*lt:~*req.*asr:60"},
+		CriticalFilterIDs: []string{"*lt:~*req.*asr:40"},
+	}
+	if err := dm.SetThresholdProfile(thPrf, true); err != nil {
+		t.Fatal(err)
+	}
+	if err := dm.SetThreshold(&Threshold{Tenant: thPrf.Tenant, ID: thPrf.ID}); err != nil {
+		t.Fatal(err)
+	}
+	var reply ThresholdState
+	if err := tS.V1GetThresholdState(&utils.TenantID{Tenant: "cgrates.org", ID: "TH_ASR"}, &reply); err != nil {
+		t.Fatal(err)
+	} else if reply.State != utils.MetaOK {
+		t.Errorf("expecting: %s, received: %s", utils.MetaOK, reply.State)
+	}
+	var prevStateTime time.Time
+	for _, tc := range []struct {
+		asr     float64
+		state   string
+		changed bool
+	}{
+		{asr: 70, state: utils.MetaOK},
+		{asr: 50, state: utils.MetaWarning, changed: true},
+		{asr: 30, state: utils.MetaCritical, changed: true},
+		{asr: 35, state: utils.MetaCritical},
+		{asr: 80, state: utils.MetaOK, changed: true},
+	} {
+		args := &ArgsProcessEvent{
+			CGREvent: &utils.CGREvent{
+				Tenant: "cgrates.org",
+				ID:     utils.GenUUID(),
+				Event: map[string]interface{}{
+					utils.StatID: "STATS_ASR",
+					"*asr":       tc.asr,
+				},
+			},
+		}
+		if _, err := tS.processEvent(args); err != nil {
+			t.Fatalf("asr %v: %v", tc.asr, err)
+		}
+		if err := tS.V1GetThresholdState(&utils.TenantID{Tenant: "cgrates.org", ID: "TH_ASR"}, &reply); err != nil {
+			t.Fatal(err)
+		}
+		if reply.State != tc.state {
+			t.Errorf("asr %v, expecting state: %s, received: %s", tc.asr, tc.state, reply.State)
+		}
+		if changed := reply.StateTime != prevStateTime; changed != tc.changed {
+			t.Errorf("asr %v, expecting changed: %v, received: %v", tc.asr, tc.changed, changed)
+		}
+		prevStateTime = reply.StateTime
+	}
+	if err := tS.V1GetThresholdState(&utils.TenantID{Tenant: "cgrates.org"}, &reply); err == nil ||
+		err.Error() != utils.NewErrMandatoryIeMissing(utils.ID).Error() {
+		t.Errorf("received error: %v", err)
+	}
+}
+
+func TestThresholdsProcessEventStatefulRecovery(t *testing.T) {
+	cfg, _ := config.NewDefaultCGRConfig()
+	cfg.ThresholdSCfg().StoreInterval = -1
+	data := NewInternalDB(nil, nil, true, cfg.DataDbCfg().Items)
+	dm := NewDataManager(data, cfg.CacheCfg(), nil)
+	tS, _ := NewThresholdService(dm, cfg, &FilterS{dm: dm, cfg: cfg})
+	thPrf := &ThresholdProfile{
+		Tenant:            "cgrates.org",
+		ID:                "TH_ASR_LOW",
+		FilterIDs:         []string{"*string:~*req.StatID:STATS_ASR", "*lt:~*req.*asr:60"}, // only the bad ASR is matched
+		MaxHits:           1,
+		WarningFilterIDs:  []string{"*lt:~*req.*asr:60"},
+		CriticalFilterIDs: []string{"*lt:~*req.*asr:40"},
+	}
+	if err := dm.SetThresholdProfile(thPrf, true); err != nil {
+		t.Fatal(err)
+	}
+	if err := dm.SetThreshold(&Threshold{Tenant: thPrf.Tenant, ID: thPrf.ID}); err != nil {
+		t.Fatal(err)
+	}
+	for _, tc := range []struct {
+		asr   float64
+		err   error
+		state string
+	}{
+		{asr: 50, state: utils.MetaWarning},
+		{asr: 30, state: utils.MetaCritical},                   // kept after MaxHits
+		{asr: 80, err: utils.ErrNotFound, state: utils.MetaOK}, // recovered without passing the FilterIDs
+		{asr: 90, err: utils.ErrNotFound, state: utils.MetaOK}, // not processed anymore
+		{asr: 45, state: utils.MetaWarning},                    // escalated again
+		{asr: 40.5, state: utils.MetaWarning},                  // no change
+		{asr: 70, err: utils.ErrNotFound, state: utils.MetaOK}, // recovered again
+	} {
+		args := &ArgsProcessEvent{
+			CGREvent: &utils.CGREvent{
+				Tenant: "cgrates.org",
+				ID:     utils.GenUUID(),
+				Event: map[string]interface{}{
+					utils.StatID: "STATS_ASR",
+					"*asr":       tc.asr,
+				},
+			},
+		}
+		if _, err := tS.processEvent(args); err != tc.err {
+			t.Fatalf("asr %v, expecting error: %v, received: %v", tc.asr, tc.err, err)
+		}
+		// the state is stored
+		if th, err := dm.GetThreshold("cgrates.org", "TH_ASR_LOW", false, false, ""); err != nil {
+			t.Fatalf("asr %v: %v", tc.asr, err)
+		} else if th.State != tc.state {
+			t.Errorf("asr %v, expecting state: %s, received: %s", tc.asr, tc.state, th.State)
+		}
+	}
+}
@@ -81,6 +81,7 @@ type MonetaryInfo struct {
 	ID           string
 	Value        float64
 	RateInterval *RateInterval
+	ExchangeRate float64 // rate converting the cost into the balance currency, 0 if not converted
 }
 
 func (mi *MonetaryInfo) Clone() *MonetaryInfo {
@@ -96,6 +97,7 @@ func (mi *MonetaryInfo) Equal(other *MonetaryInfo) bool {
 		return false
 	}
 	return mi.UUID == other.UUID &&
+		mi.ExchangeRate == other.ExchangeRate &&
 		reflect.DeepEqual(mi.RateInterval, other.RateInterval)
 }
 
@@ -821,3 +823,11 @@ func (ts *TimeSpan) Merge(other *TimeSpan) bool {
 	ts.Increments = append(ts.Increments, other.Increments...)
 	return true
 }
+
+// ratingCurrency returns the currency of the costs within the timespan
+func (ts *TimeSpan) ratingCurrency() string {
+	if ts.RateInterval == nil || ts.RateInterval.Rating == nil {
+		return ""
+	}
+	return ts.RateInterval.Rating.Currency
+}
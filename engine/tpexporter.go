@@ -98,6 +98,28 @@ func (self *TPExporter) Run() error {
 		toExportMap[utils.TimingsCsv][i] = sd
 	}
 
+	storDataCalendars, err := self.storDb.GetTPCalendars(self.tpID, "")
+	if err != nil && err.Error() != utils.ErrNotFound.Error() {
+		return err
+	}
+	for _, sd := range storDataCalendars {
+		sdModels := APItoModelCalendar(sd)
+		for _, sdModel := range sdModels {
+			toExportMap[utils.CalendarsCsv] = append(toExportMap[utils.CalendarsCsv], sdModel)
+		}
+	}
+
+	storDataExchangeRates, err := self.storDb.GetTPExchangeRates(self.tpID, "", "")
+	if err != nil && err.Error() != utils.ErrNotFound.Error() {
+		return err
+	}
+	for _, sd := range storDataExchangeRates {
+		sdModels := APItoModelExchangeRates(sd)
+		for _, sdModel := range sdModels {
+			toExportMap[utils.ExchangeRatesCsv] = append(toExportMap[utils.ExchangeRatesCsv], sdModel)
+		}
+	}
+
 	storDataDestinations, err := self.storDb.GetTPDestinations(self.tpID, "")
 	if err != nil && err.Error() != utils.ErrNotFound.Error() {
 		return err
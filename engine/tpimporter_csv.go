@@ -41,6 +41,8 @@ type TPCSVImporter struct {
 // Change it to func(string) error as soon as Travis updates.
 var fileHandlers = map[string]func(*TPCSVImporter, string) error{
 	utils.TimingsCsv:            (*TPCSVImporter).importTimings,
+	utils.CalendarsCsv:          (*TPCSVImporter).importCalendars,
+	utils.ExchangeRatesCsv:      (*TPCSVImporter).importExchangeRates,
 	utils.DestinationsCsv:       (*TPCSVImporter).importDestinations,
 	utils.RatesCsv:              (*TPCSVImporter).importRates,
 	utils.DestinationRatesCsv:   (*TPCSVImporter).importDestinationRates,
@@ -98,6 +100,36 @@ func (self *TPCSVImporter) importTimings(fn string) error {
 	return self.StorDb.SetTPTimings(tps)
 }
 
+func (self *TPCSVImporter) importCalendars(fn string) error {
+	if self.Verbose {
+		log.Printf("Processing file: <%s> ", fn)
+	}
+	tps, err := self.csvr.GetTPCalendars(self.TPid, "")
+	if err != nil {
+		return err
+	}
+	for i := 0; i < len(tps); i++ {
+		tps[i].TPid = self.TPid
+	}
+
+	return self.StorDb.SetTPCalendars(tps)
+}
+
+func (self *TPCSVImporter) importExchangeRates(fn string) error {
+	if self.Verbose {
+		log.Printf("Processing file: <%s> ", fn)
+	}
+	tps, err := self.csvr.GetTPExchangeRates(self.TPid, "", "")
+	if err != nil {
+		return err
+	}
+	for i := 0; i < len(tps); i++ {
+		tps[i].TPid = self.TPid
+	}
+
+	return self.StorDb.SetTPExchangeRates(tps)
+}
+
 func (self *TPCSVImporter) importDestinations(fn string) error {
 	if self.Verbose {
 		log.Printf("Processing file: <%s> ", fn)
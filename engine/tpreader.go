@@ -42,6 +42,8 @@ type TpReader struct {
 	accountActions     map[string]*Account
 	destinations       map[string]*Destination
 	timings            map[string]*utils.TPTiming
+	calendars          map[string]utils.StringMap // days within each calendar
+	exchangeRates      map[string]*ExchangeRates
 	rates              map[string]*utils.TPRate
 	destinationRates   map[string]*utils.TPDestinationRate
 	ratingPlans        map[string]*RatingPlan
@@ -125,6 +127,8 @@ func (tpr *TpReader) Init() {
 	tpr.destinations = make(map[string]*Destination)
 	tpr.destinationRates = make(map[string]*utils.TPDestinationRate)
 	tpr.timings = make(map[string]*utils.TPTiming)
+	tpr.calendars = make(map[string]utils.StringMap)
+	tpr.exchangeRates = make(map[string]*ExchangeRates)
 	tpr.ratingPlans = make(map[string]*RatingPlan)
 	tpr.ratingProfiles = make(map[string]*RatingProfile)
 	tpr.sharedGroups = make(map[string]*SharedGroup)
@@ -209,6 +213,86 @@ func (tpr *TpReader) LoadTimings() (err error) {
 	return err
 }
 
+func (tpr *TpReader) LoadCalendars() (err error) {
+	tps, err := tpr.lr.GetTPCalendars(tpr.tpid, "")
+	if err != nil {
+		return err
+	}
+	tpr.calendars, err = MapTPCalendars(tps)
+	return
+}
+
+// LoadCalendarsRatingPlans updates the days of the loaded calendars within the RatingPlans already stored,
+// since the calendars are resolved into the RatingPlans when these are loaded
+func (tpr *TpReader) LoadCalendarsRatingPlans() (err error) {
+	if len(tpr.calendars) == 0 {
+		return
+	}
+	rps, err := tpr.dm.GetCalendarsRatingPlans(tpr.calendars)
+	if err != nil {
+		return
+	}
+	for _, rp := range rps {
+		if _, loaded := tpr.ratingPlans[rp.Id]; loaded { // resolved already with the loaded calendars
+			continue
+		}
+		tpr.ratingPlans[rp.Id] = rp
+	}
+	return
+}
+
+// timingCalendars returns the days within the calendars referenced by the timing
+func (tpr *TpReader) timingCalendars(tm *utils.TPTiming) (includeCals, excludeCals map[string]utils.StringMap, err error) {
+	for calID, included := range tm.Calendars {
+		dates, exists := tpr.calendars[calID]
+		if !exists {
+			tpCals, err := tpr.lr.GetTPCalendars(tpr.tpid, calID)
+			if err != nil || len(tpCals) == 0 {
+				return nil, nil, fmt.Errorf("no calendar with id %s: %v", calID, err)
+			}
+			cals, err := MapTPCalendars(tpCals)
+			if err != nil {
+				return nil, nil, err
+			}
+			if dates, exists = cals[calID]; !exists {
+				return nil, nil, fmt.Errorf("no calendar with id %s", calID)
+			}
+		}
+		if !included {
+			if excludeCals == nil {
+				excludeCals = make(map[string]utils.StringMap)
+			}
+			excludeCals[calID] = dates
+			continue
+		}
+		if includeCals == nil {
+			includeCals = make(map[string]utils.StringMap)
+		}
+		includeCals[calID] = dates
+	}
+	return
+}
+
+func (tpr *TpReader) LoadExchangeRates() (err error) {
+	tps, err := tpr.lr.GetTPExchangeRates(tpr.tpid, "", "")
+	if err != nil {
+		return err
+	}
+	mapExrs := make(map[string]*ExchangeRates)
+	for _, tp := range tps {
+		exr, err := APItoExchangeRates(tp, tpr.timezone)
+		if err != nil {
+			return err
+		}
+		if _, has := mapExrs[exr.ID()]; has {
+			return fmt.Errorf("duplicate exchange rates: %s", exr.ID())
+		}
+		mapExrs[exr.ID()] = exr
+	}
+	tpr.exchangeRates = mapExrs
+	return
+}
+
 func (tpr *TpReader) LoadRates() (err error) {
 	tps, err := tpr.lr.GetTPRates(tpr.tpid, "")
 	if err != nil {
@@ -261,9 +345,10 @@ func (tpr *TpReader) LoadRatingPlansFiltered(tag string) (bool, error) {
 	}
 
 	bindings := MapTPRatingPlanBindings(mpRpls)
+	currencies := MapTPRatingPlanCurrencies(mpRpls)
 
 	for tag, rplBnds := range bindings {
-		ratingPlan := &RatingPlan{Id: tag}
+		ratingPlan := &RatingPlan{Id: tag, Currency: currencies[tag]}
 		for _, rp := range rplBnds {
 			tm := tpr.timings
 			_, exists := tpr.timings[rp.TimingId]
@@ -279,6 +364,10 @@ func (tpr *TpReader) LoadRatingPlansFiltered(tag string) (bool, error) {
 			}
 
 			rp.SetTiming(tm[rp.TimingId])
+			inclCals, exclCals, err := tpr.timingCalendars(rp.Timing())
+			if err != nil {
+				return false, err
+			}
 			tpdrm, err := tpr.lr.GetTPDestinationRates(tpr.tpid, rp.DestinationRatesId, nil)
 			if err != nil || len(tpdrm) == 0 {
 				return false, fmt.Errorf("no DestinationRates profile with id %s: %v", rp.DestinationRatesId, err)
@@ -298,7 +387,10 @@ func (tpr *TpReader) LoadRatingPlansFiltered(tag string) (bool, error) {
 				}
 
 				drate.Rate = rt[drate.RateId]
-				ratingPlan.AddRateInterval(drate.DestinationId, GetRateInterval(rp, drate))
+				ri := GetRateInterval(rp, drate)
+				ri.Timing.IncludeCalendars, ri.Timing.ExcludeCalendars = inclCals, exclCals
+				ri.Rating.Currency = ratingPlan.Currency
+				ratingPlan.AddRateInterval(drate.DestinationId, ri)
 				if drate.DestinationId == utils.ANY {
 					continue // no need of loading the destinations in this case
 				}
@@ -341,6 +433,7 @@ func (tpr *TpReader) LoadRatingPlans() (err error) {
 		return err
 	}
 	bindings := MapTPRatingPlanBindings(tps)
+	currencies := MapTPRatingPlanCurrencies(tps)
 	for tag, rplBnds := range bindings {
 		for _, rplBnd := range rplBnds {
 			t, exists := tpr.timings[rplBnd.TimingId]
@@ -348,17 +441,24 @@ func (tpr *TpReader) LoadRatingPlans() (err error) {
 				return fmt.Errorf("could not get timing for tag %v", rplBnd.TimingId)
 			}
 			rplBnd.SetTiming(t)
+			inclCals, exclCals, err := tpr.timingCalendars(t)
+			if err != nil {
+				return err
+			}
 			drs, exists := tpr.destinationRates[rplBnd.DestinationRatesId]
 			if !exists {
 				return fmt.Errorf("could not find destination rate for tag %v", rplBnd.DestinationRatesId)
 			}
 			plan, exists := tpr.ratingPlans[tag]
 			if !exists {
-				plan = &RatingPlan{Id: tag}
+				plan = &RatingPlan{Id: tag, Currency: currencies[tag]}
 				tpr.ratingPlans[plan.Id] = plan
 			}
 			for _, dr := range drs.DestinationRates {
-				plan.AddRateInterval(dr.DestinationId, GetRateInterval(rplBnd, dr))
+				ri := GetRateInterval(rplBnd, dr)
+				ri.Timing.IncludeCalendars, ri.Timing.ExcludeCalendars = inclCals, exclCals
+				ri.Rating.Currency = plan.Currency
+				plan.AddRateInterval(dr.DestinationId, ri)
 			}
 		}
 	}
@@ -1289,6 +1389,12 @@ func (tpr *TpReader) LoadAll() (err error) {
 	if err = tpr.LoadTimings(); err != nil && err.Error() != utils.NotFoundCaps {
 		return
 	}
+	if err = tpr.LoadCalendars(); err != nil && err.Error() != utils.NotFoundCaps {
+		return
+	}
+	if err = tpr.LoadExchangeRates(); err != nil && err.Error() != utils.NotFoundCaps {
+		return
+	}
 	if err = tpr.LoadRates(); err != nil && err.Error() != utils.NotFoundCaps {
 		return
 	}
@@ -1298,6 +1404,9 @@ func (tpr *TpReader) LoadAll() (err error) {
 	if err = tpr.LoadRatingPlans(); err != nil && err.Error() != utils.NotFoundCaps {
 		return
 	}
+	if err = tpr.LoadCalendarsRatingPlans(); err != nil && err.Error() != utils.NotFoundCaps {
+		return
+	}
 	if err = tpr.LoadRatingProfiles(); err != nil && err.Error() != utils.NotFoundCaps {
 		return
 	}
@@ -1762,6 +1871,20 @@ func (tpr *TpReader) WriteToDatabase(verbose, disable_reverse bool) (err error)
 	if len(tpr.timings) != 0 {
 		loadIDs[utils.CacheTimings] = loadID
 	}
+	if verbose {
+		log.Print("ExchangeRates:")
+	}
+	for _, exr := range tpr.exchangeRates {
+		if err = tpr.dm.SetExchangeRates(exr); err != nil {
+			return err
+		}
+		if verbose {
+			log.Print("\t", exr.ID())
+		}
+	}
+	if len(tpr.exchangeRates) != 0 {
+		loadIDs[utils.CacheExchangeRates] = loadID
+	}
 	if !disable_reverse {
 		if len(tpr.destinations) > 0 {
 			if verbose {
@@ -1855,6 +1978,8 @@ func (tpr *TpReader) ShowStatistics() {
 	log.Print("DispatcherProfiles: ", len(tpr.dispatcherProfiles))
 	// Dispatcher Hosts
 	log.Print("DispatcherHosts: ", len(tpr.dispatcherHosts))
+	// Exchange rates
+	log.Print("ExchangeRates: ", len(tpr.exchangeRates))
 }
 
 // Returns the identities loaded for a specific category, useful for cache reloads
@@ -2005,6 +2130,15 @@ func (tpr *TpReader) GetLoadedIds(categ string) ([]string, error) {
 			i++
 		}
 		return keys, nil
+
+	case utils.ExchangeRatesPrefix:
+		keys := make([]string, len(tpr.exchangeRates))
+		i := 0
+		for k := range tpr.exchangeRates {
+			keys[i] = k
+			i++
+		}
+		return keys, nil
 	}
 	return nil, errors.New("Unsupported load category")
 }
@@ -2258,6 +2392,18 @@ func (tpr *TpReader) RemoveFromDatabase(verbose, disable_reverse bool) (err erro
 			log.Print("\t", t.ID)
 		}
 	}
+	if verbose {
+		log.Print("ExchangeRates:")
+	}
+	for _, exr := range tpr.exchangeRates {
+		if err = tpr.dm.RemoveExchangeRates(exr.FromCurrency, exr.ToCurrency,
+			utils.NonTransactional); err != nil {
+			return err
+		}
+		if verbose {
+			log.Print("\t", exr.ID())
+		}
+	}
 	if !disable_reverse {
 		if len(tpr.destinations) > 0 {
 			if verbose {
@@ -2355,6 +2501,9 @@ func (tpr *TpReader) RemoveFromDatabase(verbose, disable_reverse bool) (err erro
 	if len(tpr.timings) != 0 {
 		loadIDs[utils.CacheTimings] = loadID
 	}
+	if len(tpr.exchangeRates) != 0 {
+		loadIDs[utils.CacheExchangeRates] = loadID
+	}
 	if err = tpr.dm.SetLoadIDs(loadIDs); err != nil {
 		return err
 	}
@@ -2387,6 +2536,7 @@ func (tpr *TpReader) ReloadCache(caching string, verbose bool, argDispatcher *ut
 	chargerIDs, _ := tpr.GetLoadedIds(utils.ChargerProfilePrefix)
 	dppIDs, _ := tpr.GetLoadedIds(utils.DispatcherProfilePrefix)
 	dphIDs, _ := tpr.GetLoadedIds(utils.DispatcherHostPrefix)
+	exrIDs, _ := tpr.GetLoadedIds(utils.ExchangeRatesPrefix)
 	aps, _ := tpr.GetLoadedIds(utils.ACTION_PLAN_PREFIX)
 
 	//compose Reload Cache argument
@@ -2415,6 +2565,7 @@ func (tpr *TpReader) ReloadCache(caching string, verbose bool, argDispatcher *ut
 				ChargerProfileIDs:     &chargerIDs,
 				DispatcherProfileIDs:  &dppIDs,
 				DispatcherHostIDs:     &dphIDs,
+				ExchangeRatesIDs:      &exrIDs,
 			},
 		},
 	}
@@ -72,7 +72,7 @@ func testInitDataDB(t *testing.T) {
 		cfg.DataDbCfg().DataDbHost, cfg.DataDbCfg().DataDbPort,
 		cfg.DataDbCfg().DataDbName, cfg.DataDbCfg().DataDbUser,
 		cfg.DataDbCfg().DataDbPass, cfg.GeneralCfg().DBDataEncoding,
-		"", cfg.DataDbCfg().Items)
+		"", false, 0, cfg.DataDbCfg().Items, cfg.DataDbCfg().InternalPersistence)
 	if err != nil {
 		log.Fatal(err)
 	}
@@ -84,7 +84,8 @@ func testInitDataDB(t *testing.T) {
 		cfg.StorDbCfg().Password, cfg.GeneralCfg().DBDataEncoding, cfg.StorDbCfg().SSLMode,
 		cfg.StorDbCfg().MaxOpenConns, cfg.StorDbCfg().MaxIdleConns,
 		cfg.StorDbCfg().ConnMaxLifetime, cfg.StorDbCfg().StringIndexedFields,
-		cfg.StorDbCfg().PrefixIndexedFields, cfg.StorDbCfg().Items)
+		cfg.StorDbCfg().PrefixIndexedFields, cfg.StorDbCfg().Items,
+		cfg.StorDbCfg().InternalPersistence)
 	if err != nil {
 		log.Fatal(err)
 	}
@@ -19,9 +19,12 @@ along with this program.  If not, see <http://www.gnu.org/licenses/>
 package ers
 
 import (
+	"encoding/json"
 	"fmt"
+	"net/url"
 	"sync"
 
+	"github.com/cgrates/cgrates/agents"
 	"github.com/cgrates/cgrates/config"
 	"github.com/cgrates/cgrates/engine"
 	"github.com/cgrates/cgrates/sessions"
@@ -32,10 +35,12 @@ import (
 type erEvent struct {
 	cgrEvent *utils.CGREvent
 	rdrCfg   *config.EventReaderCfg
+	rawEvent config.DataProvider // the record the event was built from, nil for merged events
 }
 
 // NewERService instantiates the ERService
-func NewERService(cfg *config.CGRConfig, filterS *engine.FilterS, stopChan chan struct{}, connMgr *engine.ConnManager) *ERService {
+func NewERService(cfg *config.CGRConfig, filterS *engine.FilterS, stopChan chan struct{},
+	connMgr *engine.ConnManager, httpRtr *HTTPRouter) *ERService {
 	return &ERService{
 		cfg:       cfg,
 		rdrs:      make(map[string]EventReader),
@@ -46,6 +51,7 @@ func NewERService(cfg *config.CGRConfig, filterS *engine.FilterS, stopChan chan
 		filterS:   filterS,
 		stopChan:  stopChan,
 		connMgr:   connMgr,
+		httpRtr:   httpRtr,
 	}
 }
 
@@ -62,10 +68,17 @@ type ERService struct {
 	filterS  *engine.FilterS
 	stopChan chan struct{}
 	connMgr  *engine.ConnManager
+	httpRtr  *HTTPRouter // dispatches the HTTP requests to the *http_push readers
 }
 
+// exportQueueLen is the number of read events waiting to be exported before new ones are dropped
+const exportQueueLen = 1024
+
 // ListenAndServe keeps the service alive
 func (erS *ERService) ListenAndServe(cfgRldChan chan struct{}) (err error) {
+	expEvs := make(chan *erEvent, exportQueueLen)
+	defer close(expEvs)
+	go erS.exportEvents(expEvs)
 	for cfgIdx, rdrCfg := range erS.cfg.ERsCfg().Readers {
 		if err = erS.addReader(rdrCfg.ID, cfgIdx); err != nil {
 			utils.Logger.Crit(
@@ -82,8 +95,17 @@ func (erS *ERService) ListenAndServe(cfgRldChan chan struct{}) (err error) {
 					utils.ERs, err.Error()))
 			return
 		case <-erS.stopChan:
+			erS.Lock()
+			for _, rdr := range erS.rdrs {
+				erS.removeHTTPReader(rdr)
+			}
+			erS.Unlock()
 			return
 		case erEv := <-erS.rdrEvents:
+			if len(erEv.rdrCfg.Exporters) != 0 { // mirror the event as read, before processing alters it
+				erS.queueExport(expEvs, &erEvent{cgrEvent: erEv.cgrEvent.Clone(),
+					rdrCfg: erEv.rdrCfg, rawEvent: erEv.rawEvent})
+			}
 			if err := erS.processEvent(erEv.cgrEvent, erEv.rdrCfg); err != nil {
 				utils.Logger.Warning(
 					fmt.Sprintf("<%s> reading event: <%s> got error: <%s>",
@@ -107,6 +129,7 @@ func (erS *ERService) ListenAndServe(cfgRldChan chan struct{}) (err error) {
 					}
 					pathReloaded[id] = struct{}{}
 				}
+				erS.removeHTTPReader(rdr)
 				delete(erS.rdrs, id)
 				close(erS.stopLsn[id])
 				delete(erS.stopLsn, id)
@@ -140,9 +163,22 @@ func (erS *ERService) addReader(rdrID string, cfgIdx int) (err error) {
 		return
 	}
 	erS.rdrs[rdrID] = rdr
+	if httpRdr, isHTTP := rdr.(*HTTPER); isHTTP {
+		if erS.httpRtr == nil {
+			return fmt.Errorf("no HTTP server available for reader <%s>", rdrID)
+		}
+		erS.httpRtr.addReader(httpRdr)
+	}
 	return rdr.Serve()
 }
 
+// removeHTTPReader stops the HTTP requests from reaching rdr
+func (erS *ERService) removeHTTPReader(rdr EventReader) {
+	if httpRdr, isHTTP := rdr.(*HTTPER); isHTTP && erS.httpRtr != nil {
+		erS.httpRtr.removeReader(httpRdr)
+	}
+}
+
 // processEvent will be called each time a new event is received from readers
 func (erS *ERService) processEvent(cgrEv *utils.CGREvent, rdrCfg *config.EventReaderCfg) (err error) {
 	// log the event created if requested by flags
@@ -283,3 +319,71 @@ func (erS *ERService) processEvent(cgrEv *utils.CGREvent, rdrCfg *config.EventRe
 
 	return
 }
+
+// queueExport passes the event to the exporters without blocking the reading,
+// dropping it when the export queue is full
+func (erS *ERService) queueExport(expEvs chan<- *erEvent, erEv *erEvent) {
+	select {
+	case expEvs <- erEv:
+	default:
+		utils.Logger.Warning(
+			fmt.Sprintf("<%s> export queue full, dropping event: <%s> from reader: <%s>",
+				utils.ERs, utils.ToIJSON(erEv.cgrEvent), erEv.rdrCfg.ID))
+	}
+}
+
+// exportEvents exports the queued events in the order they were read, until the queue is closed
+func (erS *ERService) exportEvents(expEvs <-chan *erEvent) {
+	for erEv := range expEvs {
+		erS.exportEvent(erEv)
+	}
+}
+
+// exportEvent sends the event towards all the exporters of the reader
+func (erS *ERService) exportEvent(erEv *erEvent) {
+	for _, expCfg := range erEv.rdrCfg.Exporters {
+		body, err := erS.exportBody(expCfg, erEv)
+		if err == nil {
+			attempts := expCfg.Attempts
+			if attempts == 0 {
+				attempts = erS.cfg.GeneralCfg().PosterAttempts
+			}
+			err = engine.PostEvent(erS.cfg.GeneralCfg(), expCfg.ExportPath, expCfg.Type,
+				utils.ERsPoster, attempts, body)
+		}
+		if err != nil {
+			utils.Logger.Warning(
+				fmt.Sprintf("<%s> reader: <%s> exporting event: <%s> with exporter: <%s> got error: <%s>",
+					utils.ERs, erEv.rdrCfg.ID, utils.ToJSON(erEv.cgrEvent), expCfg.ID, err.Error()))
+		}
+	}
+}
+
+// exportBody builds the content posted by the exporter
+// the exporter fields are applied on the raw record, without fields the mapped event is exported
+func (erS *ERService) exportBody(expCfg *config.EventExporterCfg, erEv *erEvent) (body interface{}, err error) {
+	expEv := erEv.cgrEvent.Event
+	if len(expCfg.Fields) != 0 {
+		rawEv := erEv.rawEvent
+		if rawEv == nil { // merged events do not have a raw record
+			rawEv = config.NewNavigableMap(erEv.cgrEvent.Event)
+		}
+		agReq := agents.NewAgentRequest(
+			rawEv, nil, nil, nil, nil, erEv.cgrEvent.Tenant,
+			utils.FirstNonEmpty(erEv.rdrCfg.Timezone,
+				erS.cfg.GeneralCfg().DefaultTimezone),
+			erS.filterS, nil, nil)
+		if err = agReq.SetFields(expCfg.Fields); err != nil {
+			return
+		}
+		expEv = agReq.CGRRequest.AsCGREvent(agReq.Tenant, utils.NestingSep).Event
+	}
+	if expCfg.Type == utils.MetaHTTPPost {
+		vals := url.Values{}
+		for fld, val := range expEv {
+			vals.Set(fld, utils.IfaceAsString(val))
+		}
+		return vals, nil
+	}
+	return json.Marshal(expEv)
+}
@@ -19,7 +19,11 @@ along with this program.  If not, see <http://www.gnu.org/licenses/>
 package ers
 
 import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
 	"reflect"
+	"sync"
 	"testing"
 	"time"
 
@@ -39,7 +43,7 @@ func TestERsNewERService(t *testing.T) {
 		rdrEvents: make(chan *erEvent),
 		rdrErr:    make(chan error),
 		stopChan:  nil}
-	rcv := NewERService(cfg, fltrS, nil, nil)
+	rcv := NewERService(cfg, fltrS, nil, nil, nil)
 
 	if !reflect.DeepEqual(expected.cfg, rcv.cfg) {
 		t.Errorf("Expecting: <%+v>, received: <%+v>", expected.cfg, rcv.cfg)
@@ -51,7 +55,7 @@ func TestERsNewERService(t *testing.T) {
 func TestERsAddReader(t *testing.T) {
 	cfg, _ := config.NewDefaultCGRConfig()
 	fltrS := &engine.FilterS{}
-	erS := NewERService(cfg, fltrS, nil, nil)
+	erS := NewERService(cfg, fltrS, nil, nil, nil)
 	reader := cfg.ERsCfg().Readers[0]
 	reader.Type = utils.MetaFileCSV
 	reader.ID = "file_reader"
@@ -68,3 +72,96 @@ func TestERsAddReader(t *testing.T) {
 		t.Errorf("Expecting: <%+v>, received: <%+v>", reader, erS.rdrs["file_reader"].Config())
 	}
 }
+
+func TestERsExportEvent(t *testing.T) {
+	var mux sync.Mutex
+	bodies := make(map[string]string) // map[path]body
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		mux.Lock()
+		bodies[r.URL.Path] = string(body)
+		mux.Unlock()
+	}))
+	defer srv.Close()
+	cfg, _ := config.NewDefaultCGRConfig()
+	erS := NewERService(cfg, &engine.FilterS{}, nil, nil, nil)
+	rdrCfg := cfg.ERsCfg().Readers[0]
+	rdrCfg.Exporters = []*config.EventExporterCfg{
+		{ID: "json", Type: utils.MetaHTTPjsonMap, ExportPath: srv.URL + "/json", Attempts: 1},
+		{ID: "form", Type: utils.MetaHTTPPost, ExportPath: srv.URL + "/form",
+			Fields: []*config.FCTemplate{
+				{Tag: utils.OriginID, Path: utils.MetaCgreq + utils.NestingSep + utils.OriginID, Type: utils.MetaVariable,
+					Value: config.NewRSRParsersMustCompile("~*req.1", true, utils.INFIELD_SEP)},
+			}},
+	}
+	erS.exportEvent(&erEvent{
+		cgrEvent: &utils.CGREvent{
+			Tenant: "cgrates.org",
+			ID:     "ev1",
+			Event:  map[string]interface{}{utils.Account: "1001"},
+		},
+		rdrCfg:   rdrCfg,
+		rawEvent: config.NewSliceDP([]string{"1001", "orig1"}),
+	})
+	eBodies := map[string]string{
+		"/json": `{"Account":"1001"}`,
+		"/form": "OriginID=orig1",
+	}
+	if !reflect.DeepEqual(eBodies, bodies) {
+		t.Errorf("Expecting: %+v, received: %+v", eBodies, bodies)
+	}
+}
+
+func TestERsExportEventsOrdered(t *testing.T) {
+	var mux sync.Mutex
+	var received []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		mux.Lock()
+		received = append(received, string(body))
+		mux.Unlock()
+	}))
+	defer srv.Close()
+	cfg, _ := config.NewDefaultCGRConfig()
+	erS := NewERService(cfg, &engine.FilterS{}, nil, nil, nil)
+	rdrCfg := cfg.ERsCfg().Readers[0]
+	rdrCfg.Exporters = []*config.EventExporterCfg{
+		{ID: "json", Type: utils.MetaHTTPjsonMap, ExportPath: srv.URL, Attempts: 1},
+	}
+	expEvs := make(chan *erEvent, 3)
+	for _, acnt := range []string{"1001", "1002", "1003"} {
+		expEvs <- &erEvent{
+			cgrEvent: &utils.CGREvent{Tenant: "cgrates.org",
+				Event: map[string]interface{}{utils.Account: acnt}},
+			rdrCfg: rdrCfg,
+		}
+	}
+	close(expEvs)
+	erS.exportEvents(expEvs)
+	eReceived := []string{`{"Account":"1001"}`, `{"Account":"1002"}`, `{"Account":"1003"}`}
+	if !reflect.DeepEqual(eReceived, received) {
+		t.Errorf("Expecting: %+v, received: %+v", eReceived, received)
+	}
+}
+
+func TestERsQueueExportFull(t *testing.T) {
+	cfg, _ := config.NewDefaultCGRConfig()
+	erS := NewERService(cfg, &engine.FilterS{}, nil, nil, nil)
+	rdrCfg := cfg.ERsCfg().Readers[0]
+	expEvs := make(chan *erEvent, 1)
+	for _, acnt := range []string{"1001", "1002"} {
+		erS.queueExport(expEvs, &erEvent{
+			cgrEvent: &utils.CGREvent{Tenant: "cgrates.org",
+				Event: map[string]interface{}{utils.Account: acnt}},
+			rdrCfg: rdrCfg,
+		})
+	}
+	close(expEvs)
+	var queued []interface{}
+	for erEv := range expEvs {
+		queued = append(queued, erEv.cgrEvent.Event[utils.Account])
+	}
+	if eQueued := []interface{}{"1001"}; !reflect.DeepEqual(eQueued, queued) {
+		t.Errorf("Expecting: %+v, received: %+v", eQueued, queued)
+	}
+}
@@ -168,7 +168,7 @@ func (rdr *CSVFileER) processFile(fPath, fName string) (err error) {
 		}
 		rdr.rdrEvents <- &erEvent{cgrEvent: agReq.CGRRequest.AsCGREvent(
 			agReq.Tenant, utils.NestingSep),
-			rdrCfg: rdr.Config()}
+			rdrCfg: rdr.Config(), rawEvent: agReq.Request}
 		evsPosted++
 	}
 	if rdr.Config().ProcessedPath != "" {
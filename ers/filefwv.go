@@ -212,7 +212,7 @@ func (rdr *FWVFileER) processFile(fPath, fName string) (err error) {
 		rdr.offset += rdr.lineLen // increase the offset
 		rdr.rdrEvents <- &erEvent{cgrEvent: agReq.CGRRequest.AsCGREvent(
 			agReq.Tenant, utils.NestingSep),
-			rdrCfg: rdr.Config()}
+			rdrCfg: rdr.Config(), rawEvent: agReq.Request}
 		evsPosted++
 
 	}
@@ -299,7 +299,7 @@ func (rdr *FWVFileER) processTrailer(file *os.File, rowNr, evsPosted int, absPat
 	}
 	rdr.rdrEvents <- &erEvent{cgrEvent: agReq.CGRRequest.AsCGREvent(
 		agReq.Tenant, utils.NestingSep),
-		rdrCfg: rdr.Config()}
+		rdrCfg: rdr.Config(), rawEvent: agReq.Request}
 	evsPosted++
 	// reset the cursor after process the trailer
 	_, err = file.Seek(0, 0)
@@ -340,7 +340,7 @@ func (rdr *FWVFileER) createHeaderMap(record string, rowNr, evsPosted int, absPa
 	rdr.offset += rdr.headerOffset // increase the offset
 	rdr.rdrEvents <- &erEvent{cgrEvent: agReq.CGRRequest.AsCGREvent(
 		agReq.Tenant, utils.NestingSep),
-		rdrCfg: rdr.Config()}
+		rdrCfg: rdr.Config(), rawEvent: agReq.Request}
 	evsPosted++
 	return
 }
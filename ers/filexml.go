@@ -159,7 +159,7 @@ func (rdr *XMLFileER) processFile(fPath, fName string) (err error) {
 		}
 		rdr.rdrEvents <- &erEvent{cgrEvent: agReq.CGRRequest.AsCGREvent(
 			agReq.Tenant, utils.NestingSep),
-			rdrCfg: rdr.Config()}
+			rdrCfg: rdr.Config(), rawEvent: agReq.Request}
 		evsPosted++
 	}
 
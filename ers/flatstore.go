@@ -208,7 +208,7 @@ func (rdr *FlatstoreER) processFile(fPath, fName string) (err error) {
 
 		rdr.rdrEvents <- &erEvent{cgrEvent: agReq.CGRRequest.AsCGREvent(
 			agReq.Tenant, utils.NestingSep),
-			rdrCfg: rdr.Config()}
+			rdrCfg: rdr.Config(), rawEvent: agReq.Request}
 		evsPosted++
 	}
 	if rdr.Config().ProcessedPath != "" {
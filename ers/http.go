@@ -0,0 +1,258 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+package ers
+
+import (
+	"encoding/json"
+	"fmt"
+	"mime"
+	"net/http"
+	"net/url"
+	"strconv"
+	"sync"
+
+	"github.com/antchfx/xmlquery"
+	"github.com/cgrates/cgrates/agents"
+	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/cgrates/engine"
+	"github.com/cgrates/cgrates/utils"
+)
+
+// NewHTTPER return a new HTTP event reader
+func NewHTTPER(cfg *config.CGRConfig, cfgIdx int,
+	rdrEvents chan *erEvent, rdrErr chan error,
+	fltrS *engine.FilterS, rdrExit chan struct{}) (er EventReader, err error) {
+	rdr := &HTTPER{
+		cgrCfg:          cfg,
+		cfgIdx:          cfgIdx,
+		fltrS:           fltrS,
+		rdrEvents:       rdrEvents,
+		rdrExit:         rdrExit,
+		rdrErr:          rdrErr,
+		rplyCode:        http.StatusOK,
+		rplyContentType: "text/plain",
+	}
+	if concReq := rdr.Config().ConcurrentReqs; concReq != -1 {
+		rdr.cap = make(chan struct{}, concReq)
+		for i := 0; i < concReq; i++ {
+			rdr.cap <- struct{}{}
+		}
+	}
+	err = rdr.setURL(rdr.Config().SourcePath)
+	return rdr, err
+}
+
+// HTTPER implements EventReader interface for events pushed over HTTP
+type HTTPER struct {
+	cgrCfg *config.CGRConfig
+	cfgIdx int // index of config instance within ERsCfg.Readers
+	fltrS  *engine.FilterS
+
+	path            string
+	rplyCode        int
+	rplyContentType string
+	rplyBody        string
+
+	rdrEvents chan *erEvent // channel to dispatch the events created to
+	rdrExit   chan struct{}
+	rdrErr    chan error
+	cap       chan struct{}
+}
+
+// Config returns the curent configuration
+func (rdr *HTTPER) Config() *config.EventReaderCfg {
+	return rdr.cgrCfg.ERsCfg().Readers[rdr.cfgIdx]
+}
+
+// Serve will start the gorutines needed to watch the HTTP requests
+// the handler is registered on the server by the ERService
+func (rdr *HTTPER) Serve() (err error) {
+	return
+}
+
+// ServeHTTP implements http.Handler interface
+func (rdr *HTTPER) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	if rdr.Config().ConcurrentReqs != -1 {
+		<-rdr.cap // do not try to read if the limit is reached
+		defer func() { rdr.cap <- struct{}{} }()
+	}
+	dPs, code, err := rdr.newDataProviders(req)
+	if err != nil {
+		utils.Logger.Warning(
+			fmt.Sprintf("<%s> decoding request from <%s>, error: <%s>",
+				utils.ERs, req.RemoteAddr, err.Error()))
+		http.Error(w, err.Error(), code)
+		return
+	}
+	reqVars := map[string]interface{}{utils.RemoteHost: req.RemoteAddr}
+	for _, dP := range dPs {
+		if code, err = rdr.processMessage(dP, reqVars); err != nil {
+			utils.Logger.Warning(
+				fmt.Sprintf("<%s> processing request from <%s>, error: <%s>",
+					utils.ERs, req.RemoteAddr, err.Error()))
+			http.Error(w, err.Error(), code)
+			return
+		}
+	}
+	w.Header().Set("Content-Type", rdr.rplyContentType)
+	w.WriteHeader(rdr.rplyCode)
+	w.Write([]byte(rdr.rplyBody))
+}
+
+// processMessage dispatches the event built out of dP, returning the HTTP code in case of error
+func (rdr *HTTPER) processMessage(dP config.DataProvider, reqVars map[string]interface{}) (code int, err error) {
+	agReq := agents.NewAgentRequest(
+		dP, reqVars, nil, nil, rdr.Config().Tenant,
+		rdr.cgrCfg.GeneralCfg().DefaultTenant,
+		utils.FirstNonEmpty(rdr.Config().Timezone,
+			rdr.cgrCfg.GeneralCfg().DefaultTimezone),
+		rdr.fltrS, nil, nil) // create an AgentRequest
+	var pass bool
+	if pass, err = rdr.fltrS.Pass(agReq.Tenant, rdr.Config().Filters,
+		agReq); err != nil {
+		return http.StatusInternalServerError, err
+	} else if !pass {
+		return
+	}
+	if err = agReq.SetFields(rdr.Config().Fields); err != nil {
+		return http.StatusBadRequest, err
+	}
+	select {
+	case rdr.rdrEvents <- &erEvent{cgrEvent: agReq.CGRRequest.AsCGREvent(
+		agReq.Tenant, utils.NestingSep),
+		rdrCfg: rdr.Config(), rawEvent: agReq.Request}:
+	case <-rdr.rdrExit:
+		return http.StatusServiceUnavailable, fmt.Errorf("reader <%s> stopped", rdr.Config().ID)
+	}
+	return
+}
+
+// newDataProviders decodes the request body based on its content type
+// XML bodies may contain multiple events selected by the XmlRootPath
+func (rdr *HTTPER) newDataProviders(req *http.Request) (dPs []config.DataProvider, code int, err error) {
+	var mediaType string
+	if cType := req.Header.Get("Content-Type"); cType != utils.EmptyString {
+		if mediaType, _, err = mime.ParseMediaType(cType); err != nil {
+			return nil, http.StatusUnsupportedMediaType, err
+		}
+	}
+	switch mediaType {
+	default:
+		return nil, http.StatusUnsupportedMediaType,
+			fmt.Errorf("unsupported content type <%s>", mediaType)
+	case "application/json":
+		var data map[string]interface{}
+		if err = json.NewDecoder(req.Body).Decode(&data); err != nil {
+			return nil, http.StatusBadRequest, err
+		}
+		dPs = []config.DataProvider{config.NewNavigableMap(data)}
+	case "application/xml", "text/xml":
+		var doc *xmlquery.Node
+		if doc, err = xmlquery.Parse(req.Body); err != nil {
+			return nil, http.StatusBadRequest, err
+		}
+		for _, xmlElmt := range xmlquery.Find(doc, rdr.Config().XmlRootPath.AsString("/", true)) {
+			dPs = append(dPs, config.NewXmlProvider(xmlElmt, rdr.Config().XmlRootPath))
+		}
+	case utils.EmptyString, "application/x-www-form-urlencoded", "multipart/form-data":
+		if mediaType == "multipart/form-data" {
+			err = req.ParseMultipartForm(32 << 20)
+		} else {
+			err = req.ParseForm()
+		}
+		if err != nil {
+			return nil, http.StatusBadRequest, err
+		}
+		data := make(map[string]interface{})
+		for key, vals := range req.Form {
+			data[key] = vals[0]
+		}
+		dPs = []config.DataProvider{config.NewNavigableMap(data)}
+	}
+	return
+}
+
+// setURL populates the path and the reply options out of the reader's source path
+func (rdr *HTTPER) setURL(srcPath string) (err error) {
+	var u *url.URL
+	if u, err = url.Parse(srcPath); err != nil {
+		return
+	}
+	rdr.path = u.Path
+	qry := u.Query()
+	if vals, has := qry[utils.HTTPReplyCode]; has && len(vals) != 0 {
+		if rdr.rplyCode, err = strconv.Atoi(vals[0]); err != nil {
+			return
+		}
+	}
+	if vals, has := qry[utils.HTTPReplyContentType]; has && len(vals) != 0 {
+		rdr.rplyContentType = vals[0]
+	}
+	if vals, has := qry[utils.HTTPReplyBody]; has && len(vals) != 0 {
+		rdr.rplyBody = vals[0]
+	}
+	return
+}
+
+// NewHTTPRouter returns a HTTPRouter registering the paths on server
+func NewHTTPRouter(server *utils.Server) *HTTPRouter {
+	return &HTTPRouter{
+		server: server,
+		rdrs:   make(map[string]*HTTPER),
+	}
+}
+
+// HTTPRouter dispatches the HTTP requests to the reader listening on the path
+// since the handlers can not be removed from the server each path is registered only once
+// and the router should outlive the ERService so it can be restarted
+type HTTPRouter struct {
+	sync.RWMutex
+	server *utils.Server
+	rdrs   map[string]*HTTPER // map[path]*HTTPER, nil for paths without reader
+}
+
+// addReader starts dispatching the requests for rdr.path to rdr
+func (hr *HTTPRouter) addReader(rdr *HTTPER) {
+	hr.Lock()
+	defer hr.Unlock()
+	_, registered := hr.rdrs[rdr.path]
+	hr.rdrs[rdr.path] = rdr
+	if registered {
+		return
+	}
+	path := rdr.path
+	hr.server.RegisterHttpFunc(path, func(w http.ResponseWriter, req *http.Request) {
+		hr.RLock()
+		pathRdr := hr.rdrs[path]
+		hr.RUnlock()
+		if pathRdr == nil {
+			http.NotFound(w, req)
+			return
+		}
+		pathRdr.ServeHTTP(w, req)
+	})
+}
+
+// removeReader stops dispatching the requests to rdr
+func (hr *HTTPRouter) removeReader(rdr *HTTPER) {
+	hr.Lock()
+	if hr.rdrs[rdr.path] == rdr {
+		hr.rdrs[rdr.path] = nil
+	}
+	hr.Unlock()
+}
@@ -0,0 +1,161 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+package ers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/cgrates/engine"
+	"github.com/cgrates/cgrates/utils"
+)
+
+func newTestHTTPER(t *testing.T, srcPath, fldPath string,
+	rdrEvents chan *erEvent, rdrExit chan struct{}) *HTTPER {
+	cfg, _ := config.NewDefaultCGRConfig()
+	rdrCfg := cfg.ERsCfg().Readers[0]
+	rdrCfg.Type = utils.MetaHTTPPush
+	rdrCfg.SourcePath = srcPath
+	rdrCfg.ConcurrentReqs = 1
+	rdrCfg.XmlRootPath = utils.ParseHierarchyPath("cdrs.cdr", utils.EmptyString)
+	rdrCfg.Fields = []*config.FCTemplate{
+		{Tag: utils.OriginID, Path: utils.MetaCgreq + utils.NestingSep + utils.OriginID, Type: utils.MetaVariable,
+			Value: config.NewRSRParsersMustCompile(fldPath, true, utils.INFIELD_SEP), Mandatory: true},
+	}
+	rdr, err := NewHTTPER(cfg, 0, rdrEvents, nil, new(engine.FilterS), rdrExit)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return rdr.(*HTTPER)
+}
+
+func TestHTTPERSetURL(t *testing.T) {
+	rdr := &HTTPER{rplyCode: http.StatusOK, rplyContentType: "text/plain"}
+	if err := rdr.setURL("/cdrs?reply_code=202&reply_content_type=application/json&reply_body={}"); err != nil {
+		t.Fatal(err)
+	}
+	if rdr.path != "/cdrs" {
+		t.Errorf("Expected path: %q, received: %q", "/cdrs", rdr.path)
+	}
+	if rdr.rplyCode != http.StatusAccepted {
+		t.Errorf("Expected code: %v, received: %v", http.StatusAccepted, rdr.rplyCode)
+	}
+	if rdr.rplyContentType != "application/json" {
+		t.Errorf("Expected content type: %q, received: %q", "application/json", rdr.rplyContentType)
+	}
+	if rdr.rplyBody != "{}" {
+		t.Errorf("Expected body: %q, received: %q", "{}", rdr.rplyBody)
+	}
+	if err := rdr.setURL("/cdrs?reply_code=OK"); err == nil {
+		t.Error("Expected error for invalid reply code")
+	}
+}
+
+func TestHTTPERServeHTTP(t *testing.T) {
+	rdrEvents := make(chan *erEvent, 10)
+	rdr := newTestHTTPER(t, "/cdrs?reply_code=202&reply_body=OK", "~*req.OriginID", rdrEvents, nil)
+	xmlRdr := newTestHTTPER(t, "/cdrs", "~*req.cdrs.cdr.OriginID", rdrEvents, nil)
+	for _, tc := range []struct {
+		rdr      *HTTPER
+		cType    string
+		body     string
+		code     int
+		rplyBody string
+		evs      []string
+	}{
+		{rdr: rdr, cType: "application/json", body: `{"OriginID":"json1"}`,
+			code: http.StatusAccepted, rplyBody: "OK", evs: []string{"json1"}},
+		{rdr: rdr, cType: "application/x-www-form-urlencoded",
+			body: url.Values{utils.OriginID: []string{"form1"}}.Encode(),
+			code: http.StatusAccepted, rplyBody: "OK", evs: []string{"form1"}},
+		{rdr: xmlRdr, cType: "application/xml; charset=utf-8",
+			body: `<cdrs><cdr><OriginID>xml1</OriginID></cdr><cdr><OriginID>xml2</OriginID></cdr></cdrs>`,
+			code: http.StatusOK, evs: []string{"xml1", "xml2"}},
+		{rdr: rdr, cType: "application/json", body: `{"OriginID":`,
+			code: http.StatusBadRequest},
+		{rdr: rdr, cType: "application/json", body: `{"Account":"1001"}`,
+			code: http.StatusBadRequest},
+		{rdr: rdr, cType: "text/csv", body: "json1",
+			code: http.StatusUnsupportedMediaType},
+	} {
+		req := httptest.NewRequest(http.MethodPost, "/cdrs", strings.NewReader(tc.body))
+		req.Header.Set("Content-Type", tc.cType)
+		w := httptest.NewRecorder()
+		tc.rdr.ServeHTTP(w, req)
+		if w.Code != tc.code {
+			t.Errorf("For %q expected code: %v, received: %v", tc.body, tc.code, w.Code)
+		}
+		if tc.rplyBody != utils.EmptyString && w.Body.String() != tc.rplyBody {
+			t.Errorf("For %q expected reply: %q, received: %q", tc.body, tc.rplyBody, w.Body.String())
+		}
+		for _, expID := range tc.evs {
+			select {
+			case ev := <-rdrEvents:
+				if rcv := ev.cgrEvent.Event[utils.OriginID]; rcv != expID {
+					t.Errorf("Expected %s: %q, received: %v", utils.OriginID, expID, rcv)
+				}
+			default:
+				t.Errorf("For %q expected event with %s: %q", tc.body, utils.OriginID, expID)
+			}
+		}
+		if len(rdrEvents) != 0 {
+			t.Errorf("For %q received unexpected events: %v", tc.body, len(rdrEvents))
+		}
+	}
+}
+
+func TestHTTPERServeHTTPStopped(t *testing.T) {
+	rdrExit := make(chan struct{})
+	close(rdrExit)
+	rdr := newTestHTTPER(t, "/cdrs", "~*req.OriginID", make(chan *erEvent), rdrExit)
+	req := httptest.NewRequest(http.MethodPost, "/cdrs", strings.NewReader(`{"OriginID":"json1"}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	rdr.ServeHTTP(w, req)
+	if w.Code != http.StatusServiceUnavailable {
+		t.Errorf("Expected code: %v, received: %v", http.StatusServiceUnavailable, w.Code)
+	}
+}
+
+func TestHTTPRouter(t *testing.T) {
+	hr := NewHTTPRouter(utils.NewServer())
+	rdrEvents := make(chan *erEvent, 1)
+	rdr1 := newTestHTTPER(t, "/cdrs", "~*req.OriginID", rdrEvents, nil)
+	rdr2 := newTestHTTPER(t, "/cdrs", "~*req.OriginID", rdrEvents, nil)
+	hr.addReader(rdr1)
+	hr.addReader(rdr2) // same path should not register the handler twice
+	if hr.rdrs["/cdrs"] != rdr2 {
+		t.Errorf("Expected reader: %p, received: %p", rdr2, hr.rdrs["/cdrs"])
+	}
+	hr.removeReader(rdr1) // replaced already, should not remove rdr2
+	if hr.rdrs["/cdrs"] != rdr2 {
+		t.Errorf("Expected reader: %p, received: %p", rdr2, hr.rdrs["/cdrs"])
+	}
+	hr.removeReader(rdr2)
+	if rdr, has := hr.rdrs["/cdrs"]; !has || rdr != nil {
+		t.Errorf("Expected registered path without reader, received: %v, %v", has, rdr)
+	}
+	hr.addReader(rdr1)
+	if hr.rdrs["/cdrs"] != rdr1 {
+		t.Errorf("Expected reader: %p, received: %p", rdr1, hr.rdrs["/cdrs"])
+	}
+}
@@ -176,7 +176,7 @@ func (rdr *KafkaER) processMessage(msg []byte) (err error) {
 	}
 	rdr.rdrEvents <- &erEvent{cgrEvent: agReq.CGRRequest.AsCGREvent(
 		agReq.Tenant, utils.NestingSep),
-		rdrCfg: rdr.Config()}
+		rdrCfg: rdr.Config(), rawEvent: agReq.Request}
 	return
 }
 
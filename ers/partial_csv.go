@@ -202,7 +202,7 @@ func (rdr *PartialCSVFileER) processFile(fPath, fName string) (err error) {
 		if val, has := rdr.cache.Get(cgrID); !has {
 			if utils.IsSliceMember([]string{"false", utils.EmptyString}, partial) { // complete CDR
 				rdr.rdrEvents <- &erEvent{cgrEvent: agReq.CGRRequest.AsCGREvent(agReq.Tenant, utils.NestingSep),
-					rdrCfg: rdr.Config()}
+					rdrCfg: rdr.Config(), rawEvent: agReq.Request}
 				evsPosted++
 			} else {
 				rdr.cache.Set(cgrID,
@@ -0,0 +1,432 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package ers
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/url"
+	"path"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/cgrates/cgrates/agents"
+	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/cgrates/engine"
+	"github.com/cgrates/cgrates/utils"
+)
+
+// NewS3ER return a new s3 event reader
+// "http://s3.us-east-2.amazonaws.com/?aws_region=us-east-2&aws_key=testkey&aws_secret=testsecret&queue_id=cdrs_bucket&folder_path=in"
+func NewS3ER(cfg *config.CGRConfig, cfgIdx int,
+	rdrEvents chan *erEvent, rdrErr chan error,
+	fltrS *engine.FilterS, rdrExit chan struct{}) (er EventReader, err error) {
+	rdr := &S3ER{
+		cgrCfg:    cfg,
+		cfgIdx:    cfgIdx,
+		fltrS:     fltrS,
+		rdrEvents: rdrEvents,
+		rdrExit:   rdrExit,
+		rdrErr:    rdrErr,
+		tagged:    utils.NewStringSet(nil),
+	}
+	if concReq := rdr.Config().ConcurrentReqs; concReq > 0 {
+		rdr.conReqs = make(chan struct{}, concReq)
+		for i := 0; i < concReq; i++ {
+			rdr.conReqs <- struct{}{} // Empty initiate so we do not need to wait later when we pop
+		}
+	}
+	er = rdr
+	err = rdr.setURL(rdr.Config().SourcePath)
+	return
+}
+
+// S3ER implements EventReader interface for the objects in a s3 bucket
+type S3ER struct {
+	cgrCfg *config.CGRConfig
+	cfgIdx int // index of config instance within ERsCfg.Readers
+	fltrS  *engine.FilterS
+
+	awsOpts         *engine.AWSOptions
+	bucket          string
+	folderPath      string // only the objects with this prefix are read
+	processedAction string // what to do with the object after processing: <*move|*remove|*tag>
+
+	taggedLk sync.Mutex
+	tagged   *utils.StringSet // objects known to be tagged so their tags are not read on each poll
+
+	rdrEvents chan *erEvent // channel to dispatch the events created to
+	rdrExit   chan struct{}
+	rdrErr    chan error
+	conReqs   chan struct{} // limit number of objects processed in parallel
+}
+
+// Config returns the curent configuration
+func (rdr *S3ER) Config() *config.EventReaderCfg {
+	return rdr.cgrCfg.ERsCfg().Readers[rdr.cfgIdx]
+}
+
+// Serve will start the gorutine polling the bucket
+func (rdr *S3ER) Serve() (err error) {
+	if rdr.Config().RunDelay == time.Duration(0) { // 0 disables the automatic read, maybe done per API
+		return
+	}
+	var svc *s3.S3
+	if svc, err = rdr.newS3Client(); err != nil {
+		return
+	}
+	go func() {
+		for {
+			select {
+			case <-rdr.rdrExit:
+				utils.Logger.Info(
+					fmt.Sprintf("<%s> stop monitoring s3 bucket <%s>",
+						utils.ERs, rdr.bucket))
+				return
+			default:
+			}
+			if err := rdr.readBucket(svc); err != nil {
+				rdr.rdrErr <- err
+				return
+			}
+			time.Sleep(rdr.Config().RunDelay)
+		}
+	}()
+	return
+}
+
+// readBucket processes all the objects found in the bucket
+// and waits for them to finish so they are not picked again by the next read
+func (rdr *S3ER) readBucket(svc *s3.S3) (err error) {
+	var keys []string
+	if err = svc.ListObjectsV2Pages(&s3.ListObjectsV2Input{
+		Bucket: aws.String(rdr.bucket),
+		Prefix: aws.String(rdr.folderPath),
+	}, func(page *s3.ListObjectsV2Output, lastPage bool) bool {
+		for _, obj := range page.Contents {
+			if key := aws.StringValue(obj.Key); rdr.isReadable(key) {
+				keys = append(keys, key)
+			}
+		}
+		return true
+	}); err != nil {
+		return
+	}
+	if rdr.processedAction == utils.MetaTag {
+		rdr.pruneTagged(keys)
+	}
+	var wg sync.WaitGroup
+	for _, key := range keys {
+		if rdr.processedAction == utils.MetaTag {
+			if processed, err := rdr.isTagged(svc, key); err != nil {
+				utils.Logger.Warning(
+					fmt.Sprintf("<%s> reading tags for object %s, error: %s",
+						utils.ERs, key, err.Error()))
+				continue
+			} else if processed {
+				continue
+			}
+		}
+		if rdr.conReqs != nil {
+			<-rdr.conReqs
+		}
+		wg.Add(1)
+		go func(key string) {
+			if err := rdr.processObject(svc, key); err != nil {
+				utils.Logger.Warning(
+					fmt.Sprintf("<%s> processing object %s, error: %s",
+						utils.ERs, key, err.Error()))
+			}
+			if rdr.conReqs != nil {
+				rdr.conReqs <- struct{}{}
+			}
+			wg.Done()
+		}(key)
+	}
+	wg.Wait()
+	return
+}
+
+// isReadable filters the objects based on the reader type
+func (rdr *S3ER) isReadable(key string) bool {
+	if rdr.processedAction == utils.MetaMove &&
+		strings.HasPrefix(key, rdr.Config().ProcessedPath) { // already moved
+		return false
+	}
+	switch rdr.Config().Type {
+	case utils.MetaS3CSV:
+		return strings.HasSuffix(key, utils.CSVSuffix)
+	default:
+		return strings.HasSuffix(key, utils.JSNSuffix)
+	}
+}
+
+// pruneTagged forgets the tagged objects which are no longer in the bucket
+func (rdr *S3ER) pruneTagged(keys []string) {
+	listed := utils.NewStringSet(keys)
+	rdr.taggedLk.Lock()
+	for key := range rdr.tagged.Data() {
+		if !listed.Has(key) {
+			rdr.tagged.Remove(key)
+		}
+	}
+	rdr.taggedLk.Unlock()
+}
+
+// setTagged remembers the object as processed
+func (rdr *S3ER) setTagged(key string) {
+	rdr.taggedLk.Lock()
+	rdr.tagged.Add(key)
+	rdr.taggedLk.Unlock()
+}
+
+// isTagged checks if the object was already processed
+// the tags are queried only for the objects not known as tagged
+func (rdr *S3ER) isTagged(svc *s3.S3, key string) (tagged bool, err error) {
+	rdr.taggedLk.Lock()
+	tagged = rdr.tagged.Has(key)
+	rdr.taggedLk.Unlock()
+	if tagged {
+		return
+	}
+	var tags []*s3.Tag
+	if tags, err = rdr.getTags(svc, key); err != nil {
+		return
+	}
+	for _, tag := range tags {
+		if aws.StringValue(tag.Key) == utils.S3ProcessedTag {
+			rdr.setTagged(key)
+			return true, nil
+		}
+	}
+	return
+}
+
+// getTags returns the tags of the object
+func (rdr *S3ER) getTags(svc *s3.S3, key string) (tags []*s3.Tag, err error) {
+	var out *s3.GetObjectTaggingOutput
+	if out, err = svc.GetObjectTagging(&s3.GetObjectTaggingInput{
+		Bucket: aws.String(rdr.bucket),
+		Key:    aws.String(key),
+	}); err != nil {
+		return
+	}
+	return out.TagSet, nil
+}
+
+// processObject dispatches the erEvents from one object
+func (rdr *S3ER) processObject(svc *s3.S3, key string) (err error) {
+	utils.Logger.Info(
+		fmt.Sprintf("<%s> parsing <%s/%s>", utils.ERs, rdr.bucket, key))
+	var obj *s3.GetObjectOutput
+	if obj, err = svc.GetObject(&s3.GetObjectInput{
+		Bucket: aws.String(rdr.bucket),
+		Key:    aws.String(key),
+	}); err != nil {
+		return
+	}
+	var body []byte
+	body, err = ioutil.ReadAll(obj.Body)
+	obj.Body.Close()
+	if err != nil {
+		return
+	}
+	timeStart := time.Now()
+	reqVars := map[string]interface{}{utils.FileName: key}
+	var rowNr, evsPosted int
+	if rdr.Config().Type == utils.MetaS3CSV {
+		rowNr, evsPosted, err = rdr.processCSV(key, body, reqVars)
+	} else {
+		rowNr, evsPosted, err = rdr.processJSON(key, body, reqVars)
+	}
+	if err != nil {
+		return
+	}
+	if err = rdr.markProcessed(svc, key); err != nil {
+		return
+	}
+	utils.Logger.Info(
+		fmt.Sprintf("%s finished processing object <%s/%s>. Total records processed: %d, events posted: %d, run duration: %s",
+			utils.ERs, rdr.bucket, key, rowNr, evsPosted, time.Now().Sub(timeStart)))
+	return
+}
+
+func (rdr *S3ER) processJSON(key string, body []byte,
+	reqVars map[string]interface{}) (rowNr, evsPosted int, err error) {
+	var data map[string]interface{}
+	if err = json.Unmarshal(body, &data); err != nil {
+		return
+	}
+	rowNr++
+	agReq := agents.NewAgentRequest(
+		config.NewNavigableMap(data), reqVars, nil, nil, rdr.Config().Tenant,
+		rdr.cgrCfg.GeneralCfg().DefaultTenant,
+		utils.FirstNonEmpty(rdr.Config().Timezone,
+			rdr.cgrCfg.GeneralCfg().DefaultTimezone),
+		rdr.fltrS, nil, nil) // create an AgentRequest
+	if pass, err := rdr.fltrS.Pass(agReq.Tenant, rdr.Config().Filters,
+		agReq); err != nil || !pass {
+		return rowNr, evsPosted, err
+	}
+	if err := agReq.SetFields(rdr.Config().Fields); err != nil {
+		utils.Logger.Warning(
+			fmt.Sprintf("<%s> reading object: <%s/%s> ignoring due to error: <%s>",
+				utils.ERs, rdr.bucket, key, err.Error()))
+		return rowNr, evsPosted, nil
+	}
+	rdr.rdrEvents <- &erEvent{cgrEvent: agReq.CGRRequest.AsCGREvent(
+		agReq.Tenant, utils.NestingSep),
+		rdrCfg: rdr.Config(), rawEvent: agReq.Request}
+	evsPosted++
+	return
+}
+
+func (rdr *S3ER) processCSV(key string, body []byte,
+	reqVars map[string]interface{}) (rowNr, evsPosted int, err error) {
+	csvReader := csv.NewReader(bytes.NewReader(body))
+	csvReader.FieldsPerRecord = rdr.Config().RowLength
+	csvReader.Comma = utils.CSV_SEP
+	if len(rdr.Config().FieldSep) > 0 {
+		csvReader.Comma = rune(rdr.Config().FieldSep[0])
+	}
+	csvReader.Comment = '#'
+	for {
+		var record []string
+		if record, err = csvReader.Read(); err != nil {
+			if err == io.EOF {
+				err = nil
+				break
+			}
+			return
+		}
+		rowNr++ // increment the rowNr after checking if it's not the end of file
+		agReq := agents.NewAgentRequest(
+			config.NewSliceDP(record), reqVars,
+			nil, nil, rdr.Config().Tenant,
+			rdr.cgrCfg.GeneralCfg().DefaultTenant,
+			utils.FirstNonEmpty(rdr.Config().Timezone,
+				rdr.cgrCfg.GeneralCfg().DefaultTimezone),
+			rdr.fltrS, nil, nil) // create an AgentRequest
+		if pass, err := rdr.fltrS.Pass(agReq.Tenant, rdr.Config().Filters,
+			agReq); err != nil || !pass {
+			continue
+		}
+		if err := agReq.SetFields(rdr.Config().Fields); err != nil {
+			utils.Logger.Warning(
+				fmt.Sprintf("<%s> reading object: <%s/%s> row <%d>, ignoring due to error: <%s>",
+					utils.ERs, rdr.bucket, key, rowNr, err.Error()))
+			continue
+		}
+		rdr.rdrEvents <- &erEvent{cgrEvent: agReq.CGRRequest.AsCGREvent(
+			agReq.Tenant, utils.NestingSep),
+			rdrCfg: rdr.Config(), rawEvent: agReq.Request}
+		evsPosted++
+	}
+	return
+}
+
+// markProcessed executes the processed action on the object so it is not read again
+func (rdr *S3ER) markProcessed(svc *s3.S3, key string) (err error) {
+	switch rdr.processedAction {
+	case utils.MetaTag:
+		var tags []*s3.Tag
+		if tags, err = rdr.getTags(svc, key); err != nil { // PutObjectTagging replaces the tags so keep the existing ones
+			return
+		}
+		tagSet := []*s3.Tag{{
+			Key:   aws.String(utils.S3ProcessedTag),
+			Value: aws.String(time.Now().UTC().Format(time.RFC3339)),
+		}}
+		for _, tag := range tags {
+			if aws.StringValue(tag.Key) != utils.S3ProcessedTag {
+				tagSet = append(tagSet, tag)
+			}
+		}
+		if _, err = svc.PutObjectTagging(&s3.PutObjectTaggingInput{
+			Bucket:  aws.String(rdr.bucket),
+			Key:     aws.String(key),
+			Tagging: &s3.Tagging{TagSet: tagSet},
+		}); err != nil {
+			return
+		}
+		rdr.setTagged(key)
+		return
+	case utils.MetaMove:
+		if _, err = svc.CopyObject(&s3.CopyObjectInput{
+			Bucket:     aws.String(rdr.bucket),
+			CopySource: aws.String(url.PathEscape(path.Join(rdr.bucket, key))),
+			Key:        aws.String(path.Join(rdr.Config().ProcessedPath, path.Base(key))),
+		}); err != nil {
+			return
+		}
+	}
+	_, err = svc.DeleteObject(&s3.DeleteObjectInput{
+		Bucket: aws.String(rdr.bucket),
+		Key:    aws.String(key),
+	})
+	return
+}
+
+// setURL uses the same URL format as the S3Poster
+// the processed_action option decides what happens with the object after being read:
+// *move to ProcessedPath (default if ProcessedPath is set), *remove (default otherwise) or *tag it
+func (rdr *S3ER) setURL(dialURL string) (err error) {
+	qry := utils.GetUrlRawArguments(dialURL)
+	rdr.awsOpts = engine.NewAWSOptions(dialURL)
+	rdr.bucket = utils.DefaultQueueID
+	if val, has := qry[utils.QueueID]; has {
+		rdr.bucket = val
+	}
+	rdr.folderPath = qry[utils.FolderPath]
+	rdr.processedAction = utils.MetaRemove
+	if rdr.Config().ProcessedPath != utils.EmptyString {
+		rdr.processedAction = utils.MetaMove
+	}
+	if val, has := qry[utils.S3ProcessedAction]; has {
+		rdr.processedAction = val
+	}
+	switch rdr.processedAction {
+	case utils.MetaRemove, utils.MetaTag:
+	case utils.MetaMove:
+		if rdr.Config().ProcessedPath == utils.EmptyString {
+			return fmt.Errorf("<%s> no processed_path defined for %s action",
+				utils.ERs, utils.MetaMove)
+		}
+	default:
+		return fmt.Errorf("<%s> unsupported processed action: <%s>",
+			utils.ERs, rdr.processedAction)
+	}
+	return
+}
+
+func (rdr *S3ER) newS3Client() (svc *s3.S3, err error) {
+	var ses *session.Session
+	if ses, err = rdr.awsOpts.NewSession(); err != nil {
+		return
+	}
+	return s3.New(ses), nil
+}
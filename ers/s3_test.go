@@ -0,0 +1,250 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package ers
+
+import (
+	"encoding/xml"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/cgrates/engine"
+	"github.com/cgrates/cgrates/utils"
+)
+
+// testS3Server is a minimal S3 compatible server supporting only the path style requests used by S3ER
+type testS3Server struct {
+	sync.Mutex
+	objs map[string]string            // map[bucket/key]content
+	tags map[string]map[string]string // map[bucket/key]map[tagKey]tagValue
+
+	tagReads int // number of GetObjectTagging requests
+}
+
+func (srv *testS3Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	srv.Lock()
+	defer srv.Unlock()
+	objPath := strings.TrimPrefix(r.URL.Path, "/")
+	qry := r.URL.Query()
+	switch {
+	case r.Method == http.MethodGet && qry.Get("list-type") == "2":
+		type content struct{ Key string }
+		lst := struct {
+			XMLName     xml.Name `xml:"ListBucketResult"`
+			Name        string
+			Prefix      string
+			KeyCount    int
+			IsTruncated bool
+			Contents    []content
+		}{Name: objPath, Prefix: qry.Get("prefix")}
+		for key := range srv.objs {
+			if strings.HasPrefix(key, objPath+"/"+lst.Prefix) {
+				lst.Contents = append(lst.Contents, content{Key: strings.TrimPrefix(key, objPath+"/")})
+			}
+		}
+		sort.Slice(lst.Contents, func(i, j int) bool { return lst.Contents[i].Key < lst.Contents[j].Key })
+		lst.KeyCount = len(lst.Contents)
+		xml.NewEncoder(w).Encode(lst)
+	case r.Method == http.MethodGet && qry["tagging"] != nil:
+		srv.tagReads++
+		type tag struct{ Key, Value string }
+		tgs := struct {
+			XMLName xml.Name `xml:"Tagging"`
+			TagSet  []tag    `xml:"TagSet>Tag"`
+		}{}
+		for k, v := range srv.tags[objPath] {
+			tgs.TagSet = append(tgs.TagSet, tag{Key: k, Value: v})
+		}
+		xml.NewEncoder(w).Encode(tgs)
+	case r.Method == http.MethodGet:
+		obj, has := srv.objs[objPath]
+		if !has {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Write([]byte(obj))
+	case r.Method == http.MethodPut && qry["tagging"] != nil:
+		tgs := struct {
+			TagSet []struct{ Key, Value string } `xml:"TagSet>Tag"`
+		}{}
+		body, _ := ioutil.ReadAll(r.Body)
+		xml.Unmarshal(body, &tgs)
+		srv.tags[objPath] = make(map[string]string)
+		for _, tg := range tgs.TagSet {
+			srv.tags[objPath][tg.Key] = tg.Value
+		}
+	case r.Method == http.MethodPut && r.Header.Get("X-Amz-Copy-Source") != "":
+		src, _ := url.PathUnescape(r.Header.Get("X-Amz-Copy-Source"))
+		srv.objs[objPath] = srv.objs[src]
+		w.Write([]byte(`<CopyObjectResult><ETag>"1"</ETag></CopyObjectResult>`))
+	case r.Method == http.MethodDelete:
+		delete(srv.objs, objPath)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		w.WriteHeader(http.StatusNotImplemented)
+	}
+}
+
+func (srv *testS3Server) keys() (keys []string) {
+	srv.Lock()
+	defer srv.Unlock()
+	for key := range srv.objs {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return
+}
+
+func newTestS3ER(t *testing.T, rdrType, srcPath, processedPath string,
+	rdrEvents chan *erEvent) *S3ER {
+	cfg, _ := config.NewDefaultCGRConfig()
+	rdrCfg := cfg.ERsCfg().Readers[0]
+	rdrCfg.Type = rdrType
+	rdrCfg.SourcePath = srcPath
+	rdrCfg.ProcessedPath = processedPath
+	rdrCfg.ConcurrentReqs = 2
+	rdrCfg.Fields = []*config.FCTemplate{
+		{Tag: utils.OriginID, Path: utils.MetaCgreq + utils.NestingSep + utils.OriginID, Type: utils.MetaVariable,
+			Value: config.NewRSRParsersMustCompile("~*req.0", true, utils.INFIELD_SEP)},
+	}
+	if rdrType == utils.MetaS3jsonMap {
+		rdrCfg.Fields[0].Value = config.NewRSRParsersMustCompile("~*req.OriginID", true, utils.INFIELD_SEP)
+	}
+	rdr, err := NewS3ER(cfg, 0, rdrEvents, nil, new(engine.FilterS), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return rdr.(*S3ER)
+}
+
+func TestS3ERReadBucket(t *testing.T) {
+	s3Srv := &testS3Server{
+		objs: map[string]string{
+			"cdrs/in/file1.csv":  "orig1,1001\norig2,1002\n",
+			"cdrs/in/file2.csv":  "orig3,1003\n",
+			"cdrs/in/file3.json": `{"OriginID":"orig4"}`,
+			"cdrs/other/f.csv":   "orig5,1005\n",
+		},
+		tags: map[string]map[string]string{
+			"cdrs/in/file3.json": {"Owner": "billing"},
+		},
+	}
+	httpSrv := httptest.NewServer(s3Srv)
+	defer httpSrv.Close()
+	srcPath := httpSrv.URL + "/?aws_region=us-east-2&aws_key=testkey&aws_secret=testsecret&queue_id=cdrs&folder_path=in&force_path_style=true"
+
+	rdrEvents := make(chan *erEvent, 10)
+	rdr := newTestS3ER(t, utils.MetaS3CSV, srcPath, "out", rdrEvents)
+	svc, err := rdr.newS3Client()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := rdr.readBucket(svc); err != nil {
+		t.Fatal(err)
+	}
+	close(rdrEvents)
+	var originIDs []string
+	for ev := range rdrEvents {
+		originIDs = append(originIDs, utils.IfaceAsString(ev.cgrEvent.Event[utils.OriginID]))
+	}
+	sort.Strings(originIDs)
+	if exp := []string{"orig1", "orig2", "orig3"}; !reflect.DeepEqual(exp, originIDs) {
+		t.Errorf("Expected: %v, received: %v", exp, originIDs)
+	}
+	if exp := []string{"cdrs/in/file3.json", "cdrs/other/f.csv",
+		"cdrs/out/file1.csv", "cdrs/out/file2.csv"}; !reflect.DeepEqual(exp, s3Srv.keys()) {
+		t.Errorf("Expected: %v, received: %v", exp, s3Srv.keys())
+	}
+
+	rdrEvents = make(chan *erEvent, 10)
+	rdr = newTestS3ER(t, utils.MetaS3jsonMap, srcPath+"&processed_action=*tag", utils.EmptyString, rdrEvents)
+	for i := 0; i < 2; i++ { // tagged objects should be read only once
+		if err := rdr.readBucket(svc); err != nil {
+			t.Fatal(err)
+		}
+	}
+	close(rdrEvents)
+	originIDs = nil
+	for ev := range rdrEvents {
+		originIDs = append(originIDs, utils.IfaceAsString(ev.cgrEvent.Event[utils.OriginID]))
+	}
+	if exp := []string{"orig4"}; !reflect.DeepEqual(exp, originIDs) {
+		t.Errorf("Expected: %v, received: %v", exp, originIDs)
+	}
+	if _, has := s3Srv.tags["cdrs/in/file3.json"][utils.S3ProcessedTag]; !has {
+		t.Errorf("Expected object to be tagged, received: %v", s3Srv.tags)
+	} else if s3Srv.tags["cdrs/in/file3.json"]["Owner"] != "billing" {
+		t.Errorf("Expected the existing tags to be kept, received: %v", s3Srv.tags)
+	}
+	if s3Srv.tagReads != 2 { // once before processing and once when merging the tags
+		t.Errorf("Expected the tags to be read only on first poll, received %d reads", s3Srv.tagReads)
+	}
+
+	rdrEvents = make(chan *erEvent, 10)
+	rdr = newTestS3ER(t, utils.MetaS3jsonMap, srcPath, utils.EmptyString, rdrEvents)
+	if err := rdr.readBucket(svc); err != nil {
+		t.Fatal(err)
+	}
+	if exp := []string{"cdrs/other/f.csv",
+		"cdrs/out/file1.csv", "cdrs/out/file2.csv"}; !reflect.DeepEqual(exp, s3Srv.keys()) {
+		t.Errorf("Expected: %v, received: %v", exp, s3Srv.keys())
+	}
+}
+
+func TestS3ERSetURL(t *testing.T) {
+	rdr := newTestS3ER(t, utils.MetaS3jsonMap,
+		"http://s3.us-east-2.amazonaws.com/?aws_region=us-east-2&aws_key=testkey&aws_secret=testsecret&queue_id=cdrs&folder_path=in",
+		"out", nil)
+	expOpts := &engine.AWSOptions{
+		Endpoint: "http://s3.us-east-2.amazonaws.com",
+		Region:   "us-east-2",
+		ID:       "testkey",
+		Key:      "testsecret",
+	}
+	if !reflect.DeepEqual(expOpts, rdr.awsOpts) {
+		t.Errorf("Expected: %s ,received: %s", utils.ToJSON(expOpts), utils.ToJSON(rdr.awsOpts))
+	} else if rdr.bucket != "cdrs" {
+		t.Errorf("Expected: %s ,received: %s", "cdrs", rdr.bucket)
+	} else if rdr.folderPath != "in" {
+		t.Errorf("Expected: %s ,received: %s", "in", rdr.folderPath)
+	} else if rdr.processedAction != utils.MetaMove {
+		t.Errorf("Expected: %s ,received: %s", utils.MetaMove, rdr.processedAction)
+	}
+	if err := rdr.setURL("http://s3.us-east-2.amazonaws.com/?processed_action=*copy"); err == nil {
+		t.Error("Expected error for unsupported action")
+	}
+	rdr.Config().ProcessedPath = utils.EmptyString
+	if err := rdr.setURL("http://s3.us-east-2.amazonaws.com/"); err != nil {
+		t.Error(err)
+	} else if rdr.processedAction != utils.MetaRemove {
+		t.Errorf("Expected: %s ,received: %s", utils.MetaRemove, rdr.processedAction)
+	} else if rdr.bucket != utils.DefaultQueueID {
+		t.Errorf("Expected: %s ,received: %s", utils.DefaultQueueID, rdr.bucket)
+	}
+	if err := rdr.setURL("http://s3.us-east-2.amazonaws.com/?processed_action=*move"); err == nil {
+		t.Error("Expected error for missing processed path")
+	}
+}
@@ -204,6 +204,7 @@ func (rdr *SQLEventReader) processMessage(msg map[string]interface{}) (err error
 	rdr.rdrEvents <- &erEvent{
 		cgrEvent: agReq.CGRRequest.AsCGREvent(agReq.Tenant, utils.NestingSep),
 		rdrCfg:   rdr.Config(),
+		rawEvent: agReq.Request,
 	}
 	return
 }
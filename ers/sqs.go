@@ -0,0 +1,204 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package ers
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/sqs"
+	"github.com/cgrates/cgrates/agents"
+	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/cgrates/engine"
+	"github.com/cgrates/cgrates/utils"
+)
+
+const (
+	sqsMaxMessages = 10 // maximum number of messages accepted by SQS in one receive
+	sqsWaitTime    = 20 // seconds to long poll for new messages
+)
+
+// NewSQSER return a new sqs event reader
+// "http://sqs.eu-west-2.amazonaws.com/?aws_region=eu-west-2&aws_key=testkey&aws_secret=testsecret&queue_id=cgrates_cdrs"
+func NewSQSER(cfg *config.CGRConfig, cfgIdx int,
+	rdrEvents chan *erEvent, rdrErr chan error,
+	fltrS *engine.FilterS, rdrExit chan struct{}) (er EventReader, err error) {
+	rdr := &SQSER{
+		cgrCfg:    cfg,
+		cfgIdx:    cfgIdx,
+		fltrS:     fltrS,
+		rdrEvents: rdrEvents,
+		rdrExit:   rdrExit,
+		rdrErr:    rdrErr,
+	}
+	if concReq := rdr.Config().ConcurrentReqs; concReq != -1 {
+		rdr.cap = make(chan struct{}, concReq)
+		for i := 0; i < concReq; i++ {
+			rdr.cap <- struct{}{}
+		}
+	}
+	er = rdr
+	rdr.setURL(rdr.Config().SourcePath)
+	return
+}
+
+// SQSER implements EventReader interface for sqs message
+type SQSER struct {
+	cgrCfg *config.CGRConfig
+	cfgIdx int // index of config instance within ERsCfg.Readers
+	fltrS  *engine.FilterS
+
+	awsOpts *engine.AWSOptions
+	queueID string
+
+	rdrEvents chan *erEvent // channel to dispatch the events created to
+	rdrExit   chan struct{}
+	rdrErr    chan error
+	cap       chan struct{}
+}
+
+// Config returns the curent configuration
+func (rdr *SQSER) Config() *config.EventReaderCfg {
+	return rdr.cgrCfg.ERsCfg().Readers[rdr.cfgIdx]
+}
+
+// Serve will start the gorutines needed to poll the sqs queue
+func (rdr *SQSER) Serve() (err error) {
+	if rdr.Config().RunDelay == time.Duration(0) { // 0 disables the automatic read, maybe done per API
+		return
+	}
+	var svc *sqs.SQS
+	if svc, err = rdr.newSQSClient(); err != nil {
+		return
+	}
+	var qURL *sqs.GetQueueUrlOutput
+	if qURL, err = svc.GetQueueUrl(&sqs.GetQueueUrlInput{
+		QueueName: aws.String(rdr.queueID),
+	}); err != nil {
+		return
+	}
+	go rdr.readLoop(svc, qURL.QueueUrl) // read until the reader is stopped
+	return
+}
+
+func (rdr *SQSER) readLoop(svc *sqs.SQS, queueURL *string) {
+	for {
+		select {
+		case <-rdr.rdrExit:
+			utils.Logger.Info(
+				fmt.Sprintf("<%s> stop monitoring sqs queue <%s>",
+					utils.ERs, rdr.queueID))
+			return
+		default:
+		}
+		maxMsgs := int64(sqsMaxMessages)
+		if concReq := rdr.Config().ConcurrentReqs; concReq != -1 && concReq < sqsMaxMessages {
+			maxMsgs = int64(concReq)
+		}
+		msgs, err := svc.ReceiveMessage(&sqs.ReceiveMessageInput{
+			QueueUrl:            queueURL,
+			MaxNumberOfMessages: aws.Int64(maxMsgs),
+			WaitTimeSeconds:     aws.Int64(sqsWaitTime),
+		})
+		if err != nil {
+			rdr.rdrErr <- err
+			return
+		}
+		for _, msg := range msgs.Messages {
+			if rdr.Config().ConcurrentReqs != -1 {
+				<-rdr.cap // do not process more if the limit is reached
+			}
+			go func(msg *sqs.Message) {
+				body := []byte(aws.StringValue(msg.Body))
+				if err := rdr.processMessage(body); err != nil {
+					utils.Logger.Warning(
+						fmt.Sprintf("<%s> processing message %s error: %s",
+							utils.ERs, aws.StringValue(msg.MessageId), err.Error()))
+				}
+				if _, err := svc.DeleteMessage(&sqs.DeleteMessageInput{
+					QueueUrl:      queueURL,
+					ReceiptHandle: msg.ReceiptHandle,
+				}); err != nil {
+					utils.Logger.Warning(
+						fmt.Sprintf("<%s> deleting message %s error: %s",
+							utils.ERs, aws.StringValue(msg.MessageId), err.Error()))
+				}
+				if rdr.Config().ProcessedPath != utils.EmptyString { // post it
+					if err := engine.PostersCache.PostSQS(rdr.Config().ProcessedPath,
+						rdr.cgrCfg.GeneralCfg().PosterAttempts, body); err != nil {
+						utils.Logger.Warning(
+							fmt.Sprintf("<%s> writing message %s error: %s",
+								utils.ERs, aws.StringValue(msg.MessageId), err.Error()))
+					}
+				}
+				if rdr.Config().ConcurrentReqs != -1 {
+					rdr.cap <- struct{}{}
+				}
+			}(msg)
+		}
+	}
+}
+
+func (rdr *SQSER) processMessage(msg []byte) (err error) {
+	var decodedMessage map[string]interface{}
+	if err = json.Unmarshal(msg, &decodedMessage); err != nil {
+		return
+	}
+	reqVars := make(map[string]interface{})
+	agReq := agents.NewAgentRequest(
+		config.NewNavigableMap(decodedMessage), reqVars,
+		nil, nil, rdr.Config().Tenant,
+		rdr.cgrCfg.GeneralCfg().DefaultTenant,
+		utils.FirstNonEmpty(rdr.Config().Timezone,
+			rdr.cgrCfg.GeneralCfg().DefaultTimezone),
+		rdr.fltrS, nil, nil) // create an AgentRequest
+	var pass bool
+	if pass, err = rdr.fltrS.Pass(agReq.Tenant, rdr.Config().Filters,
+		agReq); err != nil || !pass {
+		return
+	}
+	if err = agReq.SetFields(rdr.Config().Fields); err != nil {
+		return
+	}
+	rdr.rdrEvents <- &erEvent{cgrEvent: agReq.CGRRequest.AsCGREvent(
+		agReq.Tenant, utils.NestingSep),
+		rdrCfg: rdr.Config(), rawEvent: agReq.Request}
+	return
+}
+
+// setURL uses the same URL format as the SQSPoster
+func (rdr *SQSER) setURL(dialURL string) {
+	qry := utils.GetUrlRawArguments(dialURL)
+	rdr.awsOpts = engine.NewAWSOptions(dialURL)
+	rdr.queueID = utils.DefaultQueueID
+	if val, has := qry[utils.QueueID]; has {
+		rdr.queueID = val
+	}
+}
+
+func (rdr *SQSER) newSQSClient() (svc *sqs.SQS, err error) {
+	var ses *session.Session
+	if ses, err = rdr.awsOpts.NewSession(); err != nil {
+		return
+	}
+	return sqs.New(ses), nil
+}
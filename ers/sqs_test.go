@@ -0,0 +1,52 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package ers
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/cgrates/cgrates/engine"
+	"github.com/cgrates/cgrates/utils"
+)
+
+func TestSQSSetURL(t *testing.T) {
+	rdr := new(SQSER)
+	expRdr := &SQSER{
+		awsOpts: &engine.AWSOptions{
+			Endpoint: "http://sqs.eu-west-2.amazonaws.com",
+			Region:   "eu-west-2",
+			ID:       "testkey",
+			Key:      "testsecret",
+			Token:    "testtoken",
+		},
+		queueID: "cdrs",
+	}
+	rdr.setURL("http://sqs.eu-west-2.amazonaws.com/?aws_region=eu-west-2&aws_key=testkey&aws_secret=testsecret&aws_token=testtoken&queue_id=cdrs")
+	if !reflect.DeepEqual(expRdr.awsOpts, rdr.awsOpts) {
+		t.Errorf("Expected: %s ,received: %s", utils.ToJSON(expRdr.awsOpts), utils.ToJSON(rdr.awsOpts))
+	} else if expRdr.queueID != rdr.queueID {
+		t.Errorf("Expected: %s ,received: %s", expRdr.queueID, rdr.queueID)
+	}
+	rdr = new(SQSER)
+	rdr.setURL("http://sqs.eu-west-2.amazonaws.com/")
+	if rdr.queueID != "cgrates_cdrs" {
+		t.Errorf("Expected: %s ,received: %s", "cgrates_cdrs", rdr.queueID)
+	}
+}
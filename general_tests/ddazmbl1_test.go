@@ -67,7 +67,7 @@ TOPUP10_AT,TOPUP10_AC1,ASAP,10`
 			destinationRates, ratingPlans, ratingProfiles,
 			sharedGroups, actions, actionPlans, actionTriggers, accountActions,
 			resLimits, stats, thresholds, filters, suppliers,
-			attrProfiles, chargerProfiles, ``, ""), "", "", nil, nil)
+			attrProfiles, chargerProfiles, ``, "", "", ""), "", "", nil, nil)
 	if err != nil {
 		t.Error(err)
 	}
@@ -113,7 +113,7 @@ TOPUP10_AT,TOPUP10_AC1,ASAP,10`
 	engine.Cache.Clear(nil)
 
 	dataDB.LoadDataDBCache(nil, nil, nil, nil, nil, nil, nil, nil,
-		nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+		nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
 
 	if cachedDests := len(engine.Cache.GetItemIDs(utils.CacheDestinations, "")); cachedDests != 0 {
 		t.Error("Wrong number of cached destinations found", cachedDests)
@@ -31,8 +31,12 @@ import (
 type LoaderData map[string]interface{}
 
 func (ld LoaderData) TenantID() string {
-	tnt := ld[utils.Tenant].(string)
-	prflID := ld[utils.ID].(string)
+	tnt, _ := ld[utils.Tenant].(string) // empty for the data without tenant, ie: calendars
+	prflID, has := ld[utils.ID].(string)
+	if !has { // exchange rates are identified by their currencies
+		prflID = utils.ConcatenatedKey(utils.IfaceAsString(ld[utils.FromCurrency]),
+			utils.IfaceAsString(ld[utils.ToCurrency]))
+	}
 	return utils.ConcatenatedKey(tnt, prflID)
 }
 
@@ -61,6 +65,9 @@ func (ld LoaderData) UpdateFromCSV(fileName string, record []string,
 			}
 		}
 		out, err := cfgFld.Value.ParseDataProvider(csvProvider, utils.InInFieldSep)
+		if err == utils.ErrNotFound && !cfgFld.Mandatory {
+			continue // column not present in this file
+		}
 		if err != nil {
 			return err
 		}
@@ -119,8 +126,10 @@ func (cP *csvProvider) FieldAsInterface(fldPath []string) (data interface{}, err
 		cP.cache.Set(fldPath, nil, false, false)
 		return
 	}
-	if cfgFieldIdx, err := strconv.Atoi(idx); err != nil || len(cP.req) <= cfgFieldIdx {
+	if cfgFieldIdx, err := strconv.Atoi(idx); err != nil {
 		return nil, fmt.Errorf("Ignoring record: %v with error : %+v", cP.req, err)
+	} else if len(cP.req) <= cfgFieldIdx {
+		return nil, utils.ErrNotFound // optional column missing from the record
 	} else {
 		data = cP.req[cfgFieldIdx]
 	}
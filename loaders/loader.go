@@ -174,6 +174,7 @@ func (ldr *Loader) processFiles(loaderType, caching, loadOption string) (err err
 		}
 		csvReader := csv.NewReader(rdr)
 		csvReader.Comment = '#'
+		csvReader.FieldsPerRecord = -1 // optional trailing columns
 		ldr.rdrs[loaderType][fName] = &openedCSVFile{
 			fileName: fName, rdr: rdr, csvRdr: csvReader}
 		defer ldr.unreferenceFile(loaderType, fName)
@@ -540,6 +541,61 @@ func (ldr *Loader) storeLoadedData(loaderType string,
 				cacheArgs.DispatcherHostIDs = &ids
 			}
 		}
+	case utils.MetaCalendars:
+		for _, lDataSet := range lds {
+			calModels := make(engine.TpCalendars, len(lDataSet))
+			for i, ld := range lDataSet {
+				calModels[i].Tag = utils.IfaceAsString(ld[utils.ID])
+				if err = utils.UpdateStructWithIfaceMap(&calModels[i], ld); err != nil {
+					return
+				}
+			}
+			cals, err := engine.MapTPCalendars(calModels.AsTPCalendars())
+			if err != nil {
+				return err
+			}
+			if ldr.dryRun {
+				utils.Logger.Info(
+					fmt.Sprintf("<%s-%s> DRY_RUN: Calendars: %s",
+						utils.LoaderS, ldr.ldrID, utils.ToJSON(cals)))
+				continue
+			}
+			var rpIDs []string
+			if rpIDs, err = ldr.storeCalendars(cals); err != nil {
+				return err
+			}
+			// get IDs so we can reload in cache
+			if ids = append(ids, rpIDs...); len(ids) != 0 {
+				cacheArgs.RatingPlanIDs = &ids
+			}
+		}
+	case utils.MetaExchangeRates:
+		for _, lDataSet := range lds {
+			exrModels := make(engine.TpExchangeRates, len(lDataSet))
+			for i, ld := range lDataSet {
+				if err = utils.UpdateStructWithIfaceMap(&exrModels[i], ld); err != nil {
+					return
+				}
+			}
+			for _, tpExr := range exrModels.AsTPExchangeRates() {
+				exr, err := engine.APItoExchangeRates(tpExr, ldr.timezone)
+				if err != nil {
+					return err
+				}
+				if ldr.dryRun {
+					utils.Logger.Info(
+						fmt.Sprintf("<%s-%s> DRY_RUN: ExchangeRates: %s",
+							utils.LoaderS, ldr.ldrID, utils.ToJSON(exr)))
+					continue
+				}
+				// get IDs so we can reload in cache
+				ids = append(ids, exr.ID())
+				if err := ldr.dm.SetExchangeRates(exr); err != nil {
+					return err
+				}
+				cacheArgs.ExchangeRatesIDs = &ids
+			}
+		}
 	}
 
 	if len(ldr.cacheConns) != 0 {
@@ -796,6 +852,40 @@ func (ldr *Loader) removeLoadedData(loaderType, tntID, caching string) (err erro
 			}
 			cacheArgs.DispatcherHostIDs = &ids
 		}
+	case utils.MetaCalendars:
+		if ldr.dryRun {
+			utils.Logger.Info(
+				fmt.Sprintf("<%s-%s> DRY_RUN: CalendarID: %s",
+					utils.LoaderS, ldr.ldrID, tntID))
+		} else {
+			// the RatingPlans keep referencing the calendar, now without days
+			if ids, err = ldr.storeCalendars(map[string]utils.StringMap{
+				utils.NewTenantID(tntID).ID: make(utils.StringMap)}); err != nil {
+				return err
+			}
+			if len(ids) != 0 {
+				cacheArgs.RatingPlanIDs = &ids
+			}
+		}
+	case utils.MetaExchangeRates:
+		if ldr.dryRun {
+			utils.Logger.Info(
+				fmt.Sprintf("<%s-%s> DRY_RUN: ExchangeRatesID: %s",
+					utils.LoaderS, ldr.ldrID, tntID))
+		} else {
+			exrID := utils.NewTenantID(tntID).ID
+			crncs := strings.Split(exrID, utils.CONCATENATED_KEY_SEP)
+			if len(crncs) != 2 {
+				return utils.ErrInvalidKey
+			}
+			// get IDs so we can reload in cache
+			ids = append(ids, exrID)
+			if err := ldr.dm.RemoveExchangeRates(crncs[0], crncs[1],
+				utils.NonTransactional); err != nil {
+				return err
+			}
+			cacheArgs.ExchangeRatesIDs = &ids
+		}
 	}
 
 	if len(ldr.cacheConns) != 0 {
@@ -832,3 +922,19 @@ func (ldr *Loader) removeLoadedData(loaderType, tntID, caching string) (err erro
 	}
 	return
 }
+
+// storeCalendars updates the days of the calendars within the RatingPlans referencing them,
+// returning the IDs of the updated RatingPlans
+func (ldr *Loader) storeCalendars(cals map[string]utils.StringMap) (rpIDs []string, err error) {
+	var rps []*engine.RatingPlan
+	if rps, err = ldr.dm.GetCalendarsRatingPlans(cals); err != nil {
+		return
+	}
+	for _, rp := range rps {
+		if err = ldr.dm.SetRatingPlan(rp, utils.NonTransactional); err != nil {
+			return
+		}
+		rpIDs = append(rpIDs, rp.Id)
+	}
+	return
+}
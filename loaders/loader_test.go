@@ -272,11 +272,16 @@ func TestLoaderProcessResource(t *testing.T) {
 				Path:  "Thresholds",
 				Type:  utils.META_COMPOSED,
 				Value: config.NewRSRParsersMustCompile("~10", true, utils.INFIELD_SEP)},
+			&config.FCTemplate{Tag: "RateInterval",
+				Path:  "RateInterval",
+				Type:  utils.META_COMPOSED,
+				Value: config.NewRSRParsersMustCompile("~11", true, utils.INFIELD_SEP)},
 		},
 	}
 	rdr := ioutil.NopCloser(strings.NewReader(engine.ResourcesCSVContent))
 	csvRdr := csv.NewReader(rdr)
 	csvRdr.Comment = '#'
+	csvRdr.FieldsPerRecord = -1
 	ldr.rdrs = map[string]map[string]*openedCSVFile{
 		utils.MetaResources: map[string]*openedCSVFile{
 			"Resources.csv": &openedCSVFile{fileName: "Resources.csv",
@@ -314,6 +319,7 @@ func TestLoaderProcessResource(t *testing.T) {
 		Blocker:           true,
 		Stored:            true,
 		ThresholdIDs:      []string{},
+		RateInterval:      time.Minute,
 	}
 	if len(ldr.bufLoaderData) != 0 {
 		t.Errorf("wrong buffer content: %+v", ldr.bufLoaderData)
@@ -501,11 +507,28 @@ func TestLoaderProcessThresholds(t *testing.T) {
 				Path:  "Async",
 				Type:  utils.META_COMPOSED,
 				Value: config.NewRSRParsersMustCompile("~10", true, utils.INFIELD_SEP)},
+			&config.FCTemplate{Tag: "WarningFilterIDs",
+				Path:  "WarningFilterIDs",
+				Type:  utils.META_COMPOSED,
+				Value: config.NewRSRParsersMustCompile("~11", true, utils.INFIELD_SEP)},
+			&config.FCTemplate{Tag: "CriticalFilterIDs",
+				Path:  "CriticalFilterIDs",
+				Type:  utils.META_COMPOSED,
+				Value: config.NewRSRParsersMustCompile("~12", true, utils.INFIELD_SEP)},
+			&config.FCTemplate{Tag: "EscalationActionIDs",
+				Path:  "EscalationActionIDs",
+				Type:  utils.META_COMPOSED,
+				Value: config.NewRSRParsersMustCompile("~13", true, utils.INFIELD_SEP)},
+			&config.FCTemplate{Tag: "RecoveryActionIDs",
+				Path:  "RecoveryActionIDs",
+				Type:  utils.META_COMPOSED,
+				Value: config.NewRSRParsersMustCompile("~14", true, utils.INFIELD_SEP)},
 		},
 	}
 	rdr := ioutil.NopCloser(strings.NewReader(engine.ThresholdsCSVContent))
 	csvRdr := csv.NewReader(rdr)
 	csvRdr.Comment = '#'
+	csvRdr.FieldsPerRecord = -1
 	ldr.rdrs = map[string]map[string]*openedCSVFile{
 		utils.MetaThresholds: map[string]*openedCSVFile{
 			"Thresholds.csv": &openedCSVFile{fileName: "Thresholds.csv",
@@ -523,13 +546,17 @@ func TestLoaderProcessThresholds(t *testing.T) {
 		FilterIDs: []string{"*string:~*req.Account:1001", "*string:~*req.RunID:*default"},
 		ActivationInterval: &utils.ActivationInterval{
 			ActivationTime: time.Date(2014, 7, 29, 15, 0, 0, 0, time.UTC)},
-		MaxHits:   12,
-		MinHits:   10,
-		MinSleep:  time.Duration(1 * time.Second),
-		Blocker:   true,
-		Weight:    10,
-		ActionIDs: []string{"THRESH1"},
-		Async:     true,
+		MaxHits:             12,
+		MinHits:             10,
+		MinSleep:            time.Duration(1 * time.Second),
+		Blocker:             true,
+		Weight:              10,
+		ActionIDs:           []string{"THRESH1"},
+		Async:               true,
+		WarningFilterIDs:    []string{"*gte:~*req.Usage:1m"},
+		CriticalFilterIDs:   []string{"*gte:~*req.Usage:1h"},
+		EscalationActionIDs: []string{"ACT_ESCALATE"},
+		RecoveryActionIDs:   []string{"ACT_RECOVER"},
 	}
 	aps, err := ldr.dm.GetThresholdProfile("cgrates.org", "Threshold1",
 		true, false, utils.NonTransactional)
@@ -608,11 +635,20 @@ func TestLoaderProcessStats(t *testing.T) {
 				Path:  "ThresholdIDs",
 				Type:  utils.META_COMPOSED,
 				Value: config.NewRSRParsersMustCompile("~12", true, utils.INFIELD_SEP)},
+			&config.FCTemplate{Tag: "BucketInterval",
+				Path:  "BucketInterval",
+				Type:  utils.META_COMPOSED,
+				Value: config.NewRSRParsersMustCompile("~13", true, utils.INFIELD_SEP)},
+			&config.FCTemplate{Tag: "BucketCount",
+				Path:  "BucketCount",
+				Type:  utils.META_COMPOSED,
+				Value: config.NewRSRParsersMustCompile("~14", true, utils.INFIELD_SEP)},
 		},
 	}
 	rdr := ioutil.NopCloser(strings.NewReader(engine.StatsCSVContent))
 	csvRdr := csv.NewReader(rdr)
 	csvRdr.Comment = '#'
+	csvRdr.FieldsPerRecord = -1
 	ldr.rdrs = map[string]map[string]*openedCSVFile{
 		utils.MetaStats: map[string]*openedCSVFile{
 			"Stats.csv": &openedCSVFile{fileName: "Stats.csv",
@@ -663,6 +699,12 @@ func TestLoaderProcessStats(t *testing.T) {
 	} else if !reflect.DeepEqual(eSt1, aps) {
 		t.Errorf("expecting: %+v, received: %+v", utils.ToJSON(eSt1), utils.ToJSON(aps))
 	}
+	if aps, err = ldr.dm.GetStatQueueProfile("cgrates.org", "TestStats2",
+		true, false, utils.NonTransactional); err != nil {
+		t.Error(err)
+	} else if aps.BucketInterval != time.Minute || aps.BucketCount != 60 {
+		t.Errorf("wrong buckets: %+v", utils.ToJSON(aps))
+	}
 }
 
 func TestLoaderProcessSuppliers(t *testing.T) {
@@ -1110,6 +1152,181 @@ func TestLoaderProcessDispatcheHosts(t *testing.T) {
 	}
 }
 
+func TestLoaderProcessCalendars(t *testing.T) {
+	data := engine.NewInternalDB(nil, nil, true, config.CgrConfig().DataDbCfg().Items)
+	ldr := &Loader{
+		ldrID:         "TestLoaderProcessContent",
+		bufLoaderData: make(map[string][]LoaderData),
+		dm:            engine.NewDataManager(data, config.CgrConfig().CacheCfg(), nil),
+		timezone:      "UTC",
+	}
+	ldr.dataTpls = map[string][]*config.FCTemplate{
+		utils.MetaCalendars: []*config.FCTemplate{
+			&config.FCTemplate{
+				Tag:       "ID",
+				Path:      "ID",
+				Type:      utils.META_COMPOSED,
+				Value:     config.NewRSRParsersMustCompile("~0", true, utils.INFIELD_SEP),
+				Mandatory: true,
+			},
+			&config.FCTemplate{
+				Tag:   "Date",
+				Path:  "Date",
+				Type:  utils.META_COMPOSED,
+				Value: config.NewRSRParsersMustCompile("~1", true, utils.INFIELD_SEP),
+			},
+		},
+	}
+	rdr := ioutil.NopCloser(strings.NewReader(engine.CalendarsCSVContent))
+	csvRdr := csv.NewReader(rdr)
+	csvRdr.Comment = '#'
+	ldr.rdrs = map[string]map[string]*openedCSVFile{
+		utils.MetaCalendars: map[string]*openedCSVFile{
+			utils.CalendarsCsv: &openedCSVFile{
+				fileName: utils.CalendarsCsv,
+				rdr:      rdr,
+				csvRdr:   csvRdr,
+			},
+		},
+	}
+	rp := new(engine.RatingPlan)
+	rp.Id = "RP_HOLIDAYS"
+	rp.AddRateInterval(utils.ANY, &engine.RateInterval{
+		Timing: &engine.RITiming{
+			StartTime: "00:00:00",
+			IncludeCalendars: map[string]utils.StringMap{
+				"HOLIDAYS_RO": utils.StringMap{"01-01": true},
+			},
+		},
+		Weight: 10,
+	})
+	tmID := rp.DestinationRates[utils.ANY][0].Timing
+	if err := ldr.dm.SetRatingPlan(rp, utils.NonTransactional); err != nil {
+		t.Fatal(err)
+	}
+	if err := ldr.processContent(utils.MetaCalendars, utils.EmptyString); err != nil {
+		t.Error(err)
+	}
+	if len(ldr.bufLoaderData) != 0 {
+		t.Errorf("wrong buffer content: %+v", ldr.bufLoaderData)
+	}
+	eCals := map[string]utils.StringMap{
+		"HOLIDAYS_RO": utils.StringMap{"01-01": true, "12-25": true, "2020-04-19": true},
+	}
+	if rcv, err := ldr.dm.GetRatingPlan(rp.Id, true, utils.NonTransactional); err != nil {
+		t.Error(err)
+	} else if rcvTm, has := rcv.Timings[tmID]; !has {
+		t.Errorf("expecting timing: %s, received: %s", tmID, utils.ToJSON(rcv.Timings))
+	} else if !reflect.DeepEqual(eCals, rcvTm.IncludeCalendars) {
+		t.Errorf("expecting: %s, received: %s",
+			utils.ToJSON(eCals), utils.ToJSON(rcvTm.IncludeCalendars))
+	}
+
+	rdr = ioutil.NopCloser(strings.NewReader(`HOLIDAYS_RO,`))
+	csvRdr = csv.NewReader(rdr)
+	ldr.rdrs[utils.MetaCalendars][utils.CalendarsCsv] = &openedCSVFile{
+		fileName: utils.CalendarsCsv, rdr: rdr, csvRdr: csvRdr}
+	if err := ldr.removeContent(utils.MetaCalendars, utils.EmptyString); err != nil {
+		t.Error(err)
+	}
+	eCals = map[string]utils.StringMap{"HOLIDAYS_RO": utils.StringMap{}}
+	if rcv, err := ldr.dm.GetRatingPlan(rp.Id, true, utils.NonTransactional); err != nil {
+		t.Error(err)
+	} else if !reflect.DeepEqual(eCals, rcv.Timings[tmID].IncludeCalendars) {
+		t.Errorf("expecting: %s, received: %s",
+			utils.ToJSON(eCals), utils.ToJSON(rcv.Timings[tmID].IncludeCalendars))
+	}
+}
+
+func TestLoaderProcessExchangeRates(t *testing.T) {
+	data := engine.NewInternalDB(nil, nil, true, config.CgrConfig().DataDbCfg().Items)
+	ldr := &Loader{
+		ldrID:         "TestLoaderProcessContent",
+		bufLoaderData: make(map[string][]LoaderData),
+		dm:            engine.NewDataManager(data, config.CgrConfig().CacheCfg(), nil),
+		timezone:      "UTC",
+	}
+	ldr.dataTpls = map[string][]*config.FCTemplate{
+		utils.MetaExchangeRates: []*config.FCTemplate{
+			&config.FCTemplate{
+				Tag:       "FromCurrency",
+				Path:      "FromCurrency",
+				Type:      utils.META_COMPOSED,
+				Value:     config.NewRSRParsersMustCompile("~0", true, utils.INFIELD_SEP),
+				Mandatory: true,
+			},
+			&config.FCTemplate{
+				Tag:       "ToCurrency",
+				Path:      "ToCurrency",
+				Type:      utils.META_COMPOSED,
+				Value:     config.NewRSRParsersMustCompile("~1", true, utils.INFIELD_SEP),
+				Mandatory: true,
+			},
+			&config.FCTemplate{
+				Tag:   "ActivationTime",
+				Path:  "ActivationTime",
+				Type:  utils.META_COMPOSED,
+				Value: config.NewRSRParsersMustCompile("~2", true, utils.INFIELD_SEP),
+			},
+			&config.FCTemplate{
+				Tag:   "Rate",
+				Path:  "Rate",
+				Type:  utils.META_COMPOSED,
+				Value: config.NewRSRParsersMustCompile("~3", true, utils.INFIELD_SEP),
+			},
+		},
+	}
+	rdr := ioutil.NopCloser(strings.NewReader(engine.ExchangeRatesCSVContent))
+	csvRdr := csv.NewReader(rdr)
+	csvRdr.Comment = '#'
+	ldr.rdrs = map[string]map[string]*openedCSVFile{
+		utils.MetaExchangeRates: map[string]*openedCSVFile{
+			utils.ExchangeRatesCsv: &openedCSVFile{
+				fileName: utils.ExchangeRatesCsv,
+				rdr:      rdr,
+				csvRdr:   csvRdr,
+			},
+		},
+	}
+	if err := ldr.processContent(utils.MetaExchangeRates, utils.EmptyString); err != nil {
+		t.Error(err)
+	}
+	if len(ldr.bufLoaderData) != 0 {
+		t.Errorf("wrong buffer content: %+v", ldr.bufLoaderData)
+	}
+	eExr := &engine.ExchangeRates{
+		FromCurrency: "EUR",
+		ToCurrency:   "USD",
+		Rates: []*engine.ExchangeRate{
+			{ActivationTime: time.Date(2014, 1, 1, 0, 0, 0, 0, time.UTC), Rate: 1.2},
+			{ActivationTime: time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC), Rate: 1.1},
+		},
+	}
+	if rcv, err := ldr.dm.GetExchangeRates("EUR", "USD", true, utils.NonTransactional); err != nil {
+		t.Error(err)
+	} else if !reflect.DeepEqual(eExr, rcv) {
+		t.Errorf("expecting: %s, received: %s", utils.ToJSON(eExr), utils.ToJSON(rcv))
+	}
+	if _, err := ldr.dm.GetExchangeRates("GBP", "EUR", true, utils.NonTransactional); err != nil {
+		t.Error(err)
+	}
+
+	rdr = ioutil.NopCloser(strings.NewReader(`EUR,USD,,`))
+	csvRdr = csv.NewReader(rdr)
+	ldr.rdrs[utils.MetaExchangeRates][utils.ExchangeRatesCsv] = &openedCSVFile{
+		fileName: utils.ExchangeRatesCsv, rdr: rdr, csvRdr: csvRdr}
+	if err := ldr.removeContent(utils.MetaExchangeRates, utils.EmptyString); err != nil {
+		t.Error(err)
+	}
+	if _, err := ldr.dm.GetExchangeRates("EUR", "USD", true,
+		utils.NonTransactional); err != utils.ErrNotFound {
+		t.Errorf("expecting: %v, received: %v", utils.ErrNotFound, err)
+	}
+	if _, err := ldr.dm.GetExchangeRates("GBP", "EUR", true, utils.NonTransactional); err != nil {
+		t.Error(err)
+	}
+}
+
 func TestLoaderRemoveContentSingleFile(t *testing.T) {
 	data := engine.NewInternalDB(nil, nil, true, config.CgrConfig().DataDbCfg().Items)
 	ldr := &Loader{
@@ -32,7 +32,7 @@ func NewMigratorDataDB(db_type, host, port, name, user, pass,
 	itemsCacheCfg map[string]*config.ItemOpt) (db MigratorDataDB, err error) {
 	dbCon, err := engine.NewDataDBConn(db_type,
 		host, port, name, user, pass, marshaler,
-		sentinelName, itemsCacheCfg)
+		sentinelName, false, 0, itemsCacheCfg, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -60,7 +60,7 @@ func NewMigratorStorDB(db_type, host, port, name, user, pass, marshaler, sslmode
 	var d MigratorStorDB
 	storDb, err := engine.NewStorDBConn(db_type, host, port, name, user,
 		pass, marshaler, sslmode, maxConn, maxIdleConn, connMaxLifetime,
-		stringIndexedFields, prefixIndexedFields, itemsCacheCfg)
+		stringIndexedFields, prefixIndexedFields, itemsCacheCfg, nil)
 	if err != nil {
 		return nil, err
 	}
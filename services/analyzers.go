@@ -25,6 +25,7 @@ import (
 	"github.com/cgrates/cgrates/analyzers"
 	v1 "github.com/cgrates/cgrates/apier/v1"
 	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/cgrates/engine"
 	"github.com/cgrates/cgrates/servmanager"
 	"github.com/cgrates/cgrates/utils"
 	"github.com/cgrates/rpcclient"
@@ -32,12 +33,14 @@ import (
 
 // NewAnalyzerService returns the Analyzer Service
 func NewAnalyzerService(cfg *config.CGRConfig, server *utils.Server, exitChan chan bool,
-	internalAnalyzerSChan chan rpcclient.ClientConnector) servmanager.Service {
+	internalAnalyzerSChan chan rpcclient.ClientConnector,
+	connMgr *engine.ConnManager) servmanager.Service {
 	return &AnalyzerService{
 		connChan: internalAnalyzerSChan,
 		cfg:      cfg,
 		server:   server,
 		exitChan: exitChan,
+		connMgr:  connMgr,
 	}
 }
 
@@ -47,6 +50,7 @@ type AnalyzerService struct {
 	cfg      *config.CGRConfig
 	server   *utils.Server
 	exitChan chan bool
+	connMgr  *engine.ConnManager
 
 	anz      *analyzers.AnalyzerService
 	rpc      *v1.AnalyzerSv1
@@ -58,7 +62,7 @@ func (anz *AnalyzerService) Start() (err error) {
 	if anz.IsRunning() {
 		return fmt.Errorf("service aleady running")
 	}
-	if anz.anz, err = analyzers.NewAnalyzerService(); err != nil {
+	if anz.anz, err = analyzers.NewAnalyzerService(anz.cfg); err != nil {
 		utils.Logger.Crit(fmt.Sprintf("<%s> Could not init, error: %s", utils.AnalyzerS, err.Error()))
 		anz.exitChan <- true
 		return
@@ -71,6 +75,8 @@ func (anz *AnalyzerService) Start() (err error) {
 		anz.exitChan <- true
 		return
 	}()
+	anz.server.SetAnalyzer(anz.anz)
+	anz.connMgr.SetAnalyzer(anz.anz)
 	anz.rpc = v1.NewAnalyzerSv1(anz.anz)
 	if !anz.cfg.DispatcherSCfg().Enabled {
 		anz.server.RpcRegister(anz.rpc)
@@ -93,6 +99,8 @@ func (anz *AnalyzerService) Reload() (err error) {
 // Shutdown stops the service
 func (anz *AnalyzerService) Shutdown() (err error) {
 	anz.Lock()
+	anz.server.SetAnalyzer(nil)
+	anz.connMgr.SetAnalyzer(nil)
 	anz.anz.Shutdown()
 	anz.anz = nil
 	anz.rpc = nil
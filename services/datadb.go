@@ -60,7 +60,9 @@ func (db *DataDBService) Start() (err error) {
 		db.cfg.DataDbCfg().DataDbHost, db.cfg.DataDbCfg().DataDbPort,
 		db.cfg.DataDbCfg().DataDbName, db.cfg.DataDbCfg().DataDbUser,
 		db.cfg.DataDbCfg().DataDbPass, db.cfg.GeneralCfg().DBDataEncoding,
-		db.cfg.DataDbCfg().DataDbSentinelName, db.cfg.DataDbCfg().Items)
+		db.cfg.DataDbCfg().DataDbSentinelName, db.cfg.DataDbCfg().RedisCluster,
+		db.cfg.DataDbCfg().RedisClusterSync, db.cfg.DataDbCfg().Items,
+		db.cfg.DataDbCfg().InternalPersistence)
 	if db.mandatoryDB() && err != nil { // Cannot configure getter database, show stopper
 		utils.Logger.Crit(fmt.Sprintf("Could not configure dataDb: %s exiting!", err))
 		return
@@ -159,7 +161,9 @@ func (db *DataDBService) needsConnectionReload() bool {
 		return true
 	}
 	if db.oldDBCfg.DataDbType == utils.REDIS {
-		return db.oldDBCfg.DataDbSentinelName != db.cfg.DataDbCfg().DataDbSentinelName
+		return db.oldDBCfg.DataDbSentinelName != db.cfg.DataDbCfg().DataDbSentinelName ||
+			db.oldDBCfg.RedisCluster != db.cfg.DataDbCfg().RedisCluster ||
+			db.oldDBCfg.RedisClusterSync != db.cfg.DataDbCfg().RedisClusterSync
 	}
 	return false
 }
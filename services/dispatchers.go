@@ -85,6 +85,7 @@ func (dspS *DispatcherService) Start() (err error) {
 		utils.Logger.Crit(fmt.Sprintf("<%s> Could not init, error: %s", utils.DispatcherS, err.Error()))
 		return
 	}
+	dspS.dspS.StartLoop()
 
 	// for the moment we dispable Apier through dispatcher
 	// until we figured out a better sollution in case of gob server
@@ -155,7 +156,10 @@ func (dspS *DispatcherService) GetIntenternalChan() (conn chan rpcclient.ClientC
 
 // Reload handles the change of config
 func (dspS *DispatcherService) Reload() (err error) {
-	return // for the momment nothing to reload
+	dspS.Lock()
+	dspS.dspS.Reload()
+	dspS.Unlock()
+	return
 }
 
 // Shutdown stops the service
@@ -32,13 +32,14 @@ import (
 
 // NewEventReaderService returns the EventReader Service
 func NewEventReaderService(cfg *config.CGRConfig, filterSChan chan *engine.FilterS,
-	exitChan chan bool, connMgr *engine.ConnManager) servmanager.Service {
+	exitChan chan bool, connMgr *engine.ConnManager, server *utils.Server) servmanager.Service {
 	return &EventReaderService{
 		rldChan:     make(chan struct{}, 1),
 		cfg:         cfg,
 		filterSChan: filterSChan,
 		exitChan:    exitChan,
 		connMgr:     connMgr,
+		httpRtr:     ers.NewHTTPRouter(server),
 	}
 }
 
@@ -53,6 +54,7 @@ type EventReaderService struct {
 	rldChan  chan struct{}
 	stopChan chan struct{}
 	connMgr  *engine.ConnManager
+	httpRtr  *ers.HTTPRouter
 }
 
 // Start should handle the sercive start
@@ -73,7 +75,7 @@ func (erS *EventReaderService) Start() (err error) {
 	utils.Logger.Info(fmt.Sprintf("<%s> starting <%s> subsystem", utils.CoreS, utils.ERs))
 
 	// build the service
-	erS.ers = ers.NewERService(erS.cfg, filterS, erS.stopChan, erS.connMgr)
+	erS.ers = ers.NewERService(erS.cfg, filterS, erS.stopChan, erS.connMgr, erS.httpRtr)
 	go func(ers *ers.ERService, rldChan chan struct{}) {
 		if err := ers.ListenAndServe(rldChan); err != nil {
 			utils.Logger.Err(fmt.Sprintf("<%s> error: <%s>", utils.ERs, err.Error()))
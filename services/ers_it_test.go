@@ -56,7 +56,7 @@ func TestEventReaderSReload(t *testing.T) {
 	srvMngr := servmanager.NewServiceManager(cfg, engineShutdown)
 	db := NewDataDBService(cfg, nil)
 	sS := NewSessionService(cfg, db, server, make(chan rpcclient.ClientConnector, 1), engineShutdown, nil)
-	attrS := NewEventReaderService(cfg, filterSChan, engineShutdown, nil)
+	attrS := NewEventReaderService(cfg, filterSChan, engineShutdown, nil, server)
 	engine.NewConnManager(cfg, nil)
 	srvMngr.AddServices(attrS, sS,
 		NewLoaderService(cfg, db, filterSChan, server, engineShutdown, make(chan rpcclient.ClientConnector, 1), nil), db)
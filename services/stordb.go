@@ -63,7 +63,7 @@ func (db *StorDBService) Start() (err error) {
 		db.cfg.StorDbCfg().SSLMode, db.cfg.StorDbCfg().MaxOpenConns,
 		db.cfg.StorDbCfg().MaxIdleConns, db.cfg.StorDbCfg().ConnMaxLifetime,
 		db.cfg.StorDbCfg().StringIndexedFields, db.cfg.StorDbCfg().PrefixIndexedFields,
-		db.cfg.StorDbCfg().Items)
+		db.cfg.StorDbCfg().Items, db.cfg.StorDbCfg().InternalPersistence)
 	if err != nil { // Cannot configure getter database, show stopper
 		utils.Logger.Crit(fmt.Sprintf("Could not configure storDB: %s exiting!", err))
 		return
@@ -88,6 +88,9 @@ func (db *StorDBService) Reload() (err error) {
 	db.Lock()
 	defer db.Unlock()
 	if db.reconnected = db.needsConnectionReload(); db.reconnected {
+		if _, isInternal := db.db.(*engine.InternalDB); isInternal {
+			db.db.Close() // release the persistence files before they are opened again
+		}
 		var d engine.StorDB
 		if d, err = engine.NewStorDBConn(db.cfg.StorDbCfg().Type, db.cfg.StorDbCfg().Host,
 			db.cfg.StorDbCfg().Port, db.cfg.StorDbCfg().Name, db.cfg.StorDbCfg().User,
@@ -95,7 +98,7 @@ func (db *StorDBService) Reload() (err error) {
 			db.cfg.StorDbCfg().SSLMode, db.cfg.StorDbCfg().MaxOpenConns,
 			db.cfg.StorDbCfg().MaxIdleConns, db.cfg.StorDbCfg().ConnMaxLifetime,
 			db.cfg.StorDbCfg().StringIndexedFields, db.cfg.StorDbCfg().PrefixIndexedFields,
-			db.cfg.StorDbCfg().Items); err != nil {
+			db.cfg.StorDbCfg().Items, db.cfg.StorDbCfg().InternalPersistence); err != nil {
 			return
 		}
 		db.db.Close()
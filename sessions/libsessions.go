@@ -20,6 +20,7 @@ package sessions
 
 import (
 	"errors"
+	"fmt"
 	"math/rand"
 	"strings"
 	"time"
@@ -98,6 +99,73 @@ func GetSetCGRID(ev engine.MapEvent) (cgrID string) {
 	return
 }
 
+// UsageUnit is one independent usage stream within a session (ie: Diameter Rating-Group)
+type UsageUnit struct {
+	Usage    *time.Duration         // requested usage on init/update, total usage on terminate
+	LastUsed *time.Duration         // usage consumed since the previous request
+	Event    map[string]interface{} // fields overwriting the session event for this unit only
+}
+
+// getUsageUnits extracts the usage units out of the event fields in the format Units.<UnitID>.<Field>
+// the extracted fields are removed from the event
+func getUsageUnits(ev engine.MapEvent) (units map[string]*UsageUnit, err error) {
+	for fldName, val := range ev {
+		if !strings.HasPrefix(fldName, utils.Units+utils.NestingSep) {
+			continue
+		}
+		fldPath := strings.SplitN(fldName, utils.NestingSep, 3)
+		if len(fldPath) != 3 ||
+			fldPath[1] == utils.EmptyString ||
+			fldPath[2] == utils.EmptyString {
+			return nil, fmt.Errorf("invalid usage unit field: <%s>", fldName)
+		}
+		if units == nil {
+			units = make(map[string]*UsageUnit)
+		}
+		unit, has := units[fldPath[1]]
+		if !has {
+			unit = new(UsageUnit)
+			units[fldPath[1]] = unit
+		}
+		switch fldPath[2] {
+		case utils.Usage, utils.LastUsed:
+			var dur time.Duration
+			if dur, err = utils.IfaceAsDuration(val); err != nil {
+				return nil, fmt.Errorf("invalid usage unit field: <%s>, err: %s", fldName, err.Error())
+			}
+			if fldPath[2] == utils.Usage {
+				unit.Usage = &dur
+			} else {
+				unit.LastUsed = &dur
+			}
+		default:
+			if unit.Event == nil {
+				unit.Event = make(map[string]interface{})
+			}
+			unit.Event[fldPath[2]] = val
+		}
+		delete(ev, fldName)
+	}
+	return
+}
+
+// unitCGRID returns the CGRID under which an usage unit of the session is charged
+func unitCGRID(cgrID, unitID string) string {
+	if unitID == utils.EmptyString {
+		return cgrID
+	}
+	return utils.Sha1(cgrID, unitID)
+}
+
+// unitsMaxUsageAsMap converts the max usage of the units so it can be navigated in replies (ie: UnitsMaxUsage.<UnitID>)
+func unitsMaxUsageAsMap(unitsMaxUsage map[string]time.Duration) (mp map[string]interface{}) {
+	mp = make(map[string]interface{})
+	for unitID, maxUsage := range unitsMaxUsage {
+		mp[unitID] = maxUsage
+	}
+	return
+}
+
 func getFlagIDs(flag string) []string {
 	flagWithIDs := strings.Split(flag, utils.InInFieldSep)
 	if len(flagWithIDs) <= 1 {
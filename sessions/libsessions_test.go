@@ -357,3 +357,56 @@ aa+jqv4dwkr/FLEcN1zC76Y/IniI65fId55hVJvN3ORuzUqYEtzD3irmsw==
 		t.Fatal(err)
 	}
 }
+
+func TestGetUsageUnits(t *testing.T) {
+	ev := engine.MapEvent{
+		utils.OriginID:     "sess1",
+		utils.Usage:        "10s",
+		"Units.1.Usage":    "1024",
+		"Units.1.LastUsed": 512,
+		"Units.2.Usage":    2048,
+		"Units.2.Category": "data2",
+	}
+	eUnits := map[string]*UsageUnit{
+		"1": {
+			Usage:    utils.DurationPointer(1024),
+			LastUsed: utils.DurationPointer(512),
+		},
+		"2": {
+			Usage: utils.DurationPointer(2048),
+			Event: map[string]interface{}{utils.Category: "data2"},
+		},
+	}
+	eEv := engine.MapEvent{
+		utils.OriginID: "sess1",
+		utils.Usage:    "10s",
+	}
+	if units, err := getUsageUnits(ev); err != nil {
+		t.Error(err)
+	} else if !reflect.DeepEqual(eUnits, units) {
+		t.Errorf("Expected %s , received: %s", utils.ToJSON(eUnits), utils.ToJSON(units))
+	} else if !reflect.DeepEqual(eEv, ev) {
+		t.Errorf("Expected %s , received: %s", utils.ToJSON(eEv), utils.ToJSON(ev))
+	}
+	// no units
+	if units, err := getUsageUnits(ev); err != nil {
+		t.Error(err)
+	} else if units != nil {
+		t.Errorf("Expected no units, received: %s", utils.ToJSON(units))
+	}
+	if _, err := getUsageUnits(engine.MapEvent{"Units.1": 10}); err == nil {
+		t.Error("Expected error for field without unit path")
+	}
+	if _, err := getUsageUnits(engine.MapEvent{"Units.1.Usage": "notADuration"}); err == nil {
+		t.Error("Expected error for invalid usage")
+	}
+}
+
+func TestUnitCGRID(t *testing.T) {
+	if rcv := unitCGRID("cgrID1", ""); rcv != "cgrID1" {
+		t.Errorf("Expected cgrID1, received: %s", rcv)
+	}
+	if rcv := unitCGRID("cgrID1", "1"); rcv != utils.Sha1("cgrID1", "1") {
+		t.Errorf("Expected %s, received: %s", utils.Sha1("cgrID1", "1"), rcv)
+	}
+}
@@ -41,6 +41,7 @@ func (s *SessionID) CGRID() string {
 type ExternalSession struct {
 	CGRID         string
 	RunID         string
+	UnitID        string            // usage unit (ie: rating group) charged by this run, empty for single unit sessions
 	ToR           string            // type of record, meta-field, should map to one of the TORs hardcoded inside the server <*voice|*data|*sms|*generic>
 	OriginID      string            // represents the unique accounting id given by the telecom switch generating the CDR
 	OriginHost    string            // represents the IP address of the host generating the CDR (automatically populated by the server)
@@ -138,6 +139,7 @@ func (s *Session) AsExternalSessions(tmz, nodeID string) (aSs []*ExternalSession
 		aSs[i] = &ExternalSession{
 			CGRID:         s.CGRID,
 			RunID:         sr.Event.GetStringIgnoreErrors(utils.RunID),
+			UnitID:        sr.UnitID,
 			ToR:           sr.Event.GetStringIgnoreErrors(utils.ToR),
 			OriginID:      s.EventStart.GetStringIgnoreErrors(utils.OriginID),
 			OriginHost:    s.EventStart.GetStringIgnoreErrors(utils.OriginHost),
@@ -175,6 +177,7 @@ func (s *Session) AsExternalSession(sr *SRun, tmz, nodeID string) (aS *ExternalS
 	aS = &ExternalSession{
 		CGRID:         s.CGRID,
 		RunID:         sr.Event.GetStringIgnoreErrors(utils.RunID),
+		UnitID:        sr.UnitID,
 		ToR:           sr.Event.GetStringIgnoreErrors(utils.ToR),
 		OriginID:      s.EventStart.GetStringIgnoreErrors(utils.OriginID),
 		OriginHost:    s.EventStart.GetStringIgnoreErrors(utils.OriginHost),
@@ -251,11 +254,29 @@ func (s *Session) stopDebitLoops() {
 	}
 }
 
+// hasUnit returns true if the session is charging the given usage unit
+// not thread safe
+func (s *Session) hasUnit(unitID string) bool {
+	for _, sr := range s.SRuns {
+		if sr.UnitID == unitID {
+			return true
+		}
+	}
+	return false
+}
+
+// sRunCGRID returns the CGRID used when charging the SRun
+// the usage units are charged under their own CGRID so they can be stored independently
+func (s *Session) sRunCGRID(sr *SRun) string {
+	return unitCGRID(s.CGRID, sr.UnitID)
+}
+
 // SRun is one billing run for the Session
 type SRun struct {
 	Event     engine.MapEvent        // Event received from ChargerS
 	CD        *engine.CallDescriptor // initial CD used for debits, updated on each debit
 	EventCost *engine.EventCost
+	UnitID    string // usage unit (ie: rating group) charged by this run, empty for single unit sessions
 
 	ExtraDuration time.Duration // keeps the current duration debited on top of what has been asked
 	LastUsage     time.Duration // last requested Duration
@@ -267,6 +288,7 @@ type SRun struct {
 func (sr *SRun) Clone() (clsr *SRun) {
 	clsr = &SRun{
 		Event:         sr.Event.Clone(),
+		UnitID:        sr.UnitID,
 		ExtraDuration: sr.ExtraDuration,
 		LastUsage:     sr.LastUsage,
 		TotalUsage:    sr.TotalUsage,
@@ -23,6 +23,7 @@ import (
 	"fmt"
 	"math/rand"
 	"reflect"
+	"sort"
 	"strings"
 	"sync"
 	"time"
@@ -46,19 +47,22 @@ func NewSessionS(cgrCfg *config.CGRConfig,
 	dm *engine.DataManager,
 	connMgr *engine.ConnManager) *SessionS {
 	cgrCfg.SessionSCfg().SessionIndexes[utils.OriginID] = true // Make sure we have indexing for OriginID since it is a requirement on prefix searching
-
+	ms, _ := engine.NewMarshaler(cgrCfg.GeneralCfg().DBDataEncoding)
 	return &SessionS{
-		cgrCfg:        cgrCfg,
-		dm:            dm,
-		connMgr:       connMgr,
-		biJClnts:      make(map[rpcclient.ClientConnector]string),
-		biJIDs:        make(map[string]*biJClient),
-		aSessions:     make(map[string]*Session),
-		aSessionsIdx:  make(map[string]map[string]map[string]utils.StringMap),
-		aSessionsRIdx: make(map[string][]*riFieldNameVal),
-		pSessions:     make(map[string]*Session),
-		pSessionsIdx:  make(map[string]map[string]map[string]utils.StringMap),
-		pSessionsRIdx: make(map[string][]*riFieldNameVal),
+		cgrCfg:         cgrCfg,
+		dm:             dm,
+		connMgr:        connMgr,
+		ms:             ms,
+		storedSessions: make(utils.StringMap),
+		biJClnts:       make(map[rpcclient.ClientConnector]string),
+		biJIDs:         make(map[string]*biJClient),
+		aSessions:      make(map[string]*Session),
+		aSessionsIdx:   make(map[string]map[string]map[string]utils.StringMap),
+		aSessionsRIdx:  make(map[string][]*riFieldNameVal),
+		pSessions:      make(map[string]*Session),
+		pSessionsIdx:   make(map[string]map[string]map[string]utils.StringMap),
+		pSessionsRIdx:  make(map[string][]*riFieldNameVal),
+		reservations:   make(map[string]*eventReservation),
 	}
 }
 
@@ -91,11 +95,22 @@ type SessionS struct {
 	pSIMux        sync.RWMutex                                     // protects pSessionsIdx
 	pSessionsIdx  map[string]map[string]map[string]utils.StringMap // map[fieldName]map[fieldValue][cgrID]utils.StringMap[runID]sID
 	pSessionsRIdx map[string][]*riFieldNameVal                     // reverse indexes for passive sessions, used on remove
+
+	ms             engine.Marshaler // encodes the sessions stored into dataDB
+	ssMux          sync.Mutex       // protects storedSessions and serializes the dataDB operations on sessions
+	storedSessions utils.StringMap  // keep a record of active sessions which need saving, map[cgrID]bool
+
+	rsrvMux      sync.Mutex                   // protects reservations
+	reservations map[string]*eventReservation // events charged in advance, waiting for commit, indexed on CGRID
 }
 
 // ListenAndServe starts the service and binds it to the listen loop
 func (sS *SessionS) ListenAndServe(exitChan chan bool) (err error) {
 	utils.Logger.Info(fmt.Sprintf("<%s> starting <%s> subsystem", utils.CoreS, utils.SessionS))
+	sS.restoreSessions()
+	if sS.cgrCfg.SessionSCfg().StoreInterval > 0 {
+		go sS.runBackup(exitChan)
+	}
 	if sS.cgrCfg.SessionSCfg().ChannelSyncInterval != 0 {
 		go func() {
 			for { // Schedule sync channels to run repeately
@@ -117,8 +132,20 @@ func (sS *SessionS) ListenAndServe(exitChan chan bool) (err error) {
 
 // Shutdown is called by engine to clear states
 func (sS *SessionS) Shutdown() (err error) {
+	if sS.cgrCfg.SessionSCfg().StoreInterval != 0 { // keep the sessions so they can be restored
+		sS.stopReservations()
+		for _, s := range sS.getSessions("", false) {
+			s.Lock()
+			s.stopSTerminator()
+			s.stopDebitLoops()
+			s.Unlock()
+			sS.storeActiveSession(s.CGRID)
+		}
+		return
+	}
+	sS.releaseReservations()
 	for _, s := range sS.getSessions("", false) { // Force sessions shutdown
-		sS.terminateSession(s, nil, nil, nil, false)
+		sS.terminateSession(s, nil, nil, nil, nil, false)
 	}
 	return
 }
@@ -310,7 +337,7 @@ func (sS *SessionS) forceSTerminate(s *Session, extraDebit time.Duration, lastUs
 		}
 	}
 	// we apply the correction before
-	if err = sS.endSession(s, nil, nil, nil, false); err != nil {
+	if err = sS.endSession(s, nil, nil, nil, nil, false); err != nil {
 		utils.Logger.Warning(
 			fmt.Sprintf(
 				"<%s> failed force terminating session with ID <%s>, err: <%s>",
@@ -454,7 +481,7 @@ func (sS *SessionS) debitSession(s *Session, sRunIdx int, dur time.Duration,
 	sr.CD.MaxCostSoFar += cc.Cost
 	sr.CD.LoopIndex++
 	sr.TotalUsage += sr.LastUsage
-	ec := engine.NewEventCostFromCallCost(cc, s.CGRID,
+	ec := engine.NewEventCostFromCallCost(cc, s.sRunCGRID(sr),
 		sr.Event.GetStringIgnoreErrors(utils.RunID))
 	if sr.EventCost == nil {
 		if ccDuration != time.Duration(0) {
@@ -476,7 +503,7 @@ func (sS *SessionS) debitLoopSession(s *Session, sRunIdx int,
 	now := time.Now()
 	if s.SRuns[sRunIdx].NextAutoDebit != nil &&
 		now.Before(*s.SRuns[sRunIdx].NextAutoDebit) {
-		time.Sleep(now.Sub(*s.SRuns[sRunIdx].NextAutoDebit))
+		time.Sleep(s.SRuns[sRunIdx].NextAutoDebit.Sub(now))
 	}
 	for {
 		s.Lock()
@@ -516,6 +543,7 @@ func (sS *SessionS) debitLoopSession(s *Session, sRunIdx int,
 			}
 		}
 		s.Unlock()
+		sS.storeSession(s.CGRID)
 		sS.replicateSessions(s.CGRID, false, sS.cgrCfg.SessionSCfg().ReplicationConns)
 		if maxDebit < dbtIvl { // disconnect faster
 			select {
@@ -582,7 +610,7 @@ func (sS *SessionS) refundSession(s *Session, sRunIdx int, rUsage time.Duration)
 		}
 	}
 	cd := &engine.CallDescriptor{
-		CgrID:       s.CGRID,
+		CgrID:       s.sRunCGRID(sr),
 		RunID:       sr.Event.GetStringIgnoreErrors(utils.RunID),
 		Category:    sr.CD.Category,
 		Tenant:      sr.CD.Tenant,
@@ -615,7 +643,7 @@ func (sS *SessionS) storeSCost(s *Session, sRunIdx int) (err error) {
 		return // no costs to save, ignore the operation
 	}
 	smCost := &engine.V2SMCost{
-		CGRID:       s.CGRID,
+		CGRID:       s.sRunCGRID(sr),
 		CostSource:  utils.MetaSessionS,
 		RunID:       sr.Event.GetStringIgnoreErrors(utils.RunID),
 		OriginHost:  s.EventStart.GetStringIgnoreErrors(utils.OriginHost),
@@ -725,6 +753,131 @@ func (sS *SessionS) replicateSessions(cgrID string, psv bool, connIDs []string)
 	return
 }
 
+// storeSession marks the active session for saving into dataDB
+// or stores it directly if store_interval is -1
+func (sS *SessionS) storeSession(cgrID string) {
+	switch sS.cgrCfg.SessionSCfg().StoreInterval {
+	case 0: // persistence disabled
+	case -1:
+		sS.storeActiveSession(cgrID)
+	default:
+		sS.ssMux.Lock()
+		sS.storedSessions[cgrID] = true
+		sS.ssMux.Unlock()
+	}
+}
+
+// storeActiveSession stores the active session into dataDB
+// the session should not be locked by the caller
+func (sS *SessionS) storeActiveSession(cgrID string) (err error) {
+	ss := sS.getSessions(cgrID, false)
+	if len(ss) == 0 { // terminated in the meantime
+		return
+	}
+	sCln := ss[0].Clone()
+	sCln.ArgDispatcher = ss[0].ArgDispatcher
+	var sBytes []byte
+	if sBytes, err = sS.ms.Marshal(sCln); err == nil {
+		sS.ssMux.Lock()
+		delete(sS.storedSessions, cgrID)
+		if sS.isIndexed(sCln, false) { // not unregistered while encoding
+			err = sS.dm.SetStoredSession(&engine.StoredSession{
+				NodeID:  sS.cgrCfg.GeneralCfg().NodeID,
+				CGRID:   cgrID,
+				Session: sBytes,
+			})
+		}
+		sS.ssMux.Unlock()
+	}
+	if err != nil {
+		utils.Logger.Warning(
+			fmt.Sprintf("<%s> failed storing session with id <%s>, err: %s",
+				utils.SessionS, cgrID, err.Error()))
+	}
+	return
+}
+
+// removeStoredSession removes the session from dataDB
+func (sS *SessionS) removeStoredSession(cgrID string) {
+	if sS.cgrCfg.SessionSCfg().StoreInterval == 0 {
+		return
+	}
+	sS.ssMux.Lock()
+	delete(sS.storedSessions, cgrID)
+	if err := sS.dm.RemoveStoredSession(sS.cgrCfg.GeneralCfg().NodeID, cgrID); err != nil {
+		utils.Logger.Warning(
+			fmt.Sprintf("<%s> failed removing stored session with id <%s>, err: %s",
+				utils.SessionS, cgrID, err.Error()))
+	}
+	sS.ssMux.Unlock()
+}
+
+// storeSessions stores all the active sessions marked for saving
+func (sS *SessionS) storeSessions() {
+	sS.ssMux.Lock()
+	cgrIDs := sS.storedSessions.Slice()
+	sS.ssMux.Unlock()
+	for _, cgrID := range cgrIDs {
+		sS.storeActiveSession(cgrID)
+	}
+}
+
+// runBackup will regularly store the changed sessions into dataDB
+func (sS *SessionS) runBackup(exitChan chan bool) {
+	for {
+		select {
+		case e := <-exitChan:
+			exitChan <- e // put back for the others listening for shutdown request
+			return
+		case <-time.After(sS.cgrCfg.SessionSCfg().StoreInterval):
+			sS.storeSessions()
+		}
+	}
+}
+
+// restoreSessions loads the sessions stored by this node and resumes their debit loops and terminators
+// the sessions are resynced with the agents by the syncSessions loop
+func (sS *SessionS) restoreSessions() {
+	if sS.cgrCfg.SessionSCfg().StoreInterval == 0 {
+		return
+	}
+	sSs, err := sS.dm.GetStoredSessions(sS.cgrCfg.GeneralCfg().NodeID)
+	if err != nil {
+		utils.Logger.Warning(
+			fmt.Sprintf("<%s> failed querying stored sessions, err: %s",
+				utils.SessionS, err.Error()))
+		return
+	}
+	var restored int
+	for _, stored := range sSs {
+		s := new(Session)
+		if err = sS.ms.Unmarshal(stored.Session, s); err != nil {
+			utils.Logger.Warning(
+				fmt.Sprintf("<%s> failed decoding stored session with id <%s>, err: %s",
+					utils.SessionS, stored.CGRID, err.Error()))
+			continue
+		}
+		if stored.ReservedUsage != nil { // event reserved in advance
+			if sS.getReservation(stored.CGRID) == nil {
+				sS.setReservation(stored.CGRID, &eventReservation{s: s,
+					usage: *stored.ReservedUsage, expiry: stored.ReservationExpiry})
+				restored++
+			}
+			continue
+		}
+		if s.EventStart == nil || sS.isIndexed(s, false) {
+			continue
+		}
+		s.Lock()
+		sS.setSTerminator(s)
+		sS.initSessionDebitLoops(s)
+		s.Unlock()
+		sS.registerSession(s, false)
+		restored++
+	}
+	utils.Logger.Info(fmt.Sprintf("<%s> restored %d sessions", utils.SessionS, restored))
+}
+
 // registerSession will register an active or passive Session
 // called on init or relocate
 // not thread safe for the Session
@@ -739,6 +892,9 @@ func (sS *SessionS) registerSession(s *Session, passive bool) {
 	sMp[s.CGRID] = s
 	sMux.Unlock()
 	sS.indexSession(s, passive)
+	if !passive {
+		sS.storeSession(s.CGRID)
+	}
 }
 
 // isIndexed returns if the session is indexed
@@ -772,6 +928,9 @@ func (sS *SessionS) unregisterSession(cgrID string, passive bool) bool {
 	delete(sMp, cgrID)
 	sMux.Unlock()
 	sS.unindexSession(cgrID, passive)
+	if !passive {
+		sS.removeStoredSession(cgrID)
+	}
 	return true
 }
 
@@ -1054,27 +1213,84 @@ func (sS *SessionS) filterSessionsCount(sf *utils.SessionFilter, psv bool) (coun
 // forkSession will populate SRuns within a Session based on ChargerS output
 // forSession can only be called once per Session
 // not thread-safe since it should be called in init where there is no concurrency
-func (sS *SessionS) forkSession(s *Session, forceDuration bool) (err error) {
+func (sS *SessionS) forkSession(s *Session, forceDuration bool, units map[string]*UsageUnit) (err error) {
 	if len(sS.cgrCfg.SessionSCfg().ChargerSConns) == 0 {
 		return errors.New("ChargerS is disabled")
 	}
 	if len(s.SRuns) != 0 {
 		return errors.New("already forked")
 	}
+	if len(units) == 0 {
+		s.SRuns, err = sS.forkSRuns(s, utils.EmptyString, nil, forceDuration)
+		return
+	}
+	return sS.addUsageUnits(s, units, forceDuration)
+}
+
+// addUsageUnits will fork the SRuns for the usage units not yet charged by the session
+// not thread-safe, the Session needs to be locked in a layer above
+func (sS *SessionS) addUsageUnits(s *Session, units map[string]*UsageUnit, forceDuration bool) (err error) {
+	unitIDs := make([]string, 0, len(units))
+	for unitID := range units {
+		if !s.hasUnit(unitID) {
+			unitIDs = append(unitIDs, unitID)
+		}
+	}
+	sort.Strings(unitIDs) // keep the SRuns order predictable
+	for _, unitID := range unitIDs {
+		var sRuns []*SRun
+		if sRuns, err = sS.forkSRuns(s, unitID, units[unitID], forceDuration); err != nil {
+			return
+		}
+		s.SRuns = append(s.SRuns, sRuns...)
+	}
+	return
+}
+
+// updateUsageUnits adds to an active session the usage units it does not charge yet
+// the debit loops are started for the new SRuns
+func (sS *SessionS) updateUsageUnits(s *Session, units map[string]*UsageUnit, forceDuration bool) (err error) {
+	if len(units) == 0 {
+		return
+	}
+	s.Lock()
+	defer s.Unlock()
+	fromIdx := len(s.SRuns)
+	err = sS.addUsageUnits(s, units, forceDuration)
+	sS.initSRunsDebitLoops(s, fromIdx) // start the loops also for the units forked before an error
+	return
+}
+
+// forkSRuns will build the SRuns of one usage unit based on ChargerS output
+// the unit is nil for sessions charging a single usage stream
+func (sS *SessionS) forkSRuns(s *Session, unitID string, unit *UsageUnit,
+	forceDuration bool) (sRuns []*SRun, err error) {
+	ev := s.EventStart
+	if unit != nil {
+		ev = s.EventStart.Clone()
+		for k, v := range unit.Event {
+			ev[k] = v
+		}
+		if unit.Usage != nil {
+			ev[utils.Usage] = *unit.Usage
+		}
+		ev[utils.UnitID] = unitID
+		ev[utils.CGRID] = unitCGRID(s.CGRID, unitID)
+	}
 	cgrEv := &utils.CGREventWithArgDispatcher{
 		CGREvent: &utils.CGREvent{
 			Tenant: s.Tenant,
 			ID:     utils.UUIDSha1Prefix(),
-			Event:  s.EventStart,
+			Event:  ev,
 		},
 		ArgDispatcher: s.ArgDispatcher,
 	}
 	var chrgrs []*engine.ChrgSProcessEventReply
 	if err = sS.connMgr.Call(sS.cgrCfg.SessionSCfg().ChargerSConns, nil,
 		utils.ChargerSv1ProcessEvent, cgrEv, &chrgrs); err != nil {
-		return utils.NewErrChargerS(err)
+		return nil, utils.NewErrChargerS(err)
 	}
-	s.SRuns = make([]*SRun, len(chrgrs))
+	sRuns = make([]*SRun, len(chrgrs))
 	for i, chrgr := range chrgrs {
 		me := engine.MapEvent(chrgr.CGREvent.Event).Clone()
 		startTime := me.GetTimeIgnoreErrors(utils.AnswerTime,
@@ -1091,10 +1307,10 @@ func (sS *SessionS) forkSession(s *Session, forceDuration bool) (err error) {
 		if len(subject) == 0 {
 			subject = me.GetStringIgnoreErrors(utils.Account)
 		}
-		s.SRuns[i] = &SRun{
+		sRuns[i] = &SRun{
 			Event: me,
 			CD: &engine.CallDescriptor{
-				CgrID:         s.CGRID,
+				CgrID:         unitCGRID(s.CGRID, unitID),
 				RunID:         me.GetStringIgnoreErrors(utils.RunID),
 				ToR:           me.GetStringIgnoreErrors(utils.ToR),
 				Tenant:        s.Tenant,
@@ -1103,10 +1319,11 @@ func (sS *SessionS) forkSession(s *Session, forceDuration bool) (err error) {
 				Account:       me.GetStringIgnoreErrors(utils.Account),
 				Destination:   me.GetStringIgnoreErrors(utils.Destination),
 				TimeStart:     startTime,
-				TimeEnd:       startTime.Add(s.EventStart.GetDurationIgnoreErrors(utils.Usage)),
+				TimeEnd:       startTime.Add(ev.GetDurationIgnoreErrors(utils.Usage)),
 				ExtraFields:   me.AsMapString(utils.MainCDRFields),
 				ForceDuration: forceDuration,
 			},
+			UnitID: unitID,
 		}
 	}
 	return
@@ -1286,7 +1503,15 @@ func (sS *SessionS) initSessionDebitLoops(s *Session) {
 	if s.debitStop != nil { // already initialized
 		return
 	}
-	for i, sr := range s.SRuns {
+	sS.initSRunsDebitLoops(s, 0)
+}
+
+// initSRunsDebitLoops will init the debit loops for the SRuns starting with the fromIdx
+// used also when new usage units are added to an active session
+// not thread-safe, it should be protected in another layer
+func (sS *SessionS) initSRunsDebitLoops(s *Session, fromIdx int) {
+	for i := fromIdx; i < len(s.SRuns); i++ {
+		sr := s.SRuns[i]
 		if s.DebitInterval != 0 &&
 			sr.Event.GetStringIgnoreErrors(utils.RequestType) == utils.META_PREPAID {
 			if s.debitStop == nil { // init the debitStop only for the first sRun with DebitInterval and RequestType META_PREPAID
@@ -1332,7 +1557,7 @@ func (sS *SessionS) authEvent(tnt string, evStart engine.MapEvent, forceDuration
 			s.ArgDispatcher.RouteID = utils.StringPointer(routeID)
 		}
 	}
-	if err = sS.forkSession(s, forceDuration); err != nil {
+	if err = sS.forkSession(s, forceDuration, nil); err != nil {
 		return
 	}
 	var maxUsageSet bool // so we know if we have set the 0 on purpose
@@ -1360,9 +1585,11 @@ func (sS *SessionS) authEvent(tnt string, evStart engine.MapEvent, forceDuration
 }
 
 // initSession handles a new session
+// the units are optional, when present each of them is charged independently
 // not thread-safe for Session since it is constructed here
 func (sS *SessionS) initSession(tnt string, evStart engine.MapEvent, clntConnID string,
-	resID string, dbtItval time.Duration, argDisp *utils.ArgDispatcher, isMsg, forceDuration bool) (s *Session, err error) {
+	resID string, dbtItval time.Duration, argDisp *utils.ArgDispatcher, isMsg, forceDuration bool,
+	units map[string]*UsageUnit) (s *Session, err error) {
 	cgrID := GetSetCGRID(evStart)
 	s = &Session{
 		CGRID:         cgrID,
@@ -1376,7 +1603,7 @@ func (sS *SessionS) initSession(tnt string, evStart engine.MapEvent, clntConnID
 	if !isMsg && sS.isIndexed(s, false) { // check if already exists
 		return nil, utils.ErrExists
 	}
-	if err = sS.forkSession(s, forceDuration); err != nil {
+	if err = sS.forkSession(s, forceDuration, units); err != nil {
 		return nil, err
 	}
 	if !isMsg {
@@ -1387,9 +1614,12 @@ func (sS *SessionS) initSession(tnt string, evStart engine.MapEvent, clntConnID
 }
 
 // updateSession will reset terminator, perform debits and replicate sessions
-func (sS *SessionS) updateSession(s *Session, updtEv engine.MapEvent, isMsg bool) (maxUsage time.Duration, err error) {
+// with units present only the SRuns of those units are debited, returning also their individual max usage
+func (sS *SessionS) updateSession(s *Session, updtEv engine.MapEvent, units map[string]*UsageUnit,
+	isMsg bool) (maxUsage time.Duration, unitsMaxUsage map[string]time.Duration, err error) {
 	if !isMsg {
 		defer sS.replicateSessions(s.CGRID, false, sS.cgrCfg.SessionSCfg().ReplicationConns)
+		defer sS.storeSession(s.CGRID)
 		s.Lock()
 		defer s.Unlock()
 
@@ -1417,18 +1647,40 @@ func (sS *SessionS) updateSession(s *Session, updtEv engine.MapEvent, isMsg bool
 		reqMaxUsage = sS.cgrCfg.SessionSCfg().MaxCallDuration
 		updtEv[utils.Usage] = reqMaxUsage
 	}
+	lastUsed := updtEv.GetDurationPtrIgnoreErrors(utils.LastUsed)
+	if len(units) != 0 {
+		unitsMaxUsage = make(map[string]time.Duration)
+	}
 	var maxUsageSet bool // so we know if we have set the 0 on purpose
 	for i, sr := range s.SRuns {
+		sRunReqUsage := reqMaxUsage
+		sRunLastUsed := lastUsed
+		if len(units) != 0 {
+			unit, has := units[sr.UnitID]
+			if !has { // only the units present in the request are debited
+				continue
+			}
+			sRunReqUsage = sS.cgrCfg.SessionSCfg().MaxCallDuration
+			if unit.Usage != nil {
+				sRunReqUsage = *unit.Usage
+			}
+			sRunLastUsed = unit.LastUsed
+		}
 		var rplyMaxUsage time.Duration
 		if !authReqs.HasField(
 			sr.Event.GetStringIgnoreErrors(utils.RequestType)) {
-			rplyMaxUsage = reqMaxUsage
-		} else if rplyMaxUsage, err = sS.debitSession(s, i, reqMaxUsage,
-			updtEv.GetDurationPtrIgnoreErrors(utils.LastUsed)); err != nil {
+			rplyMaxUsage = sRunReqUsage
+		} else if rplyMaxUsage, err = sS.debitSession(s, i, sRunReqUsage,
+			sRunLastUsed); err != nil {
 			return
 		}
-		if rplyMaxUsage > reqMaxUsage {
-			rplyMaxUsage = reqMaxUsage
+		if rplyMaxUsage > sRunReqUsage {
+			rplyMaxUsage = sRunReqUsage
+		}
+		if unitsMaxUsage != nil {
+			if unitMaxUsage, has := unitsMaxUsage[sr.UnitID]; !has || rplyMaxUsage < unitMaxUsage {
+				unitsMaxUsage[sr.UnitID] = rplyMaxUsage
+			}
 		}
 		if !maxUsageSet || rplyMaxUsage < maxUsage {
 			maxUsage = rplyMaxUsage
@@ -1441,17 +1693,18 @@ func (sS *SessionS) updateSession(s *Session, updtEv engine.MapEvent, isMsg bool
 // terminateSession will end a session from outside
 // calls endSession thread safe
 func (sS *SessionS) terminateSession(s *Session, tUsage, lastUsage *time.Duration,
-	aTime *time.Time, isMsg bool) (err error) {
+	aTime *time.Time, units map[string]*UsageUnit, isMsg bool) (err error) {
 	s.Lock()
-	err = sS.endSession(s, tUsage, lastUsage, aTime, isMsg)
+	err = sS.endSession(s, tUsage, lastUsage, aTime, units, isMsg)
 	s.Unlock()
 	return
 }
 
 // endSession will end a session from outside
+// the SRuns of the units present are ended with the usage of their unit
 // this function is not thread safe
 func (sS *SessionS) endSession(s *Session, tUsage, lastUsage *time.Duration,
-	aTime *time.Time, isMsg bool) (err error) {
+	aTime *time.Time, units map[string]*UsageUnit, isMsg bool) (err error) {
 	if !isMsg {
 		//check if we have replicate connection and close the session there
 		defer sS.replicateSessions(s.CGRID, true, sS.cgrCfg.SessionSCfg().ReplicationConns)
@@ -1460,14 +1713,20 @@ func (sS *SessionS) endSession(s *Session, tUsage, lastUsage *time.Duration,
 		s.stopDebitLoops()
 	}
 	for sRunIdx, sr := range s.SRuns {
+		sRunTUsage, sRunLastUsage := tUsage, lastUsage
+		if unit, has := units[sr.UnitID]; has {
+			sRunTUsage, sRunLastUsage = unit.Usage, unit.LastUsed
+		} else if sr.UnitID != utils.EmptyString { // unit not reported, keep the usage debited so far
+			sRunTUsage, sRunLastUsage = nil, nil
+		}
 		sUsage := sr.TotalUsage
-		if tUsage != nil {
-			sUsage = *tUsage
-			sr.TotalUsage = *tUsage
-		} else if lastUsage != nil &&
-			sr.LastUsage != *lastUsage {
+		if sRunTUsage != nil {
+			sUsage = *sRunTUsage
+			sr.TotalUsage = *sRunTUsage
+		} else if sRunLastUsage != nil &&
+			sr.LastUsage != *sRunLastUsage {
 			sr.TotalUsage -= sr.LastUsage
-			sr.TotalUsage += *lastUsage
+			sr.TotalUsage += *sRunLastUsage
 			sUsage = sr.TotalUsage
 		}
 		if sr.EventCost != nil {
@@ -1483,7 +1742,7 @@ func (sS *SessionS) endSession(s *Session, tUsage, lastUsage *time.Duration,
 						CallDescriptor: sr.CD,
 						ArgDispatcher:  s.ArgDispatcher}, cc); err == nil {
 					sr.EventCost.Merge(
-						engine.NewEventCostFromCallCost(cc, s.CGRID,
+						engine.NewEventCostFromCallCost(cc, s.sRunCGRID(sr),
 							sr.Event.GetStringIgnoreErrors(utils.RunID)))
 				}
 			} else if notCharged < 0 { // charged too much, try refund
@@ -1521,37 +1780,224 @@ func (sS *SessionS) endSession(s *Session, tUsage, lastUsage *time.Duration,
 	return
 }
 
-// chargeEvent will charge a single event (ie: SMS)
-func (sS *SessionS) chargeEvent(tnt string, ev engine.MapEvent,
-	argDisp *utils.ArgDispatcher, forceDuration bool) (maxUsage time.Duration, err error) {
-	cgrID := GetSetCGRID(ev)
-	var s *Session
-	if s, err = sS.initSession(tnt, ev, "", "", 0, argDisp, true, forceDuration); err != nil {
+// debitEvent will init and debit a single event, returning the session to be terminated by the caller
+// the usage is the one which should be terminated with: maxUsage or the one from event for postpaid and rated
+func (sS *SessionS) debitEvent(tnt string, ev engine.MapEvent, argDisp *utils.ArgDispatcher,
+	forceDuration bool) (s *Session, maxUsage, usage time.Duration, err error) {
+	if s, err = sS.initSession(tnt, ev, "", "", 0, argDisp, true, forceDuration, nil); err != nil {
 		return
 	}
-	if maxUsage, err = sS.updateSession(s, nil, true); err != nil {
+	if maxUsage, _, err = sS.updateSession(s, nil, nil, true); err != nil {
 		if errEnd := sS.terminateSession(s,
-			utils.DurationPointer(time.Duration(0)), nil, nil, true); errEnd != nil {
+			utils.DurationPointer(time.Duration(0)), nil, nil, nil, true); errEnd != nil {
 			utils.Logger.Warning(
 				fmt.Sprintf("<%s> error when force-ending charged event: <%s>, err: <%s>",
-					utils.SessionS, cgrID, errEnd.Error()))
+					utils.SessionS, s.CGRID, errEnd.Error()))
 		}
 		err = utils.NewErrRALs(err)
 		return
 	}
-	usage := maxUsage
+	usage = maxUsage
+	//in case of postpaid and rated maxUsage = usage from event
 	if utils.SliceHasMember(utils.PostPaidRatedSlice, ev.GetStringIgnoreErrors(utils.RequestType)) {
 		usage = ev.GetDurationIgnoreErrors(utils.Usage)
 	}
-	//in case of postpaid and rated maxUsage = usage from event
-	if errEnd := sS.terminateSession(s, utils.DurationPointer(usage), nil, nil, true); errEnd != nil {
+	return
+}
+
+// chargeEvent will charge a single event (ie: SMS)
+func (sS *SessionS) chargeEvent(tnt string, ev engine.MapEvent,
+	argDisp *utils.ArgDispatcher, forceDuration bool) (maxUsage time.Duration, err error) {
+	var s *Session
+	var usage time.Duration
+	if s, maxUsage, usage, err = sS.debitEvent(tnt, ev, argDisp, forceDuration); err != nil {
+		return
+	}
+	if errEnd := sS.terminateSession(s, utils.DurationPointer(usage), nil, nil, nil, true); errEnd != nil {
 		utils.Logger.Warning(
 			fmt.Sprintf("<%s> error when ending charged event: <%s>, err: <%s>",
-				utils.SessionS, cgrID, errEnd.Error()))
+				utils.SessionS, s.CGRID, errEnd.Error()))
 	}
 	return // returns here the maxUsage from update
 }
 
+// eventReservation is an event charged in advance, waiting to be committed or canceled
+type eventReservation struct {
+	s      *Session
+	usage  time.Duration // usage committed when not specified otherwise
+	expiry *time.Time    // when the reservation is released, nil if it does not expire
+	timer  *time.Timer   // releases the reservation at expiry
+}
+
+// reservationLockID returns the ID used to serialize the operations on one reservation
+func reservationLockID(cgrID string) string {
+	return utils.ConcatenatedKey(utils.SessionS, utils.SessionSv1ReserveEvent, cgrID)
+}
+
+// reserveEvent will charge in advance a single event (ie: SMS)
+// the charges are finalized on commit or refunded on cancel and after TTL
+func (sS *SessionS) reserveEvent(tnt string, ev engine.MapEvent, argDisp *utils.ArgDispatcher,
+	forceDuration bool, ttl time.Duration) (maxUsage time.Duration, err error) {
+	cgrID := GetSetCGRID(ev)
+	refID := guardian.Guardian.GuardIDs(utils.EmptyString,
+		sS.cgrCfg.GeneralCfg().LockingTimeout, reservationLockID(cgrID))
+	defer guardian.Guardian.UnguardIDs(refID)
+	if sS.getReservation(cgrID) != nil {
+		return 0, utils.ErrExists
+	}
+	rsrv := new(eventReservation)
+	if rsrv.s, maxUsage, rsrv.usage, err = sS.debitEvent(tnt, ev, argDisp, forceDuration); err != nil {
+		return
+	}
+	if ttl > 0 {
+		rsrv.expiry = utils.TimePointer(time.Now().Add(ttl))
+	}
+	sS.setReservation(cgrID, rsrv)
+	sS.storeReservation(cgrID, rsrv)
+	return
+}
+
+// getReservation returns the pending reservation or nil if there is none
+func (sS *SessionS) getReservation(cgrID string) (rsrv *eventReservation) {
+	sS.rsrvMux.Lock()
+	rsrv = sS.reservations[cgrID]
+	sS.rsrvMux.Unlock()
+	return
+}
+
+// setReservation adds the reservation to the pending ones and starts its expiry timer
+func (sS *SessionS) setReservation(cgrID string, rsrv *eventReservation) {
+	if rsrv.expiry != nil {
+		rsrv.timer = time.AfterFunc(time.Until(*rsrv.expiry),
+			func() { sS.expireReservation(cgrID, rsrv) })
+	}
+	sS.rsrvMux.Lock()
+	sS.reservations[cgrID] = rsrv
+	sS.rsrvMux.Unlock()
+}
+
+// popReservation removes the reservation out of the pending ones and out of dataDB
+// the reservation is removed only if it is still the pending one
+func (sS *SessionS) popReservation(cgrID string, rsrv *eventReservation) {
+	sS.rsrvMux.Lock()
+	if sS.reservations[cgrID] != rsrv {
+		sS.rsrvMux.Unlock()
+		return
+	}
+	delete(sS.reservations, cgrID)
+	sS.rsrvMux.Unlock()
+	if rsrv.timer != nil {
+		rsrv.timer.Stop()
+	}
+	sS.removeStoredSession(cgrID)
+}
+
+// storeReservation stores the reservation into dataDB so it can be committed after a restart
+func (sS *SessionS) storeReservation(cgrID string, rsrv *eventReservation) {
+	if sS.cgrCfg.SessionSCfg().StoreInterval == 0 {
+		return
+	}
+	sCln := rsrv.s.Clone()
+	sCln.ArgDispatcher = rsrv.s.ArgDispatcher
+	sBytes, err := sS.ms.Marshal(sCln)
+	if err == nil {
+		sS.ssMux.Lock()
+		err = sS.dm.SetStoredSession(&engine.StoredSession{
+			NodeID:            sS.cgrCfg.GeneralCfg().NodeID,
+			CGRID:             cgrID,
+			Session:           sBytes,
+			ReservedUsage:     utils.DurationPointer(rsrv.usage),
+			ReservationExpiry: rsrv.expiry,
+		})
+		sS.ssMux.Unlock()
+	}
+	if err != nil {
+		utils.Logger.Warning(
+			fmt.Sprintf("<%s> failed storing reservation for event <%s>, err: %s",
+				utils.SessionS, cgrID, err.Error()))
+	}
+}
+
+// finishReservation will end the session of the reservation charging the usage
+// with 0 usage the complete reservation is refunded and no CDR can be generated out of it
+// the reservation is removed only if the charges were finalized so the operation can be retried on error
+func (sS *SessionS) finishReservation(cgrID string, rsrv *eventReservation, usage time.Duration) (err error) {
+	sCln := rsrv.s.Clone() // terminate modifies the session, keep it intact in case of error
+	sCln.ArgDispatcher = rsrv.s.ArgDispatcher
+	if err = sS.terminateSession(sCln, utils.DurationPointer(usage), nil, nil, nil, true); err != nil {
+		engine.Cache.Remove(utils.CacheClosedSessions, cgrID, true, utils.NonTransactional)
+		return utils.NewErrRALs(err)
+	}
+	sS.popReservation(cgrID, rsrv)
+	if usage == 0 {
+		engine.Cache.Remove(utils.CacheClosedSessions, cgrID, true, utils.NonTransactional)
+	}
+	return
+}
+
+// endReservation will commit the reservation with the given usage, defaulting to the reserved one
+func (sS *SessionS) endReservation(cgrID string, usage *time.Duration) (err error) {
+	refID := guardian.Guardian.GuardIDs(utils.EmptyString,
+		sS.cgrCfg.GeneralCfg().LockingTimeout, reservationLockID(cgrID))
+	defer guardian.Guardian.UnguardIDs(refID)
+	rsrv := sS.getReservation(cgrID)
+	if rsrv == nil {
+		return utils.ErrNotFound
+	}
+	if usage == nil {
+		usage = &rsrv.usage
+	}
+	return sS.finishReservation(cgrID, rsrv, *usage)
+}
+
+// expireReservation will release the reservation which was not committed in time
+// on error the reservation is kept so it can still be committed or canceled
+func (sS *SessionS) expireReservation(cgrID string, rsrv *eventReservation) {
+	refID := guardian.Guardian.GuardIDs(utils.EmptyString,
+		sS.cgrCfg.GeneralCfg().LockingTimeout, reservationLockID(cgrID))
+	defer guardian.Guardian.UnguardIDs(refID)
+	if sS.getReservation(cgrID) != rsrv { // committed or canceled in the meantime
+		return
+	}
+	utils.Logger.Info(
+		fmt.Sprintf("<%s> releasing expired reservation for event: <%s>",
+			utils.SessionS, cgrID))
+	if err := sS.finishReservation(cgrID, rsrv, 0); err != nil {
+		utils.Logger.Warning(
+			fmt.Sprintf("<%s> error when releasing reservation for event: <%s>, err: <%s>",
+				utils.SessionS, cgrID, err.Error()))
+	}
+}
+
+// releaseReservations will cancel all the pending reservations
+func (sS *SessionS) releaseReservations() {
+	sS.rsrvMux.Lock()
+	cgrIDs := make([]string, 0, len(sS.reservations))
+	for cgrID := range sS.reservations {
+		cgrIDs = append(cgrIDs, cgrID)
+	}
+	sS.rsrvMux.Unlock()
+	for _, cgrID := range cgrIDs {
+		if err := sS.endReservation(cgrID, utils.DurationPointer(0)); err != nil &&
+			err != utils.ErrNotFound {
+			utils.Logger.Warning(
+				fmt.Sprintf("<%s> error when releasing reservation for event: <%s>, err: <%s>",
+					utils.SessionS, cgrID, err.Error()))
+		}
+	}
+}
+
+// stopReservations will stop the expiry timers of the pending reservations, keeping them in dataDB
+func (sS *SessionS) stopReservations() {
+	sS.rsrvMux.Lock()
+	for _, rsrv := range sS.reservations {
+		if rsrv.timer != nil {
+			rsrv.timer.Stop()
+		}
+	}
+	sS.rsrvMux.Unlock()
+}
+
 // APIs start here
 
 // Call is part of RpcClientConnection interface
@@ -2015,6 +2461,7 @@ type V1InitSessionArgs struct {
 	AttributeIDs      []string
 	ThresholdIDs      []string
 	StatIDs           []string
+	Units             map[string]*UsageUnit // usage units charged independently, populated out of Units.<UnitID>.<Field> event fields if missing
 	*utils.CGREvent
 	*utils.ArgDispatcher
 }
@@ -2052,6 +2499,7 @@ type V1InitSessionReply struct {
 	Attributes         *engine.AttrSProcessEventReply
 	ResourceAllocation *string
 	MaxUsage           *time.Duration
+	UnitsMaxUsage      map[string]time.Duration
 	ThresholdIDs       *[]string
 	StatQueueIDs       *[]string
 }
@@ -2077,6 +2525,9 @@ func (v1Rply *V1InitSessionReply) AsNavigableMap(
 		if v1Rply.MaxUsage != nil {
 			cgrReply[utils.CapMaxUsage] = *v1Rply.MaxUsage
 		}
+		if v1Rply.UnitsMaxUsage != nil {
+			cgrReply[utils.CapUnitsMaxUsage] = unitsMaxUsageAsMap(v1Rply.UnitsMaxUsage)
+		}
 		if v1Rply.ThresholdIDs != nil {
 			cgrReply[utils.CapThresholds] = *v1Rply.ThresholdIDs
 		}
@@ -2164,16 +2615,28 @@ func (sS *SessionS) BiRPCv1InitiateSession(clnt rpcclient.ClientConnector,
 				return utils.NewErrRALs(err)
 			}
 		}
+		units := args.Units
+		if units == nil {
+			if units, err = getUsageUnits(ev); err != nil {
+				return err
+			}
+		}
 		s, err := sS.initSession(args.CGREvent.Tenant, ev,
-			sS.biJClntID(clnt), originID, dbtItvl, args.ArgDispatcher, false, args.ForceDuration)
+			sS.biJClntID(clnt), originID, dbtItvl, args.ArgDispatcher, false, args.ForceDuration, units)
 		if err != nil {
 			return err
 		}
 		if s.debitStop != nil { //active debit
 			rply.MaxUsage = &sS.cgrCfg.SessionSCfg().MaxCallDuration
+			if len(units) != 0 {
+				rply.UnitsMaxUsage = make(map[string]time.Duration)
+				for unitID := range units {
+					rply.UnitsMaxUsage[unitID] = sS.cgrCfg.SessionSCfg().MaxCallDuration
+				}
+			}
 		} else {
 			var maxUsage time.Duration
-			if maxUsage, err = sS.updateSession(s, nil, false); err != nil {
+			if maxUsage, rply.UnitsMaxUsage, err = sS.updateSession(s, nil, units, false); err != nil {
 				return utils.NewErrRALs(err)
 			}
 			rply.MaxUsage = &maxUsage
@@ -2272,14 +2735,16 @@ type V1UpdateSessionArgs struct {
 	UpdateSession bool
 	ForceDuration bool
 	AttributeIDs  []string
+	Units         map[string]*UsageUnit // usage units charged independently, populated out of Units.<UnitID>.<Field> event fields if missing
 	*utils.CGREvent
 	*utils.ArgDispatcher
 }
 
 // V1UpdateSessionReply contains options for session update reply
 type V1UpdateSessionReply struct {
-	Attributes *engine.AttrSProcessEventReply
-	MaxUsage   *time.Duration
+	Attributes    *engine.AttrSProcessEventReply
+	MaxUsage      *time.Duration
+	UnitsMaxUsage map[string]time.Duration
 }
 
 // AsNavigableMap is part of engine.NavigableMapper interface
@@ -2300,6 +2765,9 @@ func (v1Rply *V1UpdateSessionReply) AsNavigableMap(
 		if v1Rply.MaxUsage != nil {
 			cgrReply[utils.CapMaxUsage] = *v1Rply.MaxUsage
 		}
+		if v1Rply.UnitsMaxUsage != nil {
+			cgrReply[utils.CapUnitsMaxUsage] = unitsMaxUsageAsMap(v1Rply.UnitsMaxUsage)
+		}
 	}
 	return config.NewNavigableMap(cgrReply), nil
 }
@@ -2358,6 +2826,12 @@ func (sS *SessionS) BiRPCv1UpdateSession(clnt rpcclient.ClientConnector,
 				return utils.NewErrRALs(err)
 			}
 		}
+		units := args.Units
+		if units == nil {
+			if units, err = getUsageUnits(ev); err != nil {
+				return err
+			}
+		}
 		cgrID := GetSetCGRID(ev)
 		s := sS.getRelocateSession(cgrID,
 			ev.GetStringIgnoreErrors(utils.InitialOriginID),
@@ -2367,12 +2841,14 @@ func (sS *SessionS) BiRPCv1UpdateSession(clnt rpcclient.ClientConnector,
 			if s, err = sS.initSession(args.CGREvent.Tenant,
 				ev, sS.biJClntID(clnt),
 				ev.GetStringIgnoreErrors(utils.OriginID),
-				dbtItvl, args.ArgDispatcher, false, args.ForceDuration); err != nil {
+				dbtItvl, args.ArgDispatcher, false, args.ForceDuration, units); err != nil {
 				return err
 			}
+		} else if err = sS.updateUsageUnits(s, units, args.ForceDuration); err != nil {
+			return err
 		}
 		var maxUsage time.Duration
-		if maxUsage, err = sS.updateSession(s, ev.Clone(), false); err != nil {
+		if maxUsage, rply.UnitsMaxUsage, err = sS.updateSession(s, ev.Clone(), units, false); err != nil {
 			return utils.NewErrRALs(err)
 		}
 		rply.MaxUsage = &maxUsage
@@ -2412,6 +2888,7 @@ type V1TerminateSessionArgs struct {
 	ProcessStats      bool
 	ThresholdIDs      []string
 	StatIDs           []string
+	Units             map[string]*UsageUnit // usage units charged independently, populated out of Units.<UnitID>.<Field> event fields if missing
 	*utils.CGREvent
 	*utils.ArgDispatcher
 }
@@ -2489,6 +2966,12 @@ func (sS *SessionS) BiRPCv1TerminateSession(clnt rpcclient.ClientConnector,
 				return utils.NewErrRALs(err)
 			}
 		}
+		units := args.Units
+		if units == nil {
+			if units, err = getUsageUnits(ev); err != nil {
+				return err
+			}
+		}
 		var s *Session
 		fib := utils.Fib()
 		for i := 0; i < sS.cgrCfg.SessionSCfg().TerminateAttempts; i++ {
@@ -2496,6 +2979,14 @@ func (sS *SessionS) BiRPCv1TerminateSession(clnt rpcclient.ClientConnector,
 				ev.GetStringIgnoreErrors(utils.InitialOriginID),
 				ev.GetStringIgnoreErrors(utils.OriginID),
 				ev.GetStringIgnoreErrors(utils.OriginHost)); s != nil {
+				if len(units) != 0 { // units reported only on terminate are still part of the CDRs
+					s.Lock()
+					err = sS.addUsageUnits(s, units, args.ForceDuration)
+					s.Unlock()
+					if err != nil {
+						return
+					}
+				}
 				break
 			}
 			if i+1 < sS.cgrCfg.SessionSCfg().TerminateAttempts { // not last iteration
@@ -2506,7 +2997,7 @@ func (sS *SessionS) BiRPCv1TerminateSession(clnt rpcclient.ClientConnector,
 			if s, err = sS.initSession(args.CGREvent.Tenant,
 				ev, sS.biJClntID(clnt),
 				ev.GetStringIgnoreErrors(utils.OriginID), dbtItvl,
-				args.ArgDispatcher, false, args.ForceDuration); err != nil {
+				args.ArgDispatcher, false, args.ForceDuration, units); err != nil {
 				return err
 			}
 
@@ -2516,7 +3007,7 @@ func (sS *SessionS) BiRPCv1TerminateSession(clnt rpcclient.ClientConnector,
 			ev.GetDurationPtrIgnoreErrors(utils.Usage),
 			ev.GetDurationPtrIgnoreErrors(utils.LastUsed),
 			ev.GetTimePtrIgnoreErrors(utils.AnswerTime, utils.EmptyString),
-			false); err != nil {
+			units, false); err != nil {
 			return utils.NewErrRALs(err)
 		}
 	}
@@ -2899,6 +3390,156 @@ func (sS *SessionS) BiRPCv1ProcessMessage(clnt rpcclient.ClientConnector,
 	return
 }
 
+// V1ReserveEventArgs are the options passed to ReserveEvent API
+type V1ReserveEventArgs struct {
+	ForceDuration bool
+	TTL           *time.Duration // overwrites the reservation_ttl from config
+	*utils.CGREvent
+	*utils.ArgDispatcher
+}
+
+// BiRPCv1ReserveEvent will charge in advance one event (ie: SMS) returning the authorized usage
+// the charges need to be confirmed with CommitEvent, otherwise they are refunded after the TTL
+func (sS *SessionS) BiRPCv1ReserveEvent(clnt rpcclient.ClientConnector,
+	args *V1ReserveEventArgs, rply *time.Duration) (err error) {
+	if args.CGREvent == nil {
+		return utils.NewErrMandatoryIeMissing(utils.CGREventString)
+	}
+	if args.CGREvent.ID == "" {
+		args.CGREvent.ID = utils.GenUUID()
+	}
+
+	// RPC caching
+	if sS.cgrCfg.CacheCfg().Partitions[utils.CacheRPCResponses].Limit != 0 {
+		cacheKey := utils.ConcatenatedKey(utils.SessionSv1ReserveEvent, args.CGREvent.ID)
+		refID := guardian.Guardian.GuardIDs("",
+			sS.cgrCfg.GeneralCfg().LockingTimeout, cacheKey) // RPC caching needs to be atomic
+		defer guardian.Guardian.UnguardIDs(refID)
+
+		if itm, has := engine.Cache.Get(utils.CacheRPCResponses, cacheKey); has {
+			cachedResp := itm.(*utils.CachedRPCResponse)
+			if cachedResp.Error == nil {
+				*rply = *cachedResp.Result.(*time.Duration)
+			}
+			return cachedResp.Error
+		}
+		defer engine.Cache.Set(utils.CacheRPCResponses, cacheKey,
+			&utils.CachedRPCResponse{Result: rply, Error: err},
+			nil, true, utils.NonTransactional)
+	}
+	// end of RPC caching
+
+	if args.CGREvent.Tenant == "" {
+		args.CGREvent.Tenant = sS.cgrCfg.GeneralCfg().DefaultTenant
+	}
+	me := engine.MapEvent(args.CGREvent.Event)
+	if me.GetStringIgnoreErrors(utils.OriginID) == "" {
+		return utils.NewErrMandatoryIeMissing(utils.OriginID)
+	}
+	ttl := sS.cgrCfg.SessionSCfg().ReservationTTL
+	if args.TTL != nil {
+		ttl = *args.TTL
+	}
+	var maxUsage time.Duration
+	if maxUsage, err = sS.reserveEvent(args.CGREvent.Tenant, me,
+		args.ArgDispatcher, args.ForceDuration, ttl); err != nil {
+		return
+	}
+	*rply = maxUsage
+	return
+}
+
+// BiRPCv1CommitEvent will finalize the charges of one event reserved with ReserveEvent
+// the Usage inside the event is optional, defaulting to the reserved one
+func (sS *SessionS) BiRPCv1CommitEvent(clnt rpcclient.ClientConnector,
+	args *utils.CGREventWithArgDispatcher, rply *string) (err error) {
+	if args.CGREvent == nil {
+		return utils.NewErrMandatoryIeMissing(utils.CGREventString)
+	}
+	if args.CGREvent.ID == "" {
+		args.CGREvent.ID = utils.GenUUID()
+	}
+
+	// RPC caching
+	if sS.cgrCfg.CacheCfg().Partitions[utils.CacheRPCResponses].Limit != 0 {
+		cacheKey := utils.ConcatenatedKey(utils.SessionSv1CommitEvent, args.CGREvent.ID)
+		refID := guardian.Guardian.GuardIDs("",
+			sS.cgrCfg.GeneralCfg().LockingTimeout, cacheKey) // RPC caching needs to be atomic
+		defer guardian.Guardian.UnguardIDs(refID)
+
+		if itm, has := engine.Cache.Get(utils.CacheRPCResponses, cacheKey); has {
+			cachedResp := itm.(*utils.CachedRPCResponse)
+			if cachedResp.Error == nil {
+				*rply = *cachedResp.Result.(*string)
+			}
+			return cachedResp.Error
+		}
+		defer engine.Cache.Set(utils.CacheRPCResponses, cacheKey,
+			&utils.CachedRPCResponse{Result: rply, Error: err},
+			nil, true, utils.NonTransactional)
+	}
+	// end of RPC caching
+
+	me := engine.MapEvent(args.CGREvent.Event)
+	if me.GetStringIgnoreErrors(utils.OriginID) == "" {
+		return utils.NewErrMandatoryIeMissing(utils.OriginID)
+	}
+	var usage *time.Duration
+	if me.HasField(utils.Usage) {
+		var u time.Duration
+		if u, err = me.GetDuration(utils.Usage); err != nil {
+			return
+		}
+		usage = &u
+	}
+	if err = sS.endReservation(GetSetCGRID(me), usage); err != nil {
+		return
+	}
+	*rply = utils.OK
+	return
+}
+
+// BiRPCv1CancelEvent will refund the charges of one event reserved with ReserveEvent
+func (sS *SessionS) BiRPCv1CancelEvent(clnt rpcclient.ClientConnector,
+	args *utils.CGREventWithArgDispatcher, rply *string) (err error) {
+	if args.CGREvent == nil {
+		return utils.NewErrMandatoryIeMissing(utils.CGREventString)
+	}
+	if args.CGREvent.ID == "" {
+		args.CGREvent.ID = utils.GenUUID()
+	}
+
+	// RPC caching
+	if sS.cgrCfg.CacheCfg().Partitions[utils.CacheRPCResponses].Limit != 0 {
+		cacheKey := utils.ConcatenatedKey(utils.SessionSv1CancelEvent, args.CGREvent.ID)
+		refID := guardian.Guardian.GuardIDs("",
+			sS.cgrCfg.GeneralCfg().LockingTimeout, cacheKey) // RPC caching needs to be atomic
+		defer guardian.Guardian.UnguardIDs(refID)
+
+		if itm, has := engine.Cache.Get(utils.CacheRPCResponses, cacheKey); has {
+			cachedResp := itm.(*utils.CachedRPCResponse)
+			if cachedResp.Error == nil {
+				*rply = *cachedResp.Result.(*string)
+			}
+			return cachedResp.Error
+		}
+		defer engine.Cache.Set(utils.CacheRPCResponses, cacheKey,
+			&utils.CachedRPCResponse{Result: rply, Error: err},
+			nil, true, utils.NonTransactional)
+	}
+	// end of RPC caching
+
+	me := engine.MapEvent(args.CGREvent.Event)
+	if me.GetStringIgnoreErrors(utils.OriginID) == "" {
+		return utils.NewErrMandatoryIeMissing(utils.OriginID)
+	}
+	if err = sS.endReservation(GetSetCGRID(me), utils.DurationPointer(0)); err != nil {
+		return
+	}
+	*rply = utils.OK
+	return
+}
+
 // V1ProcessEventArgs are the options passed to ProcessEvent API
 type V1ProcessEventArgs struct {
 	Flags []string
@@ -3183,7 +3824,7 @@ func (sS *SessionS) BiRPCv1ProcessEvent(clnt rpcclient.ClientConnector,
 					}
 				}
 				s, err := sS.initSession(args.CGREvent.Tenant, ev,
-					sS.biJClntID(clnt), originID, dbtItvl, args.ArgDispatcher, false, ralsFlagsWithParams.HasKey(utils.MetaFD))
+					sS.biJClntID(clnt), originID, dbtItvl, args.ArgDispatcher, false, ralsFlagsWithParams.HasKey(utils.MetaFD), nil)
 				if err != nil {
 					return err
 				}
@@ -3191,7 +3832,7 @@ func (sS *SessionS) BiRPCv1ProcessEvent(clnt rpcclient.ClientConnector,
 					rply.MaxUsage = &sS.cgrCfg.SessionSCfg().MaxCallDuration
 				} else {
 					var maxUsage time.Duration
-					if maxUsage, err = sS.updateSession(s, nil, false); err != nil {
+					if maxUsage, _, err = sS.updateSession(s, nil, nil, false); err != nil {
 						return utils.NewErrRALs(err)
 					}
 					rply.MaxUsage = &maxUsage
@@ -3213,12 +3854,12 @@ func (sS *SessionS) BiRPCv1ProcessEvent(clnt rpcclient.ClientConnector,
 					if s, err = sS.initSession(args.CGREvent.Tenant,
 						ev, sS.biJClntID(clnt),
 						ev.GetStringIgnoreErrors(utils.OriginID), dbtItvl, args.ArgDispatcher,
-						false, ralsFlagsWithParams.HasKey(utils.MetaFD)); err != nil {
+						false, ralsFlagsWithParams.HasKey(utils.MetaFD), nil); err != nil {
 						return err
 					}
 				}
 				var maxUsage time.Duration
-				if maxUsage, err = sS.updateSession(s, ev, false); err != nil {
+				if maxUsage, _, err = sS.updateSession(s, ev, nil, false); err != nil {
 					return utils.NewErrRALs(err)
 				}
 				rply.MaxUsage = &maxUsage
@@ -3238,7 +3879,7 @@ func (sS *SessionS) BiRPCv1ProcessEvent(clnt rpcclient.ClientConnector,
 					if s, err = sS.initSession(args.CGREvent.Tenant,
 						ev, sS.biJClntID(clnt),
 						ev.GetStringIgnoreErrors(utils.OriginID), dbtItvl,
-						args.ArgDispatcher, false, ralsFlagsWithParams.HasKey(utils.MetaFD)); err != nil {
+						args.ArgDispatcher, false, ralsFlagsWithParams.HasKey(utils.MetaFD), nil); err != nil {
 						return err
 					}
 				}
@@ -3246,7 +3887,7 @@ func (sS *SessionS) BiRPCv1ProcessEvent(clnt rpcclient.ClientConnector,
 					ev.GetDurationPtrIgnoreErrors(utils.Usage),
 					ev.GetDurationPtrIgnoreErrors(utils.LastUsed),
 					ev.GetTimePtrIgnoreErrors(utils.AnswerTime, utils.EmptyString),
-					false); err != nil {
+					nil, false); err != nil {
 					return utils.NewErrRALs(err)
 				}
 			}
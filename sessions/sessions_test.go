@@ -23,6 +23,7 @@ import (
 	"reflect"
 	"sort"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 
@@ -1747,18 +1748,21 @@ func (*testRPCClientConnection) Call(string, interface{}, interface{}) error { r
 
 func TestNewSessionS(t *testing.T) {
 	cgrCGF, _ := config.NewDefaultCGRConfig()
-
+	ms, _ := engine.NewMarshaler(cgrCGF.GeneralCfg().DBDataEncoding)
 	eOut := &SessionS{
-		cgrCfg:        cgrCGF,
-		dm:            nil,
-		biJClnts:      make(map[rpcclient.ClientConnector]string),
-		biJIDs:        make(map[string]*biJClient),
-		aSessions:     make(map[string]*Session),
-		aSessionsIdx:  make(map[string]map[string]map[string]utils.StringMap),
-		aSessionsRIdx: make(map[string][]*riFieldNameVal),
-		pSessions:     make(map[string]*Session),
-		pSessionsIdx:  make(map[string]map[string]map[string]utils.StringMap),
-		pSessionsRIdx: make(map[string][]*riFieldNameVal),
+		cgrCfg:         cgrCGF,
+		dm:             nil,
+		biJClnts:       make(map[rpcclient.ClientConnector]string),
+		biJIDs:         make(map[string]*biJClient),
+		aSessions:      make(map[string]*Session),
+		aSessionsIdx:   make(map[string]map[string]map[string]utils.StringMap),
+		aSessionsRIdx:  make(map[string][]*riFieldNameVal),
+		pSessions:      make(map[string]*Session),
+		pSessionsIdx:   make(map[string]map[string]map[string]utils.StringMap),
+		pSessionsRIdx:  make(map[string][]*riFieldNameVal),
+		ms:             ms,
+		storedSessions: make(utils.StringMap),
+		reservations:   make(map[string]*eventReservation),
 	}
 	sS := NewSessionS(cgrCGF, nil, nil)
 	if !reflect.DeepEqual(sS, eOut) {
@@ -2332,3 +2336,500 @@ aa+jqv4dwkr/FLEcN1zC76Y/IniI65fId55hVJvN3ORuzUqYEtzD3irmsw==
 		t.Fatal(err)
 	}
 }
+
+func TestSessionSStoreAndRestoreSessions(t *testing.T) {
+	sSCfg, _ := config.NewDefaultCGRConfig()
+	sSCfg.SessionSCfg().StoreInterval = -1
+	data := engine.NewInternalDB(nil, nil, true, sSCfg.DataDbCfg().Items)
+	dm := engine.NewDataManager(data, sSCfg.CacheCfg(), nil)
+	sS := NewSessionS(sSCfg, dm, nil)
+	sSEv := engine.NewMapEvent(map[string]interface{}{
+		utils.ToR:         utils.VOICE,
+		utils.OriginID:    "111",
+		utils.Account:     "account1",
+		utils.Destination: "+4986517174963",
+		utils.Tenant:      "cgrates.org",
+		utils.RequestType: utils.META_PREPAID,
+		utils.OriginHost:  "127.0.0.1",
+	})
+	nextDebit := time.Date(2020, 4, 20, 10, 0, 0, 0, time.UTC)
+	s := &Session{
+		CGRID:         "session1",
+		Tenant:        "cgrates.org",
+		EventStart:    sSEv,
+		ClientConnID:  "conn1",
+		DebitInterval: 0,
+		SRuns: []*SRun{
+			{
+				Event: sSEv,
+				CD: &engine.CallDescriptor{
+					Tenant:        "cgrates.org",
+					RunID:         utils.MetaDefault,
+					LoopIndex:     2,
+					DurationIndex: time.Minute,
+				},
+				ExtraDuration: 5 * time.Second,
+				LastUsage:     30 * time.Second,
+				TotalUsage:    time.Minute,
+				NextAutoDebit: &nextDebit,
+			},
+		},
+		ArgDispatcher: &utils.ArgDispatcher{APIKey: utils.StringPointer("sesKey")},
+	}
+	sS.registerSession(s, false)
+	if sSs, err := dm.GetStoredSessions(sSCfg.GeneralCfg().NodeID); err != nil {
+		t.Fatal(err)
+	} else if len(sSs) != 1 || sSs[0].CGRID != "session1" {
+		t.Fatalf("Unexpected stored sessions: %s", utils.ToJSON(sSs))
+	}
+
+	// new SessionS sharing the same dataDB
+	sS2 := NewSessionS(sSCfg, dm, nil)
+	sS2.restoreSessions()
+	rcvS := sS2.getSessions("session1", false)
+	if len(rcvS) != 1 {
+		t.Fatalf("Expected restored session, received: %+v", rcvS)
+	}
+	if rcvS[0].ClientConnID != s.ClientConnID ||
+		rcvS[0].Tenant != s.Tenant ||
+		rcvS[0].EventStart.GetStringIgnoreErrors(utils.Account) != "account1" ||
+		rcvS[0].ArgDispatcher == nil || *rcvS[0].ArgDispatcher.APIKey != "sesKey" {
+		t.Errorf("Unexpected restored session: %s", utils.ToJSON(rcvS[0]))
+	}
+	if len(rcvS[0].SRuns) != 1 {
+		t.Fatalf("Unexpected restored SRuns: %s", utils.ToJSON(rcvS[0].SRuns))
+	}
+	sr := rcvS[0].SRuns[0]
+	if sr.ExtraDuration != 5*time.Second || sr.LastUsage != 30*time.Second ||
+		sr.TotalUsage != time.Minute || sr.CD.LoopIndex != 2 ||
+		sr.CD.DurationIndex != time.Minute ||
+		sr.NextAutoDebit == nil || !sr.NextAutoDebit.Equal(nextDebit) {
+		t.Errorf("Unexpected restored SRun: %s", utils.ToJSON(sr))
+	}
+	if !sS2.isIndexed(rcvS[0], false) {
+		t.Error("Expected restored session to be indexed")
+	}
+
+	sS2.unregisterSession("session1", false)
+	if sSs, err := dm.GetStoredSessions(sSCfg.GeneralCfg().NodeID); err != nil {
+		t.Error(err)
+	} else if len(sSs) != 0 {
+		t.Errorf("Expected no stored sessions, received: %s", utils.ToJSON(sSs))
+	}
+}
+
+func TestSessionSStoreSessionsInterval(t *testing.T) {
+	sSCfg, _ := config.NewDefaultCGRConfig()
+	sSCfg.SessionSCfg().StoreInterval = time.Hour
+	data := engine.NewInternalDB(nil, nil, true, sSCfg.DataDbCfg().Items)
+	dm := engine.NewDataManager(data, sSCfg.CacheCfg(), nil)
+	sS := NewSessionS(sSCfg, dm, nil)
+	sS.registerSession(&Session{
+		CGRID:      "session1",
+		EventStart: engine.NewMapEvent(map[string]interface{}{utils.OriginID: "111"}),
+	}, false)
+	if sSs, _ := dm.GetStoredSessions(sSCfg.GeneralCfg().NodeID); len(sSs) != 0 {
+		t.Errorf("Expected no stored sessions, received: %s", utils.ToJSON(sSs))
+	}
+	if !sS.storedSessions.HasKey("session1") {
+		t.Error("Expected session marked for storing")
+	}
+	sS.storeSessions()
+	if sSs, _ := dm.GetStoredSessions(sSCfg.GeneralCfg().NodeID); len(sSs) != 1 {
+		t.Errorf("Expected one stored session, received: %s", utils.ToJSON(sSs))
+	}
+	if len(sS.storedSessions) != 0 {
+		t.Errorf("Expected no sessions marked for storing, received: %+v", sS.storedSessions)
+	}
+}
+
+// testUnitsRPCMock is answering to ChargerS and RALs for the usage units tests
+// keeping the usage refunded so far
+type testUnitsRPCMock struct {
+	sync.Mutex
+	refunded  time.Duration
+	refundErr error // returned on refund if set
+}
+
+func (m *testUnitsRPCMock) Call(method string, args interface{}, rply interface{}) error {
+	switch method {
+	case utils.ChargerSv1ProcessEvent:
+		ev := engine.MapEvent(args.(*utils.CGREventWithArgDispatcher).Event).Clone()
+		ev[utils.RunID] = utils.MetaDefault
+		*rply.(*[]*engine.ChrgSProcessEventReply) = []*engine.ChrgSProcessEventReply{
+			{ChargerSProfile: "DEFAULT", CGREvent: &utils.CGREvent{Tenant: "cgrates.org", Event: ev}},
+		}
+	case utils.ResponderMaxDebit:
+		cd := args.(*engine.CallDescriptorWithArgDispatcher).CallDescriptor
+		usage := cd.TimeEnd.Sub(cd.TimeStart)
+		if usage > 1500 {
+			usage = 1500
+		}
+		*rply.(*engine.CallCost) = engine.CallCost{
+			AccountSummary: &engine.AccountSummary{Tenant: "cgrates.org", ID: "1001"},
+			Timespans: engine.TimeSpans{{
+				TimeStart: cd.TimeStart,
+				TimeEnd:   cd.TimeStart.Add(usage),
+				Increments: engine.Increments{{Duration: usage, CompressFactor: 1,
+					BalanceInfo: &engine.DebitInfo{AccountID: "cgrates.org:1001",
+						Unit: &engine.UnitInfo{UUID: "unitsBalance", Consumed: 1}}}},
+				CompressFactor: 1,
+			}},
+		}
+	case utils.ResponderRefundIncrements:
+		m.Lock()
+		defer m.Unlock()
+		if m.refundErr != nil {
+			return m.refundErr
+		}
+		for _, incr := range args.(*engine.CallDescriptorWithArgDispatcher).Increments {
+			m.refunded += incr.Duration
+		}
+	default:
+		return rpcclient.ErrUnsupporteServiceMethod
+	}
+	return nil
+}
+
+// getRefunded returns the usage refunded since the last call
+func (m *testUnitsRPCMock) getRefunded() (rfnd time.Duration) {
+	m.Lock()
+	rfnd, m.refunded = m.refunded, 0
+	m.Unlock()
+	return
+}
+
+// newUnitsTestSessionS returns a SessionS using the mock for ChargerS and RALs
+func newUnitsTestSessionS(sSCfg *config.CGRConfig, dm *engine.DataManager,
+	mock *testUnitsRPCMock) *SessionS {
+	sSCfg.SessionSCfg().ChargerSConns = []string{utils.ConcatenatedKey(utils.MetaInternal, utils.MetaChargers)}
+	sSCfg.SessionSCfg().RALsConns = []string{utils.ConcatenatedKey(utils.MetaInternal, utils.MetaResponder)}
+	chrgsChan := make(chan rpcclient.ClientConnector, 1)
+	chrgsChan <- mock
+	ralsChan := make(chan rpcclient.ClientConnector, 1)
+	ralsChan <- mock
+	engine.Cache.Clear([]string{utils.CacheRPCConnections}) // do not reuse the mocks of other tests
+	connMgr := engine.NewConnManager(sSCfg, map[string]chan rpcclient.ClientConnector{
+		utils.ConcatenatedKey(utils.MetaInternal, utils.MetaChargers):  chrgsChan,
+		utils.ConcatenatedKey(utils.MetaInternal, utils.MetaResponder): ralsChan,
+	})
+	return NewSessionS(sSCfg, dm, connMgr)
+}
+
+func TestSessionSUsageUnits(t *testing.T) {
+	sSCfg, _ := config.NewDefaultCGRConfig()
+	sS := newUnitsTestSessionS(sSCfg, nil, new(testUnitsRPCMock))
+	answTime := time.Date(2020, 4, 20, 10, 0, 0, 0, time.UTC)
+	newEv := func(fields map[string]interface{}) *utils.CGREvent {
+		ev := map[string]interface{}{
+			utils.ToR:         utils.DATA,
+			utils.OriginID:    "unitsSession",
+			utils.OriginHost:  "127.0.0.1",
+			utils.RequestType: utils.META_PREPAID,
+			utils.Account:     "1001",
+			utils.Destination: "data",
+			utils.AnswerTime:  answTime,
+		}
+		for k, v := range fields {
+			ev[k] = v
+		}
+		return &utils.CGREvent{Tenant: "cgrates.org", ID: utils.GenUUID(), Event: ev}
+	}
+	cgrID := utils.Sha1("unitsSession", "127.0.0.1")
+
+	// invalid unit fields are not reported as RALs errors
+	var initRply V1InitSessionReply
+	eErr := "invalid usage unit field: <Units.1>"
+	if err := sS.BiRPCv1InitiateSession(nil, &V1InitSessionArgs{
+		InitSession: true,
+		CGREvent:    newEv(map[string]interface{}{"Units.1": 1024}),
+	}, &initRply); err == nil || err.Error() != eErr {
+		t.Errorf("Expected error: %s, received: %v", eErr, err)
+	}
+
+	// units out of the event fields
+	if err := sS.BiRPCv1InitiateSession(nil, &V1InitSessionArgs{
+		InitSession: true,
+		CGREvent: newEv(map[string]interface{}{
+			"Units.1.Usage":    1024,
+			"Units.2.Usage":    2048,
+			"Units.2.Category": "data2",
+		}),
+	}, &initRply); err != nil {
+		t.Fatal(err)
+	}
+	eUnitsMaxUsage := map[string]time.Duration{"1": 1024, "2": 1500}
+	if !reflect.DeepEqual(eUnitsMaxUsage, initRply.UnitsMaxUsage) {
+		t.Errorf("Expected %+v, received: %+v", eUnitsMaxUsage, initRply.UnitsMaxUsage)
+	}
+	if initRply.MaxUsage == nil || *initRply.MaxUsage != 1024 {
+		t.Errorf("Expected MaxUsage 1024, received: %+v", utils.ToJSON(initRply.MaxUsage))
+	}
+	ss := sS.getSessions(cgrID, false)
+	if len(ss) != 1 {
+		t.Fatalf("Expected one active session, received: %s", utils.ToJSON(ss))
+	}
+	if len(ss[0].SRuns) != 2 ||
+		ss[0].SRuns[0].UnitID != "1" ||
+		ss[0].SRuns[1].UnitID != "2" {
+		t.Fatalf("Unexpected SRuns: %s", utils.ToJSON(ss[0].SRuns))
+	}
+	if ss[0].EventStart.HasField("Units.1.Usage") {
+		t.Errorf("Unit fields should not be part of the session event: %s", utils.ToJSON(ss[0].EventStart))
+	}
+	if ctgr := ss[0].SRuns[1].CD.Category; ctgr != "data2" {
+		t.Errorf("Expected unit Category data2, received: %s", ctgr)
+	}
+
+	// unit 1 is updated and unit 3 added, unit 2 is not reported
+	var updtRply V1UpdateSessionReply
+	if err := sS.BiRPCv1UpdateSession(nil, &V1UpdateSessionArgs{
+		UpdateSession: true,
+		Units: map[string]*UsageUnit{
+			"1": {Usage: utils.DurationPointer(1024), LastUsed: utils.DurationPointer(1000)},
+			"3": {Usage: utils.DurationPointer(512)},
+		},
+		CGREvent: newEv(nil),
+	}, &updtRply); err != nil {
+		t.Fatal(err)
+	}
+	eUnitsMaxUsage = map[string]time.Duration{"1": 1024, "3": 512}
+	if !reflect.DeepEqual(eUnitsMaxUsage, updtRply.UnitsMaxUsage) {
+		t.Errorf("Expected %+v, received: %+v", eUnitsMaxUsage, updtRply.UnitsMaxUsage)
+	}
+	if len(ss[0].SRuns) != 3 ||
+		ss[0].SRuns[2].UnitID != "3" {
+		t.Fatalf("Unexpected SRuns: %s", utils.ToJSON(ss[0].SRuns))
+	}
+	if tUsage := ss[0].SRuns[0].TotalUsage; tUsage != 2024 {
+		t.Errorf("Expected unit 1 TotalUsage 2024, received: %v", tUsage)
+	}
+	if tUsage := ss[0].SRuns[1].TotalUsage; tUsage != 1500 {
+		t.Errorf("Expected unit 2 TotalUsage 1500, received: %v", tUsage)
+	}
+
+	var rply string
+	if err := sS.BiRPCv1TerminateSession(nil, &V1TerminateSessionArgs{
+		TerminateSession: true,
+		CGREvent: newEv(map[string]interface{}{
+			utils.Usage:        4000, // not overwriting the usage of unit 3, missing from request
+			"Units.1.LastUsed": 500,
+			"Units.2.Usage":    1200,
+		}),
+	}, &rply); err != nil {
+		t.Fatal(err)
+	}
+	if len(sS.getSessions(cgrID, false)) != 0 {
+		t.Error("Expected session to be terminated")
+	}
+	sIface, has := engine.Cache.Get(utils.CacheClosedSessions, cgrID)
+	if !has {
+		t.Fatal("Expected closed session in cache")
+	}
+	cgrEvs, _ := sIface.(*Session).asCGREvents()
+	eUsages := map[string]time.Duration{"1": 1500, "2": 1200, "3": 512}
+	if len(cgrEvs) != 3 {
+		t.Fatalf("Expected 3 CDR events, received: %s", utils.ToJSON(cgrEvs))
+	}
+	for _, cgrEv := range cgrEvs {
+		ev := engine.MapEvent(cgrEv.Event)
+		unitID := ev.GetStringIgnoreErrors(utils.UnitID)
+		if evCGRID := ev.GetStringIgnoreErrors(utils.CGRID); evCGRID != unitCGRID(cgrID, unitID) {
+			t.Errorf("Unexpected CGRID for unit <%s>: %s", unitID, evCGRID)
+		}
+		if usage := ev.GetDurationIgnoreErrors(utils.Usage); usage != eUsages[unitID] {
+			t.Errorf("Expected usage %v for unit <%s>, received: %v", eUsages[unitID], unitID, usage)
+		}
+	}
+}
+
+func TestSessionSReserveEvent(t *testing.T) {
+	sSCfg, _ := config.NewDefaultCGRConfig()
+	mock := new(testUnitsRPCMock)
+	sS := newUnitsTestSessionS(sSCfg, nil, mock)
+	newEv := func(originID string, fields map[string]interface{}) *utils.CGREvent {
+		ev := map[string]interface{}{
+			utils.ToR:         utils.SMS,
+			utils.OriginID:    originID,
+			utils.OriginHost:  "127.0.0.1",
+			utils.RequestType: utils.META_PREPAID,
+			utils.Account:     "1001",
+			utils.Destination: "1002",
+			utils.AnswerTime:  time.Date(2020, 4, 20, 10, 0, 0, 0, time.UTC),
+			utils.Usage:       1,
+		}
+		for k, v := range fields {
+			ev[k] = v
+		}
+		return &utils.CGREvent{Tenant: "cgrates.org", ID: utils.GenUUID(), Event: ev}
+	}
+
+	// reserve and commit
+	var maxUsage time.Duration
+	if err := sS.BiRPCv1ReserveEvent(nil, &V1ReserveEventArgs{
+		CGREvent: newEv("rsrvCommit", nil)}, &maxUsage); err != nil {
+		t.Fatal(err)
+	} else if maxUsage != 1 {
+		t.Errorf("Expected maxUsage 1, received: %v", maxUsage)
+	}
+	cgrID := utils.Sha1("rsrvCommit", "127.0.0.1")
+	if sS.getReservation(cgrID) == nil {
+		t.Fatal("Expected pending reservation")
+	}
+	if _, has := engine.Cache.Get(utils.CacheClosedSessions, cgrID); has {
+		t.Error("Reserved event should not be closed before commit")
+	}
+	if err := sS.BiRPCv1ReserveEvent(nil, &V1ReserveEventArgs{
+		CGREvent: newEv("rsrvCommit", nil)}, &maxUsage); err != utils.ErrExists {
+		t.Errorf("Expected %v, received: %v", utils.ErrExists, err)
+	}
+	var rply string
+	if err := sS.BiRPCv1CommitEvent(nil, &utils.CGREventWithArgDispatcher{
+		CGREvent: newEv("rsrvCommit", nil)}, &rply); err != nil {
+		t.Fatal(err)
+	} else if rply != utils.OK {
+		t.Errorf("Unexpected reply: %s", rply)
+	}
+	if sS.getReservation(cgrID) != nil {
+		t.Error("Reservation should be removed after commit")
+	}
+	if rfnd := mock.getRefunded(); rfnd != 0 {
+		t.Errorf("Expected no refund on commit, received: %v", rfnd)
+	}
+	sIface, has := engine.Cache.Get(utils.CacheClosedSessions, cgrID)
+	if !has {
+		t.Fatal("Expected closed session in cache")
+	}
+	if usage := sIface.(*Session).SRuns[0].TotalUsage; usage != 1 {
+		t.Errorf("Expected usage 1, received: %v", usage)
+	}
+	if err := sS.BiRPCv1CommitEvent(nil, &utils.CGREventWithArgDispatcher{
+		CGREvent: newEv("rsrvCommit", nil)}, &rply); err != utils.ErrNotFound {
+		t.Errorf("Expected %v, received: %v", utils.ErrNotFound, err)
+	}
+
+	// reserve and cancel
+	if err := sS.BiRPCv1ReserveEvent(nil, &V1ReserveEventArgs{
+		CGREvent: newEv("rsrvCancel", nil)}, &maxUsage); err != nil {
+		t.Fatal(err)
+	}
+	cgrID = utils.Sha1("rsrvCancel", "127.0.0.1")
+	if err := sS.BiRPCv1CancelEvent(nil, &utils.CGREventWithArgDispatcher{
+		CGREvent: newEv("rsrvCancel", nil)}, &rply); err != nil {
+		t.Fatal(err)
+	}
+	if sS.getReservation(cgrID) != nil {
+		t.Error("Reservation should be removed after cancel")
+	}
+	if rfnd := mock.getRefunded(); rfnd != 1 {
+		t.Errorf("Expected refunded usage 1, received: %v", rfnd)
+	}
+	if _, has := engine.Cache.Get(utils.CacheClosedSessions, cgrID); has {
+		t.Error("Canceled event should not be available for CDRs")
+	}
+
+	// failed refund keeps the reservation
+	if err := sS.BiRPCv1ReserveEvent(nil, &V1ReserveEventArgs{
+		CGREvent: newEv("rsrvRetry", nil)}, &maxUsage); err != nil {
+		t.Fatal(err)
+	}
+	cgrID = utils.Sha1("rsrvRetry", "127.0.0.1")
+	mock.refundErr = utils.ErrServerError
+	if err := sS.BiRPCv1CancelEvent(nil, &utils.CGREventWithArgDispatcher{
+		CGREvent: newEv("rsrvRetry", nil)}, &rply); err == nil {
+		t.Error("Expected error on refund")
+	}
+	if sS.getReservation(cgrID) == nil {
+		t.Fatal("Reservation should be kept after failed cancel")
+	}
+	mock.refundErr = nil
+	if err := sS.BiRPCv1CancelEvent(nil, &utils.CGREventWithArgDispatcher{
+		CGREvent: newEv("rsrvRetry", nil)}, &rply); err != nil {
+		t.Fatal(err)
+	}
+	if rfnd := mock.getRefunded(); rfnd != 1 {
+		t.Errorf("Expected refunded usage 1, received: %v", rfnd)
+	}
+
+	// reservation released after TTL
+	if err := sS.BiRPCv1ReserveEvent(nil, &V1ReserveEventArgs{
+		TTL:      utils.DurationPointer(10 * time.Millisecond),
+		CGREvent: newEv("rsrvExpire", nil)}, &maxUsage); err != nil {
+		t.Fatal(err)
+	}
+	cgrID = utils.Sha1("rsrvExpire", "127.0.0.1")
+	time.Sleep(50 * time.Millisecond)
+	if sS.getReservation(cgrID) != nil {
+		t.Error("Reservation should be released after TTL")
+	}
+	if rfnd := mock.getRefunded(); rfnd != 1 {
+		t.Errorf("Expected refunded usage 1, received: %v", rfnd)
+	}
+	if err := sS.BiRPCv1CommitEvent(nil, &utils.CGREventWithArgDispatcher{
+		CGREvent: newEv("rsrvExpire", nil)}, &rply); err != utils.ErrNotFound {
+		t.Errorf("Expected %v, received: %v", utils.ErrNotFound, err)
+	}
+}
+
+func TestSessionSReserveEventRestore(t *testing.T) {
+	sSCfg, _ := config.NewDefaultCGRConfig()
+	sSCfg.SessionSCfg().StoreInterval = -1
+	data := engine.NewInternalDB(nil, nil, true, sSCfg.DataDbCfg().Items)
+	dm := engine.NewDataManager(data, sSCfg.CacheCfg(), nil)
+	mock := new(testUnitsRPCMock)
+	sS := newUnitsTestSessionS(sSCfg, dm, mock)
+	ev := &utils.CGREvent{
+		Tenant: "cgrates.org",
+		ID:     utils.GenUUID(),
+		Event: map[string]interface{}{
+			utils.ToR:         utils.SMS,
+			utils.OriginID:    "rsrvRestore",
+			utils.OriginHost:  "127.0.0.1",
+			utils.RequestType: utils.META_PREPAID,
+			utils.Account:     "1001",
+			utils.Destination: "1002",
+			utils.AnswerTime:  time.Date(2020, 4, 20, 10, 0, 0, 0, time.UTC),
+			utils.Usage:       1,
+		},
+	}
+	var maxUsage time.Duration
+	if err := sS.BiRPCv1ReserveEvent(nil, &V1ReserveEventArgs{
+		TTL:      utils.DurationPointer(time.Hour),
+		CGREvent: ev}, &maxUsage); err != nil {
+		t.Fatal(err)
+	}
+	cgrID := utils.Sha1("rsrvRestore", "127.0.0.1")
+	if sSs, err := dm.GetStoredSessions(sSCfg.GeneralCfg().NodeID); err != nil {
+		t.Fatal(err)
+	} else if len(sSs) != 1 || sSs[0].CGRID != cgrID ||
+		sSs[0].ReservedUsage == nil || *sSs[0].ReservedUsage != 1 ||
+		sSs[0].ReservationExpiry == nil {
+		t.Fatalf("Unexpected stored sessions: %s", utils.ToJSON(sSs))
+	}
+	if err := sS.Shutdown(); err != nil {
+		t.Fatal(err)
+	}
+	if rfnd := mock.getRefunded(); rfnd != 0 {
+		t.Errorf("Expected the reservation kept on shutdown, refunded: %v", rfnd)
+	}
+
+	sS = newUnitsTestSessionS(sSCfg, dm, mock)
+	sS.restoreSessions()
+	if sS.getReservation(cgrID) == nil {
+		t.Fatal("Expected the reservation to be restored")
+	}
+	var rply string
+	if err := sS.BiRPCv1CommitEvent(nil, &utils.CGREventWithArgDispatcher{
+		CGREvent: ev}, &rply); err != nil {
+		t.Fatal(err)
+	}
+	if sS.getReservation(cgrID) != nil {
+		t.Error("Reservation should be removed after commit")
+	}
+	if sSs, err := dm.GetStoredSessions(sSCfg.GeneralCfg().NodeID); err != nil &&
+		err != utils.ErrNotFound {
+		t.Fatal(err)
+	} else if len(sSs) != 0 {
+		t.Errorf("Expected no stored sessions, received: %s", utils.ToJSON(sSs))
+	}
+}
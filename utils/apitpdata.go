@@ -82,6 +82,32 @@ type TPDestination struct {
 	Prefixes []string // Prefixes attached to this destination
 }
 
+// TPCalendar is a named list of days (ie: public holidays of a country) referenced by Timings
+type TPCalendar struct {
+	TPid  string   // Tariff plan id
+	ID    string   // Calendar id
+	Dates []string // Days in the calendar, as YYYY-MM-DD or MM-DD for the ones repeating each year
+}
+
+// TPExchangeRates are the time-effective rates converting FromCurrency into ToCurrency
+type TPExchangeRates struct {
+	TPid         string            // Tariff plan id
+	FromCurrency string            // Currency of the rated costs
+	ToCurrency   string            // Currency of the debited balances
+	Rates        []*TPExchangeRate // Rates with their activation time
+}
+
+// TPExchangeRate converts one unit of the source currency starting with ActivationTime
+type TPExchangeRate struct {
+	ActivationTime string
+	Rate           float64
+}
+
+// ID returns the identifier of the exchange rates within the tariff plan
+func (tpExr *TPExchangeRates) ID() string {
+	return ConcatenatedKey(tpExr.FromCurrency, tpExr.ToCurrency)
+}
+
 // This file deals with tp_* data definition
 
 type TPRate struct {
@@ -165,6 +191,7 @@ type ApierTPTiming struct {
 	MonthDays string // semicolon separated list of month's days this timing is valid on, *any supported
 	WeekDays  string // semicolon separated list of week day names this timing is valid on *any supported
 	Time      string // String representing the time this timing starts on
+	Calendars string // semicolon separated list of calendars this timing is also active on, ! prefix excluding the calendar days
 }
 
 type TPTiming struct {
@@ -175,6 +202,7 @@ type TPTiming struct {
 	WeekDays  WeekDays
 	StartTime string
 	EndTime   string
+	Calendars StringMap // calendar IDs, false for the excluded ones
 }
 
 // TPTimingWithArgDispatcher is used in replicatorV1 for dispatcher
@@ -202,6 +230,7 @@ func NewTiming(ID, years, mounths, mounthdays, weekdays, time string) (rt *TPTim
 type TPRatingPlan struct {
 	TPid               string                 // Tariff plan id
 	ID                 string                 // RatingPlan profile id
+	Currency           string                 // Currency of the costs, empty for the implicit one
 	RatingPlanBindings []*TPRatingPlanBinding // Set of destinationid-rateid bindings
 }
 
@@ -457,6 +486,7 @@ type ArgsCache struct {
 	DispatcherProfileIDs  *[]string
 	DispatcherHostIDs     *[]string
 	DispatcherRoutesIDs   *[]string
+	ExchangeRatesIDs      *[]string
 }
 
 // Data used to do remote cache reloads via api
@@ -494,6 +524,7 @@ func InitAttrReloadCache() (rpl AttrReloadCache) {
 	rpl.DispatcherProfileIDs = &[]string{}
 	rpl.DispatcherHostIDs = &[]string{}
 	rpl.DispatcherRoutesIDs = &[]string{}
+	rpl.ExchangeRatesIDs = &[]string{}
 	return
 }
 
@@ -966,6 +997,7 @@ type TPResourceProfile struct {
 	Stored             bool
 	Weight             float64  // Weight to sort the ResourceLimits
 	ThresholdIDs       []string // Thresholds to check after changing Limit
+	RateInterval       string   // sliding window of a rate based resource
 }
 
 // TPActivationInterval represents an activation interval for an item
@@ -1055,22 +1087,28 @@ type TPStatProfile struct {
 	Weight             float64
 	MinItems           int
 	ThresholdIDs       []string
+	BucketInterval     string
+	BucketCount        int
 }
 
 // TPThresholdProfile is used in APIs to manage remotely offline ThresholdProfile
 type TPThresholdProfile struct {
-	TPid               string
-	Tenant             string
-	ID                 string
-	FilterIDs          []string
-	ActivationInterval *TPActivationInterval // Time when this limit becomes active and expires
-	MaxHits            int
-	MinHits            int
-	MinSleep           string
-	Blocker            bool    // blocker flag to stop processing on filters matched
-	Weight             float64 // Weight to sort the thresholds
-	ActionIDs          []string
-	Async              bool
+	TPid                string
+	Tenant              string
+	ID                  string
+	FilterIDs           []string
+	ActivationInterval  *TPActivationInterval // Time when this limit becomes active and expires
+	MaxHits             int
+	MinHits             int
+	MinSleep            string
+	Blocker             bool    // blocker flag to stop processing on filters matched
+	Weight              float64 // Weight to sort the thresholds
+	ActionIDs           []string
+	Async               bool
+	WarningFilterIDs    []string
+	CriticalFilterIDs   []string
+	EscalationActionIDs []string
+	RecoveryActionIDs   []string
 }
 
 // TPFilterProfile is used in APIs to manage remotely offline FilterProfile
@@ -1409,6 +1447,24 @@ type GetCostOnRatingPlansArgs struct {
 	RatingPlanIDs []string
 }
 
+// GetCostsOnRatingPlansArgs is used to calculate in one call the cost of an event
+// on multiple lists of RatingPlans (ie: one list for each supplier)
+type GetCostsOnRatingPlansArgs struct {
+	Account       string
+	Subject       string
+	Destination   string
+	Tenant        string
+	SetupTime     time.Time
+	Usage         time.Duration
+	RatingPlanIDs map[string][]string // lists of RatingPlans indexed on an ID chosen by the caller
+}
+
+// CostOnRatingPlans is the cost of one list of RatingPlans returned by GetCostsOnRatingPlans
+type CostOnRatingPlans struct {
+	CostData map[string]interface{} // empty if none of the RatingPlans could rate the event
+	Error    string
+}
+
 type GetMaxSessionTimeOnAccountsArgs struct {
 	Subject     string
 	Destination string
@@ -997,6 +997,7 @@ func TestInitAttrReloadCache(t *testing.T) {
 	expected.ChargerProfileIDs = &[]string{}
 	expected.DispatcherProfileIDs = &[]string{}
 	expected.DispatcherHostIDs = &[]string{}
+	expected.ExchangeRatesIDs = &[]string{}
 	expected.DispatcherRoutesIDs = &[]string{}
 
 	if rcv := InitAttrReloadCache(); !reflect.DeepEqual(rcv, expected) {
@@ -70,7 +70,7 @@ var (
 		CacheAttributeFilterIndexes, CacheChargerFilterIndexes, CacheDispatcherFilterIndexes,
 		CacheDispatcherRoutes, CacheDispatcherLoads, CacheDiameterMessages, CacheRPCResponses,
 		CacheClosedSessions, CacheCDRIDs, CacheLoadIDs, CacheRPCConnections, CacheRatingProfilesTmp,
-		CacheUCH, CacheSTIR})
+		CacheUCH, CacheSTIR, CacheSupplierCosts, CacheExchangeRates})
 	CacheInstanceToPrefix = map[string]string{
 		CacheDestinations:            DESTINATION_PREFIX,
 		CacheReverseDestinations:     REVERSE_DESTINATION_PREFIX,
@@ -103,6 +103,7 @@ var (
 		CacheDispatcherFilterIndexes: DispatcherFilterIndexes,
 		CacheLoadIDs:                 LoadIDPrefix,
 		CacheAccounts:                ACCOUNT_PREFIX,
+		CacheExchangeRates:           ExchangeRatesPrefix,
 	}
 	CachePrefixToInstance map[string]string // will be built on init
 	PrefixToIndexCache    = map[string]string{
@@ -131,7 +132,7 @@ var (
 		CacheThresholdFilterIndexes, CacheSupplierFilterIndexes, CacheAttributeFilterIndexes,
 		CacheChargerFilterIndexes, CacheDispatcherFilterIndexes, CacheLoadIDs, CacheAccounts})
 
-	CacheStorDBPartitions = NewStringSet([]string{TBLTPTimings, TBLTPDestinations, TBLTPRates,
+	CacheStorDBPartitions = NewStringSet([]string{TBLTPTimings, TBLTPCalendars, TBLTPExchangeRates, TBLTPDestinations, TBLTPRates,
 		TBLTPDestinationRates, TBLTPRatingPlans, TBLTPRateProfiles, TBLTPSharedGroups,
 		TBLTPActions, TBLTPActionPlans, TBLTPActionTriggers, TBLTPAccountActions, TBLTPResources, TBLTPStats,
 		TBLTPThresholds, TBLTPFilters, SessionCostsTBL, CDRsTBL,
@@ -208,6 +209,7 @@ const (
 	PDD                          = "PDD"
 	SUPPLIER                     = "Supplier"
 	RunID                        = "RunID"
+	UnitID                       = "UnitID"
 	AttributeIDs                 = "AttributeIDs"
 	MetaReqRunID                 = "*req.RunID"
 	COST                         = "Cost"
@@ -275,6 +277,9 @@ const (
 	ThresholdProfilePrefix       = "thp_"
 	StatQueuePrefix              = "stq_"
 	LoadIDPrefix                 = "lid_"
+	SessionsPrefix               = "ses_"
+	ResourceUsagesPrefix         = "rsu_"
+	ExchangeRatesPrefix          = "exr_"
 	LOADINST_KEY                 = "load_history"
 	CREATE_CDRS_TABLES_SQL       = "create_cdrs_tables.sql"
 	CREATE_TARIFFPLAN_TABLES_SQL = "create_tariffplan_tables.sql"
@@ -294,6 +299,8 @@ const (
 	MetaSQL                      = "*sql"
 	MetaMySQL                    = "*mysql"
 	MetaS3jsonMap                = "*s3_json_map"
+	MetaS3CSV                    = "*s3_csv"
+	MetaHTTPPush                 = "*http_push"
 	CONFIG_PATH                  = "/etc/cgrates/"
 	DISCONNECT_CAUSE             = "DisconnectCause"
 	MetaFlatstore                = "*flatstore"
@@ -353,6 +360,7 @@ const (
 	NonTransactional            = ""
 	DataDB                      = "data_db"
 	StorDB                      = "stor_db"
+	InternalDB                  = "InternalDB"
 	NotFoundCaps                = "NOT_FOUND"
 	ServerErrorCaps             = "SERVER_ERROR"
 	MandatoryIEMissingCaps      = "MANDATORY_IE_MISSING"
@@ -379,6 +387,8 @@ const (
 	MetaConfig                  = "*config"
 	MetaDispatchers             = "*dispatchers"
 	MetaDispatcherHosts         = "*dispatcher_hosts"
+	MetaCalendars               = "*calendars"
+	MetaExchangeRates           = "*exchange_rates"
 	MetaFilters                 = "*filters"
 	MetaCDRs                    = "*cdrs"
 	MetaCaches                  = "*caches"
@@ -402,6 +412,7 @@ const (
 	FileLockPrefix              = "file_"
 	ActionsPoster               = "act"
 	CDRPoster                   = "cdr"
+	ERsPoster                   = "ers"
 	MetaFileCSV                 = "*file_csv"
 	MetaFileFWV                 = "*file_fwv"
 	MetaFScsv                   = "*freeswitch_csv"
@@ -413,6 +424,8 @@ const (
 	SharedGroups                = "SharedGroups"
 	TimingIDs                   = "TimingIDs"
 	Timings                     = "Timings"
+	Calendars                   = "Calendars"
+	ExchangeRates               = "ExchangeRates"
 	Rates                       = "Rates"
 	DestinationRates            = "DestinationRates"
 	RatingPlans                 = "RatingPlans"
@@ -425,6 +438,10 @@ const (
 	DispatcherHosts             = "DispatcherHosts"
 	MetaEveryMinute             = "*every_minute"
 	MetaHourly                  = "*hourly"
+	MetaDaily                   = "*daily"
+	MetaWeekly                  = "*weekly"
+	MetaMonthly                 = "*monthly"
+	MetaYearly                  = "*yearly"
 	ID                          = "ID"
 	Address                     = "Address"
 	Transport                   = "Transport"
@@ -457,6 +474,9 @@ const (
 	ResourceID                = "ResourceID"
 	TotalUsage                = "TotalUsage"
 	StatID                    = "StatID"
+	ThresholdID               = "ThresholdID"
+	ThresholdState            = "ThresholdState"
+	PreviousThresholdState    = "PreviousThresholdState"
 	BalanceType               = "BalanceType"
 	BalanceID                 = "BalanceID"
 	BalanceDestinationIds     = "BalanceDestinationIds"
@@ -504,11 +524,17 @@ const (
 	MetaQOS                   = "*qos"
 	MetaReas                  = "*reas"
 	MetaReds                  = "*reds"
+	MetaLCQOS                 = "*lc_qos"
+	MetaQOSThresholds         = "*qos_thresholds"
+	MetaExclude               = "*exclude"
+	MetaQuota                 = "*quota"
+	MetaPeriod                = "*period"
 	Weight                    = "Weight"
 	ThresholdIDs              = "ThresholdIDs"
 	Cost                      = "Cost"
 	Limit                     = "Limit"
 	UsageTTL                  = "UsageTTL"
+	RateInterval              = "RateInterval"
 	AllocationMessage         = "AllocationMessage"
 	Stored                    = "Stored"
 	DestinationIDs            = "DestinationIDs"
@@ -540,6 +566,10 @@ const (
 	BalanceUUID               = "BalanceUUID"
 	RatingID                  = "RatingID"
 	ExtraChargeID             = "ExtraChargeID"
+	ExchangeRate              = "ExchangeRate"
+	Currency                  = "Currency"
+	FromCurrency              = "FromCurrency"
+	ToCurrency                = "ToCurrency"
 	ConnectFee                = "ConnectFee"
 	RoundingMethod            = "RoundingMethod"
 	RoundingDecimals          = "RoundingDecimals"
@@ -561,6 +591,10 @@ const (
 	MinHits                   = "MinHits"
 	ActionIDs                 = "ActionIDs"
 	Async                     = "Async"
+	WarningFilterIDs          = "WarningFilterIDs"
+	CriticalFilterIDs         = "CriticalFilterIDs"
+	EscalationActionIDs       = "EscalationActionIDs"
+	RecoveryActionIDs         = "RecoveryActionIDs"
 	Sorting                   = "Sorting"
 	SortingParameters         = "SortingParameters"
 	SupplierAccountIDs        = "SupplierAccountIDs"
@@ -644,6 +678,13 @@ const (
 	MetaRemoveAll             = "*removeall"
 	MetaStore                 = "*store"
 	MetaClear                 = "*clear"
+	MetaSet                   = "*set"
+	MetaAlways                = "*always"
+	MetaNever                 = "*never"
+	MetaOK                    = "*ok"
+	MetaWarning               = "*warning"
+	MetaCritical              = "*critical"
+	MetaInterval              = "*interval"
 	MetaExport                = "*export"
 	LoadIDs                   = "load_ids"
 	DNSAgent                  = "DNSAgent"
@@ -677,6 +718,11 @@ const (
 	ERs                       = "ERs"
 	Ratio                     = "Ratio"
 	Load                      = "Load"
+	QOSViolations             = "QOSViolations"
+	Target                    = "Target"
+	Share                     = "Share"
+	Progress                  = "Progress"
+	PercentageSign            = "%"
 	Slash                     = "/"
 	UUID                      = "UUID"
 	ActionsID                 = "ActionsID"
@@ -753,17 +799,21 @@ const (
 
 // MetaMetrics
 const (
-	MetaASR      = "*asr"
-	MetaACD      = "*acd"
-	MetaTCD      = "*tcd"
-	MetaACC      = "*acc"
-	MetaTCC      = "*tcc"
-	MetaPDD      = "*pdd"
-	MetaDDC      = "*ddc"
-	MetaSum      = "*sum"
-	MetaAverage  = "*average"
-	MetaDistinct = "*distinct"
-	MetaRAR      = "*rar"
+	MetaASR        = "*asr"
+	MetaACD        = "*acd"
+	MetaTCD        = "*tcd"
+	MetaACC        = "*acc"
+	MetaTCC        = "*tcc"
+	MetaPDD        = "*pdd"
+	MetaDDC        = "*ddc"
+	MetaSum        = "*sum"
+	MetaAverage    = "*average"
+	MetaDistinct   = "*distinct"
+	MetaRAR        = "*rar"
+	MetaMin        = "*min"
+	MetaMax        = "*max"
+	MetaStdDev     = "*stddev"
+	MetaPercentile = "*percentile"
 )
 
 // Services
@@ -868,6 +918,7 @@ const (
 	CapResourceMessage      = "ResourceMessage"
 	CapResourceAllocation   = "ResourceAllocation"
 	CapMaxUsage             = "MaxUsage"
+	CapUnitsMaxUsage        = "UnitsMaxUsage"
 	CapSuppliers            = "Suppliers"
 	CapThresholds           = "Thresholds"
 	CapStatQueues           = "StatQueues"
@@ -904,6 +955,8 @@ const (
 	MetaBroadcast      = "*broadcast"
 	MetaRoundRobin     = "*round_robin"
 	MetaRatio          = "*ratio"
+	MetaHash           = "*hash"
+	MetaHashField      = "*hash_field"
 	ThresholdSv1       = "ThresholdSv1"
 	StatSv1            = "StatSv1"
 	ResourceSv1        = "ResourceSv1"
@@ -937,6 +990,10 @@ const (
 	MetaGreaterOrEqual = "*gte"
 	MetaResources      = "*resources"
 	MetaEqual          = "*eq"
+	MetaRegex          = "*regex"
+	MetaIPNet          = "*ipnet"
+	MetaOr             = "*or"
+	MetaCron           = "*cron"
 
 	MetaNotString       = "*notstring"
 	MetaNotPrefix       = "*notprefix"
@@ -949,6 +1006,10 @@ const (
 	MetaNotDestinations = "*notdestinations"
 	MetaNotResources    = "*notresources"
 	MetaNotEqual        = "*noteq"
+	MetaNotRegex        = "*notregex"
+	MetaNotIPNet        = "*notipnet"
+	MetaNotOr           = "*notor"
+	MetaNotCron         = "*notcron"
 
 	MetaEC = "*ec"
 )
@@ -1054,6 +1115,9 @@ const (
 	APIerSv1ExportToFolder              = "APIerSv1.ExportToFolder"
 	APIerSv1GetCost                     = "APIerSv1.GetCost"
 	APIerSv1SetBalance                  = "APIerSv1.SetBalance"
+	APIerSv1SetExchangeRates            = "APIerSv1.SetExchangeRates"
+	APIerSv1GetExchangeRates            = "APIerSv1.GetExchangeRates"
+	APIerSv1RemoveExchangeRates         = "APIerSv1.RemoveExchangeRates"
 	APIerSv1GetFilter                   = "APIerSv1.GetFilter"
 	APIerSv1GetFilterIndexes            = "APIerSv1.GetFilterIndexes"
 	APIerSv1RemoveFilterIndexes         = "APIerSv1.RemoveFilterIndexes"
@@ -1206,6 +1270,14 @@ const (
 	APIerSv1GetTPTiming              = "APIerSv1.GetTPTiming"
 	APIerSv1RemoveTPTiming           = "APIerSv1.RemoveTPTiming"
 	APIerSv1GetTPTimingIds           = "APIerSv1.GetTPTimingIds"
+	APIerSv1SetTPCalendar            = "APIerSv1.SetTPCalendar"
+	APIerSv1GetTPCalendar            = "APIerSv1.GetTPCalendar"
+	APIerSv1RemoveTPCalendar         = "APIerSv1.RemoveTPCalendar"
+	APIerSv1GetTPCalendarIds         = "APIerSv1.GetTPCalendarIds"
+	APIerSv1SetTPExchangeRates       = "APIerSv1.SetTPExchangeRates"
+	APIerSv1GetTPExchangeRates       = "APIerSv1.GetTPExchangeRates"
+	APIerSv1RemoveTPExchangeRates    = "APIerSv1.RemoveTPExchangeRates"
+	APIerSv1GetTPExchangeRatesIds    = "APIerSv1.GetTPExchangeRatesIds"
 	APIerSv1LoadTariffPlanFromStorDb = "APIerSv1.LoadTariffPlanFromStorDb"
 	APIerSv1RemoveTPFromFolder       = "APIerSv1.RemoveTPFromFolder"
 )
@@ -1306,6 +1378,7 @@ const (
 	ThresholdSv1ProcessEvent          = "ThresholdSv1.ProcessEvent"
 	ThresholdSv1GetThreshold          = "ThresholdSv1.GetThreshold"
 	ThresholdSv1GetThresholdIDs       = "ThresholdSv1.GetThresholdIDs"
+	ThresholdSv1GetThresholdState     = "ThresholdSv1.GetThresholdState"
 	ThresholdSv1Ping                  = "ThresholdSv1.Ping"
 	ThresholdSv1GetThresholdsForEvent = "ThresholdSv1.GetThresholdsForEvent"
 	APIerSv1GetThresholdProfileIDs    = "APIerSv1.GetThresholdProfileIDs"
@@ -1320,6 +1393,7 @@ const (
 	StatSv1GetQueueIDs             = "StatSv1.GetQueueIDs"
 	StatSv1GetQueueStringMetrics   = "StatSv1.GetQueueStringMetrics"
 	StatSv1GetQueueFloatMetrics    = "StatSv1.GetQueueFloatMetrics"
+	StatSv1GetQueueHistory         = "StatSv1.GetQueueHistory"
 	StatSv1Ping                    = "StatSv1.Ping"
 	StatSv1GetStatQueuesForEvent   = "StatSv1.GetStatQueuesForEvent"
 	StatSv1GetStatQueue            = "StatSv1.GetStatQueue"
@@ -1354,6 +1428,9 @@ const (
 	SessionSv1TerminateSession           = "SessionSv1.TerminateSession"
 	SessionSv1ProcessCDR                 = "SessionSv1.ProcessCDR"
 	SessionSv1ProcessMessage             = "SessionSv1.ProcessMessage"
+	SessionSv1ReserveEvent               = "SessionSv1.ReserveEvent"
+	SessionSv1CommitEvent                = "SessionSv1.CommitEvent"
+	SessionSv1CancelEvent                = "SessionSv1.CancelEvent"
 	SessionSv1ProcessEvent               = "SessionSv1.ProcessEvent"
 	SessionSv1GetCost                    = "SessionSv1.GetCost"
 	SessionSv1DisconnectSession          = "SessionSv1.DisconnectSession"
@@ -1387,6 +1464,7 @@ const (
 	ResponderRefundRounding              = "Responder.RefundRounding"
 	ResponderGetCost                     = "Responder.GetCost"
 	ResponderGetCostOnRatingPlans        = "Responder.GetCostOnRatingPlans"
+	ResponderGetCostsOnRatingPlans       = "Responder.GetCostsOnRatingPlans"
 	ResponderGetMaxSessionTimeOnAccounts = "Responder.GetMaxSessionTimeOnAccounts"
 	ResponderShutdown                    = "Responder.Shutdown"
 	ResponderPing                        = "Responder.Ping"
@@ -1396,14 +1474,30 @@ const (
 const (
 	DispatcherSv1Ping               = "DispatcherSv1.Ping"
 	DispatcherSv1GetProfileForEvent = "DispatcherSv1.GetProfileForEvent"
+	DispatcherSv1GetHostsStatus     = "DispatcherSv1.GetHostsStatus"
 	DispatcherSv1Apier              = "DispatcherSv1.Apier"
 	DispatcherServicePing           = "DispatcherService.Ping"
 )
 
 // AnalyzerS APIs
 const (
-	AnalyzerSv1     = "AnalyzerSv1"
-	AnalyzerSv1Ping = "AnalyzerSv1.Ping"
+	AnalyzerSv1            = "AnalyzerSv1"
+	AnalyzerSv1Ping        = "AnalyzerSv1.Ping"
+	AnalyzerSv1StringQuery = "AnalyzerSv1.StringQuery"
+)
+
+// AnalyzerS fields
+const (
+	RequestID          = "RequestID"
+	RequestMethod      = "RequestMethod"
+	RequestEncoding    = "RequestEncoding"
+	RequestSource      = "RequestSource"
+	RequestDestination = "RequestDestination"
+	RequestParams      = "RequestParams"
+	RequestStartTime   = "RequestStartTime"
+	RequestDuration    = "RequestDuration"
+	Reply              = "Reply"
+	ReplyError         = "ReplyError"
 )
 
 // LoaderS APIs
@@ -1487,6 +1581,8 @@ const (
 //CSV file name
 const (
 	TimingsCsv            = "Timings.csv"
+	CalendarsCsv          = "Calendars.csv"
+	ExchangeRatesCsv      = "ExchangeRates.csv"
 	DestinationsCsv       = "Destinations.csv"
 	RatesCsv              = "Rates.csv"
 	DestinationRatesCsv   = "DestinationRates.csv"
@@ -1511,6 +1607,8 @@ const (
 // Table Name
 const (
 	TBLTPTimings          = "tp_timings"
+	TBLTPCalendars        = "tp_calendars"
+	TBLTPExchangeRates    = "tp_exchange_rates"
 	TBLTPDestinations     = "tp_destinations"
 	TBLTPRates            = "tp_rates"
 	TBLTPDestinationRates = "tp_destination_rates"
@@ -1583,6 +1681,10 @@ const (
 	CacheRatingProfilesTmp       = "*tmp_rating_profiles"
 	CacheUCH                     = "*uch"
 	CacheSTIR                    = "*stir"
+	CacheSupplierCosts           = "*supplier_costs"
+	CacheStoredSessions          = "*stored_sessions" // partition used only by the internal DataDB
+	CacheResourceUsages          = "*resource_usages" // partition used only by the internal DataDB
+	CacheExchangeRates           = "*exchange_rates"
 )
 
 // Prefix for indexing
@@ -1617,6 +1719,25 @@ const (
 	KafkaTopic   = "topic"
 	KafkaGroupID = "group_id"
 	KafkaMaxWait = "max_wait"
+
+	QueueID                 = "queue_id"
+	DefaultQueueID          = "cgrates_cdrs"
+	AMQPExchange            = "exchange"
+	AMQPExchangeType        = "exchange_type"
+	AMQPDefaultExchangeType = "direct"
+	AMQPRoutingKey          = "routing_key"
+	AMQPConsumerTag         = "consumer_tag"
+	AMQPDefaultConsumerTag  = "cgrates"
+	AWSToken                = "aws_token"
+	AWSForcePathStyle       = "force_path_style"
+	FolderPath              = "folder_path"
+	S3ProcessedAction       = "processed_action"
+	S3ProcessedTag          = "cgr_processed"
+	MetaMove                = "*move"
+	MetaTag                 = "*tag"
+	HTTPReplyCode           = "reply_code"
+	HTTPReplyContentType    = "reply_content_type"
+	HTTPReplyBody           = "reply_body"
 )
 
 // Google_API
@@ -1685,15 +1806,21 @@ const (
 
 // DataDbCfg
 const (
-	DataDbTypeCfg         = "db_type"
-	DataDbHostCfg         = "db_host"
-	DataDbPortCfg         = "db_port"
-	DataDbNameCfg         = "db_name"
-	DataDbUserCfg         = "db_user"
-	DataDbPassCfg         = "db_password"
-	DataDbSentinelNameCfg = "redis_sentinel"
-	RmtConnsCfg           = "remote_conns"
-	RplConnsCfg           = "replication_conns"
+	DataDbTypeCfg          = "db_type"
+	DataDbHostCfg          = "db_host"
+	DataDbPortCfg          = "db_port"
+	DataDbNameCfg          = "db_name"
+	DataDbUserCfg          = "db_user"
+	DataDbPassCfg          = "db_password"
+	DataDbSentinelNameCfg  = "redis_sentinel"
+	RedisClusterCfg        = "redis_cluster"
+	RedisClusterSyncCfg    = "redis_cluster_sync"
+	RmtConnsCfg            = "remote_conns"
+	RplConnsCfg            = "replication_conns"
+	InternalPersistenceCfg = "internal_persistence"
+	FsyncCfg               = "fsync"
+	FsyncIntervalCfg       = "fsync_interval"
+	SnapshotIntervalCfg    = "snapshot_interval"
 )
 
 // ItemOpt
@@ -1738,9 +1865,10 @@ const (
 
 // FilterSCfg
 const (
-	StatSConnsCfg     = "stats_conns"
-	ResourceSConnsCfg = "resources_conns"
-	ApierSConnsCfg    = "apiers_conns"
+	StatSConnsCfg      = "stats_conns"
+	ResourceSConnsCfg  = "resources_conns"
+	ApierSConnsCfg     = "apiers_conns"
+	TenantTimezonesCfg = "tenant_timezones"
 )
 
 // RalsCfg
@@ -1795,6 +1923,7 @@ const (
 	AlterableFieldsCfg     = "alterable_fields"
 	MinDurLowBalanceCfg    = "min_dur_low_balance"
 	STIRCfg                = "stir"
+	ReservationTTLCfg      = "reservation_ttl"
 
 	AllowedAtestCfg       = "allowed_attest"
 	PayloadMaxdurationCfg = "payload_maxduration"
@@ -1867,9 +1996,18 @@ const (
 	// ChargerSCfg
 	StoreIntervalCfg = "store_interval"
 
+	// ResourceSCfg
+	SharedStateCfg = "shared_state"
+
 	// StatSCfg
 	StoreUncompressedLimitCfg = "store_uncompressed_limit"
 
+	// DispatcherSCfg
+	HealthCheckIntervalCfg  = "health_check_interval"
+	HealthCheckMethodCfg    = "health_check_method"
+	HealthCheckFailuresCfg  = "health_check_failures"
+	HealthCheckSuccessesCfg = "health_check_successes"
+
 	// Cache
 	PartitionsCfg = "partitions"
 	StaticTTL     = "StaticTTL"
@@ -1891,6 +2029,7 @@ const (
 	DataCfg         = "data"
 
 	DefaultRatioCfg            = "default_ratio"
+	CostTimeBucketCfg          = "cost_time_bucket"
 	ReadersCfg                 = "readers"
 	PoolSize                   = "poolSize"
 	Conns                      = "conns"
@@ -2007,6 +2146,7 @@ const (
 	PartialCacheExpiryActionCfg = "soome"
 	FieldsCfg                   = "fields"
 	CacheDumpFieldsCfg          = "cache_dump_fields"
+	ExportersCfg                = "exporters"
 )
 
 // CGRConfig
@@ -40,6 +40,7 @@ import (
 
 	"github.com/cenkalti/rpc2"
 	rpc2_jsonrpc "github.com/cenkalti/rpc2/jsonrpc"
+	"github.com/cgrates/rpcclient"
 	"golang.org/x/net/websocket"
 )
 
@@ -67,12 +68,61 @@ type Server struct {
 	httpsMux        *http.ServeMux
 	httpMux         *http.ServeMux
 	isDispatched    bool
+	anz             RPCAnalyzer
+}
+
+// RPCAnalyzer is implemented by the services capturing the RPC traffic
+type RPCAnalyzer interface {
+	NewServerCodec(sc rpc.ServerCodec, enc, from, to string) rpc.ServerCodec
+	NewAnalyzerConnector(conn rpcclient.ClientConnector, enc, from, to string) rpcclient.ClientConnector
+	NewBiRPCCodec(sc rpc2.Codec, enc, from, to string) rpc2.Codec
 }
 
 func (s *Server) SetDispatched() {
 	s.isDispatched = true
 }
 
+// SetAnalyzer will make the server log the traffic through the given analyzer
+// use nil to stop capturing
+func (s *Server) SetAnalyzer(anz RPCAnalyzer) {
+	s.Lock()
+	s.anz = anz
+	s.Unlock()
+}
+
+// serveCodec serves the RPC requests on the codec, passing them through the analyzer if active
+func (s *Server) serveCodec(codec rpc.ServerCodec, enc string, conn net.Conn) {
+	s.RLock()
+	anz := s.anz
+	s.RUnlock()
+	if anz != nil {
+		codec = anz.NewServerCodec(codec, enc,
+			conn.RemoteAddr().String(), conn.LocalAddr().String())
+	}
+	rpc.ServeCodec(codec)
+}
+
+// serveBiRPCCodec serves the BiRPC requests on the codec, passing them through the analyzer if active
+func (s *Server) serveBiRPCCodec(codec rpc2.Codec, enc string, conn net.Conn) {
+	s.RLock()
+	anz := s.anz
+	s.RUnlock()
+	if anz != nil {
+		codec = anz.NewBiRPCCodec(codec, enc,
+			conn.RemoteAddr().String(), conn.LocalAddr().String())
+	}
+	s.birpcSrv.ServeCodec(codec)
+}
+
+// serveJSONConn serves the JSON RPC requests received on conn
+func (s *Server) serveJSONConn(conn net.Conn) {
+	if s.isDispatched {
+		s.serveCodec(NewCustomJSONServerCodec(conn), MetaJSON, conn)
+		return
+	}
+	s.serveCodec(jsonrpc.NewServerCodec(conn), MetaJSON, conn)
+}
+
 func (s *Server) RpcRegister(rcvr interface{}) {
 	rpc.Register(rcvr)
 	s.Lock()
@@ -173,11 +223,7 @@ func (s *Server) ServeJSON(addr string) {
 			continue
 		}
 		//utils.Logger.Info(fmt.Sprintf("<CGRServer> New incoming connection: %v", conn.RemoteAddr()))
-		if s.isDispatched {
-			go rpc.ServeCodec(NewCustomJSONServerCodec(conn))
-		} else {
-			go jsonrpc.ServeConn(conn)
-		}
+		go s.serveJSONConn(conn)
 
 	}
 
@@ -214,15 +260,26 @@ func (s *Server) ServeGOB(addr string) {
 		}
 
 		//utils.Logger.Info(fmt.Sprintf("<CGRServer> New incoming connection: %v", conn.RemoteAddr()))
-		go rpc.ServeConn(conn)
+		go s.serveCodec(NewGobServerCodec(conn), MetaGOB, conn)
 	}
 }
 
-func handleRequest(w http.ResponseWriter, r *http.Request) {
+func (s *Server) handleRequest(w http.ResponseWriter, r *http.Request) {
 	defer r.Body.Close()
 	w.Header().Set("Content-Type", "application/json")
-	res := NewRPCRequest(r.Body).Call()
-	io.Copy(w, res)
+	rpcReq := NewRPCRequest(r.Body)
+	codec := jsonrpc.NewServerCodec(rpcReq)
+	s.RLock()
+	anz := s.anz
+	s.RUnlock()
+	if anz != nil {
+		var to string
+		if lAddr, canCast := r.Context().Value(http.LocalAddrContextKey).(net.Addr); canCast {
+			to = lAddr.String()
+		}
+		codec = anz.NewServerCodec(codec, MetaJSON, r.RemoteAddr, to)
+	}
+	io.Copy(w, rpcReq.CallCodec(codec))
 }
 
 func registerProfiler(addr string, mux *http.ServeMux) {
@@ -263,9 +320,9 @@ func (s *Server) ServeHTTP(addr string, jsonRPCURL string, wsRPCURL string,
 
 		Logger.Info("<HTTP> enabling handler for JSON-RPC")
 		if useBasicAuth {
-			s.httpMux.HandleFunc(jsonRPCURL, use(handleRequest, basicAuth(userList)))
+			s.httpMux.HandleFunc(jsonRPCURL, use(s.handleRequest, basicAuth(userList)))
 		} else {
-			s.httpMux.HandleFunc(jsonRPCURL, handleRequest)
+			s.httpMux.HandleFunc(jsonRPCURL, s.handleRequest)
 		}
 	}
 	if enabled && wsRPCURL != "" {
@@ -274,11 +331,7 @@ func (s *Server) ServeHTTP(addr string, jsonRPCURL string, wsRPCURL string,
 		s.Unlock()
 		Logger.Info("<HTTP> enabling handler for WebSocket connections")
 		wsHandler := websocket.Handler(func(ws *websocket.Conn) {
-			if s.isDispatched {
-				rpc.ServeCodec(NewCustomJSONServerCodec(ws))
-			} else {
-				jsonrpc.ServeConn(ws)
-			}
+			s.serveJSONConn(ws)
 		})
 		if useBasicAuth {
 			s.httpMux.HandleFunc(wsRPCURL, use(func(w http.ResponseWriter, r *http.Request) {
@@ -327,7 +380,7 @@ func (s *Server) ServeBiJSON(addr string, onConn func(*rpc2.Client), onDis func(
 				log.Fatal(err)
 				return // stop if we get Accept error
 			}
-			go s.birpcSrv.ServeCodec(rpc2_jsonrpc.NewJSONCodec(conn))
+			go s.serveBiRPCCodec(rpc2_jsonrpc.NewJSONCodec(conn), MetaJSON, conn)
 		}
 	}(lBiJSON)
 	<-s.stopbiRPCServer // wait until server is stoped to close the listener
@@ -372,7 +425,12 @@ func (r *rpcRequest) Close() error {
 
 // Call invokes the RPC request, waits for it to complete, and returns the results.
 func (r *rpcRequest) Call() io.Reader {
-	go jsonrpc.ServeConn(r)
+	return r.CallCodec(jsonrpc.NewServerCodec(r))
+}
+
+// CallCodec invokes the RPC request using the given codec over the rpcRequest
+func (r *rpcRequest) CallCodec(codec rpc.ServerCodec) io.Reader {
+	go rpc.ServeCodec(codec)
 	<-r.done
 	return r.rw
 }
@@ -453,7 +511,7 @@ func (s *Server) ServeGOBTLS(addr, serverCrt, serverKey, caCert string,
 			continue
 		}
 		//utils.Logger.Info(fmt.Sprintf("<CGRServer> New incoming connection: %v", conn.RemoteAddr()))
-		go rpc.ServeConn(conn)
+		go s.serveCodec(NewGobServerCodec(conn), MetaGOB, conn)
 	}
 }
 
@@ -492,11 +550,7 @@ func (s *Server) ServeJSONTLS(addr, serverCrt, serverKey, caCert string,
 			}
 			continue
 		}
-		if s.isDispatched {
-			go rpc.ServeCodec(NewCustomJSONServerCodec(conn))
-		} else {
-			go jsonrpc.ServeConn(conn)
-		}
+		go s.serveJSONConn(conn)
 	}
 }
 
@@ -516,9 +570,9 @@ func (s *Server) ServeHTTPTLS(addr, serverCrt, serverKey, caCert string, serverP
 		s.Unlock()
 		Logger.Info("<HTTPS> enabling handler for JSON-RPC")
 		if useBasicAuth {
-			s.httpsMux.HandleFunc(jsonRPCURL, use(handleRequest, basicAuth(userList)))
+			s.httpsMux.HandleFunc(jsonRPCURL, use(s.handleRequest, basicAuth(userList)))
 		} else {
-			s.httpsMux.HandleFunc(jsonRPCURL, handleRequest)
+			s.httpsMux.HandleFunc(jsonRPCURL, s.handleRequest)
 		}
 	}
 	if enabled && wsRPCURL != "" {
@@ -527,11 +581,7 @@ func (s *Server) ServeHTTPTLS(addr, serverCrt, serverKey, caCert string, serverP
 		s.Unlock()
 		Logger.Info("<HTTPS> enabling handler for WebSocket connections")
 		wsHandler := websocket.Handler(func(ws *websocket.Conn) {
-			if s.isDispatched {
-				rpc.ServeCodec(NewCustomJSONServerCodec(ws))
-			} else {
-				jsonrpc.ServeConn(ws)
-			}
+			s.serveJSONConn(ws)
 		})
 		if useBasicAuth {
 			s.httpsMux.HandleFunc(wsRPCURL, use(func(w http.ResponseWriter, r *http.Request) {